@@ -28,6 +28,8 @@ import (
 	"knative.dev/pkg/injection/sharedmain"
 
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/broker"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/channel"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/consumer"
@@ -43,22 +45,31 @@ const (
 
 func main() {
 
-	brokerEnv, err := config.GetEnvConfig("BROKER", broker.ValidateDefaultBackoffDelayMs)
+	brokerEnv, err := config.GetEnvConfig("BROKER", broker.ValidateDefaultBackoffDelayMs, broker.ValidateBrokerTopicTemplate, broker.ValidateIngressIPFamily, broker.ValidateExternalTopicPolicy, broker.ValidateNamespaceTopicPrefixes, base.ValidateDataPlanePodSelectors)
 	if err != nil {
 		log.Fatal("cannot process environment variables with prefix BROKER", err)
 	}
 
-	channelEnv, err := config.GetEnvConfig("CHANNEL")
+	channelEnv, err := config.GetEnvConfig("CHANNEL", base.ValidateDataPlanePodSelectors)
 	if err != nil {
 		log.Fatal("cannot process environment variables with prefix CHANNEL", err)
 	}
 
-	sinkEnv, err := config.GetEnvConfig("SINK")
+	sinkEnv, err := config.GetEnvConfig("SINK", base.ValidateDataPlanePodSelectors)
 	if err != nil {
 		log.Fatal("cannot process environment variables with prefix SINK", err)
 	}
 
-	sharedmain.MainNamed(signals.NewContext(), component,
+	ctx := signals.NewContext()
+
+	// The controllers share the connectivity to the Kafka clusters, so that a Kafka outage is reported by the controller.
+	connectivityTracker := kafka.NewConnectivityTracker(kafka.DefaultConnectivityFailureThreshold, kafka.DefaultConnectivityTTL)
+	ctx = kafka.WithConnectivityTracker(ctx, connectivityTracker)
+	if brokerEnv.KafkaConnectivityReadinessCheck {
+		ctx = injection.AddReadiness(ctx, connectivityTracker.ReadinessHandler(ctx))
+	}
+
+	sharedmain.MainNamed(ctx, component,
 
 		// Broker controller
 		injection.NamedControllerConstructor{
@@ -18,9 +18,13 @@ package main
 
 import (
 	"context"
+	"os"
+	"time"
 
+	"github.com/Shopify/sarama"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection/sharedmain"
@@ -42,10 +46,24 @@ import (
 	eventingv1 "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/eventing/v1"
 	eventingv1alpha1 "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/eventing/v1alpha1"
 	kafkainternals "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/internals/kafka/eventing/v1alpha1"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
 )
 
 const (
 	defaultWebhookPort = 8443
+
+	// replicationFactorValidationEnv enables the check of the replication factor of the broker configs against the
+	// number of brokers of their Kafka cluster, supported values are replicationFactorValidationWarn and
+	// replicationFactorValidationReject.
+	replicationFactorValidationEnv    = "BROKER_REPLICATION_FACTOR_VALIDATION"
+	replicationFactorValidationWarn   = "warn"
+	replicationFactorValidationReject = "reject"
+
+	// clusterBrokersCacheTTL is how long the number of brokers of a Kafka cluster is cached for.
+	clusterBrokersCacheTTL = time.Minute
+	// clusterDescriptionTimeout bounds the time spent describing a Kafka cluster, so that unreachable Kafka clusters
+	// don't make admission requests time out.
+	clusterDescriptionTimeout = 2 * time.Second
 )
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
@@ -119,8 +137,26 @@ func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 	featureStore := feature.NewStore(logging.FromContext(ctx).Named("feature-config-store"))
 	featureStore.WatchConfigs(cmw)
 
+	// The fallback namespace is configured through the same environment variable as the broker controller one.
+	fallbackNamespace := os.Getenv("BROKER_CONFIG_FALLBACK_NAMESPACE")
+	brokerConfigValidator := eventingv1.NewBrokerConfigMapValidator(configmapinformer.Get(ctx).Lister(), fallbackNamespace)
+
+	switch validation := os.Getenv(replicationFactorValidationEnv); validation {
+	case replicationFactorValidationWarn, replicationFactorValidationReject:
+		cache := kafka.NewClusterBrokersCache(clusterBrokersCacheTTL, clusterDescriptionTimeout, sarama.NewClusterAdmin)
+		brokerConfigValidator = eventingv1.BrokerConfigValidators(
+			brokerConfigValidator,
+			eventingv1.NewBrokerReplicationFactorValidator(configmapinformer.Get(ctx).Lister(), fallbackNamespace, cache, validation == replicationFactorValidationReject),
+		)
+	case "":
+	default:
+		logging.FromContext(ctx).Warnf("Invalid %s value %q, expected %s or %s, the replication factor isn't validated",
+			replicationFactorValidationEnv, validation, replicationFactorValidationWarn, replicationFactorValidationReject)
+	}
+
 	// Decorate contexts with the current state of the config.
 	ctxFunc := func(ctx context.Context) context.Context {
+		ctx = eventingv1.WithBrokerConfigValidator(ctx, brokerConfigValidator)
 		return featureStore.ToContext(ctx)
 	}
 
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"sync"
 	"text/template"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,6 +43,8 @@ type features struct {
 	TriggersConsumerGroupTemplate    template.Template
 	BrokersTopicTemplate             template.Template
 	ChannelsTopicTemplate            template.Template
+	ControllerProberTimeout          time.Duration
+	ControllerProberReadyThreshold   int
 }
 
 type KafkaFeatureFlags struct {
@@ -85,6 +88,8 @@ func NewFeaturesConfigFromMap(cm *corev1.ConfigMap) (*KafkaFeatureFlags, error)
 		asTemplate("triggers.consumergroup.template", &nc.features.TriggersConsumerGroupTemplate),
 		asTemplate("brokers.topic.template", &nc.features.BrokersTopicTemplate),
 		asTemplate("channels.topic.template", &nc.features.ChannelsTopicTemplate),
+		configmap.AsDuration("controller.prober.timeout", &nc.features.ControllerProberTimeout),
+		configmap.AsInt("controller.prober.ready-threshold", &nc.features.ControllerProberReadyThreshold),
 	)
 	return nc, err
 }
@@ -107,6 +112,18 @@ func (f *KafkaFeatureFlags) IsControllerAutoscalerEnabled() bool {
 	return f.features.ControllerAutoscaler == feature.Enabled
 }
 
+// ControllerProberTimeout returns the timeout of data plane probe requests, a non-positive value means that it isn't
+// configured.
+func (f *KafkaFeatureFlags) ControllerProberTimeout() time.Duration {
+	return f.features.ControllerProberTimeout
+}
+
+// ControllerProberReadyThreshold returns the number of consecutive probe results with the expected status required
+// before considering a resource ready, a non-positive value means that it isn't configured.
+func (f *KafkaFeatureFlags) ControllerProberReadyThreshold() int {
+	return f.features.ControllerProberReadyThreshold
+}
+
 func (f *KafkaFeatureFlags) ExecuteTriggersConsumerGroupTemplate(triggerMetadata v1.ObjectMeta) (string, error) {
 	return executeTemplateToString(f.features.TriggersConsumerGroupTemplate, triggerMetadata, "unable to execute triggers consumergroup template: %w")
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 	"text/template"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -64,7 +65,8 @@ func TestGetFlags(t *testing.T) {
 	require.Equal(t, flags.features.BrokersTopicTemplate.Name(), "brokers.topic.template")
 	require.Len(t, flags.features.ChannelsTopicTemplate.Tree.Root.Nodes, 4)
 	require.Equal(t, flags.features.ChannelsTopicTemplate.Name(), "channels.topic.template")
-
+	require.Equal(t, 5*time.Second, flags.ControllerProberTimeout())
+	require.Equal(t, 3, flags.ControllerProberReadyThreshold())
 }
 
 func TestStoreLoadWithConfigMap(t *testing.T) {
@@ -18,17 +18,23 @@ package v1
 
 import (
 	"context"
+	"errors"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	corelisters "k8s.io/client-go/listers/core/v1"
 
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/security"
 
 	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
 
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/logging"
 	"knative.dev/pkg/webhook/resourcesemantics"
 )
 
@@ -53,6 +59,100 @@ var (
 	_ resourcesemantics.GenericCRD = (*BrokerStub)(nil)
 )
 
+// BrokerConfigValidator validates the config referenced by a Broker at admission time.
+type BrokerConfigValidator func(ctx context.Context, broker *eventing.Broker) error
+
+type brokerConfigValidatorKey struct{}
+
+// NewBrokerConfigMapValidator returns a BrokerConfigValidator verifying that the ConfigMap referenced by a broker
+// exists.
+//
+// The ConfigMap is looked up the same way the broker reconciler does, so a missing ConfigMap is accepted when it can
+// be rebuilt from the broker status annotations.
+// Brokers annotated with kafka.ExternalConfigAnnotation and brokers whose config is a Secret aren't verified.
+// fallbackNamespace is the namespace where ConfigMaps not found in the broker namespace are looked up, like the
+// broker reconciler does with its BrokerConfigFallbackNamespace.
+func NewBrokerConfigMapValidator(lister corelisters.ConfigMapLister, fallbackNamespace string) BrokerConfigValidator {
+	return func(ctx context.Context, broker *eventing.Broker) error {
+		if _, ok := broker.Annotations[kafka.ExternalConfigAnnotation]; ok {
+			return nil
+		}
+		if kafka.IsBrokerConfigSecret(broker) {
+			// Secret based configs can't be read by the webhook.
+			return nil
+		}
+
+		cm, err := kafka.BrokerConfigMapWithFallback(lister, broker, fallbackNamespace)
+		if apierrors.IsNotFound(err) && cm != nil && len(cm.Data) > 0 {
+			return nil
+		}
+		return err
+	}
+}
+
+// NewBrokerReplicationFactorValidator returns a BrokerConfigValidator verifying, best-effort, that the replication
+// factor of the ConfigMap referenced by a broker doesn't exceed the number of brokers of its Kafka cluster, since the
+// broker topic can't be created otherwise.
+//
+// The Kafka clusters are described through the given cache, Kafka clusters that can't be described and configs that
+// can't be read are skipped, as well as configs referencing an auth secret since the webhook can't read secrets.
+// When reject is false, a replication factor exceeding the number of brokers is reported as a BrokerConfigWarning.
+func NewBrokerReplicationFactorValidator(lister corelisters.ConfigMapLister, fallbackNamespace string, cache *kafka.ClusterBrokersCache, reject bool) BrokerConfigValidator {
+	return func(ctx context.Context, broker *eventing.Broker) error {
+		if _, ok := broker.Annotations[kafka.ExternalConfigAnnotation]; ok || kafka.IsBrokerConfigSecret(broker) {
+			return nil
+		}
+
+		cm, err := kafka.BrokerConfigMapWithFallback(lister, broker, fallbackNamespace)
+		if err != nil || cm.Data[security.AuthSecretNameKey] != "" || cm.Data[security.AuthSecretNamesKey] != "" {
+			return nil
+		}
+		topicConfig, err := kafka.TopicConfigFromConfigMap(logging.FromContext(ctx).Desugar(), cm)
+		if err != nil {
+			return nil
+		}
+
+		err = cache.CheckReplicationFactor(topicConfig.BootstrapServers, topicConfig.TopicDetail.ReplicationFactor)
+		if err != nil && !reject {
+			return BrokerConfigWarning{Err: err}
+		}
+		return err
+	}
+}
+
+// BrokerConfigValidators returns a BrokerConfigValidator running the given validators in order, it returns the first
+// error.
+func BrokerConfigValidators(validators ...BrokerConfigValidator) BrokerConfigValidator {
+	return func(ctx context.Context, broker *eventing.Broker) error {
+		for _, validator := range validators {
+			if err := validator(ctx, broker); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// BrokerConfigWarning is returned by a BrokerConfigValidator to warn about the config of a broker without refusing
+// the broker.
+type BrokerConfigWarning struct {
+	Err error
+}
+
+func (w BrokerConfigWarning) Error() string {
+	return w.Err.Error()
+}
+
+func (w BrokerConfigWarning) Unwrap() error {
+	return w.Err
+}
+
+// WithBrokerConfigValidator returns a context carrying the given BrokerConfigValidator, which is used by
+// BrokerStub.Validate on create and update.
+func WithBrokerConfigValidator(ctx context.Context, validator BrokerConfigValidator) context.Context {
+	return context.WithValue(ctx, brokerConfigValidatorKey{}, validator)
+}
+
 type BrokerStubSpec struct {
 	// Config is a KReference to the configuration that specifies
 	// configuration options for this Broker. For example, this could be
@@ -61,7 +161,7 @@ type BrokerStubSpec struct {
 	Config *duckv1.KReference `json:"config,omitempty"`
 }
 
-func (b *BrokerStub) Validate(context.Context) *apis.FieldError {
+func (b *BrokerStub) Validate(ctx context.Context) *apis.FieldError {
 	if b.Annotations[eventing.BrokerClassAnnotationKey] != kafka.BrokerClass && b.Annotations[eventing.BrokerClassAnnotationKey] != kafka.NamespacedBrokerClass {
 		// validation for the broker of other classes is done by the other webhooks
 		return nil
@@ -73,21 +173,54 @@ func (b *BrokerStub) Validate(context.Context) *apis.FieldError {
 		return nil
 	}
 
-	// we specifically expect our broker class to use ConfigMap as the config type
-	if strings.ToLower(b.Spec.Config.Kind) != "configmap" {
-		return apis.ErrInvalidValue(b.Spec.Config.Kind, "kind", "Expected ConfigMap").ViaField("config").ViaField("spec")
+	// we specifically expect our broker class to use ConfigMap or Secret as the config type
+	if kind := strings.ToLower(b.Spec.Config.Kind); kind != "configmap" && kind != "secret" {
+		return apis.ErrInvalidValue(b.Spec.Config.Kind, "kind", "Expected ConfigMap or Secret").ViaField("config").ViaField("spec")
 	}
 
 	// for the namespaced broker, we expect the config to be in the same namespace as the broker
-	if b.Annotations[eventing.BrokerClassAnnotationKey] != kafka.NamespacedBrokerClass {
-		return nil
-	}
-	if b.Spec.Config.Namespace != "" && b.Spec.Config.Namespace != b.Namespace {
+	if b.Annotations[eventing.BrokerClassAnnotationKey] == kafka.NamespacedBrokerClass &&
+		b.Spec.Config.Namespace != "" && b.Spec.Config.Namespace != b.Namespace {
 		return apis.ErrInvalidValue(b.Spec.Config.Namespace, "namespace", "Expected ConfigMap in same namespace with broker resource").
 			ViaField("config").
 			ViaField("spec")
 	}
 
+	return b.validateConfig(ctx)
+}
+
+// validateConfig validates the broker config with the BrokerConfigValidator in the context, if any.
+//
+// The config is validated on create and when the config reference changes, so that brokers referencing a ConfigMap
+// that has been deleted afterwards can still be updated, for example, to remove their finalizers.
+func (b *BrokerStub) validateConfig(ctx context.Context) *apis.FieldError {
+	validator, ok := ctx.Value(brokerConfigValidatorKey{}).(BrokerConfigValidator)
+	if !ok || b.DeletionTimestamp != nil {
+		return nil
+	}
+	if apis.IsInUpdate(ctx) {
+		if original, ok := apis.GetBaseline(ctx).(*BrokerStub); ok && equality.Semantic.DeepEqual(original.Spec.Config, b.Spec.Config) {
+			return nil
+		}
+	} else if !apis.IsInCreate(ctx) {
+		return nil
+	}
+
+	broker := &eventing.Broker{
+		TypeMeta:   b.TypeMeta,
+		ObjectMeta: b.ObjectMeta,
+		Spec:       b.Spec,
+		Status:     b.Status,
+	}
+	if err := validator(ctx, broker); err != nil {
+		fieldErr := apis.ErrInvalidValue(b.Spec.Config.Name, "name", err.Error()).
+			ViaField("config").
+			ViaField("spec")
+		if errors.As(err, &BrokerConfigWarning{}) {
+			return fieldErr.At(apis.WarningLevel)
+		}
+		return fieldErr
+	}
 	return nil
 }
 
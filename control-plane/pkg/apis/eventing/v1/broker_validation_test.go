@@ -18,13 +18,23 @@ package v1
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/Shopify/sarama"
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	kafkatesting "knative.dev/eventing-kafka-broker/control-plane/pkg/kafka/testing"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/security"
 )
 
 func TestValidate(t *testing.T) {
@@ -81,7 +91,7 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
-		want: apis.ErrInvalidValue("Service", "kind", "Expected ConfigMap").ViaField("config").ViaField("spec"),
+		want: apis.ErrInvalidValue("Service", "kind", "Expected ConfigMap or Secret").ViaField("config").ViaField("spec"),
 	}, {
 		name: "spec.config.namespace is different",
 		b: BrokerStub{
@@ -139,3 +149,193 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateBrokerConfig(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	_ = indexer.Add(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "my-namespace", Name: "existing"},
+	})
+	_ = indexer.Add(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "operator-namespace", Name: "shared"},
+	})
+	validator := NewBrokerConfigMapValidator(corelisters.NewConfigMapLister(indexer), "operator-namespace")
+
+	broker := func(name string, options ...func(b *BrokerStub)) *BrokerStub {
+		b := &BrokerStub{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "my-namespace",
+				Annotations: map[string]string{"eventing.knative.dev/broker.class": "Kafka"},
+			},
+			Spec: eventingv1.BrokerSpec{
+				Config: &duckv1.KReference{
+					Name:       name,
+					Kind:       "ConfigMap",
+					APIVersion: "v1",
+				},
+			},
+		}
+		for _, opt := range options {
+			opt(b)
+		}
+		return b
+	}
+
+	notFound := apis.ErrInvalidValue("missing", "name", `configmap "missing" not found`).ViaField("config").ViaField("spec")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		b    *BrokerStub
+		want *apis.FieldError
+	}{{
+		name: "create - existing config map",
+		ctx:  apis.WithinCreate(context.Background()),
+		b:    broker("existing"),
+	}, {
+		name: "create - missing config map",
+		ctx:  apis.WithinCreate(context.Background()),
+		b:    broker("missing"),
+		want: notFound,
+	}, {
+		name: "create - missing config map annotated as external",
+		ctx:  apis.WithinCreate(context.Background()),
+		b: broker("missing", func(b *BrokerStub) {
+			b.Annotations[kafka.ExternalConfigAnnotation] = "true"
+		}),
+	}, {
+		name: "create - missing config map rebuilt from status annotations",
+		ctx:  apis.WithinCreate(context.Background()),
+		b: broker("missing", func(b *BrokerStub) {
+			b.Status.Annotations = map[string]string{kafka.BootstrapServersConfigMapKey: "kafka:9092"}
+		}),
+	}, {
+		name: "create - config map in fallback namespace",
+		ctx:  apis.WithinCreate(context.Background()),
+		b:    broker("shared"),
+	}, {
+		name: "update - config reference changed to missing config map",
+		ctx:  apis.WithinUpdate(context.Background(), broker("existing")),
+		b:    broker("missing"),
+		want: notFound,
+	}, {
+		name: "update - config reference unchanged",
+		ctx:  apis.WithinUpdate(context.Background(), broker("missing")),
+		b:    broker("missing"),
+	}, {
+		name: "update - deleted broker",
+		ctx:  apis.WithinUpdate(context.Background(), broker("existing")),
+		b: broker("missing", func(b *BrokerStub) {
+			b.DeletionTimestamp = &metav1.Time{}
+		}),
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := WithBrokerConfigValidator(test.ctx, validator)
+			got := test.b.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Error("Broker.Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestValidateBrokerReplicationFactor(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	configMap := func(name, replicationFactor string, data ...string) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "my-namespace", Name: name},
+			Data: map[string]string{
+				kafka.BootstrapServersConfigMapKey:              "kafka:9092",
+				kafka.DefaultTopicNumPartitionConfigMapKey:      "10",
+				kafka.DefaultTopicReplicationFactorConfigMapKey: replicationFactor,
+			},
+		}
+		for i := 0; i+1 < len(data); i += 2 {
+			cm.Data[data[i]] = data[i+1]
+		}
+		return cm
+	}
+	_ = indexer.Add(configMap("fitting", "3"))
+	_ = indexer.Add(configMap("exceeding", "4"))
+	_ = indexer.Add(configMap("exceeding-with-auth", "4", security.AuthSecretNameKey, "kafka-auth"))
+	lister := corelisters.NewConfigMapLister(indexer)
+
+	brokersCache := kafka.NewClusterBrokersCache(time.Minute, time.Second, func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		return &kafkatesting.MockKafkaClusterAdmin{ExpectedBrokersOnDescribeCluster: make([]*sarama.Broker, 3)}, nil
+	})
+	unreachableCache := kafka.NewClusterBrokersCache(time.Minute, time.Second, func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		return nil, errors.New("unreachable")
+	})
+
+	broker := func(name string) *BrokerStub {
+		return &BrokerStub{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "my-namespace",
+				Annotations: map[string]string{"eventing.knative.dev/broker.class": "Kafka"},
+			},
+			Spec: eventingv1.BrokerSpec{
+				Config: &duckv1.KReference{
+					Name:       name,
+					Kind:       "ConfigMap",
+					APIVersion: "v1",
+				},
+			},
+		}
+	}
+
+	exceeding := apis.ErrInvalidValue("exceeding", "name", "replication factor 4 is greater than the 3 brokers of Kafka cluster kafka:9092").
+		ViaField("config").
+		ViaField("spec")
+
+	tests := []struct {
+		name      string
+		validator BrokerConfigValidator
+		b         *BrokerStub
+		want      *apis.FieldError
+	}{{
+		name:      "replication factor fitting the cluster",
+		validator: NewBrokerReplicationFactorValidator(lister, "", brokersCache, true),
+		b:         broker("fitting"),
+	}, {
+		name:      "replication factor exceeding the cluster - reject",
+		validator: NewBrokerReplicationFactorValidator(lister, "", brokersCache, true),
+		b:         broker("exceeding"),
+		want:      exceeding,
+	}, {
+		name:      "replication factor exceeding the cluster - warn",
+		validator: NewBrokerReplicationFactorValidator(lister, "", brokersCache, false),
+		b:         broker("exceeding"),
+		want:      exceeding.At(apis.WarningLevel),
+	}, {
+		name:      "replication factor exceeding the cluster - auth secret",
+		validator: NewBrokerReplicationFactorValidator(lister, "", brokersCache, true),
+		b:         broker("exceeding-with-auth"),
+	}, {
+		name:      "unreachable cluster",
+		validator: NewBrokerReplicationFactorValidator(lister, "", unreachableCache, true),
+		b:         broker("exceeding"),
+	}, {
+		name:      "missing config map",
+		validator: NewBrokerReplicationFactorValidator(lister, "", brokersCache, true),
+		b:         broker("missing"),
+	}, {
+		name:      "chained with config map validator",
+		validator: BrokerConfigValidators(NewBrokerConfigMapValidator(lister, ""), NewBrokerReplicationFactorValidator(lister, "", brokersCache, true)),
+		b:         broker("missing"),
+		want:      apis.ErrInvalidValue("missing", "name", `configmap "missing" not found`).ViaField("config").ViaField("spec"),
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := WithBrokerConfigValidator(apis.WithinCreate(context.Background()), test.validator)
+			got := test.b.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Error("Broker.Validate (-want, +got) =", diff)
+			}
+			if test.want != nil && got != nil {
+				if diff := cmp.Diff(test.want.Level, got.Level); diff != "" {
+					t.Error("Broker.Validate level (-want, +got) =", diff)
+				}
+			}
+		})
+	}
+}
@@ -18,8 +18,11 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 )
 
 type Env struct {
@@ -32,6 +35,12 @@ type Env struct {
 	// and the data plane.
 	ContractConfigMapName string `required:"true" split_words:"true"` // example: kafka-broker-brokers-triggers
 
+	// ContractConfigMapShards is the number of config maps the broker contract is sharded across, so that large
+	// deployments don't hit the config map size limit. Shards other than the first one are named after
+	// ContractConfigMapName with the shard number as suffix (for example, kafka-broker-brokers-triggers-1), the data
+	// plane pods need to mount every shard. The contract isn't sharded when it's lower than 2.
+	ContractConfigMapShards int `required:"false" split_words:"true"`
+
 	// DataPlaneConfigConfigMapName is the name of the configmap that holds the data plane configurations.
 	DataPlaneConfigConfigMapName string `required:"true" split_words:"true"` // example: config-kafka-broker-data-plane
 
@@ -45,8 +54,254 @@ type Env struct {
 	SystemNamespace         string `required:"true" split_words:"true"`
 	ContractConfigMapFormat string `required:"true" split_words:"true"`
 	DefaultBackoffDelayMs   uint64 `required:"false" split_words:"true"`
+
+	// ClusterAdminPoolSize is the maximum number of Kafka cluster admin clients kept open and reused across
+	// reconciliations, a non-positive value disables pooling.
+	ClusterAdminPoolSize int `required:"false" split_words:"true"`
+	// ClusterAdminPoolIdleTTL is the time after which an unused pooled Kafka cluster admin client is closed.
+	ClusterAdminPoolIdleTTL time.Duration `required:"false" split_words:"true"`
+
+	// ClusterAdminCircuitBreakerThreshold is the number of consecutive failures to create a Kafka cluster admin
+	// client, within ClusterAdminCircuitBreakerWindow, after which connecting to the same cluster is short-circuited
+	// until ClusterAdminCircuitBreakerCooldown elapses, a non-positive value disables the circuit breaker.
+	ClusterAdminCircuitBreakerThreshold int `required:"false" split_words:"true"`
+	// ClusterAdminCircuitBreakerWindow is the window in which consecutive failures open the circuit.
+	ClusterAdminCircuitBreakerWindow time.Duration `required:"false" split_words:"true"`
+	// ClusterAdminCircuitBreakerCooldown is the time after which an open circuit lets a single attempt through.
+	ClusterAdminCircuitBreakerCooldown time.Duration `required:"false" split_words:"true"`
+
+	// KafkaConnectivityReadinessCheck makes the controller readiness probe fail when every Kafka cluster the
+	// reconcilers recently connected to is unreachable, so that a Kafka outage is visible at the controller level.
+	KafkaConnectivityReadinessCheck bool `required:"false" split_words:"true"`
+
+	// ClusterAdminDialTimeout, ClusterAdminReadTimeout and ClusterAdminMetadataTimeout are the timeouts of the
+	// requests made by the Kafka cluster admin clients, so that reconciliations fail fast and are requeued when the
+	// Kafka cluster is slow, non-positive values leave the Kafka client defaults.
+	ClusterAdminDialTimeout     time.Duration `required:"false" split_words:"true"`
+	ClusterAdminReadTimeout     time.Duration `required:"false" split_words:"true"`
+	ClusterAdminMetadataTimeout time.Duration `required:"false" split_words:"true"`
+
+	// ExternalTopicPresenceCheckAttempts is the number of times the presence of an external topic is checked
+	// before considering the topic not present, a non-positive value disables retries.
+	ExternalTopicPresenceCheckAttempts int `required:"false" split_words:"true"`
+	// ExternalTopicPresenceCheckInitialDelay is the delay before the first retry, it doubles at every attempt.
+	ExternalTopicPresenceCheckInitialDelay time.Duration `required:"false" split_words:"true"`
+	// ExternalTopicPresenceCheckMaxDelay is the maximum delay between two retries.
+	ExternalTopicPresenceCheckMaxDelay time.Duration `required:"false" split_words:"true"`
+	// ExternalTopicNotFoundRequeueDelay is the delay before reconciling again a broker whose external topic isn't
+	// present, once the presence check retries are exhausted, so that topics provisioned by slow external pipelines
+	// aren't checked at the default requeue rate, a non-positive value leaves the default rate.
+	ExternalTopicNotFoundRequeueDelay time.Duration `required:"false" split_words:"true"`
+
+	// DryRun makes reconcilers report the changes they would apply to Kafka topics and to the data plane contract
+	// without applying them, auth secrets don't get finalizers either. Deleted brokers are released without deleting
+	// their topic and their contract resource.
+	DryRun bool `required:"false" split_words:"true"`
+
+	// BrokerTopicTemplate is a Go template used to name broker topics, it's evaluated against the broker metadata
+	// (for example, team.{{ .Namespace }}.{{ .Name }}.events) and it takes precedence over the brokers topic
+	// template feature flag.
+	BrokerTopicTemplate string `required:"false" split_words:"true"`
+
+	// NamespaceTopicPrefixes are comma separated <namespace>=<prefix> pairs, the topics of the brokers of a listed
+	// namespace are named with its prefix prepended (for example, tenant-a=tenant-a. makes the brokers of the
+	// tenant-a namespace create tenant-a.knative-broker-tenant-a-<name> topics), so that tenants sharing a Kafka
+	// cluster can be isolated with prefixed ACLs. Topics already created aren't renamed, and prefixed topics aren't
+	// looked up by the orphaned topics sweeps.
+	NamespaceTopicPrefixes string `required:"false" split_words:"true"`
+
+	// TopicLagMetricsEnabled makes the broker reconciler publish the total lag of the broker triggers on the broker
+	// topic, it's disabled by default since it requires additional Kafka admin calls at every reconciliation.
+	TopicLagMetricsEnabled bool `required:"false" split_words:"true"`
+
+	// TopicDeletionGracePeriod defers the deletion of broker topics on broker finalization, a broker created again
+	// with the same name within the grace period adopts its previous topic. Topics whose grace period elapsed are
+	// deleted by subsequent broker reconciliations. A non-positive value deletes topics immediately.
+	TopicDeletionGracePeriod time.Duration `required:"false" split_words:"true"`
+
+	// TopicDeletionConfirmationTimeout makes the broker finalizer wait, up to the given timeout, until Kafka has
+	// actually deleted the broker topic, so that a broker created again with the same name doesn't race against its
+	// previous topic still being deleted. The broker finalization is requeued when the topic is still present. It's
+	// disabled when it's not positive since it adds latency to broker finalizations.
+	TopicDeletionConfirmationTimeout time.Duration `required:"false" split_words:"true"`
+
+	// TopicReuseByName makes broker topics stable across the re-creation of brokers with the same namespace and
+	// name: the topic of a deleted broker is retained, unless the topic delete policy is explicitly Delete, and a
+	// broker created again adopts the existing topic and contract resource instead of being keyed by its new UID.
+	// It requires the broker topic name not to depend on the broker UID, it's disabled by default.
+	TopicReuseByName bool `required:"false" split_words:"true"`
+
+	// TopicWritabilityCheckEnabled makes the broker reconciler mark broker topics ready only when every partition
+	// has a leader and at least min.insync.replicas in-sync replicas, it's disabled by default since it requires an
+	// additional Kafka admin call at every reconciliation.
+	TopicWritabilityCheckEnabled bool `required:"false" split_words:"true"`
+
+	// SoftDataPlaneAvailabilityGate makes the broker reconciler continue reconciling brokers while the receiver isn't
+	// running, instead of failing with DataPlaneNotAvailable, to reduce status flapping during data plane upgrades.
+	// The DataPlaneAvailabilityGateAnnotation broker annotation takes precedence over it.
+	SoftDataPlaneAvailabilityGate bool `required:"false" split_words:"true"`
+
+	// ReplicationFactorFallbackEnabled makes the broker reconciler retry the topic creation once with the replication
+	// factor clamped to the number of available brokers when the Kafka cluster has fewer brokers than the configured
+	// replication factor, the broker reports the degraded durability with a warning condition.
+	ReplicationFactorFallbackEnabled bool `required:"false" split_words:"true"`
+
+	// RackAwareReplicaAssignmentEnabled makes reconcilers create topics with an explicit replica assignment spreading
+	// the replicas of each partition across the racks of the Kafka cluster brokers, to improve durability in multi-AZ
+	// clusters. Topics are created with the Kafka cluster replica assignment when the brokers rack metadata isn't
+	// available.
+	RackAwareReplicaAssignmentEnabled bool `required:"false" split_words:"true"`
+
+	// ContractResourceStatusAnnotationEnabled makes the broker reconciler write a JSON summary of the broker resource
+	// programmed into the data plane contract into the broker status annotations, it's disabled by default.
+	ContractResourceStatusAnnotationEnabled bool `required:"false" split_words:"true"`
+
+	// DiagnosticsStatusAnnotationEnabled makes the broker reconciler write a JSON summary of the last reconciliation of
+	// each broker into the broker status annotations: the phase that failed, if any, the Kafka error code, whether the
+	// topic is external and the probe status. It's disabled by default.
+	DiagnosticsStatusAnnotationEnabled bool `required:"false" split_words:"true"`
+
+	// IngressReachabilityCheckEnabled makes the broker reconciler send a HEAD request to the advertised broker address
+	// from the controller, and report addresses that aren't reachable with the IngressReachable condition, to catch
+	// DNS and networking misconfigurations that the data plane probes don't catch. It's disabled by default since it
+	// adds a network call at every reconciliation, requests time out after ProbeTimeout.
+	IngressReachabilityCheckEnabled bool `required:"false" split_words:"true"`
+
+	// ProbeTimeout is the timeout of the requests made to the data plane to check whether a resource is ready, the
+	// controller.prober.timeout Kafka feature takes precedence over it.
+	ProbeTimeout time.Duration `required:"false" split_words:"true"`
+	// ProbeReadyThreshold is the number of consecutive successful probes required before a resource is considered
+	// ready, the controller.prober.ready-threshold Kafka feature takes precedence over it.
+	ProbeReadyThreshold int `required:"false" split_words:"true"`
+	// ProbePath is the path of the data plane health endpoint probed to check whether a broker is ready, when it's
+	// empty the advertised broker address is probed.
+	ProbePath string `required:"false" split_words:"true"`
+
+	// BrokerConfigFallbackNamespace is the namespace, usually the operator namespace, where the ConfigMap
+	// referenced by a broker config without namespace is looked up when it isn't found in the broker namespace, so
+	// that brokers can share a central config.
+	BrokerConfigFallbackNamespace string `required:"false" split_words:"true"`
+
+	// DefaultBootstrapServers are the bootstrap servers of brokers whose config doesn't specify them and whose
+	// namespace doesn't have a default either.
+	DefaultBootstrapServers string `required:"false" split_words:"true"`
+
+	// ExternalTopicAllowPattern is a regular expression that the external topics referenced by brokers must match,
+	// when set, brokers referencing other topics aren't reconciled.
+	ExternalTopicAllowPattern string `required:"false" split_words:"true"`
+	// ExternalTopicDenyPattern is a regular expression that the external topics referenced by brokers must not match,
+	// it takes precedence over ExternalTopicAllowPattern.
+	ExternalTopicDenyPattern string `required:"false" split_words:"true"`
+
+	// PartitionCapacityEventsPerSecond is the number of events per second a single topic partition is expected to
+	// sustain, it's used to compute the number of partitions of the topics of brokers setting a target throughput.
+	PartitionCapacityEventsPerSecond int `required:"false" split_words:"true"`
+
+	// UnmanagedTopics disables the management of broker topics: the reconciler never creates, validates nor deletes
+	// topics, it only programs the data plane contract with the topic names, so topics have to be provisioned
+	// out-of-band.
+	UnmanagedTopics bool `required:"false" split_words:"true"`
+
+	// OrphanedTopicsSweepInterval is the interval between sweeps of the Kafka clusters in use by brokers looking for
+	// broker topics that aren't owned by any broker anymore, for example, because a broker finalization partially
+	// failed. Sweeps are disabled when it's not positive.
+	OrphanedTopicsSweepInterval time.Duration `required:"false" split_words:"true"`
+
+	// OrphanedTopicsDeletionEnabled makes sweeps delete the orphaned topics they find, they're only reported
+	// otherwise. Topics retained on broker deletion with the Retain topic delete policy are orphaned too, so it's
+	// disabled by default.
+	OrphanedTopicsDeletionEnabled bool `required:"false" split_words:"true"`
+
+	// ConflictRetryMaxAttempts is the maximum number of attempts made to reconcile a resource when updates conflict,
+	// a non-positive value uses the default number of attempts.
+	ConflictRetryMaxAttempts int `required:"false" split_words:"true"`
+	// ConflictRetryMaxDuration is the maximum cumulative time spent retrying the reconciliation of a resource when
+	// updates conflict, a non-positive value doesn't bound it.
+	// When either ConflictRetryMaxAttempts or ConflictRetryMaxDuration is set and the budget is exhausted, the
+	// resource is requeued instead of failing with the conflict error.
+	ConflictRetryMaxDuration time.Duration `required:"false" split_words:"true"`
+
+	// ContractUpdateCoalesceWindow is the window within which the changes made to the data plane contract by
+	// concurrent reconciliations are coalesced into a single config map update, to reduce update conflicts on the
+	// shared contract config map. Updates aren't coalesced when it's not positive.
+	ContractUpdateCoalesceWindow time.Duration `required:"false" split_words:"true"`
+
+	// SecretFinalizerPrefix is the prefix of the finalizers added to the auth secrets of resources, so that separate
+	// installations don't remove each other's finalizers, it defaults to DefaultSecretFinalizerPrefix.
+	SecretFinalizerPrefix string `required:"false" split_words:"true"`
+
+	// SecretFinalizersDisabled makes reconcilers neither add finalizers to nor remove finalizers from the auth
+	// secrets of resources, for clusters where secrets are managed by operators that don't tolerate foreign
+	// finalizers. Secret changes are still picked up since secrets are tracked, but an auth secret can be deleted
+	// before the resources referencing it, leaving behind the topics that can't be deleted without it. Finalizers
+	// previously added aren't removed once disabled.
+	SecretFinalizersDisabled bool `required:"false" split_words:"true"`
+
+	// IngressIPFamily is the IP family (IPv4 or IPv6) of the ingress service cluster IP used as the host of
+	// addresses. When not set, the ingress service hostname is used.
+	IngressIPFamily string `required:"false" split_words:"true"`
+
+	// DebugEgressFanOut is a debug option making the trigger reconciler add as many copies of every trigger egress to
+	// the data plane contract, each with its own consumer group and targeting the same subscriber, to load test the
+	// dispatcher with many triggers from a single one. It must not be set in production, it's disabled when it's not
+	// positive.
+	DebugEgressFanOut int `required:"false" split_words:"true"`
+
+	// StrimziIntegrationEnabled allows brokers to reference a Strimzi Kafka resource, and optionally a KafkaUser, with
+	// the kafka.eventing.knative.dev/strimzi.* annotations, instead of setting the bootstrap servers and the auth
+	// secret in their config map. Only TLS KafkaUsers are supported, and the credentials are only used for the broker
+	// topic, not for the trigger consumers. It's disabled by default since it requires access to the Strimzi resources.
+	StrimziIntegrationEnabled bool `required:"false" split_words:"true"`
+
+	// StatusUpdateMinInterval is the minimum interval between the status updates of a broker, so that brokers whose
+	// probes flap don't generate a storm of status updates. Throttled brokers are requeued, their latest status is
+	// written once the interval elapsed, and status changes following spec changes aren't throttled. It's disabled
+	// when it's not positive.
+	StatusUpdateMinInterval time.Duration `required:"false" split_words:"true"`
+
+	// ReconcileFastPathMaxAge makes the broker reconciler skip the Kafka admin calls of brokers that are ready and
+	// whose generation, resolved config, auth secrets and contract resource haven't changed since their last full
+	// reconciliation, so that periodic resyncs don't dial Kafka clusters. Brokers get a full reconciliation at least
+	// once per max age, to catch changes made out-of-band to their topic. It's disabled when it's not positive.
+	ReconcileFastPathMaxAge time.Duration `required:"false" split_words:"true"`
+
+	// TopicMetadataCacheTTL is the time the broker topic metadata described by the controller are cached, keyed by
+	// Kafka cluster and topic, so that brokers reconciled in bursts against the same Kafka cluster don't describe the
+	// same topics every time. Topics created or deleted by the controller are invalidated right away, changes made
+	// out-of-band are visible once the TTL elapsed. It's disabled when it's not positive.
+	TopicMetadataCacheTTL time.Duration `required:"false" split_words:"true"`
+
+	// BrokerDrainTimeout makes the broker reconciler drain deleted brokers before deleting their topic: the receivers
+	// stop accepting events for the broker and the topic is deleted once the broker triggers consumed it, progress is
+	// reported with events. Brokers whose topic isn't deleted with them aren't drained. It's disabled when it's not
+	// positive.
+	BrokerDrainTimeout time.Duration `required:"false" split_words:"true"`
+	// BrokerDrainTimeoutEnforced makes the broker reconciler delete the topic of brokers that haven't been drained
+	// within the BrokerDrainTimeout, otherwise deleted brokers wait for their topic to be drained.
+	BrokerDrainTimeoutEnforced bool `required:"false" split_words:"true"`
+
+	// SecretChangeMinInterval is the interval the changes of a secret are coalesced over before reconciling the
+	// brokers referencing it, so that a credential rotation updating a secret shared by many brokers multiple times
+	// reconciles them once. Pooled Kafka cluster admin clients created with the secret are closed once per coalesced
+	// change. It's disabled when it's not positive.
+	SecretChangeMinInterval time.Duration `required:"false" split_words:"true"`
+
+	// ReceiverPodSelector is the label selector of the receiver pods annotated when the contract changes, for
+	// deployments with multiple data plane variants, for example, one per node pool. It defaults to the app label of
+	// the receiver.
+	ReceiverPodSelector string `required:"false" split_words:"true"`
+	// DispatcherPodSelector is the label selector of the dispatcher pods annotated when the contract changes, it
+	// defaults to the app label of the dispatcher.
+	DispatcherPodSelector string `required:"false" split_words:"true"`
 }
 
+const (
+	DefaultExternalTopicPresenceCheckInitialDelay = time.Second
+	DefaultExternalTopicPresenceCheckMaxDelay     = time.Minute
+
+	DefaultSecretFinalizerPrefix = "kafka.eventing"
+)
+
 // ValidationOption represents a function to validate the Env configurations.
 type ValidationOption func(env Env) error
 
@@ -69,3 +324,48 @@ func GetEnvConfig(prefix string, validations ...ValidationOption) (*Env, error)
 func (c *Env) DataPlaneConfigMapAsString() string {
 	return fmt.Sprintf("%s/%s", c.DataPlaneConfigMapNamespace, c.ContractConfigMapName)
 }
+
+// ExternalTopicPresenceCheckDelay returns the exponential backoff delay for the given external topic presence check
+// attempt, starting from 1.
+func (c *Env) ExternalTopicPresenceCheckDelay(attempt int) time.Duration {
+	initialDelay := c.ExternalTopicPresenceCheckInitialDelay
+	if initialDelay <= 0 {
+		initialDelay = DefaultExternalTopicPresenceCheckInitialDelay
+	}
+	maxDelay := c.ExternalTopicPresenceCheckMaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultExternalTopicPresenceCheckMaxDelay
+	}
+
+	delay := initialDelay
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// ConflictRetryBackoff returns the backoff used to retry reconciliations when updates conflict, it's
+// retry.DefaultBackoff with at most ConflictRetryMaxAttempts steps.
+func (c *Env) ConflictRetryBackoff() wait.Backoff {
+	backoff := retry.DefaultBackoff
+	if c.ConflictRetryMaxAttempts > 0 {
+		backoff.Steps = c.ConflictRetryMaxAttempts
+	}
+	return backoff
+}
+
+// HasConflictRetryBudget returns true if the conflict retry budget is configured.
+func (c *Env) HasConflictRetryBudget() bool {
+	return c.ConflictRetryMaxAttempts > 0 || c.ConflictRetryMaxDuration > 0
+}
+
+// GetSecretFinalizerPrefix returns SecretFinalizerPrefix, or DefaultSecretFinalizerPrefix when it's not set.
+func (c *Env) GetSecretFinalizerPrefix() string {
+	if c.SecretFinalizerPrefix == "" {
+		return DefaultSecretFinalizerPrefix
+	}
+	return c.SecretFinalizerPrefix
+}
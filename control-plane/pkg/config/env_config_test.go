@@ -21,6 +21,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestGetEnvConfig(t *testing.T) {
@@ -152,3 +153,57 @@ func TestGetEnvConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvExternalTopicPresenceCheckDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     Env
+		attempt int
+		want    time.Duration
+	}{
+		{
+			name:    "defaults first attempt",
+			attempt: 1,
+			want:    DefaultExternalTopicPresenceCheckInitialDelay,
+		},
+		{
+			name:    "defaults capped",
+			attempt: 100,
+			want:    DefaultExternalTopicPresenceCheckMaxDelay,
+		},
+		{
+			name: "exponential",
+			env: Env{
+				ExternalTopicPresenceCheckInitialDelay: 100 * time.Millisecond,
+				ExternalTopicPresenceCheckMaxDelay:     time.Second,
+			},
+			attempt: 3,
+			want:    400 * time.Millisecond,
+		},
+		{
+			name: "capped",
+			env: Env{
+				ExternalTopicPresenceCheckInitialDelay: 100 * time.Millisecond,
+				ExternalTopicPresenceCheckMaxDelay:     time.Second,
+			},
+			attempt: 5,
+			want:    time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.env.ExternalTopicPresenceCheckDelay(tt.attempt); got != tt.want {
+				t.Errorf("ExternalTopicPresenceCheckDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvGetSecretFinalizerPrefix(t *testing.T) {
+	if got := (&Env{}).GetSecretFinalizerPrefix(); got != DefaultSecretFinalizerPrefix {
+		t.Errorf("GetSecretFinalizerPrefix() = %q, want %q", got, DefaultSecretFinalizerPrefix)
+	}
+	if got := (&Env{SecretFinalizerPrefix: "other.eventing"}).GetSecretFinalizerPrefix(); got != "other.eventing" {
+		t.Errorf("GetSecretFinalizerPrefix() = %q, want %q", got, "other.eventing")
+	}
+}
@@ -53,7 +53,7 @@ func AddOrUpdateEgressConfig(ct *contract.Contract, resourceIndex int, egress *c
 		prev := ct.Resources[resourceIndex].Egresses[egressIndex]
 		ct.Resources[resourceIndex].Egresses[egressIndex] = egress
 
-		if proto.Equal(prev, egress) {
+		if EgressesEqual(prev, egress) {
 			return EgressUnchanged
 		}
 		return EgressChanged
@@ -74,7 +74,7 @@ func AddOrUpdateEgressConfigForResource(resource *contract.Resource, egress *con
 		prev := resource.Egresses[egressIndex]
 		resource.Egresses[egressIndex] = egress
 
-		if proto.Equal(prev, egress) {
+		if EgressesEqual(prev, egress) {
 			return EgressUnchanged
 		}
 		return EgressChanged
@@ -85,6 +85,30 @@ func AddOrUpdateEgressConfigForResource(resource *contract.Resource, egress *con
 	return EgressChanged
 }
 
+// EgressesEqual returns whether the given egresses are semantically equal, an empty egress config is equal to an
+// absent one.
+func EgressesEqual(a, b *contract.Egress) bool {
+	return proto.Equal(normalizeEgress(a), normalizeEgress(b))
+}
+
+// normalizeEgress returns the given egress without its egress config when it's empty, the given egress is left
+// untouched.
+func normalizeEgress(egress *contract.Egress) *contract.Egress {
+	if egress == nil || egress.EgressConfig == nil || normalizeEgressConfig(egress.EgressConfig) != nil {
+		return egress
+	}
+	e := proto.Clone(egress).(*contract.Egress)
+	e.EgressConfig = nil
+	return e
+}
+
+func normalizeEgressConfig(egressConfig *contract.EgressConfig) *contract.EgressConfig {
+	if egressConfig != nil && proto.Equal(egressConfig, &contract.EgressConfig{}) {
+		return nil
+	}
+	return egressConfig
+}
+
 // KeyTypeFromString returns the contract.KeyType associated to a given string.
 func KeyTypeFromString(s string) contract.KeyType {
 	switch s {
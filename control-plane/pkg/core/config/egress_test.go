@@ -207,6 +207,41 @@ func TestAddOrUpdateEgressConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Egress found - empty egress config - unchanged",
+			givenCt: &contract.Contract{
+				Generation: 0,
+				Resources: []*contract.Resource{
+					{
+						Egresses: []*contract.Egress{
+							{
+								Uid: "abc",
+							},
+						},
+					},
+				},
+			},
+			brokerIndex: 0,
+			egress: &contract.Egress{
+				Uid:          "abc",
+				EgressConfig: &contract.EgressConfig{},
+			},
+			egressIndex: 0,
+			changed:     EgressUnchanged,
+			wantCt: &contract.Contract{
+				Generation: 0,
+				Resources: []*contract.Resource{
+					{
+						Egresses: []*contract.Egress{
+							{
+								Uid:          "abc",
+								EgressConfig: &contract.EgressConfig{},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
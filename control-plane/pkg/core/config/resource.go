@@ -17,6 +17,8 @@
 package config
 
 import (
+	"sort"
+
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 	"k8s.io/apimachinery/pkg/types"
@@ -41,6 +43,19 @@ func FindResource(contract *contract.Contract, resource types.UID) int {
 	return resourceIndex
 }
 
+// FindResourceByReference finds the resource referencing the object with the given namespace and name in the given
+// resources list, regardless of the object UID.
+func FindResourceByReference(contract *contract.Contract, namespace, name string) int {
+	resourceIndex := NoResource
+	for i, b := range contract.Resources {
+		if b.Reference.GetNamespace() == namespace && b.Reference.GetName() == name {
+			resourceIndex = i
+			break
+		}
+	}
+	return resourceIndex
+}
+
 const (
 	ResourceChanged = iota
 	ResourceUnchanged
@@ -61,7 +76,7 @@ func AddOrUpdateResourceConfig(contract *contract.Contract, resource *contract.R
 		prev := contract.Resources[index]
 		contract.Resources[index] = resource
 
-		if proto.Equal(prev, resource) {
+		if ResourcesEqual(prev, resource) {
 			return ResourceUnchanged
 		}
 		return ResourceChanged
@@ -74,6 +89,31 @@ func AddOrUpdateResourceConfig(contract *contract.Contract, resource *contract.R
 	return ResourceChanged
 }
 
+// ResourcesEqual returns whether the given resources are semantically equal, that is equal regardless of the order
+// of their topics and egresses, and of empty egress configs, so that cosmetic differences don't bump the contract
+// generation and reload the data plane.
+func ResourcesEqual(a, b *contract.Resource) bool {
+	return proto.Equal(normalizeResource(a), normalizeResource(b))
+}
+
+// normalizeResource returns a copy of the given resource with sorted topics and egresses and with empty egress
+// configs removed, the given resource is left untouched.
+func normalizeResource(resource *contract.Resource) *contract.Resource {
+	if resource == nil {
+		return nil
+	}
+	r := proto.Clone(resource).(*contract.Resource)
+	sort.Strings(r.Topics)
+	r.EgressConfig = normalizeEgressConfig(r.EgressConfig)
+	for i, egress := range r.Egresses {
+		r.Egresses[i] = normalizeEgress(egress)
+	}
+	sort.SliceStable(r.Egresses, func(i, j int) bool {
+		return r.Egresses[i].GetUid() < r.Egresses[j].GetUid()
+	})
+	return r
+}
+
 // DeleteResource deletes the resource at the given index from Resources.
 func DeleteResource(ct *contract.Contract, index int) {
 
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
@@ -79,6 +80,58 @@ func TestFindResource(t *testing.T) {
 	}
 }
 
+func TestFindResourceByReference(t *testing.T) {
+	ct := &contract.Contract{
+		Resources: []*contract.Resource{
+			{
+				Uid:       "1",
+				Reference: &contract.Reference{Uuid: "1", Namespace: "ns", Name: "name-1"},
+			},
+			{
+				Uid: "2",
+			},
+			{
+				Uid:       "3",
+				Reference: &contract.Reference{Uuid: "3", Namespace: "ns", Name: "name-3"},
+			},
+		},
+		Generation: 1,
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		resource  string
+		want      int
+	}{
+		{
+			name:      "resource not found",
+			namespace: "ns",
+			resource:  "name-2",
+			want:      NoResource,
+		},
+		{
+			name:      "resource found",
+			namespace: "ns",
+			resource:  "name-3",
+			want:      2,
+		},
+		{
+			name:      "resource in a different namespace",
+			namespace: "other",
+			resource:  "name-1",
+			want:      NoResource,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindResourceByReference(ct, tt.namespace, tt.resource); got != tt.want {
+				t.Errorf("FindResourceByReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAddOrUpdateResourcesConfig(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -343,6 +396,50 @@ func TestAddOrUpdateResourcesConfig(t *testing.T) {
 				Generation: 1,
 			},
 		},
+		{
+			name: "resource found - reordered topics and egresses - unchanged",
+			haveContract: &contract.Contract{
+				Resources: []*contract.Resource{
+					{
+						Uid:    "1",
+						Topics: []string{"topic-name-1", "topic-name-2"},
+						Egresses: []*contract.Egress{
+							{Uid: "egress-1", ConsumerGroup: "egress-1", Destination: "http://localhost:8080"},
+							{Uid: "egress-2", ConsumerGroup: "egress-2", Destination: "http://localhost:8081"},
+						},
+						BootstrapServers: "broker:9092",
+					},
+				},
+				Generation: 1,
+			},
+			newResource: &contract.Resource{
+				Uid:    "1",
+				Topics: []string{"topic-name-2", "topic-name-1"},
+				Egresses: []*contract.Egress{
+					{Uid: "egress-2", ConsumerGroup: "egress-2", Destination: "http://localhost:8081", EgressConfig: &contract.EgressConfig{}},
+					{Uid: "egress-1", ConsumerGroup: "egress-1", Destination: "http://localhost:8080"},
+				},
+				EgressConfig:     &contract.EgressConfig{},
+				BootstrapServers: "broker:9092",
+			},
+			index:   0,
+			changed: ResourceUnchanged,
+			wantContract: &contract.Contract{
+				Resources: []*contract.Resource{
+					{
+						Uid:    "1",
+						Topics: []string{"topic-name-2", "topic-name-1"},
+						Egresses: []*contract.Egress{
+							{Uid: "egress-2", ConsumerGroup: "egress-2", Destination: "http://localhost:8081", EgressConfig: &contract.EgressConfig{}},
+							{Uid: "egress-1", ConsumerGroup: "egress-1", Destination: "http://localhost:8080"},
+						},
+						EgressConfig:     &contract.EgressConfig{},
+						BootstrapServers: "broker:9092",
+					},
+				},
+				Generation: 1,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -358,6 +455,66 @@ func TestAddOrUpdateResourcesConfig(t *testing.T) {
 	}
 }
 
+func TestResourcesEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *contract.Resource
+		b    *contract.Resource
+		want bool
+	}{
+		{
+			name: "nil resources",
+			want: true,
+		},
+		{
+			name: "reordered topics",
+			a:    &contract.Resource{Uid: "1", Topics: []string{"t1", "t2"}},
+			b:    &contract.Resource{Uid: "1", Topics: []string{"t2", "t1"}},
+			want: true,
+		},
+		{
+			name: "reordered egresses",
+			a:    &contract.Resource{Uid: "1", Egresses: []*contract.Egress{{Uid: "e1"}, {Uid: "e2"}}},
+			b:    &contract.Resource{Uid: "1", Egresses: []*contract.Egress{{Uid: "e2"}, {Uid: "e1"}}},
+			want: true,
+		},
+		{
+			name: "empty egress config",
+			a:    &contract.Resource{Uid: "1", EgressConfig: &contract.EgressConfig{}, Egresses: []*contract.Egress{{Uid: "e1", EgressConfig: &contract.EgressConfig{}}}},
+			b:    &contract.Resource{Uid: "1", Egresses: []*contract.Egress{{Uid: "e1"}}},
+			want: true,
+		},
+		{
+			name: "different topics",
+			a:    &contract.Resource{Uid: "1", Topics: []string{"t1", "t2"}},
+			b:    &contract.Resource{Uid: "1", Topics: []string{"t1", "t3"}},
+			want: false,
+		},
+		{
+			name: "different egresses",
+			a:    &contract.Resource{Uid: "1", Egresses: []*contract.Egress{{Uid: "e1", Destination: "http://a"}}},
+			b:    &contract.Resource{Uid: "1", Egresses: []*contract.Egress{{Uid: "e1", Destination: "http://b"}}},
+			want: false,
+		},
+		{
+			name: "different egress config",
+			a:    &contract.Resource{Uid: "1", EgressConfig: &contract.EgressConfig{Retry: 3}},
+			b:    &contract.Resource{Uid: "1", EgressConfig: &contract.EgressConfig{}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := proto.Clone(tt.a), proto.Clone(tt.b)
+			assert.Equal(t, tt.want, ResourcesEqual(tt.a, tt.b))
+			assert.Equal(t, tt.want, ResourcesEqual(tt.b, tt.a))
+			// The resources must be left untouched.
+			assert.True(t, proto.Equal(a, tt.a))
+			assert.True(t, proto.Equal(b, tt.b))
+		})
+	}
+}
+
 func TestDeleteResource(t *testing.T) {
 
 	tests := []struct {
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+)
+
+const (
+	// ExternalConfigAnnotation marks the broker config ConfigMap as provisioned out-of-band, so that its existence
+	// isn't verified at admission.
+	ExternalConfigAnnotation = "kafka.eventing.knative.dev/external.config"
+)
+
+// BrokerConfigNamespace returns the namespace of the broker config, it defaults to the broker namespace.
+func BrokerConfigNamespace(broker *eventing.Broker) string {
+	namespace := broker.Spec.Config.Namespace
+	if namespace == "" {
+		// Namespace not specified, use broker namespace.
+		namespace = broker.Namespace
+	}
+	return namespace
+}
+
+// BrokerConfigMap returns the ConfigMap referenced by the given broker config.
+//
+// When the ConfigMap isn't found, it returns a ConfigMap rebuilt from the broker status annotations along with the
+// not found error.
+func BrokerConfigMap(lister corelisters.ConfigMapLister, broker *eventing.Broker) (*corev1.ConfigMap, error) {
+	if strings.ToLower(broker.Spec.Config.Kind) != "configmap" {
+		return nil, fmt.Errorf("supported config Kind: ConfigMap - got %s", broker.Spec.Config.Kind)
+	}
+
+	namespace := BrokerConfigNamespace(broker)
+
+	// There might be cases where the ConfigMap is deleted before the Broker.
+	// In these cases, we rebuild the ConfigMap from broker status annotations.
+	//
+	// These annotations aren't guaranteed to be there or valid since there might
+	// be other actions messing with them, so when we try to rebuild the ConfigMap's
+	// data but the guess is wrong or data are invalid we return the
+	// `StatusReasonNotFound` error instead of the error generated from the fake
+	// "re-built" ConfigMap.
+
+	cm, getCmError := lister.ConfigMaps(namespace).Get(broker.Spec.Config.Name)
+	if getCmError != nil && !apierrors.IsNotFound(getCmError) {
+		return cm, fmt.Errorf("failed to get configmap %s/%s: %w", namespace, broker.Spec.Config.Name, getCmError)
+	}
+	if apierrors.IsNotFound(getCmError) {
+		// will at least return an empty CM
+		cm = rebuildCMFromStatusAnnotations(broker)
+	}
+
+	return cm, getCmError
+}
+
+// BrokerConfigMapWithFallback returns the ConfigMap referenced by the given broker config like BrokerConfigMap, except
+// that when the config doesn't specify a namespace and the ConfigMap isn't found in the broker namespace, the
+// ConfigMap with the same name in fallbackNamespace is returned.
+//
+// The ConfigMap is therefore looked up in the config namespace, then in the broker namespace and then in
+// fallbackNamespace, an empty fallbackNamespace disables the fallback.
+func BrokerConfigMapWithFallback(lister corelisters.ConfigMapLister, broker *eventing.Broker, fallbackNamespace string) (*corev1.ConfigMap, error) {
+	cm, err := BrokerConfigMap(lister, broker)
+	if !apierrors.IsNotFound(err) || fallbackNamespace == "" || broker.Spec.Config.Namespace != "" || fallbackNamespace == broker.Namespace {
+		return cm, err
+	}
+
+	fallback, fallbackErr := lister.ConfigMaps(fallbackNamespace).Get(broker.Spec.Config.Name)
+	if apierrors.IsNotFound(fallbackErr) {
+		return cm, err
+	}
+	if fallbackErr != nil {
+		return nil, fmt.Errorf("failed to get configmap %s/%s: %w", fallbackNamespace, broker.Spec.Config.Name, fallbackErr)
+	}
+	return fallback, nil
+}
+
+// Creates the Broker ConfigMap from the status annotation, if any present
+func rebuildCMFromStatusAnnotations(br *eventing.Broker) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: br.Spec.Config.Namespace,
+			Name:      br.Spec.Config.Name,
+		},
+	}
+	for k, v := range br.Status.Annotations {
+		if cm.Data == nil {
+			cm.Data = make(map[string]string, len(br.Status.Annotations))
+		}
+		cm.Data[k] = v
+	}
+	return cm
+}
+
+// IsBrokerConfigSecret returns true if the given broker config references a Secret rather than a ConfigMap.
+func IsBrokerConfigSecret(broker *eventing.Broker) bool {
+	return broker.Spec.Config != nil && strings.EqualFold(broker.Spec.Config.Kind, "Secret")
+}
+
+// BrokerConfigFromSecret returns the broker config stored in the Secret referenced by the given broker as a ConfigMap
+// having the Secret data, so that the topic config is built the same way as for ConfigMap based configs.
+//
+// Secret based configs are sensitive, therefore they're never rebuilt from the broker status annotations: when the
+// Secret isn't found, it returns an empty ConfigMap along with the NotFound error.
+func BrokerConfigFromSecret(lister corelisters.SecretLister, broker *eventing.Broker) (*corev1.ConfigMap, error) {
+	namespace := BrokerConfigNamespace(broker)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      broker.Spec.Config.Name,
+		},
+	}
+
+	secret, err := lister.Secrets(namespace).Get(broker.Spec.Config.Name)
+	if apierrors.IsNotFound(err) {
+		return cm, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, broker.Spec.Config.Name, err)
+	}
+
+	cm.Data = make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		cm.Data[k] = string(v)
+	}
+	return cm, nil
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestBrokerConfigFromSecret(t *testing.T) {
+	broker := &eventing.Broker{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "broker"},
+		Spec: eventing.BrokerSpec{
+			Config: &duckv1.KReference{Kind: "Secret", Namespace: "config-ns", Name: "config"},
+		},
+	}
+	require.True(t, IsBrokerConfigSecret(broker))
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	lister := corelisters.NewSecretLister(indexer)
+
+	cm, err := BrokerConfigFromSecret(lister, broker)
+	require.True(t, apierrors.IsNotFound(err))
+	require.Equal(t, "config-ns", cm.Namespace)
+	require.Equal(t, "config", cm.Name)
+	require.Empty(t, cm.Data)
+
+	require.NoError(t, indexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "config-ns", Name: "config"},
+		Data: map[string][]byte{
+			BootstrapServersConfigMapKey:         []byte("kafka-1:9092"),
+			DefaultTopicNumPartitionConfigMapKey: []byte("10"),
+		},
+	}))
+
+	cm, err = BrokerConfigFromSecret(lister, broker)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		BootstrapServersConfigMapKey:         "kafka-1:9092",
+		DefaultTopicNumPartitionConfigMapKey: "10",
+	}, cm.Data)
+
+	broker.Spec.Config.Kind = "ConfigMap"
+	require.False(t, IsBrokerConfigSecret(broker))
+}
+
+func TestBrokerConfigMapWithFallback(t *testing.T) {
+	broker := func(configNamespace string) *eventing.Broker {
+		return &eventing.Broker{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "broker"},
+			Spec: eventing.BrokerSpec{
+				Config: &duckv1.KReference{Kind: "ConfigMap", Namespace: configNamespace, Name: "config"},
+			},
+		}
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	lister := corelisters.NewConfigMapLister(indexer)
+
+	_, err := BrokerConfigMapWithFallback(lister, broker(""), "operator-ns")
+	require.True(t, apierrors.IsNotFound(err))
+
+	require.NoError(t, indexer.Add(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "operator-ns", Name: "config"}}))
+
+	cm, err := BrokerConfigMapWithFallback(lister, broker(""), "operator-ns")
+	require.NoError(t, err)
+	require.Equal(t, "operator-ns", cm.Namespace)
+
+	// The fallback is used only when the config doesn't specify a namespace.
+	_, err = BrokerConfigMapWithFallback(lister, broker("config-ns"), "operator-ns")
+	require.True(t, apierrors.IsNotFound(err))
+
+	_, err = BrokerConfigMapWithFallback(lister, broker(""), "")
+	require.True(t, apierrors.IsNotFound(err))
+
+	// The broker namespace takes precedence over the fallback namespace.
+	require.NoError(t, indexer.Add(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "config"}}))
+
+	cm, err = BrokerConfigMapWithFallback(lister, broker(""), "operator-ns")
+	require.NoError(t, err)
+	require.Equal(t, "ns", cm.Namespace)
+}
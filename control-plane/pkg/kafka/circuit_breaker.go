@@ -0,0 +1,155 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCircuitBreakerWindow is the default window in which consecutive failures open the circuit.
+	DefaultCircuitBreakerWindow = time.Minute
+	// DefaultCircuitBreakerCooldown is the default time after which an open circuit lets a single attempt through.
+	DefaultCircuitBreakerCooldown = 30 * time.Second
+)
+
+// CircuitOpenError is returned when connecting to a Kafka cluster is short-circuited because the previous attempts
+// failed.
+type CircuitOpenError struct {
+	BootstrapServers string
+	// RetryAfter is the time after which a new attempt is allowed.
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker open for Kafka cluster %s after repeated connection failures", e.BootstrapServers)
+}
+
+// IsCircuitOpen returns the CircuitOpenError wrapped by the given error, if any.
+func IsCircuitOpen(err error) (*CircuitOpenError, bool) {
+	var circuitOpen *CircuitOpenError
+	if errors.As(err, &circuitOpen) {
+		return circuitOpen, true
+	}
+	return nil, false
+}
+
+// CircuitBreaker tracks connection failures to Kafka clusters keyed by bootstrap servers.
+//
+// A circuit opens after threshold consecutive failures within window, while it's open attempts are short-circuited
+// until cooldown elapses, then a single attempt is let through (half-open): a success closes the circuit and a
+// failure opens it again.
+type CircuitBreaker struct {
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+
+	now func() time.Time
+
+	lock     sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	failures     int
+	firstFailure time.Time
+	open         bool
+	openedAt     time.Time
+}
+
+// NewCircuitBreaker creates a CircuitBreaker, non-positive window and cooldown use the defaults.
+func NewCircuitBreaker(threshold int, window, cooldown time.Duration) *CircuitBreaker {
+	if window <= 0 {
+		window = DefaultCircuitBreakerWindow
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultCircuitBreakerCooldown
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		window:    window,
+		cooldown:  cooldown,
+		now:       time.Now,
+		circuits:  make(map[string]*circuit),
+	}
+}
+
+// Allow returns nil when an attempt to connect to the given bootstrap servers is allowed, otherwise it returns a
+// CircuitOpenError.
+func (b *CircuitBreaker) Allow(bootstrapServers []string) error {
+	key := BootstrapServersCommaSeparated(bootstrapServers)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	c, ok := b.circuits[key]
+	if !ok || !c.open {
+		return nil
+	}
+
+	now := b.now()
+	if retryAfter := c.openedAt.Add(b.cooldown).Sub(now); retryAfter > 0 {
+		return &CircuitOpenError{BootstrapServers: key, RetryAfter: retryAfter}
+	}
+
+	// Half-open, let this attempt through and short-circuit the others for another cooldown.
+	c.openedAt = now
+	return nil
+}
+
+// RecordSuccess closes the circuit of the given bootstrap servers.
+func (b *CircuitBreaker) RecordSuccess(bootstrapServers []string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.circuits, BootstrapServersCommaSeparated(bootstrapServers))
+}
+
+// RecordFailure records a failed attempt to connect to the given bootstrap servers.
+func (b *CircuitBreaker) RecordFailure(bootstrapServers []string) {
+	key := BootstrapServersCommaSeparated(bootstrapServers)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.now()
+	c, ok := b.circuits[key]
+	if !ok {
+		c = &circuit{}
+		b.circuits[key] = c
+	}
+
+	if c.open {
+		// The half-open attempt failed.
+		c.openedAt = now
+		return
+	}
+
+	if c.failures == 0 || now.Sub(c.firstFailure) > b.window {
+		c.failures = 0
+		c.firstFailure = now
+	}
+	c.failures++
+
+	if c.failures >= b.threshold {
+		c.open = true
+		c.openedAt = now
+	}
+}
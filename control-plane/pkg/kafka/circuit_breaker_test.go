@@ -0,0 +1,101 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(3, time.Minute, 30*time.Second)
+	breaker.now = func() time.Time { return now }
+
+	servers := []string{"kafka-1:9092", "kafka-2:9092"}
+	otherServers := []string{"kafka-3:9092"}
+
+	// Failures below the threshold keep the circuit closed.
+	for i := 0; i < 2; i++ {
+		require.Nil(t, breaker.Allow(servers))
+		breaker.RecordFailure(servers)
+	}
+	require.Nil(t, breaker.Allow(servers))
+	breaker.RecordFailure(servers)
+
+	// The threshold is reached, short-circuit attempts.
+	err := breaker.Allow(servers)
+	circuitOpen, ok := IsCircuitOpen(fmt.Errorf("wrapped: %w", err))
+	require.True(t, ok, "expected circuit open error, got %v", err)
+	assert.Equal(t, "kafka-1:9092,kafka-2:9092", circuitOpen.BootstrapServers)
+	assert.Equal(t, 30*time.Second, circuitOpen.RetryAfter)
+
+	// Circuits are independent.
+	assert.Nil(t, breaker.Allow(otherServers))
+
+	// After the cooldown a single attempt is let through.
+	now = now.Add(30 * time.Second)
+	require.Nil(t, breaker.Allow(servers))
+	_, ok = IsCircuitOpen(breaker.Allow(servers))
+	require.True(t, ok)
+
+	// The half-open attempt fails, the circuit opens again.
+	breaker.RecordFailure(servers)
+	now = now.Add(10 * time.Second)
+	circuitOpen, ok = IsCircuitOpen(breaker.Allow(servers))
+	require.True(t, ok)
+	assert.Equal(t, 20*time.Second, circuitOpen.RetryAfter)
+
+	// The half-open attempt succeeds, the circuit closes.
+	now = now.Add(20 * time.Second)
+	require.Nil(t, breaker.Allow(servers))
+	breaker.RecordSuccess(servers)
+	assert.Nil(t, breaker.Allow(servers))
+	assert.Nil(t, breaker.Allow(servers))
+}
+
+func TestCircuitBreakerFailuresOutsideWindow(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(2, time.Minute, 30*time.Second)
+	breaker.now = func() time.Time { return now }
+
+	servers := []string{"kafka-1:9092"}
+
+	breaker.RecordFailure(servers)
+	now = now.Add(2 * time.Minute)
+	breaker.RecordFailure(servers)
+	assert.Nil(t, breaker.Allow(servers))
+
+	breaker.RecordFailure(servers)
+	_, ok := IsCircuitOpen(breaker.Allow(servers))
+	assert.True(t, ok)
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	breaker := NewCircuitBreaker(2, 0, 0)
+
+	servers := []string{"kafka-1:9092"}
+
+	breaker.RecordFailure(servers)
+	breaker.RecordSuccess(servers)
+	breaker.RecordFailure(servers)
+	assert.Nil(t, breaker.Allow(servers))
+}
@@ -17,8 +17,12 @@
 package kafka
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/Shopify/sarama"
 	"github.com/rcrowley/go-metrics"
+	"golang.org/x/net/proxy"
 )
 
 func init() {
@@ -47,12 +51,49 @@ func DisableOffsetAutoCommitConfigOption(config *sarama.Config) error {
 	return nil
 }
 
+// TimeoutsConfigOption returns a ConfigOption setting the timeouts of the requests made to the Kafka cluster,
+// non-positive timeouts leave the sarama defaults.
+func TimeoutsConfigOption(dial, read, metadata time.Duration) ConfigOption {
+	return func(config *sarama.Config) error {
+		if dial > 0 {
+			config.Net.DialTimeout = dial
+		}
+		if read > 0 {
+			config.Net.ReadTimeout = read
+		}
+		if metadata > 0 {
+			config.Metadata.Timeout = metadata
+		}
+		return nil
+	}
+}
+
 // NewClusterAdminClientFunc creates new sarama.ClusterAdmin.
 type NewClusterAdminClientFunc func(addrs []string, config *sarama.Config) (sarama.ClusterAdmin, error)
 
 // NewClientFunc creates new sarama.Client.
 type NewClientFunc func(addrs []string, config *sarama.Config) (sarama.Client, error)
 
+// DialerFactoryFunc creates the dialer used to connect to the Kafka cluster with the given bootstrap servers.
+type DialerFactoryFunc func(addrs []string, config *sarama.Config) (proxy.Dialer, error)
+
+// WithDialer returns a copy of the given config whose connections are opened with the dialer created by factory.
+//
+// The given config is left untouched, a nil factory returns the given config as is.
+func WithDialer(factory DialerFactoryFunc, addrs []string, config *sarama.Config) (*sarama.Config, error) {
+	if factory == nil {
+		return config, nil
+	}
+	dialer, err := factory(addrs, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dialer for %s: %w", BootstrapServersCommaSeparated(addrs), err)
+	}
+	c := *config
+	c.Net.Proxy.Enable = true
+	c.Net.Proxy.Dialer = dialer
+	return &c, nil
+}
+
 // GetSaramaConfig returns Kafka Client configuration with the given options applied.
 func GetSaramaConfig(configOptions ...ConfigOption) (*sarama.Config, error) {
 	config := sarama.NewConfig()
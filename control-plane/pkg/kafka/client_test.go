@@ -0,0 +1,72 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/proxy"
+)
+
+func TestWithDialer(t *testing.T) {
+	addrs := []string{"my-cluster-kafka-bootstrap:9092"}
+	config := sarama.NewConfig()
+
+	got, err := WithDialer(nil, addrs, config)
+	require.NoError(t, err)
+	require.Same(t, config, got)
+
+	dialer := &net.Dialer{}
+	got, err = WithDialer(func(a []string, c *sarama.Config) (proxy.Dialer, error) {
+		require.Equal(t, addrs, a)
+		require.Same(t, config, c)
+		return dialer, nil
+	}, addrs, config)
+	require.NoError(t, err)
+	require.NotSame(t, config, got)
+	require.True(t, got.Net.Proxy.Enable)
+	require.Same(t, dialer, got.Net.Proxy.Dialer)
+	require.False(t, config.Net.Proxy.Enable)
+	require.Nil(t, config.Net.Proxy.Dialer)
+
+	factoryErr := errors.New("failed")
+	_, err = WithDialer(func([]string, *sarama.Config) (proxy.Dialer, error) {
+		return nil, factoryErr
+	}, addrs, config)
+	require.ErrorIs(t, err, factoryErr)
+}
+
+func TestTimeoutsConfigOption(t *testing.T) {
+	defaults := sarama.NewConfig()
+
+	config, err := GetSaramaConfig(TimeoutsConfigOption(time.Second, 2*time.Second, 3*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, time.Second, config.Net.DialTimeout)
+	require.Equal(t, 2*time.Second, config.Net.ReadTimeout)
+	require.Equal(t, 3*time.Second, config.Metadata.Timeout)
+
+	config, err = GetSaramaConfig(TimeoutsConfigOption(0, -1, 0))
+	require.NoError(t, err)
+	require.Equal(t, defaults.Net.DialTimeout, config.Net.DialTimeout)
+	require.Equal(t, defaults.Net.ReadTimeout, config.Net.ReadTimeout)
+	require.Equal(t, defaults.Metadata.Timeout, config.Metadata.Timeout)
+}
@@ -0,0 +1,448 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	corev1 "k8s.io/api/core/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+)
+
+const (
+	// DefaultClusterAdminPoolIdleTTL is the default time after which an unused pooled ClusterAdmin gets closed.
+	DefaultClusterAdminPoolIdleTTL = 5 * time.Minute
+)
+
+// ClusterAdminPool caches sarama.ClusterAdmin clients keyed by bootstrap servers and auth secret, so that
+// reconciling many resources against the same Kafka cluster doesn't establish a new connection every time.
+//
+// Clients returned by the pool are shared, calling Close on them releases them. Clients are created outside the pool
+// lock, so that an unreachable cluster doesn't block the reconciliations against other clusters, and concurrent
+// requests of the same client wait for a single connection.
+//
+// A pooled client is removed from the pool when:
+//   - it hasn't been used for longer than the idle TTL,
+//   - the ResourceVersion of the associated secret changes,
+//   - the associated secret is invalidated,
+//   - the pool is full and the client is the least recently used one,
+//   - an operation of the client fails with a connection or authentication error, so that a broken connection, for
+//     example, because of a Kafka broker restart or of an expired SASL session, is replaced by the next Get.
+//
+// Removed clients are closed once every holder released them.
+//
+// When a CircuitBreaker is given, Get is short-circuited while the circuit of the bootstrap servers is open, including
+// when a client is pooled, and the pool records the result of creating clients and the connection errors of the
+// pooled clients.
+type ClusterAdminPool struct {
+	newClusterAdmin NewClusterAdminClientFunc
+	size            int
+	idleTTL         time.Duration
+	breaker         *CircuitBreaker
+
+	mu      sync.Mutex
+	clients map[string]*pooledClusterAdmin
+	// dials are the clients being created, keyed like clients.
+	dials map[string]*clusterAdminDial
+	// closed is set once every pooled client has been closed, clients created afterwards aren't pooled.
+	closed bool
+}
+
+type pooledClusterAdmin struct {
+	admin sarama.ClusterAdmin
+	// secret is the <namespace>/<name> of the secret used to create the client, if any.
+	secret string
+	// version is the ResourceVersion of the secret used to create the client.
+	version  string
+	lastUsed time.Time
+	// refs is the number of holders of the client that haven't released it yet.
+	refs int
+	// removed is set once the client has been removed from the pool, it's closed once refs drops to zero.
+	removed bool
+}
+
+// clusterAdminDial is a client being created by a ClusterAdminPool.
+type clusterAdminDial struct {
+	version string
+	// done is closed once the client has been created, or once its creation failed with err.
+	done chan struct{}
+	err  error
+}
+
+// sharedClusterAdmin is a sarama.ClusterAdmin owned by a ClusterAdminPool.
+type sharedClusterAdmin struct {
+	sarama.ClusterAdmin
+	release func()
+	once    sync.Once
+	// observe is called with the error returned by every operation of the client.
+	observe func(err error)
+}
+
+// Close releases the client, the ClusterAdminPool owns the underlying client.
+func (s *sharedClusterAdmin) Close() error {
+	s.once.Do(s.release)
+	return nil
+}
+
+func (s *sharedClusterAdmin) CreateTopic(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
+	err := s.ClusterAdmin.CreateTopic(topic, detail, validateOnly)
+	s.observe(err)
+	return err
+}
+
+func (s *sharedClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	topics, err := s.ClusterAdmin.ListTopics()
+	s.observe(err)
+	return topics, err
+}
+
+func (s *sharedClusterAdmin) DescribeTopics(topics []string) ([]*sarama.TopicMetadata, error) {
+	metadata, err := s.ClusterAdmin.DescribeTopics(topics)
+	s.observe(err)
+	return metadata, err
+}
+
+func (s *sharedClusterAdmin) DeleteTopic(topic string) error {
+	err := s.ClusterAdmin.DeleteTopic(topic)
+	s.observe(err)
+	return err
+}
+
+func (s *sharedClusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
+	err := s.ClusterAdmin.CreatePartitions(topic, count, assignment, validateOnly)
+	s.observe(err)
+	return err
+}
+
+func (s *sharedClusterAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+	entries, err := s.ClusterAdmin.DescribeConfig(resource)
+	s.observe(err)
+	return entries, err
+}
+
+func (s *sharedClusterAdmin) AlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]*string, validateOnly bool) error {
+	err := s.ClusterAdmin.AlterConfig(resourceType, name, entries, validateOnly)
+	s.observe(err)
+	return err
+}
+
+func (s *sharedClusterAdmin) IncrementalAlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]sarama.IncrementalAlterConfigsEntry, validateOnly bool) error {
+	err := s.ClusterAdmin.IncrementalAlterConfig(resourceType, name, entries, validateOnly)
+	s.observe(err)
+	return err
+}
+
+func (s *sharedClusterAdmin) DescribeConsumerGroups(groups []string) ([]*sarama.GroupDescription, error) {
+	descriptions, err := s.ClusterAdmin.DescribeConsumerGroups(groups)
+	s.observe(err)
+	return descriptions, err
+}
+
+func (s *sharedClusterAdmin) ListConsumerGroupOffsets(group string, topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	offsets, err := s.ClusterAdmin.ListConsumerGroupOffsets(group, topicPartitions)
+	s.observe(err)
+	return offsets, err
+}
+
+func (s *sharedClusterAdmin) DeleteConsumerGroup(group string) error {
+	err := s.ClusterAdmin.DeleteConsumerGroup(group)
+	s.observe(err)
+	return err
+}
+
+func (s *sharedClusterAdmin) DescribeCluster() ([]*sarama.Broker, int32, error) {
+	brokers, controllerID, err := s.ClusterAdmin.DescribeCluster()
+	s.observe(err)
+	return brokers, controllerID, err
+}
+
+// NewClusterAdminPool creates a ClusterAdminPool holding at most size clients.
+// A non-positive idleTTL defaults to DefaultClusterAdminPoolIdleTTL, a nil breaker disables the circuit breaker.
+//
+// Every pooled client is closed when the given context is done.
+func NewClusterAdminPool(ctx context.Context, newClusterAdmin NewClusterAdminClientFunc, size int, idleTTL time.Duration, breaker *CircuitBreaker) *ClusterAdminPool {
+	if idleTTL <= 0 {
+		idleTTL = DefaultClusterAdminPoolIdleTTL
+	}
+
+	p := &ClusterAdminPool{
+		newClusterAdmin: newClusterAdmin,
+		size:            size,
+		idleTTL:         idleTTL,
+		breaker:         breaker,
+		clients:         make(map[string]*pooledClusterAdmin, size),
+		dials:           make(map[string]*clusterAdminDial),
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				p.closeAll()
+				return
+			case <-time.After(idleTTL):
+				p.removeExpiredClients(time.Now())
+			}
+		}
+	}()
+
+	return p
+}
+
+// Get returns a sarama.ClusterAdmin for the given bootstrap servers, secret and trust bundle, creating it when there
+// is no pooled client or when the pooled client was created with a different version of the secret or trust bundle.
+func (p *ClusterAdminPool) Get(addrs []string, secret *corev1.Secret, trustBundle *contract.TrustBundleReference, config *sarama.Config) (sarama.ClusterAdmin, error) {
+	if p.breaker != nil {
+		if err := p.breaker.Allow(addrs); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.size <= 0 {
+		// Nothing can be pooled, the caller owns the client.
+		return p.dial(addrs, config)
+	}
+
+	key, version := clusterAdminPoolKey(addrs, secret, trustBundle)
+
+	p.mu.Lock()
+	for {
+		if c, ok := p.clients[key]; ok {
+			if c.version == version {
+				c.lastUsed = time.Now()
+				c.refs++
+				p.mu.Unlock()
+				return p.share(addrs, key, c), nil
+			}
+			// The secret changed, credentials might have been rotated.
+			p.remove(key)
+		}
+
+		d, ok := p.dials[key]
+		if !ok {
+			break
+		}
+		p.mu.Unlock()
+		<-d.done
+		if d.err != nil && d.version == version {
+			return nil, d.err
+		}
+		p.mu.Lock()
+	}
+
+	d := &clusterAdminDial{version: version, done: make(chan struct{})}
+	p.dials[key] = d
+	p.mu.Unlock()
+
+	admin, err := p.dial(addrs, config)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.dials, key)
+	d.err = err
+	close(d.done)
+
+	if err != nil {
+		return nil, err
+	}
+	if p.closed {
+		return admin, nil
+	}
+
+	if len(p.clients) >= p.size {
+		p.removeLeastRecentlyUsed()
+	}
+	c := &pooledClusterAdmin{
+		admin:    admin,
+		secret:   secretKey(secret),
+		version:  version,
+		lastUsed: time.Now(),
+		refs:     1,
+	}
+	p.clients[key] = c
+
+	return p.share(addrs, key, c), nil
+}
+
+// dial creates a client, recording the result in the circuit breaker, if any.
+func (p *ClusterAdminPool) dial(addrs []string, config *sarama.Config) (sarama.ClusterAdmin, error) {
+	admin, err := p.newClusterAdmin(addrs, config)
+	if p.breaker != nil {
+		if err != nil {
+			p.breaker.RecordFailure(addrs)
+		} else {
+			p.breaker.RecordSuccess(addrs)
+		}
+	}
+	return admin, err
+}
+
+// share returns a client releasing the given pooled client when it's closed, and removing it from the pool when one
+// of its operations fails with a connection or authentication error.
+func (p *ClusterAdminPool) share(addrs []string, key string, c *pooledClusterAdmin) sarama.ClusterAdmin {
+	return &sharedClusterAdmin{
+		ClusterAdmin: c.admin,
+		release: func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+
+			c.refs--
+			if c.removed && c.refs == 0 {
+				_ = c.admin.Close()
+			}
+		},
+		observe: func(err error) {
+			if !isConnectionError(err) {
+				return
+			}
+			if p.breaker != nil {
+				p.breaker.RecordFailure(addrs)
+			}
+
+			p.mu.Lock()
+			defer p.mu.Unlock()
+
+			// The client might have already been replaced.
+			if p.clients[key] == c {
+				p.remove(key)
+			}
+		},
+	}
+}
+
+// Len returns the number of pooled clients.
+func (p *ClusterAdminPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.clients)
+}
+
+// InvalidateSecret closes the pooled clients created with the secret with the given namespace and name, so that
+// clients sharing a rotated secret are closed once, rather than by the first reconciliation of each resource.
+func (p *ClusterAdminPool) InvalidateSecret(namespace, name string) {
+	key := namespace + "/" + name
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for k, c := range p.clients {
+		if c.secret == key {
+			p.remove(k)
+		}
+	}
+}
+
+func (p *ClusterAdminPool) removeExpiredClients(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, c := range p.clients {
+		// Held clients are in use.
+		if c.refs == 0 && now.Sub(c.lastUsed) > p.idleTTL {
+			p.remove(key)
+		}
+	}
+}
+
+func (p *ClusterAdminPool) removeLeastRecentlyUsed() {
+	var lruKey string
+	var lru *pooledClusterAdmin
+	for key, c := range p.clients {
+		if lru == nil || c.lastUsed.Before(lru.lastUsed) {
+			lruKey, lru = key, c
+		}
+	}
+	if lru != nil {
+		p.remove(lruKey)
+	}
+}
+
+func (p *ClusterAdminPool) closeAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	for key := range p.clients {
+		p.remove(key)
+	}
+}
+
+// remove removes the client associated with the given key, the client is closed right away when it isn't held,
+// otherwise once it's released. It must be called with the lock held.
+func (p *ClusterAdminPool) remove(key string) {
+	if c, ok := p.clients[key]; ok {
+		delete(p.clients, key)
+		c.removed = true
+		if c.refs == 0 {
+			_ = c.admin.Close()
+		}
+	}
+}
+
+// isConnectionError returns true when the given error returned by an operation of a client means that the connection
+// of the client is broken and a new client is needed, for example, because the Kafka brokers have been restarted or
+// because the SASL session has expired.
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netError net.Error
+	if errors.Is(err, sarama.ErrOutOfBrokers) ||
+		errors.Is(err, sarama.ErrNotConnected) ||
+		errors.Is(err, sarama.ErrClosedClient) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.As(err, &netError) {
+		return true
+	}
+	code, ok := KafkaErrorCode(err)
+	return ok && (code == sarama.ErrSASLAuthenticationFailed || code == sarama.ErrNetworkException)
+}
+
+func secretKey(secret *corev1.Secret) string {
+	if secret == nil {
+		return ""
+	}
+	return secret.Namespace + "/" + secret.Name
+}
+
+// clusterAdminPoolKey returns the key and the version of the pooled client for the given bootstrap servers, secret and
+// trust bundle. The TLS config of a client depends on both the secret and the trust bundle, so clients are never
+// shared across them.
+func clusterAdminPoolKey(addrs []string, secret *corev1.Secret, trustBundle *contract.TrustBundleReference) (string, string) {
+	key, version := []string{BootstrapServersCommaSeparated(addrs)}, []string{""}
+	if secret != nil {
+		key = append(key, secret.Namespace, secret.Name)
+		version[0] = secret.ResourceVersion
+	}
+	if trustBundle != nil {
+		ref := trustBundle.GetReference()
+		key = append(key, "trust-bundle", ref.GetNamespace(), ref.GetName(), trustBundle.GetKey())
+		version = append(version, ref.GetVersion())
+	}
+	return strings.Join(key, "/"), strings.Join(version, "/")
+}
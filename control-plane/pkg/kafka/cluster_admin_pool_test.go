@@ -0,0 +1,329 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	kafkatesting "knative.dev/eventing-kafka-broker/control-plane/pkg/kafka/testing"
+)
+
+func TestClusterAdminPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var created []*kafkatesting.MockKafkaClusterAdmin
+	newClusterAdmin := func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		admin := &kafkatesting.MockKafkaClusterAdmin{T: t}
+		created = append(created, admin)
+		return admin, nil
+	}
+
+	pool := NewClusterAdminPool(ctx, newClusterAdmin, 2, time.Hour, nil)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "secret", ResourceVersion: "1"},
+	}
+
+	admin, err := pool.Get([]string{"kafka-1:9092"}, secret, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	require.NoError(t, admin.Close())
+	require.False(t, created[0].ExpectedClose, "pooled client closed by the caller")
+
+	admin, err = pool.Get([]string{"kafka-1:9092"}, secret, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	require.NoError(t, admin.Close())
+	require.Len(t, created, 1, "expected pooled client to be reused")
+
+	// Secret rotation invalidates the pooled client.
+	rotated := secret.DeepCopy()
+	rotated.ResourceVersion = "2"
+	admin, err = pool.Get([]string{"kafka-1:9092"}, rotated, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	require.NoError(t, admin.Close())
+	require.Len(t, created, 2)
+	require.True(t, created[0].ExpectedClose, "expected invalidated client to be closed")
+	require.Equal(t, 1, pool.Len())
+
+	// The pool is full, the least recently used client gets closed.
+	time.Sleep(time.Millisecond)
+	admin, err = pool.Get([]string{"kafka-2:9092"}, nil, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	require.NoError(t, admin.Close())
+	admin, err = pool.Get([]string{"kafka-3:9092"}, nil, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	require.NoError(t, admin.Close())
+	require.Len(t, created, 4)
+	require.True(t, created[1].ExpectedClose, "expected least recently used client to be closed")
+	require.Equal(t, 2, pool.Len())
+
+	// Idle clients get closed.
+	pool.removeExpiredClients(time.Now().Add(2 * time.Hour))
+	require.Equal(t, 0, pool.Len())
+	require.True(t, created[2].ExpectedClose)
+	require.True(t, created[3].ExpectedClose)
+}
+
+func TestClusterAdminPoolTrustBundle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var created []*kafkatesting.MockKafkaClusterAdmin
+	newClusterAdmin := func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		admin := &kafkatesting.MockKafkaClusterAdmin{T: t}
+		created = append(created, admin)
+		return admin, nil
+	}
+
+	pool := NewClusterAdminPool(ctx, newClusterAdmin, 10, time.Hour, nil)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "secret", ResourceVersion: "1"},
+	}
+	trustBundle := func(name, version string) *contract.TrustBundleReference {
+		return &contract.TrustBundleReference{
+			Reference: &contract.Reference{Namespace: "ns", Name: name, Version: version},
+			Key:       "ca.crt",
+		}
+	}
+
+	get := func(trustBundle *contract.TrustBundleReference) {
+		admin, err := pool.Get([]string{"kafka-1:9092"}, secret, trustBundle, sarama.NewConfig())
+		require.NoError(t, err)
+		require.NoError(t, admin.Close())
+	}
+
+	get(nil)
+	get(trustBundle("bundle-1", "1"))
+	get(trustBundle("bundle-2", "1"))
+	require.Len(t, created, 3, "expected clients with different trust bundles not to be shared")
+	require.Equal(t, 3, pool.Len())
+
+	get(trustBundle("bundle-1", "1"))
+	require.Len(t, created, 3, "expected pooled client to be reused")
+
+	// Trust bundle rotation invalidates the pooled client.
+	get(trustBundle("bundle-1", "2"))
+	require.Len(t, created, 4)
+	require.True(t, created[1].ExpectedClose, "expected invalidated client to be closed")
+	require.False(t, created[0].ExpectedClose)
+	require.False(t, created[2].ExpectedClose)
+	require.Equal(t, 3, pool.Len())
+}
+
+func TestClusterAdminPoolConnectionErrorReplacesClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var created []*kafkatesting.MockKafkaClusterAdmin
+	pool := NewClusterAdminPool(ctx, func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		admin := &kafkatesting.MockKafkaClusterAdmin{T: t, ExpectedTopicName: "topic"}
+		if len(created) == 0 {
+			admin.ErrorOnDeleteTopic = sarama.ErrOutOfBrokers
+		}
+		created = append(created, admin)
+		return admin, nil
+	}, 2, time.Hour, nil)
+
+	admin, err := pool.Get([]string{"kafka-1:9092"}, nil, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	require.ErrorIs(t, admin.DeleteTopic("topic"), sarama.ErrOutOfBrokers)
+	require.Equal(t, 0, pool.Len(), "expected broken client to be removed")
+	require.False(t, created[0].ExpectedClose, "held client closed")
+	require.NoError(t, admin.Close())
+	require.True(t, created[0].ExpectedClose, "expected broken client to be closed once released")
+
+	admin, err = pool.Get([]string{"kafka-1:9092"}, nil, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	require.Len(t, created, 2, "expected broken client to be replaced")
+	require.NoError(t, admin.DeleteTopic("topic"))
+	require.NoError(t, admin.Close())
+
+	// Errors returned by the Kafka cluster don't break the connection.
+	created[1].ErrorOnDeleteTopic = sarama.ErrInvalidTopic
+	admin, err = pool.Get([]string{"kafka-1:9092"}, nil, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	require.ErrorIs(t, admin.DeleteTopic("topic"), sarama.ErrInvalidTopic)
+	require.NoError(t, admin.Close())
+	require.Equal(t, 1, pool.Len())
+	require.Len(t, created, 2)
+}
+
+func TestClusterAdminPoolCircuitBreaker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	breaker := NewCircuitBreaker(1, time.Hour, time.Hour)
+	admin := &kafkatesting.MockKafkaClusterAdmin{T: t, ExpectedTopicName: "topic", ErrorOnDeleteTopic: sarama.ErrNotConnected}
+	dials := 0
+	pool := NewClusterAdminPool(ctx, func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		dials++
+		return admin, nil
+	}, 2, time.Hour, breaker)
+
+	got, err := pool.Get([]string{"kafka-1:9092"}, nil, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	held, err := pool.Get([]string{"kafka-1:9092"}, nil, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	require.Equal(t, 1, dials)
+
+	// The connection error of the pooled client opens the circuit.
+	require.Error(t, got.DeleteTopic("topic"))
+	require.NoError(t, got.Close())
+	require.NoError(t, held.Close())
+
+	_, err = pool.Get([]string{"kafka-1:9092"}, nil, nil, sarama.NewConfig())
+	_, ok := IsCircuitOpen(err)
+	require.True(t, ok, "expected circuit open error, got %v", err)
+	require.Equal(t, 1, dials, "expected no dial while the circuit is open")
+}
+
+func TestClusterAdminPoolNoSize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	admin := &kafkatesting.MockKafkaClusterAdmin{T: t}
+	pool := NewClusterAdminPool(ctx, func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		return admin, nil
+	}, 0, 0, nil)
+
+	got, err := pool.Get([]string{"kafka-1:9092"}, nil, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	require.NoError(t, got.Close())
+	require.True(t, admin.ExpectedClose, "expected unpooled client to be closed by the caller")
+	require.Equal(t, 0, pool.Len())
+}
+
+func TestClusterAdminPoolInvalidateSecret(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var created []*kafkatesting.MockKafkaClusterAdmin
+	pool := NewClusterAdminPool(ctx, func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		admin := &kafkatesting.MockKafkaClusterAdmin{T: t}
+		created = append(created, admin)
+		return admin, nil
+	}, 3, time.Hour, nil)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "secret", ResourceVersion: "1"},
+	}
+
+	for _, addr := range []string{"kafka-1:9092", "kafka-2:9092"} {
+		admin, err := pool.Get([]string{addr}, secret, nil, sarama.NewConfig())
+		require.NoError(t, err)
+		require.NoError(t, admin.Close())
+	}
+	admin, err := pool.Get([]string{"kafka-3:9092"}, nil, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	require.NoError(t, admin.Close())
+
+	pool.InvalidateSecret("ns", "secret")
+	require.Equal(t, 1, pool.Len())
+	require.True(t, created[0].ExpectedClose)
+	require.True(t, created[1].ExpectedClose)
+	require.False(t, created[2].ExpectedClose, "expected client without secret to be kept")
+}
+
+func TestClusterAdminPoolHeldClientClosedOnceReleased(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	admin := &kafkatesting.MockKafkaClusterAdmin{T: t}
+	pool := NewClusterAdminPool(ctx, func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		return admin, nil
+	}, 1, time.Hour, nil)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "secret", ResourceVersion: "1"},
+	}
+
+	first, err := pool.Get([]string{"kafka-1:9092"}, secret, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	second, err := pool.Get([]string{"kafka-1:9092"}, secret, nil, sarama.NewConfig())
+	require.NoError(t, err)
+
+	pool.InvalidateSecret("ns", "secret")
+	pool.removeExpiredClients(time.Now().Add(2 * time.Hour))
+	require.Equal(t, 0, pool.Len())
+	require.False(t, admin.ExpectedClose, "held client closed")
+
+	require.NoError(t, first.Close())
+	require.NoError(t, first.Close(), "expected closing a client twice to release it once")
+	require.False(t, admin.ExpectedClose, "held client closed")
+
+	require.NoError(t, second.Close())
+	require.True(t, admin.ExpectedClose, "expected released client to be closed")
+}
+
+func TestClusterAdminPoolConcurrentDials(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	unreachable := make(chan struct{})
+	var unreachableDials int32
+	pool := NewClusterAdminPool(ctx, func(addrs []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		if addrs[0] == "unreachable:9092" {
+			atomic.AddInt32(&unreachableDials, 1)
+			<-unreachable
+			return nil, errors.New("failed to connect")
+		}
+		return &kafkatesting.MockKafkaClusterAdmin{T: t}, nil
+	}, 2, time.Hour, nil)
+
+	var started, wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		started.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			_, err := pool.Get([]string{"unreachable:9092"}, nil, nil, sarama.NewConfig())
+			errs <- err
+		}()
+	}
+	started.Wait()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&unreachableDials) == 1 }, time.Second, time.Millisecond)
+	// Let the other request wait for the dial in progress.
+	time.Sleep(50 * time.Millisecond)
+
+	// Clients of other clusters don't wait for the unreachable cluster.
+	admin, err := pool.Get([]string{"kafka-1:9092"}, nil, nil, sarama.NewConfig())
+	require.NoError(t, err)
+	require.NoError(t, admin.Close())
+
+	close(unreachable)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.Error(t, err)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&unreachableDials), "expected concurrent requests to share the dial")
+	require.Equal(t, 1, pool.Len())
+}
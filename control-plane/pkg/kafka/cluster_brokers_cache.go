@@ -0,0 +1,128 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ClusterBrokersCache caches the number of brokers of Kafka clusters, keyed by bootstrap servers, so that checks made
+// on every admission request don't describe the same Kafka cluster every time.
+//
+// Failures to describe a Kafka cluster are cached as well, so that an unreachable Kafka cluster delays at most one
+// check per TTL.
+type ClusterBrokersCache struct {
+	ttl      time.Duration
+	timeout  time.Duration
+	newAdmin NewClusterAdminClientFunc
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]clusterBrokersCacheEntry
+}
+
+type clusterBrokersCacheEntry struct {
+	brokers int
+	err     error
+	expires time.Time
+}
+
+// NewClusterBrokersCache creates a ClusterBrokersCache whose entries expire after the given TTL, Kafka clusters are
+// described with clients created by newAdmin whose requests time out after the given timeout.
+func NewClusterBrokersCache(ttl, timeout time.Duration, newAdmin NewClusterAdminClientFunc) *ClusterBrokersCache {
+	return &ClusterBrokersCache{
+		ttl:      ttl,
+		timeout:  timeout,
+		newAdmin: newAdmin,
+		now:      time.Now,
+		entries:  make(map[string]clusterBrokersCacheEntry),
+	}
+}
+
+// BrokerCount returns the number of brokers of the Kafka cluster with the given bootstrap servers.
+func (c *ClusterBrokersCache) BrokerCount(addrs []string) (int, error) {
+	cluster := BootstrapServersCommaSeparated(addrs)
+
+	c.mu.Lock()
+	entry, ok := c.entries[cluster]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.brokers, entry.err
+	}
+
+	brokers, err := c.describeCluster(addrs)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[cluster] = clusterBrokersCacheEntry{brokers: brokers, err: err, expires: c.now().Add(c.ttl)}
+	return brokers, err
+}
+
+func (c *ClusterBrokersCache) describeCluster(addrs []string) (int, error) {
+	config, err := GetSaramaConfig(TimeoutsConfigOption(c.timeout, c.timeout, c.timeout))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create sarama config: %w", err)
+	}
+	config.Admin.Timeout = c.timeout
+	config.Metadata.Retry.Max = 0
+
+	admin, err := c.newAdmin(addrs, config)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create cluster admin: %w", err)
+	}
+	defer admin.Close()
+
+	brokers, _, err := admin.DescribeCluster()
+	if err != nil {
+		return 0, fmt.Errorf("failed to describe cluster: %w", err)
+	}
+	return len(brokers), nil
+}
+
+// CheckReplicationFactor checks, best-effort, that the Kafka cluster with the given bootstrap servers has at least as
+// many brokers as the given replication factor, since topics can't be created otherwise.
+//
+// Kafka clusters that can't be described are skipped, it returns a ReplicationFactorExceedsBrokers error only when the
+// replication factor is known to exceed the number of brokers.
+func (c *ClusterBrokersCache) CheckReplicationFactor(addrs []string, replicationFactor int16) error {
+	brokers, err := c.BrokerCount(addrs)
+	if err != nil || brokers == 0 {
+		return nil
+	}
+	if int(replicationFactor) > brokers {
+		return ReplicationFactorExceedsBrokers{
+			BootstrapServers:  BootstrapServersCommaSeparated(addrs),
+			ReplicationFactor: replicationFactor,
+			Brokers:           brokers,
+		}
+	}
+	return nil
+}
+
+// ReplicationFactorExceedsBrokers is returned when a replication factor is greater than the number of brokers of the
+// Kafka cluster topics are created in.
+type ReplicationFactorExceedsBrokers struct {
+	BootstrapServers  string
+	ReplicationFactor int16
+	Brokers           int
+}
+
+func (e ReplicationFactorExceedsBrokers) Error() string {
+	return fmt.Sprintf("replication factor %d is greater than the %d brokers of Kafka cluster %s", e.ReplicationFactor, e.Brokers, e.BootstrapServers)
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+// describingClusterAdmin counts the cluster descriptions.
+type describingClusterAdmin struct {
+	sarama.ClusterAdmin
+	brokers   int
+	described int
+}
+
+func (a *describingClusterAdmin) DescribeCluster() ([]*sarama.Broker, int32, error) {
+	a.described++
+	return make([]*sarama.Broker, a.brokers), 0, nil
+}
+
+func (a *describingClusterAdmin) Close() error {
+	return nil
+}
+
+func TestClusterBrokersCacheCheckReplicationFactor(t *testing.T) {
+	admin := &describingClusterAdmin{brokers: 3}
+	cache := NewClusterBrokersCache(time.Minute, time.Second, func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		return admin, nil
+	})
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	addrs := []string{"kafka-1:9092", "kafka-2:9092"}
+
+	require.NoError(t, cache.CheckReplicationFactor(addrs, 3))
+	err := cache.CheckReplicationFactor(addrs, 4)
+	require.Equal(t, ReplicationFactorExceedsBrokers{BootstrapServers: "kafka-1:9092,kafka-2:9092", ReplicationFactor: 4, Brokers: 3}, err)
+	require.Equal(t, 1, admin.described)
+
+	now = now.Add(time.Minute)
+	admin.brokers = 4
+	require.NoError(t, cache.CheckReplicationFactor(addrs, 4))
+	require.Equal(t, 2, admin.described)
+}
+
+func TestClusterBrokersCacheUnreachableCluster(t *testing.T) {
+	created := 0
+	cache := NewClusterBrokersCache(time.Minute, time.Second, func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		created++
+		return nil, errors.New("unreachable")
+	})
+
+	addrs := []string{"kafka-1:9092"}
+
+	require.NoError(t, cache.CheckReplicationFactor(addrs, 3))
+	_, err := cache.BrokerCount(addrs)
+	require.Error(t, err)
+	require.Equal(t, 1, created)
+}
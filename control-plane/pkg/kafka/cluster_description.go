@@ -0,0 +1,77 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+)
+
+// ClusterDescription describes the Kafka cluster a cluster admin client is connected to.
+type ClusterDescription struct {
+	// ClusterID is the id of the Kafka cluster, it's empty when the cluster doesn't report it.
+	ClusterID    string
+	ControllerID int32
+	// Brokers are the addresses of the brokers of the Kafka cluster.
+	Brokers []string
+}
+
+// DescribeClusterFunc describes the Kafka cluster the given cluster admin client is connected to.
+type DescribeClusterFunc func(admin sarama.ClusterAdmin, config *sarama.Config) (*ClusterDescription, error)
+
+// DescribeCluster describes the Kafka cluster the given cluster admin client is connected to.
+//
+// The cluster admin doesn't expose the cluster id, so it's requested to the controller broker with a metadata request
+// made with the given config.
+func DescribeCluster(admin sarama.ClusterAdmin, config *sarama.Config) (*ClusterDescription, error) {
+	brokers, controllerID, err := admin.DescribeCluster()
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe cluster: %w", err)
+	}
+	if len(brokers) == 0 {
+		return nil, errors.New("failed to describe cluster: no brokers")
+	}
+
+	description := &ClusterDescription{
+		ControllerID: controllerID,
+		Brokers:      make([]string, 0, len(brokers)),
+	}
+	controller := brokers[0]
+	for _, b := range brokers {
+		description.Brokers = append(description.Brokers, b.Addr())
+		if b.ID() == controllerID {
+			controller = b
+		}
+	}
+
+	if err := controller.Open(config); err != nil && !errors.Is(err, sarama.ErrAlreadyConnected) {
+		return nil, fmt.Errorf("failed to connect to broker %s: %w", controller.Addr(), err)
+	}
+	defer controller.Close()
+
+	// The cluster id is part of metadata responses since version 2.
+	response, err := controller.GetMetadata(&sarama.MetadataRequest{Version: 2})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata from broker %s: %w", controller.Addr(), err)
+	}
+	if response.ClusterID != nil {
+		description.ClusterID = *response.ClusterID
+	}
+	return description, nil
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kafkatesting "knative.dev/eventing-kafka-broker/control-plane/pkg/kafka/testing"
+)
+
+func TestDescribeCluster(t *testing.T) {
+	clusterID := "cluster-id-1"
+
+	mockBroker := sarama.NewMockBroker(t, 1)
+	defer mockBroker.Close()
+	mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockWrapper(&sarama.MetadataResponse{
+			Version:   2,
+			ClusterID: &clusterID,
+		}),
+	})
+
+	ca := &kafkatesting.MockKafkaClusterAdmin{
+		ExpectedBrokersOnDescribeCluster: []*sarama.Broker{sarama.NewBroker(mockBroker.Addr())},
+		T:                                t,
+	}
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+
+	description, err := DescribeCluster(ca, config)
+	require.NoError(t, err)
+	assert.Equal(t, clusterID, description.ClusterID)
+	assert.Equal(t, []string{mockBroker.Addr()}, description.Brokers)
+}
+
+func TestDescribeClusterError(t *testing.T) {
+	ca := &kafkatesting.MockKafkaClusterAdmin{
+		ErrorOnDescribeCluster: errors.New("failed"),
+		T:                      t,
+	}
+
+	_, err := DescribeCluster(ca, sarama.NewConfig())
+	assert.Error(t, err)
+
+	ca = &kafkatesting.MockKafkaClusterAdmin{T: t}
+
+	_, err = DescribeCluster(ca, sarama.NewConfig())
+	assert.Error(t, err)
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultConnectivityFailureThreshold is the default number of consecutive failures after which a Kafka cluster
+	// is considered unreachable.
+	DefaultConnectivityFailureThreshold = 3
+	// DefaultConnectivityTTL is the default time after which the connectivity of a Kafka cluster that isn't
+	// connected to anymore, for example, because the brokers using it were deleted, is forgotten.
+	DefaultConnectivityTTL = time.Hour
+)
+
+// ClusterConnectivity is the connectivity of the control plane to a Kafka cluster.
+type ClusterConnectivity struct {
+	BootstrapServers string
+	Reachable        bool
+	// ConsecutiveFailures is the number of failed attempts since the last successful one.
+	ConsecutiveFailures int
+	// LastError is the error of the last failed attempt, if any.
+	LastError error
+	// LastSeen is the time of the last attempt.
+	LastSeen time.Time
+}
+
+// ConnectivityTracker aggregates the results of the attempts to connect to Kafka clusters keyed by bootstrap
+// servers, so that a Kafka cluster unreachable by every reconciler is visible at the controller level.
+//
+// A cluster is unreachable after threshold consecutive failures, clusters not attempted within ttl are forgotten.
+type ConnectivityTracker struct {
+	threshold int
+	ttl       time.Duration
+
+	now func() time.Time
+
+	lock     sync.Mutex
+	clusters map[string]*ClusterConnectivity
+}
+
+// NewConnectivityTracker creates a ConnectivityTracker, non-positive threshold and ttl use the defaults.
+func NewConnectivityTracker(threshold int, ttl time.Duration) *ConnectivityTracker {
+	if threshold <= 0 {
+		threshold = DefaultConnectivityFailureThreshold
+	}
+	if ttl <= 0 {
+		ttl = DefaultConnectivityTTL
+	}
+	return &ConnectivityTracker{
+		threshold: threshold,
+		ttl:       ttl,
+		now:       time.Now,
+		clusters:  make(map[string]*ClusterConnectivity),
+	}
+}
+
+// Record records the result of an attempt to connect to the given bootstrap servers, a nil error is a success, and
+// it returns the resulting connectivity of the cluster.
+func (t *ConnectivityTracker) Record(bootstrapServers []string, err error) ClusterConnectivity {
+	key := BootstrapServersCommaSeparated(bootstrapServers)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	c, ok := t.clusters[key]
+	if !ok {
+		c = &ClusterConnectivity{BootstrapServers: key}
+		t.clusters[key] = c
+	}
+
+	c.LastSeen = t.now()
+	if err == nil {
+		c.ConsecutiveFailures = 0
+		c.LastError = nil
+	} else {
+		c.ConsecutiveFailures++
+		c.LastError = err
+	}
+	c.Reachable = c.ConsecutiveFailures < t.threshold
+	return *c
+}
+
+// Status returns the connectivity of the clusters attempted within the ttl sorted by bootstrap servers.
+func (t *ConnectivityTracker) Status() []ClusterConnectivity {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	status := make([]ClusterConnectivity, 0, len(t.clusters))
+	for key, c := range t.clusters {
+		if now.Sub(c.LastSeen) > t.ttl {
+			delete(t.clusters, key)
+			continue
+		}
+		status = append(status, *c)
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].BootstrapServers < status[j].BootstrapServers
+	})
+	return status
+}
+
+// Check returns an error when every cluster attempted within the ttl is unreachable, a partial outage is reported
+// by the resources using the unreachable clusters.
+func (t *ConnectivityTracker) Check() error {
+	status := t.Status()
+	if len(status) == 0 {
+		return nil
+	}
+
+	unreachable := make([]string, 0, len(status))
+	for _, c := range status {
+		if c.Reachable {
+			return nil
+		}
+		unreachable = append(unreachable, fmt.Sprintf("%s: %v", c.BootstrapServers, c.LastError))
+	}
+	return fmt.Errorf("all Kafka clusters are unreachable: %s", strings.Join(unreachable, "; "))
+}
+
+// ReadinessHandler returns a readiness probe handler failing when every Kafka cluster is unreachable or once the
+// given context is done, like the default readiness probe.
+func (t *ConnectivityTracker) ReadinessHandler(ctx context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		err := t.Check()
+		if ctx.Err() != nil {
+			err = errors.New("received SIGTERM from kubelet")
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+type connectivityTrackerKey struct{}
+
+// WithConnectivityTracker returns a copy of the given context carrying the given ConnectivityTracker, so that
+// controllers share it.
+func WithConnectivityTracker(ctx context.Context, tracker *ConnectivityTracker) context.Context {
+	return context.WithValue(ctx, connectivityTrackerKey{}, tracker)
+}
+
+// ConnectivityTrackerFromContext returns the ConnectivityTracker of the given context, or nil.
+func ConnectivityTrackerFromContext(ctx context.Context) *ConnectivityTracker {
+	tracker, _ := ctx.Value(connectivityTrackerKey{}).(*ConnectivityTracker)
+	return tracker
+}
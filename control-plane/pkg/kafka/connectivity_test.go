@@ -0,0 +1,107 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectivityTracker(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewConnectivityTracker(2, time.Hour)
+	tracker.now = func() time.Time { return now }
+
+	servers := []string{"kafka-1:9092", "kafka-2:9092"}
+	otherServers := []string{"kafka-3:9092"}
+	dialErr := errors.New("dial tcp: connection refused")
+
+	// No cluster attempted yet.
+	assert.Nil(t, tracker.Check())
+
+	assert.True(t, tracker.Record(servers, nil).Reachable)
+	assert.True(t, tracker.Record(otherServers, dialErr).Reachable)
+	assert.False(t, tracker.Record(otherServers, dialErr).Reachable)
+
+	// A reachable cluster is left.
+	assert.Nil(t, tracker.Check())
+
+	tracker.Record(servers, dialErr)
+	c := tracker.Record(servers, dialErr)
+	assert.False(t, c.Reachable)
+	assert.Equal(t, 2, c.ConsecutiveFailures)
+	assert.Equal(t, dialErr, c.LastError)
+
+	err := tracker.Check()
+	require.NotNil(t, err)
+	assert.Equal(t, "all Kafka clusters are unreachable: kafka-1:9092,kafka-2:9092: dial tcp: connection refused; kafka-3:9092: dial tcp: connection refused", err.Error())
+
+	// A success makes the cluster reachable again.
+	c = tracker.Record(servers, nil)
+	assert.True(t, c.Reachable)
+	assert.Equal(t, 0, c.ConsecutiveFailures)
+	assert.Nil(t, c.LastError)
+	assert.Nil(t, tracker.Check())
+
+	// Clusters not attempted within the ttl are forgotten.
+	now = now.Add(time.Hour)
+	tracker.Record(otherServers, dialErr)
+	now = now.Add(time.Minute)
+	status := tracker.Status()
+	require.Len(t, status, 1)
+	assert.Equal(t, "kafka-3:9092", status[0].BootstrapServers)
+	assert.NotNil(t, tracker.Check())
+}
+
+func TestConnectivityTrackerReadinessHandler(t *testing.T) {
+	tracker := NewConnectivityTracker(1, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	handler := tracker.ReadinessHandler(ctx)
+
+	probe := func() int {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/readiness", nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, probe())
+
+	tracker.Record([]string{"kafka-1:9092"}, errors.New("dial tcp: connection refused"))
+	assert.Equal(t, http.StatusInternalServerError, probe())
+
+	tracker.Record([]string{"kafka-1:9092"}, nil)
+	assert.Equal(t, http.StatusOK, probe())
+
+	cancel()
+	assert.Equal(t, http.StatusInternalServerError, probe())
+}
+
+func TestConnectivityTrackerFromContext(t *testing.T) {
+	assert.Nil(t, ConnectivityTrackerFromContext(context.Background()))
+
+	tracker := NewConnectivityTracker(0, 0)
+	assert.Equal(t, DefaultConnectivityFailureThreshold, tracker.threshold)
+	assert.Equal(t, DefaultConnectivityTTL, tracker.ttl)
+	assert.Same(t, tracker, ConnectivityTrackerFromContext(WithConnectivityTracker(context.Background(), tracker)))
+}
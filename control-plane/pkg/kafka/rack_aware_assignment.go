@@ -0,0 +1,125 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+// rackAwareTopicDetail returns a copy of the given topic detail with an explicit replica assignment spreading the
+// replicas of each partition across the racks of the Kafka cluster brokers.
+//
+// It returns the given topic detail, leaving the replica assignment to the Kafka cluster, when the brokers rack
+// metadata isn't available.
+func rackAwareTopicDetail(admin sarama.ClusterAdmin, logger *zap.Logger, topic string, detail *sarama.TopicDetail) *sarama.TopicDetail {
+	brokers, _, err := admin.DescribeCluster()
+	if err != nil {
+		logger.Warn("failed to describe cluster for rack aware replica assignment, falling back to the default replica assignment",
+			zap.String("topic", topic),
+			zap.Error(err),
+		)
+		return detail
+	}
+
+	racks := make(map[int32]string, len(brokers))
+	for _, b := range brokers {
+		racks[b.ID()] = b.Rack()
+	}
+	assignment, err := rackAwareReplicaAssignment(racks, detail.NumPartitions, detail.ReplicationFactor)
+	if err != nil {
+		logger.Warn("rack aware replica assignment not possible, falling back to the default replica assignment",
+			zap.String("topic", topic),
+			zap.Error(err),
+		)
+		return detail
+	}
+
+	logger.Debug("rack aware replica assignment",
+		zap.String("topic", topic),
+		zap.Any("replicaAssignment", assignment),
+	)
+
+	// The number of partitions and the replication factor must not be set along with the replica assignment.
+	rackAware := *detail
+	rackAware.NumPartitions = -1
+	rackAware.ReplicationFactor = -1
+	rackAware.ReplicaAssignment = assignment
+	return &rackAware
+}
+
+// rackAwareReplicaAssignment assigns the replicas of the given number of partitions to the given brokers, keyed by
+// broker id with their rack as value, so that the replicas of each partition are on as many racks as possible and
+// that leaders and replicas are evenly spread across brokers.
+func rackAwareReplicaAssignment(racks map[int32]string, numPartitions int32, replicationFactor int16) (map[int32][]int32, error) {
+	if numPartitions <= 0 || replicationFactor <= 0 {
+		return nil, fmt.Errorf("invalid number of partitions %d or replication factor %d", numPartitions, replicationFactor)
+	}
+	if len(racks) < int(replicationFactor) {
+		return nil, fmt.Errorf("%d brokers available, fewer than the replication factor %d", len(racks), replicationFactor)
+	}
+
+	brokersByRack := make(map[string][]int32)
+	for id, rack := range racks {
+		if rack == "" {
+			return nil, fmt.Errorf("rack of broker %d is unknown", id)
+		}
+		brokersByRack[rack] = append(brokersByRack[rack], id)
+	}
+	rackNames := make([]string, 0, len(brokersByRack))
+	for rack, ids := range brokersByRack {
+		rackNames = append(rackNames, rack)
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	}
+	sort.Strings(rackNames)
+
+	// Brokers are interleaved by rack, so that consecutive brokers are on different racks.
+	brokers := make([]int32, 0, len(racks))
+	for i := 0; len(brokers) < len(racks); i++ {
+		for _, rack := range rackNames {
+			if ids := brokersByRack[rack]; i < len(ids) {
+				brokers = append(brokers, ids[i])
+			}
+		}
+	}
+
+	assignment := make(map[int32][]int32, numPartitions)
+	for p := int32(0); p < numPartitions; p++ {
+		replicas := make([]int32, 0, replicationFactor)
+		assigned := make(map[int32]bool, replicationFactor)
+		usedRacks := make(map[string]bool, replicationFactor)
+
+		// Replicas go to brokers on racks not used yet by the partition first, then to the remaining brokers when
+		// there are fewer racks than the replication factor.
+		for _, onNewRack := range []bool{true, false} {
+			for i := 0; i < len(brokers) && len(replicas) < int(replicationFactor); i++ {
+				id := brokers[(int(p)+i)%len(brokers)]
+				if assigned[id] || (onNewRack && usedRacks[racks[id]]) {
+					continue
+				}
+				replicas = append(replicas, id)
+				assigned[id] = true
+				usedRacks[racks[id]] = true
+			}
+		}
+		assignment[p] = replicas
+	}
+	return assignment, nil
+}
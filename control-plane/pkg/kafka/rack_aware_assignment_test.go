@@ -0,0 +1,134 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	kafkatesting "knative.dev/eventing-kafka-broker/control-plane/pkg/kafka/testing"
+)
+
+func TestRackAwareReplicaAssignment(t *testing.T) {
+	tests := []struct {
+		name              string
+		racks             map[int32]string
+		numPartitions     int32
+		replicationFactor int16
+		want              map[int32][]int32
+		wantErr           bool
+	}{
+		{
+			name:              "one broker per rack",
+			racks:             map[int32]string{0: "a", 1: "b", 2: "c"},
+			numPartitions:     3,
+			replicationFactor: 3,
+			want: map[int32][]int32{
+				0: {0, 1, 2},
+				1: {1, 2, 0},
+				2: {2, 0, 1},
+			},
+		},
+		{
+			name:              "several brokers per rack",
+			racks:             map[int32]string{0: "a", 1: "a", 2: "b", 3: "b"},
+			numPartitions:     4,
+			replicationFactor: 2,
+			want: map[int32][]int32{
+				0: {0, 2},
+				1: {2, 1},
+				2: {1, 3},
+				3: {3, 0},
+			},
+		},
+		{
+			name:              "fewer racks than replication factor",
+			racks:             map[int32]string{0: "a", 1: "a", 2: "b"},
+			numPartitions:     2,
+			replicationFactor: 3,
+			want: map[int32][]int32{
+				0: {0, 2, 1},
+				1: {2, 1, 0},
+			},
+		},
+		{
+			name:              "unknown rack",
+			racks:             map[int32]string{0: "a", 1: ""},
+			numPartitions:     1,
+			replicationFactor: 2,
+			wantErr:           true,
+		},
+		{
+			name:              "fewer brokers than replication factor",
+			racks:             map[int32]string{0: "a", 1: "b"},
+			numPartitions:     1,
+			replicationFactor: 3,
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rackAwareReplicaAssignment(tt.racks, tt.numPartitions, tt.replicationFactor)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateTopicIfAbsentRackAwareReplicaAssignmentFallback(t *testing.T) {
+	tests := []struct {
+		name               string
+		brokers            []*sarama.Broker
+		describeClusterErr error
+	}{
+		{
+			name:    "rack metadata unavailable",
+			brokers: []*sarama.Broker{sarama.NewBroker("b-0:9092"), sarama.NewBroker("b-1:9092")},
+		},
+		{
+			name:               "describe cluster failure",
+			describeClusterErr: errors.New("failed"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &TopicConfig{
+				TopicDetail:                       sarama.TopicDetail{NumPartitions: 10, ReplicationFactor: 2},
+				RackAwareReplicaAssignmentEnabled: true,
+			}
+			ca := &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:                "topic-name-1",
+				ExpectedTopicDetail:              sarama.TopicDetail{NumPartitions: 10, ReplicationFactor: 2},
+				ExpectedBrokersOnDescribeCluster: tt.brokers,
+				ErrorOnDescribeCluster:           tt.describeClusterErr,
+				T:                                t,
+			}
+
+			created, err := CreateTopicIfAbsent(ca, zap.NewNop(), "topic-name-1", config)
+			assert.NoError(t, err)
+			assert.True(t, created)
+		})
+	}
+}
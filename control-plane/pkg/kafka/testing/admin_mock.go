@@ -37,10 +37,21 @@ type MockKafkaClusterAdmin struct {
 	// CreateTopic
 	ExpectedTopicDetail sarama.TopicDetail
 	ErrorOnCreateTopic  error
+	// MaxReplicationFactorOnCreateTopic, when set, rejects topics with a higher replication factor with
+	// sarama.ErrInvalidReplicationFactor, as a cluster with MaxReplicationFactorOnCreateTopic brokers would do.
+	MaxReplicationFactorOnCreateTopic int16
+
+	// DescribeCluster
+	ExpectedBrokersOnDescribeCluster []*sarama.Broker
+	ErrorOnDescribeCluster           error
 
 	// DeleteTopic
 	ErrorOnDeleteTopic error
 
+	// ListTopics
+	ExpectedTopicsOnListTopics map[string]sarama.TopicDetail
+	ErrorOnListTopics          error
+
 	ExpectedClose      bool
 	ExpectedCloseError error
 
@@ -56,6 +67,25 @@ type MockKafkaClusterAdmin struct {
 
 	ErrorOnDeleteConsumerGroup error
 
+	// CreatePartitions
+	ExpectedCountOnCreatePartitions int32
+	ErrorOnCreatePartitions         error
+	CreatePartitionsCalled          bool
+
+	// DescribeConfig
+	ExpectedConfigEntriesOnDescribeConfig []sarama.ConfigEntry
+	ErrorOnDescribeConfig                 error
+
+	// AlterConfig
+	ExpectedConfigEntriesOnAlterConfig map[string]*string
+	ErrorOnAlterConfig                 error
+	AlterConfigCalled                  bool
+
+	// IncrementalAlterConfig
+	ExpectedConfigEntriesOnIncrementalAlterConfig map[string]sarama.IncrementalAlterConfigsEntry
+	ErrorOnIncrementalAlterConfig                 error
+	IncrementalAlterConfigCalled                  bool
+
 	T *testing.T
 }
 
@@ -96,6 +126,10 @@ func (m *MockKafkaClusterAdmin) CreateTopic(topic string, detail *sarama.TopicDe
 		m.T.Errorf("expected topic %s got %s", m.ExpectedTopicName, topic)
 	}
 
+	if m.MaxReplicationFactorOnCreateTopic > 0 && detail.ReplicationFactor > m.MaxReplicationFactorOnCreateTopic {
+		return &sarama.TopicError{Err: sarama.ErrInvalidReplicationFactor}
+	}
+
 	if diff := cmp.Diff(*detail, m.ExpectedTopicDetail); diff != "" {
 		m.T.Errorf("unexpected topic detail (-want +got) %s", diff)
 	}
@@ -104,7 +138,7 @@ func (m *MockKafkaClusterAdmin) CreateTopic(topic string, detail *sarama.TopicDe
 }
 
 func (m *MockKafkaClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
-	panic("implement me")
+	return m.ExpectedTopicsOnListTopics, m.ErrorOnListTopics
 }
 
 func (m *MockKafkaClusterAdmin) DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error) {
@@ -125,7 +159,17 @@ func (m *MockKafkaClusterAdmin) DeleteTopic(topic string) error {
 }
 
 func (m *MockKafkaClusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
-	panic("implement me")
+	m.CreatePartitionsCalled = true
+
+	if topic != m.ExpectedTopicName {
+		m.T.Errorf("expected topic %s got %s", m.ExpectedTopicName, topic)
+	}
+
+	if count != m.ExpectedCountOnCreatePartitions {
+		m.T.Errorf("expected partitions count %d got %d", m.ExpectedCountOnCreatePartitions, count)
+	}
+
+	return m.ErrorOnCreatePartitions
 }
 
 func (m *MockKafkaClusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
@@ -141,15 +185,43 @@ func (m *MockKafkaClusterAdmin) DeleteRecords(topic string, partitionOffsets map
 }
 
 func (m *MockKafkaClusterAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
-	panic("implement me")
+	if resource.Name != m.ExpectedTopicName {
+		m.T.Errorf("expected topic %s got %s", m.ExpectedTopicName, resource.Name)
+	}
+
+	return m.ExpectedConfigEntriesOnDescribeConfig, m.ErrorOnDescribeConfig
 }
 
 func (m *MockKafkaClusterAdmin) AlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]*string, validateOnly bool) error {
-	panic("implement me")
+	m.AlterConfigCalled = true
+
+	if name != m.ExpectedTopicName {
+		m.T.Errorf("expected topic %s got %s", m.ExpectedTopicName, name)
+	}
+
+	if m.ExpectedConfigEntriesOnAlterConfig != nil {
+		if diff := cmp.Diff(m.ExpectedConfigEntriesOnAlterConfig, entries); diff != "" {
+			m.T.Errorf("unexpected config entries (-want +got) %s", diff)
+		}
+	}
+
+	return m.ErrorOnAlterConfig
 }
 
 func (m *MockKafkaClusterAdmin) IncrementalAlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]sarama.IncrementalAlterConfigsEntry, validateOnly bool) error {
-	panic("implement me")
+	m.IncrementalAlterConfigCalled = true
+
+	if name != m.ExpectedTopicName {
+		m.T.Errorf("expected topic %s got %s", m.ExpectedTopicName, name)
+	}
+
+	if m.ExpectedConfigEntriesOnIncrementalAlterConfig != nil {
+		if diff := cmp.Diff(m.ExpectedConfigEntriesOnIncrementalAlterConfig, entries); diff != "" {
+			m.T.Errorf("unexpected config entries (-want +got) %s", diff)
+		}
+	}
+
+	return m.ErrorOnIncrementalAlterConfig
 }
 
 func (m *MockKafkaClusterAdmin) CreateACL(resource sarama.Resource, acl sarama.Acl) error {
@@ -185,7 +257,7 @@ func (m *MockKafkaClusterAdmin) DeleteConsumerGroup(group string) error {
 }
 
 func (m *MockKafkaClusterAdmin) DescribeCluster() (brokers []*sarama.Broker, controllerID int32, err error) {
-	panic("implement me")
+	return m.ExpectedBrokersOnDescribeCluster, 0, m.ErrorOnDescribeCluster
 }
 
 func (m *MockKafkaClusterAdmin) DescribeLogDirs(brokers []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
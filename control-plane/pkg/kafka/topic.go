@@ -17,30 +17,71 @@
 package kafka
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/configmap"
 )
 
 const (
 	DefaultTopicNumPartitionConfigMapKey      = "default.topic.partitions"
 	DefaultTopicReplicationFactorConfigMapKey = "default.topic.replication.factor"
-	BootstrapServersConfigMapKey              = "bootstrap.servers"
+	// DefaultTopicMinInSyncReplicasConfigMapKey is the key for the min.insync.replicas config of topics, it must not
+	// be greater than the replication factor.
+	DefaultTopicMinInSyncReplicasConfigMapKey = "default.topic.config.min.insync.replicas"
+	// DefaultTopicCleanupPolicyConfigMapKey is the key for the cleanup.policy config of topics, supported values are
+	// delete, compact and compact,delete.
+	DefaultTopicCleanupPolicyConfigMapKey = "default.topic.config.cleanup.policy"
+	// DefaultTopicMaxMessageBytesConfigMapKey is the key for the max.message.bytes config of topics, it's the maximum
+	// size of the events accepted by the ingress too.
+	DefaultTopicMaxMessageBytesConfigMapKey = "default.topic.config.max.message.bytes"
+	// DefaultTopicConfigConfigMapKeyPrefix is the prefix of the keys of topic configs, for example,
+	// default.topic.config.segment.ms, configs without a dedicated key are passed as is to Kafka, which validates them.
+	DefaultTopicConfigConfigMapKeyPrefix = "default.topic.config."
+	BootstrapServersConfigMapKey         = "bootstrap.servers"
+	// FailoverBootstrapServersConfigMapKey is the key for an ordered list of bootstrap servers of independent Kafka
+	// clusters, separated by ';', to fall back to when the cluster of BootstrapServersConfigMapKey isn't reachable.
+	FailoverBootstrapServersConfigMapKey = "bootstrap.servers.failover"
+	// TopicConfigEnforceConfigMapKey is the key enabling the enforce mode of topic configs, external changes to the
+	// topic configs set in the ConfigMap are reverted and reported, instead of only reported, it defaults to false.
+	TopicConfigEnforceConfigMapKey = "topic.config.enforce"
 
 	GroupIDConfigMapKey = "group.id"
 
 	TopicAnnotation = "default.topic"
+
+	// RetentionMsTopicConfigKey is the Kafka topic config key for the topic retention.
+	RetentionMsTopicConfigKey = "retention.ms"
+	// MinInSyncReplicasTopicConfigKey is the Kafka topic config key for the minimum number of in-sync replicas.
+	MinInSyncReplicasTopicConfigKey = "min.insync.replicas"
+	// CleanupPolicyTopicConfigKey is the Kafka topic config key for the policy of old log segments, deleted or
+	// compacted.
+	CleanupPolicyTopicConfigKey = "cleanup.policy"
+	// MaxMessageBytesTopicConfigKey is the Kafka topic config key for the largest record batch size allowed.
+	MaxMessageBytesTopicConfigKey = "max.message.bytes"
 )
 
 // TopicConfig contains configurations for creating a topic.
 type TopicConfig struct {
 	TopicDetail      sarama.TopicDetail
 	BootstrapServers []string
+	// FailoverBootstrapServers are the bootstrap servers of the clusters to fall back to, in order, when the
+	// cluster of BootstrapServers isn't reachable.
+	FailoverBootstrapServers [][]string
+	// RackAwareReplicaAssignmentEnabled makes topics created with an explicit replica assignment spreading the
+	// replicas of each partition across the racks of the Kafka cluster brokers.
+	RackAwareReplicaAssignmentEnabled bool
+	// EnforceConfig makes external changes to the topic config entries reverted, otherwise they're only reported as
+	// drift.
+	EnforceConfig bool
 }
 
 func TopicConfigFromConfigMap(logger *zap.Logger, cm *corev1.ConfigMap) (*TopicConfig, error) {
@@ -49,8 +90,8 @@ func TopicConfigFromConfigMap(logger *zap.Logger, cm *corev1.ConfigMap) (*TopicC
 		return nil, err
 	}
 
-	if err := validateTopicConfig(config); err != nil {
-		return nil, fmt.Errorf("error validating topic config from configmap %s - ConfigMap data: %v", err, cm.Data)
+	if err := validateTopicConfig(cm, config); err != nil {
+		return nil, err
 	}
 
 	logger.Debug("topic config from configmap",
@@ -87,37 +128,199 @@ func buildTopicConfigFromConfigMap(cm *corev1.ConfigMap) (*TopicConfig, error) {
 
 	var replicationFactor int32
 	var bootstrapServers string
+	var failoverBootstrapServers string
+	var minInSyncReplicas string
+	var cleanupPolicy string
+	var maxMessageBytes string
 
 	err := configmap.Parse(cm.Data,
-		configmap.AsInt32(DefaultTopicNumPartitionConfigMapKey, &topicDetail.NumPartitions),
-		configmap.AsInt32(DefaultTopicReplicationFactorConfigMapKey, &replicationFactor),
 		configmap.AsString(BootstrapServersConfigMapKey, &bootstrapServers),
+		configmap.AsString(FailoverBootstrapServersConfigMapKey, &failoverBootstrapServers),
+		configmap.AsString(DefaultTopicMinInSyncReplicasConfigMapKey, &minInSyncReplicas),
+		configmap.AsString(DefaultTopicCleanupPolicyConfigMapKey, &cleanupPolicy),
+		configmap.AsString(DefaultTopicMaxMessageBytesConfigMapKey, &maxMessageBytes),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse config map %s/%s: %w", cm.Namespace, cm.Name, err)
 	}
 
+	// integers are parsed one by one to report the key that isn't valid.
+	integers := []struct {
+		key    string
+		target *int32
+	}{
+		{key: DefaultTopicNumPartitionConfigMapKey, target: &topicDetail.NumPartitions},
+		{key: DefaultTopicReplicationFactorConfigMapKey, target: &replicationFactor},
+	}
+	for _, i := range integers {
+		if err := configmap.Parse(cm.Data, configmap.AsInt32(i.key, i.target)); err != nil {
+			return nil, newInvalidTopicConfig(cm, i.key, "is not a valid integer")
+		}
+	}
+
 	topicDetail.ReplicationFactor = int16(replicationFactor)
 
+	var enforceConfig bool
+	if err := configmap.Parse(cm.Data, configmap.AsBool(TopicConfigEnforceConfigMapKey, &enforceConfig)); err != nil {
+		return nil, newInvalidTopicConfig(cm, TopicConfigEnforceConfigMapKey, "must be true or false")
+	}
+
+	if minInSyncReplicas = strings.TrimSpace(minInSyncReplicas); minInSyncReplicas != "" {
+		if _, err := strconv.ParseInt(minInSyncReplicas, 10, 16); err != nil {
+			return nil, newInvalidTopicConfig(cm, DefaultTopicMinInSyncReplicasConfigMapKey, "is not a valid integer")
+		}
+		topicDetail.ConfigEntries = map[string]*string{MinInSyncReplicasTopicConfigKey: &minInSyncReplicas}
+	}
+
+	if strings.TrimSpace(cleanupPolicy) != "" {
+		policy, ok := normalizeCleanupPolicy(cleanupPolicy)
+		if !ok {
+			return nil, newInvalidTopicConfig(cm, DefaultTopicCleanupPolicyConfigMapKey, "must be one of delete, compact or compact,delete")
+		}
+		if topicDetail.ConfigEntries == nil {
+			topicDetail.ConfigEntries = make(map[string]*string, 1)
+		}
+		topicDetail.ConfigEntries[CleanupPolicyTopicConfigKey] = &policy
+	}
+
+	if maxMessageBytes = strings.TrimSpace(maxMessageBytes); maxMessageBytes != "" {
+		if v, err := strconv.ParseInt(maxMessageBytes, 10, 32); err != nil || v <= 0 {
+			return nil, newInvalidTopicConfig(cm, DefaultTopicMaxMessageBytesConfigMapKey, "must be a positive integer")
+		}
+		if topicDetail.ConfigEntries == nil {
+			topicDetail.ConfigEntries = make(map[string]*string, 1)
+		}
+		topicDetail.ConfigEntries[MaxMessageBytesTopicConfigKey] = &maxMessageBytes
+	}
+
+	// Other topic configs are forwarded as is, Kafka rejects unknown or invalid ones when the topic is created.
+	for k, v := range cm.Data {
+		name := strings.TrimPrefix(k, DefaultTopicConfigConfigMapKeyPrefix)
+		if name == k || name == "" || name == MinInSyncReplicasTopicConfigKey || name == CleanupPolicyTopicConfigKey || name == MaxMessageBytesTopicConfigKey {
+			continue
+		}
+		if topicDetail.ConfigEntries == nil {
+			topicDetail.ConfigEntries = make(map[string]*string, 1)
+		}
+		value := strings.TrimSpace(v)
+		topicDetail.ConfigEntries[name] = &value
+	}
+
 	config := &TopicConfig{
-		TopicDetail:      topicDetail,
-		BootstrapServers: BootstrapServersArray(bootstrapServers),
+		TopicDetail:              topicDetail,
+		BootstrapServers:         BootstrapServersArray(bootstrapServers),
+		FailoverBootstrapServers: FailoverBootstrapServersArray(failoverBootstrapServers),
+		EnforceConfig:            enforceConfig,
 	}
 	return config, nil
 }
 
-func validateTopicConfig(config *TopicConfig) error {
-	if config.TopicDetail.NumPartitions <= 0 || config.TopicDetail.ReplicationFactor <= 0 || len(config.BootstrapServers) == 0 {
-		return fmt.Errorf(
-			"invalid configuration - numPartitions: %d - replicationFactor: %d - bootstrapServers: %s",
-			config.TopicDetail.NumPartitions,
-			config.TopicDetail.ReplicationFactor,
-			config.BootstrapServers,
-		)
+// normalizeCleanupPolicy returns the given cleanup policy in the form reported by Kafka, so that it can be compared
+// to the config of existing topics, and whether it's a supported policy.
+func normalizeCleanupPolicy(cleanupPolicy string) (string, bool) {
+	policies := sets.NewString()
+	for _, p := range strings.Split(cleanupPolicy, ",") {
+		p = strings.TrimSpace(p)
+		if p != "delete" && p != "compact" {
+			return "", false
+		}
+		policies.Insert(p)
+	}
+	return strings.Join(policies.List(), ","), true
+}
+
+func validateTopicConfig(cm *corev1.ConfigMap, config *TopicConfig) error {
+	if config.TopicDetail.NumPartitions <= 0 {
+		return newInvalidTopicConfig(cm, DefaultTopicNumPartitionConfigMapKey, "must be greater than 0")
+	}
+	if config.TopicDetail.ReplicationFactor <= 0 {
+		return newInvalidTopicConfig(cm, DefaultTopicReplicationFactorConfigMapKey, "must be greater than 0")
+	}
+	if len(config.BootstrapServers) == 0 {
+		return newInvalidTopicConfig(cm, BootstrapServersConfigMapKey, "must contain at least one bootstrap server")
+	}
+	if v, ok := config.TopicDetail.ConfigEntries[MinInSyncReplicasTopicConfigKey]; ok && v != nil {
+		// A topic with min.insync.replicas greater than the replication factor can't be written with acks=all.
+		minInSyncReplicas, _ := strconv.ParseInt(*v, 10, 16)
+		if minInSyncReplicas <= 0 || minInSyncReplicas > int64(config.TopicDetail.ReplicationFactor) {
+			return newInvalidTopicConfig(
+				cm,
+				DefaultTopicMinInSyncReplicasConfigMapKey,
+				fmt.Sprintf("must be between 1 and the replication factor %d", config.TopicDetail.ReplicationFactor),
+			)
+		}
 	}
 	return nil
 }
 
+// InvalidTopicConfig is returned when a key of the topic config ConfigMap is missing or invalid, it names the key
+// without exposing the ConfigMap data.
+type InvalidTopicConfig struct {
+	// ConfigMap is the namespace/name of the ConfigMap.
+	ConfigMap string
+	Key       string
+	// Value is the invalid value, it's redacted when the key looks like it holds credentials.
+	Value string
+	// Missing is true when the key isn't in the ConfigMap.
+	Missing bool
+	Reason  string
+}
+
+func newInvalidTopicConfig(cm *corev1.ConfigMap, key string, reason string) InvalidTopicConfig {
+	value, ok := cm.Data[key]
+	if isSensitiveConfigMapKey(key) {
+		value = redactedValue
+	}
+	return InvalidTopicConfig{
+		ConfigMap: cm.Namespace + "/" + cm.Name,
+		Key:       key,
+		Value:     value,
+		Missing:   !ok,
+		Reason:    reason,
+	}
+}
+
+func (it InvalidTopicConfig) Error() string {
+	if it.Missing {
+		return fmt.Sprintf("invalid topic config in configmap %s: key %s is missing", it.ConfigMap, it.Key)
+	}
+	return fmt.Sprintf("invalid topic config in configmap %s: key %s with value %q %s", it.ConfigMap, it.Key, it.Value, it.Reason)
+}
+
+// IsInvalidTopicConfig returns true if the given error is an InvalidTopicConfig error.
+func IsInvalidTopicConfig(err error) bool {
+	var invalidTopicConfig InvalidTopicConfig
+	return errors.As(err, &invalidTopicConfig)
+}
+
+const redactedValue = "<redacted>"
+
+// sensitiveConfigMapKeyFragments are the fragments of ConfigMap keys whose values are redacted from errors.
+var sensitiveConfigMapKeyFragments = []string{"password", "secret", "token", "credential"}
+
+func isSensitiveConfigMapKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, f := range sensitiveConfigMapKeyFragments {
+		if strings.Contains(key, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxMessageBytes returns the max.message.bytes config of the topic, it returns 0 when it's not set.
+func (c TopicConfig) MaxMessageBytes() uint32 {
+	v, ok := c.TopicDetail.ConfigEntries[MaxMessageBytesTopicConfigKey]
+	if !ok || v == nil {
+		return 0
+	}
+	maxMessageBytes, err := strconv.ParseUint(*v, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(maxMessageBytes)
+}
+
 // GetBootstrapServers returns TopicConfig.BootstrapServers as a comma separated list of bootstrap servers.
 func (c TopicConfig) GetBootstrapServers() string {
 	return BootstrapServersCommaSeparated(c.BootstrapServers)
@@ -142,6 +345,24 @@ func BootstrapServersArray(bootstrapServers string) []string {
 	return bss[:j]
 }
 
+// FailoverBootstrapServersArray returns the bootstrap servers of each cluster in the given ';' separated list of
+// comma separated bootstrap servers, skipping empty entries.
+func FailoverBootstrapServersArray(failoverBootstrapServers string) [][]string {
+	var clusters [][]string
+	for _, c := range strings.Split(failoverBootstrapServers, ";") {
+		if bss := BootstrapServersArray(c); len(bss) > 0 {
+			clusters = append(clusters, bss)
+		}
+	}
+	return clusters
+}
+
+// AllBootstrapServers returns the bootstrap servers of the primary cluster followed by the failover ones, in the
+// order they should be tried.
+func (c TopicConfig) AllBootstrapServers() [][]string {
+	return append([][]string{c.BootstrapServers}, c.FailoverBootstrapServers...)
+}
+
 // BrokerTopic returns a topic name given a topic prefix and a Broker.
 func BrokerTopic(prefix string, obj metav1.Object) string {
 	return fmt.Sprintf("%s%s-%s", prefix, obj.GetNamespace(), obj.GetName())
@@ -152,6 +373,18 @@ func ChannelTopic(prefix string, obj metav1.Object) string {
 	return fmt.Sprintf("%s.%s.%s", prefix, obj.GetNamespace(), obj.GetName())
 }
 
+// NormalizeTopicName returns the given topic name in a form shared by the topic names that collide with it: topic names
+// are compared case-insensitively, so that topics don't clash on case-insensitive systems, and Kafka doesn't allow
+// topic names differing only by '.' and '_' since they collide in metric names.
+func NormalizeTopicName(topic string) string {
+	return strings.ToLower(strings.ReplaceAll(topic, ".", "_"))
+}
+
+// TopicNamesCollide returns true if the given topic names are different but collide.
+func TopicNamesCollide(a, b string) bool {
+	return a != b && NormalizeTopicName(a) == NormalizeTopicName(b)
+}
+
 // CreateTopicIfDoesntExist creates a topic with name 'topic' following the TopicConfig configuration passed as parameter.
 //
 // It returns the topic name or an error.
@@ -159,18 +392,466 @@ func ChannelTopic(prefix string, obj metav1.Object) string {
 // If the topic already exists, it will return no errors.
 // TODO: what happens if the topic exists but it has a different config?
 func CreateTopicIfDoesntExist(admin sarama.ClusterAdmin, logger *zap.Logger, topic string, config *TopicConfig) (string, error) {
+	_, err := CreateTopicIfAbsent(admin, logger, topic, config)
+	return topic, err
+}
+
+// CreateTopicIfAbsent is like CreateTopicIfDoesntExist, but it reports whether the topic has been created.
+//
+// It returns false and no errors if the topic already exists.
+func CreateTopicIfAbsent(admin sarama.ClusterAdmin, logger *zap.Logger, topic string, config *TopicConfig) (bool, error) {
 	logger.Debug("create topic",
 		zap.String("topic", topic),
 		zap.Int16("replicationFactor", config.TopicDetail.ReplicationFactor),
 		zap.Int32("numPartitions", config.TopicDetail.NumPartitions),
 	)
 
-	createTopicError := admin.CreateTopic(topic, &config.TopicDetail, false)
-	if err, ok := createTopicError.(*sarama.TopicError); ok && err.Err == sarama.ErrTopicAlreadyExists {
-		return topic, nil
+	detail := &config.TopicDetail
+	if config.RackAwareReplicaAssignmentEnabled && len(detail.ReplicaAssignment) == 0 {
+		detail = rackAwareTopicDetail(admin, logger, topic, detail)
+	}
+
+	createTopicError := admin.CreateTopic(topic, detail, false)
+	if IsTopicAlreadyExists(createTopicError) {
+		// Another reconciler, for example another control plane replica, might have created the topic concurrently,
+		// callers validate the existing topic as for any other existing topic.
+		logger.Debug("topic already exists", zap.String("topic", topic))
+		return false, nil
+	}
+	if createTopicError != nil {
+		return false, createTopicError
+	}
+
+	return true, nil
+}
+
+// CreateTopicIfAbsentWithReplicationFactorFallback is like CreateTopicIfAbsent, but when the topic creation fails
+// because the Kafka cluster has fewer brokers than the requested replication factor, it retries once with the
+// replication factor clamped to the number of available brokers.
+//
+// It returns the replication factor the topic has been created with, callers should surface a replication factor
+// lower than the requested one since the topic durability is degraded.
+func CreateTopicIfAbsentWithReplicationFactorFallback(admin sarama.ClusterAdmin, logger *zap.Logger, topic string, config *TopicConfig) (bool, int16, error) {
+	created, err := CreateTopicIfAbsent(admin, logger, topic, config)
+	if !IsInsufficientBrokers(err) {
+		return created, config.TopicDetail.ReplicationFactor, err
+	}
+
+	brokers, _, describeErr := admin.DescribeCluster()
+	if describeErr != nil {
+		return false, 0, fmt.Errorf("%w (failed to describe cluster for replication factor fallback: %v)", err, describeErr)
+	}
+	available := int16(len(brokers))
+	if available == 0 || available >= config.TopicDetail.ReplicationFactor {
+		return false, 0, err
+	}
+
+	logger.Warn("not enough brokers for the topic replication factor, falling back to the number of available brokers",
+		zap.String("topic", topic),
+		zap.Int16("replicationFactor", config.TopicDetail.ReplicationFactor),
+		zap.Int16("availableBrokers", available),
+	)
+
+	fallback := *config
+	fallback.TopicDetail.ReplicationFactor = available
+	created, err = CreateTopicIfAbsent(admin, logger, topic, &fallback)
+	if err != nil {
+		return false, 0, err
+	}
+	return created, available, nil
+}
+
+// IsTopicAlreadyExists returns true if the given error, or any error it wraps, is a TopicExistsException returned by
+// the Kafka cluster, either as a sarama.TopicError or as a bare sarama.KError.
+func IsTopicAlreadyExists(err error) bool {
+	return isTopicError(err, sarama.ErrTopicAlreadyExists)
+}
+
+// IsInsufficientBrokers returns true if the given error, or any error it wraps, is an InvalidReplicationFactorException
+// returned by the Kafka cluster, which is returned when the replication factor is larger than the number of available
+// brokers.
+func IsInsufficientBrokers(err error) bool {
+	return isTopicError(err, sarama.ErrInvalidReplicationFactor)
+}
+
+func isTopicError(err error, kError sarama.KError) bool {
+	var topicError *sarama.TopicError
+	if errors.As(err, &topicError) {
+		return topicError.Err == kError
+	}
+	return errors.Is(err, kError)
+}
+
+// AlterTopicConfigIfChanged compares the config entries of the given TopicConfig with the configuration of the
+// existing topic and alters the topic configuration when they differ.
+//
+// It returns true if the topic configuration has been altered.
+//
+// Only the config entries of the given TopicConfig are altered, the other configs of the topic, like the ones set out
+// of band on adopted or external topics, are left untouched.
+func AlterTopicConfigIfChanged(admin sarama.ClusterAdmin, logger *zap.Logger, topic string, config *TopicConfig) (bool, error) {
+	discrepancies, err := ReconcileTopicConfigEntries(admin, logger, topic, config)
+	return len(discrepancies) > 0, err
+}
+
+// ReconcileTopicConfigEntries is AlterTopicConfigIfChanged returning the config entries discrepancies that have been
+// corrected, sorted by name, so that callers can report them.
+func ReconcileTopicConfigEntries(admin sarama.ClusterAdmin, logger *zap.Logger, topic string, config *TopicConfig) ([]Discrepancy, error) {
+	discrepancies, err := TopicConfigEntriesDiscrepancies(admin, topic, config)
+	if err != nil {
+		return nil, err
+	}
+	if err := AlterTopicConfigEntries(admin, logger, topic, discrepancies); err != nil {
+		return nil, err
+	}
+	return discrepancies, nil
+}
+
+// TopicConfigEntriesDiscrepancies returns the config entries of the given TopicConfig whose actual value differs,
+// sorted by name, without altering the topic.
+func TopicConfigEntriesDiscrepancies(admin sarama.ClusterAdmin, topic string, config *TopicConfig) ([]Discrepancy, error) {
+	actual, err := describeTopicConfigEntries(admin, topic, config)
+	if err != nil {
+		return nil, err
+	}
+	return configEntriesDiscrepancies(actual, config), nil
+}
+
+// AlterTopicConfigEntries sets the desired value of the given config entries discrepancies, the other configs of the
+// topic are left untouched.
+func AlterTopicConfigEntries(admin sarama.ClusterAdmin, logger *zap.Logger, topic string, discrepancies []Discrepancy) error {
+	entries := incrementalConfigEntries(discrepancies)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	logger.Debug("alter topic config",
+		zap.String("topic", topic),
+		zap.Any("discrepancies", discrepancies),
+	)
+
+	if err := admin.IncrementalAlterConfig(sarama.TopicResource, topic, entries, false); err != nil {
+		return fmt.Errorf("failed to alter config of topic %s: %w", topic, err)
+	}
+	return nil
+}
+
+// incrementalConfigEntries returns the entries setting the desired value of the given config entries discrepancies.
+func incrementalConfigEntries(discrepancies []Discrepancy) map[string]sarama.IncrementalAlterConfigsEntry {
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry, len(discrepancies))
+	for _, d := range discrepancies {
+		if d.Kind != ConfigEntryDiscrepancy {
+			continue
+		}
+		value := d.Desired
+		entries[d.ConfigEntry] = sarama.IncrementalAlterConfigsEntry{
+			Operation: sarama.IncrementalAlterConfigsOperationSet,
+			Value:     &value,
+		}
+	}
+	return entries
+}
+
+// describeTopicConfigEntries returns the actual value of the config entries of the given TopicConfig.
+//
+// The described config entries might include topic configs other than the requested ones, most of them defaulted by
+// Kafka, so only the config entries of the given TopicConfig, which are the ones explicitly set in the broker config
+// map or annotations, are returned. Topic configs that aren't set never drift, otherwise topics would be altered at
+// every reconciliation.
+func describeTopicConfigEntries(admin sarama.ClusterAdmin, topic string, config *TopicConfig) (map[string]string, error) {
+	if len(config.TopicDetail.ConfigEntries) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(config.TopicDetail.ConfigEntries))
+	for k := range config.TopicDetail.ConfigEntries {
+		names = append(names, k)
+	}
+
+	entries, err := admin.DescribeConfig(sarama.ConfigResource{
+		Type:        sarama.TopicResource,
+		Name:        topic,
+		ConfigNames: names,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe config of topic %s: %w", topic, err)
+	}
+
+	actual := make(map[string]string, len(names))
+	for _, e := range entries {
+		if _, ok := config.TopicDetail.ConfigEntries[e.Name]; ok {
+			actual[e.Name] = e.Value
+		}
+	}
+	return actual, nil
+}
+
+// configEntriesDiscrepancies returns the config entries of the given TopicConfig whose actual value differs, sorted
+// by name.
+func configEntriesDiscrepancies(actual map[string]string, config *TopicConfig) []Discrepancy {
+	var discrepancies []Discrepancy
+	for _, k := range sets.StringKeySet(config.TopicDetail.ConfigEntries).List() {
+		v := config.TopicDetail.ConfigEntries[k]
+		if v == nil {
+			continue
+		}
+		if a, ok := actual[k]; !ok || a != *v {
+			discrepancies = append(discrepancies, Discrepancy{
+				Kind:        ConfigEntryDiscrepancy,
+				ConfigEntry: k,
+				Desired:     *v,
+				Actual:      a,
+			})
+		}
+	}
+	return discrepancies
+}
+
+// PlanTopicChanges returns a description of the changes that CreateTopicIfAbsent, AlterTopicConfigIfChanged and
+// ReconcileTopicPartitions would apply to the given topic, without altering the topic.
+//
+// It returns no changes when the topic already matches the given TopicConfig.
+func PlanTopicChanges(admin sarama.ClusterAdmin, topic string, config *TopicConfig) ([]string, error) {
+	discrepancies, err := ValidateTopicConfig(admin, topic, config)
+	if IsInvalidOrNotPresentTopic(err) {
+		return []string{fmt.Sprintf("create topic %s with %d partitions and replication factor %d",
+			topic, config.TopicDetail.NumPartitions, config.TopicDetail.ReplicationFactor)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	for _, d := range discrepancies {
+		if d.Kind == ConfigEntryDiscrepancy {
+			changes = append(changes, fmt.Sprintf("alter config of topic %s", topic))
+			break
+		}
+	}
+	for _, d := range discrepancies {
+		if d.Kind == PartitionsDiscrepancy {
+			changes = append(changes, fmt.Sprintf("increase partitions of topic %s from %s to %s", topic, d.Actual, d.Desired))
+		}
+	}
+
+	return changes, nil
+}
+
+// DiscrepancyKind is the kind of a difference between the desired and the actual config of a topic.
+type DiscrepancyKind string
+
+const (
+	// PartitionsDiscrepancy is reported when a topic has fewer partitions than desired.
+	PartitionsDiscrepancy DiscrepancyKind = "Partitions"
+	// ReplicationFactorDiscrepancy is reported when the replication factor of a topic differs from the desired one.
+	ReplicationFactorDiscrepancy DiscrepancyKind = "ReplicationFactor"
+	// ConfigEntryDiscrepancy is reported when a config entry of a topic differs from the desired one.
+	ConfigEntryDiscrepancy DiscrepancyKind = "ConfigEntry"
+)
+
+// Discrepancy is a difference between the desired and the actual config of a topic.
+type Discrepancy struct {
+	Kind DiscrepancyKind
+	// ConfigEntry is the name of the config entry, it's only set for ConfigEntryDiscrepancy.
+	ConfigEntry string
+	Desired     string
+	// Actual is empty for config entries that aren't set.
+	Actual string
+}
+
+func (d Discrepancy) String() string {
+	switch d.Kind {
+	case PartitionsDiscrepancy:
+		return fmt.Sprintf("%s partitions, expected at least %s", d.Actual, d.Desired)
+	case ReplicationFactorDiscrepancy:
+		return fmt.Sprintf("replication factor %s, expected %s", d.Actual, d.Desired)
+	default:
+		return fmt.Sprintf("config %s=%q, expected %q", d.ConfigEntry, d.Actual, d.Desired)
+	}
+}
+
+// ValidateTopicConfig compares the number of partitions, the replication factor and the config entries of the given
+// topic with the given desired TopicConfig, without altering the topic.
+//
+// It returns the discrepancies found, partitions first, then the replication factor and the config entries sorted by
+// name, and an InvalidOrNotPresentTopic error when the topic isn't present.
+func ValidateTopicConfig(admin sarama.ClusterAdmin, topic string, desired *TopicConfig) ([]Discrepancy, error) {
+	topicMetadata, err := describeTopic(admin, topic)
+	if err != nil {
+		return nil, err
+	}
+	if topicMetadata == nil {
+		return nil, InvalidOrNotPresentTopic{Topic: topic}
+	}
+
+	var discrepancies []Discrepancy
+
+	var partitionsMismatch PartitionsMismatch
+	if err := checkTopicPartitions(topicMetadata, desired); errors.As(err, &partitionsMismatch) {
+		discrepancies = append(discrepancies, Discrepancy{
+			Kind:    PartitionsDiscrepancy,
+			Desired: strconv.Itoa(int(partitionsMismatch.Desired)),
+			Actual:  strconv.Itoa(int(partitionsMismatch.Actual)),
+		})
+	}
+
+	var replicationFactorMismatch ReplicationFactorMismatch
+	if err := checkTopicReplicationFactor(topicMetadata, desired); errors.As(err, &replicationFactorMismatch) {
+		discrepancies = append(discrepancies, Discrepancy{
+			Kind:    ReplicationFactorDiscrepancy,
+			Desired: strconv.Itoa(int(replicationFactorMismatch.Desired)),
+			Actual:  strconv.Itoa(int(replicationFactorMismatch.Actual)),
+		})
+	}
+
+	actual, err := describeTopicConfigEntries(admin, topic, desired)
+	if err != nil {
+		return nil, err
+	}
+	discrepancies = append(discrepancies, configEntriesDiscrepancies(actual, desired)...)
+
+	return discrepancies, nil
+}
+
+// ReconcileTopicPartitions compares the number of partitions and the replication factor of the existing topic with
+// the given TopicConfig.
+//
+// Since increasing the number of partitions of a topic is safe, partitions are added when the desired number of
+// partitions is greater than the actual one.
+// The replication factor can't be changed online, so a ReplicationFactorMismatch error is returned when the desired
+// replication factor differs from the actual one.
+//
+// If the topic metadata aren't available yet, it will return no errors.
+func ReconcileTopicPartitions(admin sarama.ClusterAdmin, logger *zap.Logger, topic string, config *TopicConfig) error {
+	topicMetadata, err := describeTopic(admin, topic)
+	if err != nil {
+		return err
+	}
+	if topicMetadata == nil {
+		return nil
+	}
+
+	actualPartitions := int32(len(topicMetadata.Partitions))
+	if config.TopicDetail.NumPartitions > actualPartitions {
+		logger.Debug("create partitions",
+			zap.String("topic", topic),
+			zap.Int32("numPartitions", config.TopicDetail.NumPartitions),
+			zap.Int32("actualNumPartitions", actualPartitions),
+		)
+
+		if err := admin.CreatePartitions(topic, config.TopicDetail.NumPartitions, nil, false); err != nil {
+			return fmt.Errorf("failed to increase partitions of topic %s from %d to %d: %w", topic, actualPartitions, config.TopicDetail.NumPartitions, err)
+		}
+	}
+
+	return checkTopicReplicationFactor(topicMetadata, config)
+}
+
+// CheckTopicPartitionsAndReplicationFactor verifies that the number of partitions and the replication factor of an
+// existing topic are compatible with the given TopicConfig, without altering the topic.
+//
+// It returns a PartitionsMismatch error when the topic has fewer partitions than configured and a
+// ReplicationFactorMismatch error when the replication factor differs from the configured one.
+func CheckTopicPartitionsAndReplicationFactor(admin sarama.ClusterAdmin, topic string, config *TopicConfig) error {
+	topicMetadata, err := describeTopic(admin, topic)
+	if err != nil {
+		return err
+	}
+	if topicMetadata == nil {
+		return InvalidOrNotPresentTopic{Topic: topic}
+	}
+
+	if err := checkTopicPartitions(topicMetadata, config); err != nil {
+		return err
+	}
+	return checkTopicReplicationFactor(topicMetadata, config)
+}
+
+// CheckTopicWritable checks whether every partition of the given topic has a leader and at least the
+// min.insync.replicas of the given config in-sync replicas (1 when not set), so that the topic accepts writes with
+// acks=all.
+//
+// It returns an UnhealthyTopic error when the topic isn't writable.
+func CheckTopicWritable(admin sarama.ClusterAdmin, topic string, config *TopicConfig) error {
+	topicMetadata, err := describeTopic(admin, topic)
+	if err != nil {
+		return err
+	}
+	if topicMetadata == nil {
+		return InvalidOrNotPresentTopic{Topic: topic}
+	}
+
+	minInSyncReplicas := config.minInSyncReplicas()
+	unhealthy := UnhealthyTopic{Topic: topic, MinInSyncReplicas: minInSyncReplicas}
+	for _, p := range topicMetadata.Partitions {
+		if p.Leader < 0 || p.Err == sarama.ErrLeaderNotAvailable {
+			unhealthy.LeaderlessPartitions = append(unhealthy.LeaderlessPartitions, p.ID)
+		} else if len(p.Isr) < minInSyncReplicas {
+			unhealthy.UnderReplicatedPartitions = append(unhealthy.UnderReplicatedPartitions, p.ID)
+		}
+	}
+	if len(unhealthy.LeaderlessPartitions) > 0 || len(unhealthy.UnderReplicatedPartitions) > 0 {
+		return unhealthy
+	}
+	return nil
+}
+
+// minInSyncReplicas returns the min.insync.replicas of the topic config, or 1 when not set.
+func (c TopicConfig) minInSyncReplicas() int {
+	if v, ok := c.TopicDetail.ConfigEntries[MinInSyncReplicasTopicConfigKey]; ok && v != nil {
+		if minInSyncReplicas, err := strconv.Atoi(*v); err == nil && minInSyncReplicas > 0 {
+			return minInSyncReplicas
+		}
+	}
+	return 1
+}
+
+// IsTopicPresent reports whether the given topic exists according to the Kafka cluster metadata.
+func IsTopicPresent(admin sarama.ClusterAdmin, topic string) (bool, error) {
+	metadata, err := describeTopic(admin, topic)
+	if err != nil {
+		return false, err
+	}
+	return metadata != nil, nil
+}
+
+// describeTopic returns the metadata of the given topic, or nil when the topic metadata aren't available.
+func describeTopic(admin sarama.ClusterAdmin, topic string) (*sarama.TopicMetadata, error) {
+	metadata, err := admin.DescribeTopics([]string{topic})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe topic %s: %w", topic, err)
+	}
+
+	for _, m := range metadata {
+		if m.Name == topic && m.Err == sarama.ErrNoError && len(m.Partitions) > 0 {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+func checkTopicPartitions(topicMetadata *sarama.TopicMetadata, config *TopicConfig) error {
+	actualPartitions := int32(len(topicMetadata.Partitions))
+	if actualPartitions < config.TopicDetail.NumPartitions {
+		return PartitionsMismatch{
+			Topic:   topicMetadata.Name,
+			Desired: config.TopicDetail.NumPartitions,
+			Actual:  actualPartitions,
+		}
 	}
+	return nil
+}
 
-	return topic, createTopicError
+func checkTopicReplicationFactor(topicMetadata *sarama.TopicMetadata, config *TopicConfig) error {
+	actualReplicationFactor := int16(len(topicMetadata.Partitions[0].Replicas))
+	if config.TopicDetail.ReplicationFactor != actualReplicationFactor {
+		return ReplicationFactorMismatch{
+			Topic:   topicMetadata.Name,
+			Desired: config.TopicDetail.ReplicationFactor,
+			Actual:  actualReplicationFactor,
+		}
+	}
+	return nil
 }
 
 func DeleteTopic(admin sarama.ClusterAdmin, topic string) (string, error) {
@@ -187,6 +868,27 @@ func DeleteTopic(admin sarama.ClusterAdmin, topic string) (string, error) {
 	return topic, nil
 }
 
+// WaitTopicDeleted lists the topics of the Kafka cluster every interval until the given topic isn't listed anymore or
+// the timeout elapses, since Kafka deletes topics asynchronously once the delete request is accepted.
+//
+// It returns whether the topic has been deleted within the timeout.
+func WaitTopicDeleted(admin sarama.ClusterAdmin, topic string, timeout, interval time.Duration) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		topics, err := admin.ListTopics()
+		if err != nil {
+			return false, fmt.Errorf("failed to list topics to confirm topic %s deletion: %w", topic, err)
+		}
+		if _, ok := topics[topic]; !ok {
+			return true, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return false, nil
+		}
+		time.Sleep(interval)
+	}
+}
+
 func AreTopicsPresentAndValid(kafkaClusterAdmin sarama.ClusterAdmin, topics ...string) (bool, error) {
 	if len(topics) == 0 {
 		return false, fmt.Errorf("expected at least one topic, got 0")
@@ -204,6 +906,10 @@ func AreTopicsPresentAndValid(kafkaClusterAdmin sarama.ClusterAdmin, topics ...s
 
 	for _, t := range topics {
 		m, ok := metadataByTopic[t]
+		if ok && IsAuthorizationError(m.Err) {
+			// Topics the client isn't authorized to describe are reported as not present, unless we tell why.
+			return false, fmt.Errorf("failed to describe topic %s: %w", t, m.Err)
+		}
 		if !ok || !isValidSingleTopicMetadata(m, t) {
 			return false, InvalidOrNotPresentTopic{Topic: t}
 		}
@@ -222,3 +928,65 @@ type InvalidOrNotPresentTopic struct {
 func (it InvalidOrNotPresentTopic) Error() string {
 	return fmt.Sprintf("invalid topic %s", it.Topic)
 }
+
+// IsInvalidOrNotPresentTopic returns true if the given error is an InvalidOrNotPresentTopic error.
+func IsInvalidOrNotPresentTopic(err error) bool {
+	var invalidOrNotPresentTopic InvalidOrNotPresentTopic
+	return errors.As(err, &invalidOrNotPresentTopic)
+}
+
+// UnhealthyTopic is returned when a topic exists but doesn't accept writes, since some partitions have no leader or
+// fewer in-sync replicas than min.insync.replicas.
+type UnhealthyTopic struct {
+	Topic                     string
+	MinInSyncReplicas         int
+	LeaderlessPartitions      []int32
+	UnderReplicatedPartitions []int32
+}
+
+func (ut UnhealthyTopic) Error() string {
+	var problems []string
+	if len(ut.LeaderlessPartitions) > 0 {
+		problems = append(problems, fmt.Sprintf("partitions %v have no leader", ut.LeaderlessPartitions))
+	}
+	if len(ut.UnderReplicatedPartitions) > 0 {
+		problems = append(problems, fmt.Sprintf("partitions %v have fewer than %d in-sync replicas", ut.UnderReplicatedPartitions, ut.MinInSyncReplicas))
+	}
+	return fmt.Sprintf("topic %s is not writable: %s", ut.Topic, strings.Join(problems, ", "))
+}
+
+// IsUnhealthyTopic returns true if the given error is an UnhealthyTopic error.
+func IsUnhealthyTopic(err error) bool {
+	var unhealthyTopic UnhealthyTopic
+	return errors.As(err, &unhealthyTopic)
+}
+
+// ReplicationFactorMismatch is returned when the replication factor of an existing topic differs from the desired
+// replication factor.
+type ReplicationFactorMismatch struct {
+	Topic   string
+	Desired int16
+	Actual  int16
+}
+
+func (rf ReplicationFactorMismatch) Error() string {
+	return fmt.Sprintf("topic %s has replication factor %d, expected %d", rf.Topic, rf.Actual, rf.Desired)
+}
+
+// PartitionsMismatch is returned when an existing topic has fewer partitions than the desired number of partitions.
+type PartitionsMismatch struct {
+	Topic   string
+	Desired int32
+	Actual  int32
+}
+
+func (p PartitionsMismatch) Error() string {
+	return fmt.Sprintf("topic %s has %d partitions, expected at least %d", p.Topic, p.Actual, p.Desired)
+}
+
+// IsTopicConfigMismatch returns true if the given error is a PartitionsMismatch or a ReplicationFactorMismatch error.
+func IsTopicConfigMismatch(err error) bool {
+	var partitionsMismatch PartitionsMismatch
+	var replicationFactorMismatch ReplicationFactorMismatch
+	return errors.As(err, &partitionsMismatch) || errors.As(err, &replicationFactorMismatch)
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/Shopify/sarama"
+)
+
+// TopicErrorClass is the class of an error returned by topic operations, it tells whether the operation can succeed
+// without user action.
+type TopicErrorClass int
+
+const (
+	// TopicErrorUnknown is the class of errors that aren't known to be either transient or permanent.
+	TopicErrorUnknown TopicErrorClass = iota
+	// TopicErrorTransient is the class of errors expected to go away without user action, for example, when a Kafka
+	// broker is temporarily unavailable.
+	TopicErrorTransient
+	// TopicErrorPermanent is the class of errors requiring user action, for example, when the topic config is
+	// invalid or when the topic operation isn't authorized.
+	TopicErrorPermanent
+)
+
+func (c TopicErrorClass) String() string {
+	switch c {
+	case TopicErrorTransient:
+		return "transient"
+	case TopicErrorPermanent:
+		return "permanent"
+	default:
+		return "unknown"
+	}
+}
+
+// topicErrorClasses maps the Kafka error codes known to be returned by topic operations to their class.
+var topicErrorClasses = map[sarama.KError]TopicErrorClass{
+	// The Kafka cluster is temporarily unable to serve the request.
+	sarama.ErrUnknownTopicOrPartition:         TopicErrorTransient,
+	sarama.ErrLeaderNotAvailable:              TopicErrorTransient,
+	sarama.ErrNotLeaderForPartition:           TopicErrorTransient,
+	sarama.ErrRequestTimedOut:                 TopicErrorTransient,
+	sarama.ErrBrokerNotAvailable:              TopicErrorTransient,
+	sarama.ErrNetworkException:                TopicErrorTransient,
+	sarama.ErrNotEnoughReplicas:               TopicErrorTransient,
+	sarama.ErrNotController:                   TopicErrorTransient,
+	sarama.ErrKafkaStorageError:               TopicErrorTransient,
+	sarama.ErrReassignmentInProgress:          TopicErrorTransient,
+	sarama.ErrThrottlingQuotaExceeded:         TopicErrorTransient,
+	sarama.ErrConsumerCoordinatorNotAvailable: TopicErrorTransient,
+
+	// The request is rejected because of the topic config or of the client permissions.
+	sarama.ErrInvalidTopic:               TopicErrorPermanent,
+	sarama.ErrInvalidPartitions:          TopicErrorPermanent,
+	sarama.ErrInvalidReplicationFactor:   TopicErrorPermanent,
+	sarama.ErrInvalidReplicaAssignment:   TopicErrorPermanent,
+	sarama.ErrInvalidConfig:              TopicErrorPermanent,
+	sarama.ErrPolicyViolation:            TopicErrorPermanent,
+	sarama.ErrInvalidRequest:             TopicErrorPermanent,
+	sarama.ErrUnsupportedVersion:         TopicErrorPermanent,
+	sarama.ErrTopicAuthorizationFailed:   TopicErrorPermanent,
+	sarama.ErrClusterAuthorizationFailed: TopicErrorPermanent,
+	sarama.ErrSASLAuthenticationFailed:   TopicErrorPermanent,
+	sarama.ErrTopicDeletionDisabled:      TopicErrorPermanent,
+}
+
+// authorizationErrors are the Kafka error codes returned when the client isn't authorized to perform an operation,
+// they're fixed by granting the client the missing ACLs.
+var authorizationErrors = map[sarama.KError]bool{
+	sarama.ErrTopicAuthorizationFailed:   true,
+	sarama.ErrGroupAuthorizationFailed:   true,
+	sarama.ErrClusterAuthorizationFailed: true,
+}
+
+// IsAuthorizationError returns true when the given error is returned by the Kafka cluster because the client isn't
+// authorized to perform the operation, either a bare sarama.KError or a sarama.KError wrapped in a sarama.TopicError.
+func IsAuthorizationError(err error) bool {
+	code, ok := KafkaErrorCode(err)
+	return ok && authorizationErrors[code]
+}
+
+// ClassifyTopicError returns the class of the given error returned by a topic operation.
+//
+// Kafka errors, either bare or wrapped in a sarama.TopicError, are classified with their error code, connectivity
+// errors are transient and other errors are unknown.
+func ClassifyTopicError(err error) TopicErrorClass {
+	if err == nil {
+		return TopicErrorUnknown
+	}
+
+	var topicError *sarama.TopicError
+	if errors.As(err, &topicError) {
+		if c, ok := topicErrorClasses[topicError.Err]; ok {
+			return c
+		}
+	}
+	var kError sarama.KError
+	if errors.As(err, &kError) {
+		if c, ok := topicErrorClasses[kError]; ok {
+			return c
+		}
+	}
+
+	var netError net.Error
+	if errors.Is(err, sarama.ErrOutOfBrokers) ||
+		errors.Is(err, sarama.ErrNotConnected) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &netError) {
+		return TopicErrorTransient
+	}
+	return TopicErrorUnknown
+}
+
+// KafkaErrorCode returns the Kafka error code of the given error, either a bare sarama.KError or a sarama.KError
+// wrapped in a sarama.TopicError, errors not returned by the Kafka cluster have no error code.
+func KafkaErrorCode(err error) (sarama.KError, bool) {
+	var topicError *sarama.TopicError
+	if errors.As(err, &topicError) && topicError.Err != sarama.ErrNoError {
+		return topicError.Err, true
+	}
+	var kError sarama.KError
+	if errors.As(err, &kError) && kError != sarama.ErrNoError {
+		return kError, true
+	}
+	return sarama.ErrNoError, false
+}
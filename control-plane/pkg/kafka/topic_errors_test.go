@@ -0,0 +1,129 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyTopicError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want TopicErrorClass
+	}{
+		{
+			name: "nil",
+			want: TopicErrorUnknown,
+		},
+		{
+			name: "transient kafka error",
+			err:  sarama.ErrNotController,
+			want: TopicErrorTransient,
+		},
+		{
+			name: "transient topic error",
+			err:  &sarama.TopicError{Err: sarama.ErrRequestTimedOut},
+			want: TopicErrorTransient,
+		},
+		{
+			name: "permanent kafka error",
+			err:  sarama.ErrTopicAuthorizationFailed,
+			want: TopicErrorPermanent,
+		},
+		{
+			name: "wrapped permanent topic error",
+			err:  fmt.Errorf("failed to create topic: %w", &sarama.TopicError{Err: sarama.ErrPolicyViolation}),
+			want: TopicErrorPermanent,
+		},
+		{
+			name: "out of brokers",
+			err:  fmt.Errorf("failed to create topic: %w", sarama.ErrOutOfBrokers),
+			want: TopicErrorTransient,
+		},
+		{
+			name: "deadline exceeded",
+			err:  context.DeadlineExceeded,
+			want: TopicErrorTransient,
+		},
+		{
+			name: "unclassified kafka error",
+			err:  sarama.ErrTopicAlreadyExists,
+			want: TopicErrorUnknown,
+		},
+		{
+			name: "other error",
+			err:  errors.New("failed"),
+			want: TopicErrorUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyTopicError(tt.err))
+		})
+	}
+}
+
+func TestKafkaErrorCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		want   sarama.KError
+		wantOk bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:   "kafka error",
+			err:    fmt.Errorf("failed to create topic: %w", sarama.ErrTopicAuthorizationFailed),
+			want:   sarama.ErrTopicAuthorizationFailed,
+			wantOk: true,
+		},
+		{
+			name:   "topic error",
+			err:    fmt.Errorf("failed to create topic: %w", &sarama.TopicError{Err: sarama.ErrPolicyViolation}),
+			want:   sarama.ErrPolicyViolation,
+			wantOk: true,
+		},
+		{
+			name: "not a kafka error",
+			err:  sarama.ErrOutOfBrokers,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := KafkaErrorCode(tt.err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestIsAuthorizationError(t *testing.T) {
+	assert.True(t, IsAuthorizationError(fmt.Errorf("failed to create topic: %w", sarama.ErrTopicAuthorizationFailed)))
+	assert.True(t, IsAuthorizationError(&sarama.TopicError{Err: sarama.ErrClusterAuthorizationFailed}))
+	assert.False(t, IsAuthorizationError(sarama.ErrSASLAuthenticationFailed))
+	assert.False(t, IsAuthorizationError(sarama.ErrOutOfBrokers))
+	assert.False(t, IsAuthorizationError(nil))
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// TopicMetadataCache caches the topic metadata described by sarama.ClusterAdmin clients, keyed by Kafka cluster and
+// topic, so that many resources reconciled against the same Kafka cluster within the TTL don't describe the same
+// topic every time.
+//
+// Only the metadata of existing topics are cached, so that absent topics are always looked up again. The metadata of
+// a topic are invalidated when the topic is created, deleted or gets new partitions through a client wrapped by the
+// cache, changes made out-of-band are visible once the TTL elapsed.
+type TopicMetadataCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[topicMetadataCacheKey]topicMetadataCacheEntry
+}
+
+type topicMetadataCacheKey struct {
+	cluster string
+	topic   string
+}
+
+type topicMetadataCacheEntry struct {
+	metadata *sarama.TopicMetadata
+	expires  time.Time
+}
+
+// NewTopicMetadataCache creates a TopicMetadataCache whose entries expire after the given TTL.
+func NewTopicMetadataCache(ttl time.Duration) *TopicMetadataCache {
+	return &TopicMetadataCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[topicMetadataCacheKey]topicMetadataCacheEntry),
+	}
+}
+
+// Wrap returns a sarama.ClusterAdmin describing topics of the Kafka cluster with the given bootstrap servers through
+// the cache, the other calls are delegated to the given client.
+//
+// The returned metadata are shared, they must not be modified.
+func (c *TopicMetadataCache) Wrap(admin sarama.ClusterAdmin, addrs []string) sarama.ClusterAdmin {
+	return &cachingClusterAdmin{
+		ClusterAdmin: admin,
+		cache:        c,
+		cluster:      BootstrapServersCommaSeparated(addrs),
+	}
+}
+
+// Len returns the number of cached topic metadata, including expired ones.
+func (c *TopicMetadataCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+func (c *TopicMetadataCache) get(cluster, topic string) (*sarama.TopicMetadata, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := topicMetadataCacheKey{cluster: cluster, topic: topic}
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.metadata, true
+}
+
+func (c *TopicMetadataCache) put(cluster string, metadata *sarama.TopicMetadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[topicMetadataCacheKey{cluster: cluster, topic: metadata.Name}] = topicMetadataCacheEntry{
+		metadata: metadata,
+		expires:  c.now().Add(c.ttl),
+	}
+}
+
+func (c *TopicMetadataCache) invalidate(cluster, topic string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, topicMetadataCacheKey{cluster: cluster, topic: topic})
+}
+
+// cachingClusterAdmin is a sarama.ClusterAdmin describing topics through a TopicMetadataCache.
+type cachingClusterAdmin struct {
+	sarama.ClusterAdmin
+	cache   *TopicMetadataCache
+	cluster string
+}
+
+// DescribeTopics describes the topics whose metadata aren't cached, the metadata are returned in the order of the
+// given topics.
+func (a *cachingClusterAdmin) DescribeTopics(topics []string) ([]*sarama.TopicMetadata, error) {
+	metadata := make([]*sarama.TopicMetadata, len(topics))
+	var missing []string
+	for i, topic := range topics {
+		if m, ok := a.cache.get(a.cluster, topic); ok {
+			metadata[i] = m
+		} else {
+			missing = append(missing, topic)
+		}
+	}
+	if len(missing) == 0 {
+		return metadata, nil
+	}
+
+	described, err := a.ClusterAdmin.DescribeTopics(missing)
+	if err != nil {
+		return nil, err
+	}
+	byTopic := make(map[string]*sarama.TopicMetadata, len(described))
+	for _, m := range described {
+		byTopic[m.Name] = m
+		if m.Err == sarama.ErrNoError && len(m.Partitions) > 0 {
+			a.cache.put(a.cluster, m)
+		}
+	}
+
+	result := make([]*sarama.TopicMetadata, 0, len(topics))
+	for i, topic := range topics {
+		if metadata[i] != nil {
+			result = append(result, metadata[i])
+		} else if m, ok := byTopic[topic]; ok {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+func (a *cachingClusterAdmin) CreateTopic(topic string, detail *sarama.TopicDetail, validateOnly bool) error {
+	defer a.cache.invalidate(a.cluster, topic)
+	return a.ClusterAdmin.CreateTopic(topic, detail, validateOnly)
+}
+
+func (a *cachingClusterAdmin) DeleteTopic(topic string) error {
+	defer a.cache.invalidate(a.cluster, topic)
+	return a.ClusterAdmin.DeleteTopic(topic)
+}
+
+func (a *cachingClusterAdmin) CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error {
+	defer a.cache.invalidate(a.cluster, topic)
+	return a.ClusterAdmin.CreatePartitions(topic, count, assignment, validateOnly)
+}
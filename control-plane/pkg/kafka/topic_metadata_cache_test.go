@@ -0,0 +1,97 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+// countingClusterAdmin counts the topics described.
+type countingClusterAdmin struct {
+	sarama.ClusterAdmin
+	metadata  map[string]*sarama.TopicMetadata
+	described []string
+}
+
+func (a *countingClusterAdmin) DescribeTopics(topics []string) ([]*sarama.TopicMetadata, error) {
+	a.described = append(a.described, topics...)
+	metadata := make([]*sarama.TopicMetadata, 0, len(topics))
+	for _, topic := range topics {
+		if m, ok := a.metadata[topic]; ok {
+			metadata = append(metadata, m)
+		} else {
+			metadata = append(metadata, &sarama.TopicMetadata{Name: topic, Err: sarama.ErrUnknownTopicOrPartition})
+		}
+	}
+	return metadata, nil
+}
+
+func (a *countingClusterAdmin) CreateTopic(string, *sarama.TopicDetail, bool) error {
+	return nil
+}
+
+func (a *countingClusterAdmin) DeleteTopic(string) error {
+	return nil
+}
+
+func TestTopicMetadataCache(t *testing.T) {
+	now := time.Unix(0, 0)
+	cache := NewTopicMetadataCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	underlying := &countingClusterAdmin{metadata: map[string]*sarama.TopicMetadata{
+		"t1": {Name: "t1", Partitions: []*sarama.PartitionMetadata{{ID: 0}}},
+		"t2": {Name: "t2", Partitions: []*sarama.PartitionMetadata{{ID: 0}}},
+	}}
+	admin := cache.Wrap(underlying, []string{"kafka-1:9092"})
+
+	present, err := AreTopicsPresentAndValid(admin, "t1", "t2")
+	require.NoError(t, err)
+	require.True(t, present)
+	require.Equal(t, []string{"t1", "t2"}, underlying.described)
+	require.Equal(t, 2, cache.Len())
+
+	// Cached topics aren't described again, absent ones are.
+	metadata, err := admin.DescribeTopics([]string{"t2", "absent", "t1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"t2", "absent", "t1"}, []string{metadata[0].Name, metadata[1].Name, metadata[2].Name})
+	require.Equal(t, []string{"t1", "t2", "absent"}, underlying.described)
+
+	// Other clusters don't share the cached metadata.
+	_, err = cache.Wrap(underlying, []string{"kafka-2:9092"}).DescribeTopics([]string{"t1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"t1", "t2", "absent", "t1"}, underlying.described)
+
+	// Topics created or deleted by the controller are invalidated.
+	underlying.described = nil
+	require.NoError(t, admin.CreateTopic("t1", &sarama.TopicDetail{}, false))
+	require.NoError(t, admin.DeleteTopic("t2"))
+	_, err = admin.DescribeTopics([]string{"t1", "t2"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"t1", "t2"}, underlying.described)
+
+	// Expired metadata are described again.
+	underlying.described = nil
+	now = now.Add(time.Minute)
+	_, err = admin.DescribeTopics([]string{"t1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"t1"}, underlying.described)
+}
@@ -17,9 +17,11 @@
 package kafka
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
@@ -27,6 +29,7 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
 
 	kafkatesting "knative.dev/eventing-kafka-broker/control-plane/pkg/kafka/testing"
@@ -170,6 +173,30 @@ func TestTopic(t *testing.T) {
 	}
 }
 
+func TestTopicNamesCollide(t *testing.T) {
+	topics := []string{
+		"knative-broker-ns-b1",
+		"knative-broker-NS-b1",
+		"knative.broker.ns.b2",
+		"knative_broker_ns_b2",
+		"knative-broker-ns-b3",
+	}
+
+	var collisions [][]string
+	for i, a := range topics {
+		for _, b := range topics[i+1:] {
+			if TopicNamesCollide(a, b) {
+				collisions = append(collisions, []string{a, b})
+			}
+		}
+	}
+	assert.Equal(t, [][]string{
+		{"knative-broker-ns-b1", "knative-broker-NS-b1"},
+		{"knative.broker.ns.b2", "knative_broker_ns_b2"},
+	}, collisions)
+	assert.False(t, TopicNamesCollide("knative-broker-ns-b1", "knative-broker-ns-b1"))
+}
+
 func TestTopicConfig_GetBootstrapServers(t *testing.T) {
 	type fields struct {
 		TopicDetail      sarama.TopicDetail
@@ -188,130 +215,1128 @@ func TestTopicConfig_GetBootstrapServers(t *testing.T) {
 			want: "broker1:9092",
 		},
 		{
-			name: "2 brokers",
-			fields: fields{
-				BootstrapServers: []string{"broker1:9092", "broker2:9092"},
-			},
-			want: "broker1:9092,broker2:9092",
+			name: "2 brokers",
+			fields: fields{
+				BootstrapServers: []string{"broker1:9092", "broker2:9092"},
+			},
+			want: "broker1:9092,broker2:9092",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := TopicConfig{
+				TopicDetail:      tt.fields.TopicDetail,
+				BootstrapServers: tt.fields.BootstrapServers,
+			}
+			if got := c.GetBootstrapServers(); got != tt.want {
+				t.Errorf("GetBootstrapServers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteTopic(t *testing.T) {
+	type args struct {
+		admin sarama.ClusterAdmin
+		topic string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "no errors",
+			args: args{
+				admin: &kafkatesting.MockKafkaClusterAdmin{
+					ExpectedTopicName: "topic-name",
+					T:                 t,
+				},
+				topic: "topic-name",
+			},
+			want:    "topic-name",
+			wantErr: false,
+		},
+		{
+			name: "unknown error",
+			args: args{
+				admin: &kafkatesting.MockKafkaClusterAdmin{
+					ExpectedTopicName:  "topic-name",
+					ErrorOnDeleteTopic: fmt.Errorf("failed to delete topic topic-name"),
+					T:                  t,
+				},
+				topic: "topic-name",
+			},
+			want:    "topic-name",
+			wantErr: true,
+		},
+		{
+			name: "unknown topic or partition error (external)",
+			args: args{
+				admin: &kafkatesting.MockKafkaClusterAdmin{
+					ExpectedTopicName:  "topic-name",
+					ErrorOnDeleteTopic: sarama.ErrUnknownTopicOrPartition,
+					T:                  t,
+				},
+				topic: "topic-name",
+			},
+			want:    "topic-name",
+			wantErr: false,
+		},
+		{
+			name: "unknown topic or partition error (internal)",
+			args: args{
+				admin: &kafkatesting.MockKafkaClusterAdmin{
+					ExpectedTopicName: "topic-name",
+					ErrorOnDeleteTopic: &sarama.TopicError{
+						Err: sarama.ErrUnknownTopicOrPartition,
+					},
+					T: t,
+				},
+				topic: "topic-name",
+			},
+			want:    "topic-name",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeleteTopic(tt.args.admin, tt.args.topic)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteTopic() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("DeleteTopic() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitTopicDeleted(t *testing.T) {
+	tests := []struct {
+		name    string
+		topics  map[string]sarama.TopicDetail
+		err     error
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:   "topic deleted",
+			topics: map[string]sarama.TopicDetail{"other": {}},
+			want:   true,
+		},
+		{
+			name:   "topic still present",
+			topics: map[string]sarama.TopicDetail{"topic": {}},
+			want:   false,
+		},
+		{
+			name:    "list topics error",
+			err:     errors.New("failed"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admin := &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicsOnListTopics: tt.topics,
+				ErrorOnListTopics:          tt.err,
+				T:                          t,
+			}
+
+			got, err := WaitTopicDeleted(admin, "topic", 2*time.Millisecond, time.Millisecond)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateTopicTopicAlreadyExists(t *testing.T) {
+
+	b := &eventing.Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bname",
+			Namespace: "bnamespace",
+		},
+	}
+	topic := BrokerTopic("", b)
+	errMsg := "topic already exists"
+
+	ca := &kafkatesting.MockKafkaClusterAdmin{
+		ExpectedTopicName:   topic,
+		ExpectedTopicDetail: sarama.TopicDetail{},
+		ErrorOnCreateTopic: &sarama.TopicError{
+			Err:    sarama.ErrTopicAlreadyExists,
+			ErrMsg: &errMsg,
+		},
+		T: t,
+	}
+
+	topicRet, err := CreateTopicIfDoesntExist(ca, zap.NewNop(), topic, &TopicConfig{})
+
+	assert.Equal(t, topicRet, topic, "expected topic %s go %s", topic, topicRet)
+	assert.Nil(t, err, "expected nil error on topic already exists")
+}
+
+func TestCreateTopicIfAbsent(t *testing.T) {
+	ca := &kafkatesting.MockKafkaClusterAdmin{
+		ExpectedTopicName:   "topic-name-1",
+		ExpectedTopicDetail: sarama.TopicDetail{},
+		T:                   t,
+	}
+
+	created, err := CreateTopicIfAbsent(ca, zap.NewNop(), "topic-name-1", &TopicConfig{})
+	assert.Nil(t, err)
+	assert.True(t, created, "expected topic to be created")
+
+	ca.ErrorOnCreateTopic = &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}
+
+	created, err = CreateTopicIfAbsent(ca, zap.NewNop(), "topic-name-1", &TopicConfig{})
+	assert.Nil(t, err, "expected nil error on topic already exists")
+	assert.False(t, created, "expected existing topic not to be created")
+}
+
+func TestCreateTopicIfAbsentCreatedConcurrently(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "bare error",
+			err:  sarama.ErrTopicAlreadyExists,
+		},
+		{
+			name: "wrapped topic error",
+			err:  fmt.Errorf("failed to create topic: %w", &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:   "topic-name-1",
+				ExpectedTopicDetail: sarama.TopicDetail{},
+				ErrorOnCreateTopic:  tt.err,
+				T:                   t,
+			}
+
+			created, err := CreateTopicIfAbsent(ca, zap.NewNop(), "topic-name-1", &TopicConfig{})
+			assert.Nil(t, err, "expected nil error on topic already exists")
+			assert.False(t, created, "expected existing topic not to be created")
+		})
+	}
+}
+
+func TestIsTopicAlreadyExists(t *testing.T) {
+	assert.True(t, IsTopicAlreadyExists(&sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}))
+	assert.True(t, IsTopicAlreadyExists(sarama.ErrTopicAlreadyExists))
+	assert.False(t, IsTopicAlreadyExists(&sarama.TopicError{Err: sarama.ErrInvalidTopic}))
+	assert.False(t, IsTopicAlreadyExists(sarama.ErrInvalidTopic))
+	assert.False(t, IsTopicAlreadyExists(nil))
+}
+
+func TestCreateTopicIfAbsentWithReplicationFactorFallback(t *testing.T) {
+	brokers := []*sarama.Broker{sarama.NewBroker("b-0:9092"), sarama.NewBroker("b-1:9092")}
+	config := &TopicConfig{TopicDetail: sarama.TopicDetail{NumPartitions: 10, ReplicationFactor: 3}}
+
+	tests := []struct {
+		name                  string
+		maxReplicationFactor  int16
+		brokers               []*sarama.Broker
+		describeClusterErr    error
+		wantTopicDetail       sarama.TopicDetail
+		wantReplicationFactor int16
+		wantErr               bool
+	}{
+		{
+			name:                  "enough brokers",
+			brokers:               brokers,
+			wantTopicDetail:       sarama.TopicDetail{NumPartitions: 10, ReplicationFactor: 3},
+			wantReplicationFactor: 3,
+		},
+		{
+			name:                  "fewer brokers than replication factor",
+			maxReplicationFactor:  2,
+			brokers:               brokers,
+			wantTopicDetail:       sarama.TopicDetail{NumPartitions: 10, ReplicationFactor: 2},
+			wantReplicationFactor: 2,
+		},
+		{
+			name:                 "describe cluster failure",
+			maxReplicationFactor: 2,
+			describeClusterErr:   errors.New("failed"),
+			wantErr:              true,
+		},
+		{
+			name:                 "no brokers",
+			maxReplicationFactor: 2,
+			wantErr:              true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:                 "topic-name-1",
+				ExpectedTopicDetail:               tt.wantTopicDetail,
+				MaxReplicationFactorOnCreateTopic: tt.maxReplicationFactor,
+				ExpectedBrokersOnDescribeCluster:  tt.brokers,
+				ErrorOnDescribeCluster:            tt.describeClusterErr,
+				T:                                 t,
+			}
+
+			created, replicationFactor, err := CreateTopicIfAbsentWithReplicationFactorFallback(ca, zap.NewNop(), "topic-name-1", config)
+			if tt.wantErr {
+				assert.True(t, IsInsufficientBrokers(err), "expected insufficient brokers error, got %v", err)
+				assert.False(t, created)
+				return
+			}
+			assert.Nil(t, err)
+			assert.True(t, created)
+			assert.Equal(t, tt.wantReplicationFactor, replicationFactor)
+			assert.Equal(t, int16(3), config.TopicDetail.ReplicationFactor, "expected config not to be modified")
+		})
+	}
+}
+
+func TestIsInsufficientBrokers(t *testing.T) {
+	assert.True(t, IsInsufficientBrokers(&sarama.TopicError{Err: sarama.ErrInvalidReplicationFactor}))
+	assert.True(t, IsInsufficientBrokers(fmt.Errorf("failed: %w", sarama.ErrInvalidReplicationFactor)))
+	assert.False(t, IsInsufficientBrokers(&sarama.TopicError{Err: sarama.ErrTopicAlreadyExists}))
+	assert.False(t, IsInsufficientBrokers(nil))
+}
+
+// describedTopicConfigEntries returns the config entries Kafka describes for a topic, most of them defaulted by Kafka,
+// with the given topic overrides.
+func describedTopicConfigEntries(overrides ...sarama.ConfigEntry) []sarama.ConfigEntry {
+	entries := []sarama.ConfigEntry{
+		{Name: "compression.type", Value: "producer", Source: sarama.SourceDefault, Default: true},
+		{Name: "leader.replication.throttled.replicas", Value: "", Source: sarama.SourceDefault, Default: true},
+		{Name: "message.downconversion.enable", Value: "true", Source: sarama.SourceDefault, Default: true},
+		{Name: MinInSyncReplicasTopicConfigKey, Value: "2", Source: sarama.SourceStaticBroker},
+		{Name: "segment.jitter.ms", Value: "0", Source: sarama.SourceDefault, Default: true},
+		{Name: CleanupPolicyTopicConfigKey, Value: "delete", Source: sarama.SourceDefault, Default: true},
+		{Name: "flush.ms", Value: "9223372036854775807", Source: sarama.SourceDefault, Default: true},
+		{Name: "follower.replication.throttled.replicas", Value: "", Source: sarama.SourceDefault, Default: true},
+		{Name: "segment.bytes", Value: "1073741824", Source: sarama.SourceStaticBroker},
+		{Name: RetentionMsTopicConfigKey, Value: "604800000", Source: sarama.SourceDefault, Default: true},
+		{Name: "flush.messages", Value: "9223372036854775807", Source: sarama.SourceDefault, Default: true},
+		{Name: "message.format.version", Value: "3.0-IV1", Source: sarama.SourceDefault, Default: true},
+		{Name: "max.compaction.lag.ms", Value: "9223372036854775807", Source: sarama.SourceDefault, Default: true},
+		{Name: "file.delete.delay.ms", Value: "60000", Source: sarama.SourceDefault, Default: true},
+		{Name: MaxMessageBytesTopicConfigKey, Value: "1048588", Source: sarama.SourceDefault, Default: true},
+		{Name: "min.compaction.lag.ms", Value: "0", Source: sarama.SourceDefault, Default: true},
+		{Name: "message.timestamp.type", Value: "CreateTime", Source: sarama.SourceDefault, Default: true},
+		{Name: "preallocate", Value: "false", Source: sarama.SourceDefault, Default: true},
+		{Name: "min.cleanable.dirty.ratio", Value: "0.5", Source: sarama.SourceDefault, Default: true},
+		{Name: "index.interval.bytes", Value: "4096", Source: sarama.SourceDefault, Default: true},
+		{Name: "unclean.leader.election.enable", Value: "false", Source: sarama.SourceDefault, Default: true},
+		{Name: "retention.bytes", Value: "-1", Source: sarama.SourceDefault, Default: true},
+		{Name: "delete.retention.ms", Value: "86400000", Source: sarama.SourceDefault, Default: true},
+		{Name: "segment.ms", Value: "604800000", Source: sarama.SourceDefault, Default: true},
+		{Name: "message.timestamp.difference.max.ms", Value: "9223372036854775807", Source: sarama.SourceDefault, Default: true},
+		{Name: "segment.index.bytes", Value: "10485760", Source: sarama.SourceDefault, Default: true},
+	}
+	for _, o := range overrides {
+		for i := range entries {
+			if entries[i].Name == o.Name {
+				entries[i] = sarama.ConfigEntry{Name: o.Name, Value: o.Value, Source: sarama.SourceTopic}
+			}
+		}
+	}
+	return entries
+}
+
+// setConfigEntries returns the incremental config entries setting the given config entries.
+func setConfigEntries(entries map[string]*string) map[string]sarama.IncrementalAlterConfigsEntry {
+	set := make(map[string]sarama.IncrementalAlterConfigsEntry, len(entries))
+	for k, v := range entries {
+		set[k] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationSet, Value: v}
+	}
+	return set
+}
+
+func TestAlterTopicConfigIfChanged(t *testing.T) {
+	retention := "3600000"
+	compact := "compact"
+
+	tests := []struct {
+		name                       string
+		admin                      *kafkatesting.MockKafkaClusterAdmin
+		config                     *TopicConfig
+		want                       bool
+		wantErr                    bool
+		wantIncrementalAlterConfig bool
+	}{
+		{
+			name: "no config entries",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName: "topic-name-1",
+				T:                 t,
+			},
+			config: &TopicConfig{},
+		},
+		{
+			name: "config entries match",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName: "topic-name-1",
+				ExpectedConfigEntriesOnDescribeConfig: []sarama.ConfigEntry{
+					{Name: RetentionMsTopicConfigKey, Value: retention},
+				},
+				T: t,
+			},
+			config: &TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					ConfigEntries: map[string]*string{RetentionMsTopicConfigKey: &retention},
+				},
+			},
+		},
+		{
+			name: "config entries differ",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName: "topic-name-1",
+				ExpectedConfigEntriesOnDescribeConfig: []sarama.ConfigEntry{
+					{Name: RetentionMsTopicConfigKey, Value: "1000"},
+				},
+				ExpectedConfigEntriesOnIncrementalAlterConfig: setConfigEntries(map[string]*string{RetentionMsTopicConfigKey: &retention}),
+				T: t,
+			},
+			config: &TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					ConfigEntries: map[string]*string{RetentionMsTopicConfigKey: &retention},
+				},
+			},
+			want:                       true,
+			wantIncrementalAlterConfig: true,
+		},
+		{
+			name: "cleanup policy changed",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName: "topic-name-1",
+				ExpectedConfigEntriesOnDescribeConfig: []sarama.ConfigEntry{
+					{Name: CleanupPolicyTopicConfigKey, Value: "delete"},
+				},
+				ExpectedConfigEntriesOnIncrementalAlterConfig: setConfigEntries(map[string]*string{CleanupPolicyTopicConfigKey: &compact}),
+				T: t,
+			},
+			config: &TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					ConfigEntries: map[string]*string{CleanupPolicyTopicConfigKey: &compact},
+				},
+			},
+			want:                       true,
+			wantIncrementalAlterConfig: true,
+		},
+		{
+			name: "max message bytes changed",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName: "topic-name-1",
+				ExpectedConfigEntriesOnDescribeConfig: []sarama.ConfigEntry{
+					{Name: MaxMessageBytesTopicConfigKey, Value: "1048588"},
+				},
+				ExpectedConfigEntriesOnIncrementalAlterConfig: setConfigEntries(map[string]*string{MaxMessageBytesTopicConfigKey: pointer.String("2097152")}),
+				T: t,
+			},
+			config: &TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					ConfigEntries: map[string]*string{MaxMessageBytesTopicConfigKey: pointer.String("2097152")},
+				},
+			},
+			want:                       true,
+			wantIncrementalAlterConfig: true,
+		},
+		{
+			name: "Kafka defaulted config entries ignored",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName: "topic-name-1",
+				ExpectedConfigEntriesOnDescribeConfig: describedTopicConfigEntries(
+					sarama.ConfigEntry{Name: RetentionMsTopicConfigKey, Value: retention},
+					sarama.ConfigEntry{Name: CleanupPolicyTopicConfigKey, Value: compact},
+				),
+				T: t,
+			},
+			config: &TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					ConfigEntries: map[string]*string{
+						RetentionMsTopicConfigKey:   &retention,
+						CleanupPolicyTopicConfigKey: &compact,
+					},
+				},
+			},
+		},
+		{
+			name: "Kafka defaulted value of a set config entry",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:                             "topic-name-1",
+				ExpectedConfigEntriesOnDescribeConfig:         describedTopicConfigEntries(),
+				ExpectedConfigEntriesOnIncrementalAlterConfig: setConfigEntries(map[string]*string{RetentionMsTopicConfigKey: &retention}),
+				T: t,
+			},
+			config: &TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					ConfigEntries: map[string]*string{RetentionMsTopicConfigKey: &retention},
+				},
+			},
+			want:                       true,
+			wantIncrementalAlterConfig: true,
+		},
+		{
+			name: "Kafka defaulted value equal to a set config entry",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:                     "topic-name-1",
+				ExpectedConfigEntriesOnDescribeConfig: describedTopicConfigEntries(),
+				T:                                     t,
+			},
+			config: &TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					ConfigEntries: map[string]*string{MaxMessageBytesTopicConfigKey: pointer.String("1048588")},
+				},
+			},
+		},
+		{
+			name: "describe config error",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:     "topic-name-1",
+				ErrorOnDescribeConfig: fmt.Errorf("error"),
+				T:                     t,
+			},
+			config: &TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					ConfigEntries: map[string]*string{RetentionMsTopicConfigKey: &retention},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "alter config error",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:             "topic-name-1",
+				ErrorOnIncrementalAlterConfig: fmt.Errorf("error"),
+				T:                             t,
+			},
+			config: &TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					ConfigEntries: map[string]*string{RetentionMsTopicConfigKey: &retention},
+				},
+			},
+			wantErr:                    true,
+			wantIncrementalAlterConfig: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AlterTopicConfigIfChanged(tt.admin, zap.NewNop(), "topic-name-1", tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AlterTopicConfigIfChanged() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("AlterTopicConfigIfChanged() got = %v, want %v", got, tt.want)
+			}
+			if tt.admin.IncrementalAlterConfigCalled != tt.wantIncrementalAlterConfig {
+				t.Errorf("IncrementalAlterConfig called = %v, want %v", tt.admin.IncrementalAlterConfigCalled, tt.wantIncrementalAlterConfig)
+			}
+		})
+	}
+}
+
+func TestReconcileTopicConfigEntries(t *testing.T) {
+	retention := "3600000"
+	config := &TopicConfig{
+		TopicDetail: sarama.TopicDetail{
+			ConfigEntries: map[string]*string{RetentionMsTopicConfigKey: &retention},
+		},
+	}
+
+	admin := &kafkatesting.MockKafkaClusterAdmin{
+		ExpectedTopicName: "topic-name-1",
+		ExpectedConfigEntriesOnDescribeConfig: []sarama.ConfigEntry{
+			{Name: RetentionMsTopicConfigKey, Value: "1000"},
+		},
+		ExpectedConfigEntriesOnIncrementalAlterConfig: setConfigEntries(map[string]*string{RetentionMsTopicConfigKey: &retention}),
+		T: t,
+	}
+	discrepancies, err := ReconcileTopicConfigEntries(admin, zap.NewNop(), "topic-name-1", config)
+	require.NoError(t, err)
+	require.True(t, admin.IncrementalAlterConfigCalled)
+	require.Equal(t, []Discrepancy{{
+		Kind:        ConfigEntryDiscrepancy,
+		ConfigEntry: RetentionMsTopicConfigKey,
+		Desired:     retention,
+		Actual:      "1000",
+	}}, discrepancies)
+
+	admin = &kafkatesting.MockKafkaClusterAdmin{
+		ExpectedTopicName:             "topic-name-1",
+		ErrorOnIncrementalAlterConfig: fmt.Errorf("error"),
+		T:                             t,
+	}
+	discrepancies, err = ReconcileTopicConfigEntries(admin, zap.NewNop(), "topic-name-1", config)
+	require.Error(t, err)
+	require.Nil(t, discrepancies)
+}
+
+// topicConfigClusterAdmin stores the config of a single topic, altering it the way Kafka does.
+type topicConfigClusterAdmin struct {
+	*kafkatesting.MockKafkaClusterAdmin
+	configs map[string]string
+}
+
+func (a *topicConfigClusterAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+	var entries []sarama.ConfigEntry
+	for _, name := range resource.ConfigNames {
+		if v, ok := a.configs[name]; ok {
+			entries = append(entries, sarama.ConfigEntry{Name: name, Value: v, Source: sarama.SourceTopic})
+		}
+	}
+	return entries, nil
+}
+
+func (a *topicConfigClusterAdmin) AlterConfig(_ sarama.ConfigResourceType, _ string, entries map[string]*string, _ bool) error {
+	// Configs that aren't given are reset to their default.
+	a.configs = make(map[string]string, len(entries))
+	for k, v := range entries {
+		a.configs[k] = *v
+	}
+	return nil
+}
+
+func (a *topicConfigClusterAdmin) IncrementalAlterConfig(_ sarama.ConfigResourceType, _ string, entries map[string]sarama.IncrementalAlterConfigsEntry, _ bool) error {
+	for k, e := range entries {
+		if e.Operation == sarama.IncrementalAlterConfigsOperationSet {
+			a.configs[k] = *e.Value
+		}
+	}
+	return nil
+}
+
+func TestReconcileTopicConfigEntriesUndeclaredConfigsKept(t *testing.T) {
+	retention := "3600000"
+	config := &TopicConfig{
+		TopicDetail: sarama.TopicDetail{
+			ConfigEntries: map[string]*string{RetentionMsTopicConfigKey: &retention},
+		},
+	}
+
+	admin := &topicConfigClusterAdmin{
+		MockKafkaClusterAdmin: &kafkatesting.MockKafkaClusterAdmin{T: t},
+		configs: map[string]string{
+			RetentionMsTopicConfigKey:       "1000",
+			MinInSyncReplicasTopicConfigKey: "2",
+			CleanupPolicyTopicConfigKey:     "compact",
+		},
+	}
+	discrepancies, err := ReconcileTopicConfigEntries(admin, zap.NewNop(), "topic-name-1", config)
+	require.NoError(t, err)
+	require.Len(t, discrepancies, 1)
+	require.Equal(t, map[string]string{
+		RetentionMsTopicConfigKey:       retention,
+		MinInSyncReplicasTopicConfigKey: "2",
+		CleanupPolicyTopicConfigKey:     "compact",
+	}, admin.configs)
+}
+
+func TestReconcileTopicPartitions(t *testing.T) {
+	metadata := func(partitions, replicas int) []*sarama.TopicMetadata {
+		m := &sarama.TopicMetadata{Name: "topic-name-1"}
+		for i := 0; i < partitions; i++ {
+			m.Partitions = append(m.Partitions, &sarama.PartitionMetadata{
+				ID:       int32(i),
+				Replicas: make([]int32, replicas),
+			})
+		}
+		return []*sarama.TopicMetadata{m}
+	}
+
+	config := &TopicConfig{
+		TopicDetail: sarama.TopicDetail{
+			NumPartitions:     10,
+			ReplicationFactor: 3,
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		admin                *kafkatesting.MockKafkaClusterAdmin
+		wantErr              bool
+		wantRFMismatch       bool
+		wantCreatePartitions bool
+	}{
+		{
+			name: "topic in sync",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:                      "topic-name-1",
+				ExpectedTopics:                         []string{"topic-name-1"},
+				ExpectedTopicsMetadataOnDescribeTopics: metadata(10, 3),
+				T:                                      t,
+			},
+		},
+		{
+			name: "topic metadata not available",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName: "topic-name-1",
+				ExpectedTopics:    []string{"topic-name-1"},
+				T:                 t,
+			},
+		},
+		{
+			name: "more partitions than desired",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:                      "topic-name-1",
+				ExpectedTopics:                         []string{"topic-name-1"},
+				ExpectedTopicsMetadataOnDescribeTopics: metadata(20, 3),
+				T:                                      t,
+			},
+		},
+		{
+			name: "fewer partitions than desired",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:                      "topic-name-1",
+				ExpectedTopics:                         []string{"topic-name-1"},
+				ExpectedTopicsMetadataOnDescribeTopics: metadata(5, 3),
+				ExpectedCountOnCreatePartitions:        10,
+				T:                                      t,
+			},
+			wantCreatePartitions: true,
+		},
+		{
+			name: "create partitions error",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:                      "topic-name-1",
+				ExpectedTopics:                         []string{"topic-name-1"},
+				ExpectedTopicsMetadataOnDescribeTopics: metadata(5, 3),
+				ExpectedCountOnCreatePartitions:        10,
+				ErrorOnCreatePartitions:                fmt.Errorf("error"),
+				T:                                      t,
+			},
+			wantErr:              true,
+			wantCreatePartitions: true,
+		},
+		{
+			name: "replication factor mismatch",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:                      "topic-name-1",
+				ExpectedTopics:                         []string{"topic-name-1"},
+				ExpectedTopicsMetadataOnDescribeTopics: metadata(10, 1),
+				T:                                      t,
+			},
+			wantErr:        true,
+			wantRFMismatch: true,
+		},
+		{
+			name: "describe topics error",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:             "topic-name-1",
+				ExpectedTopics:                []string{"topic-name-1"},
+				ExpectedErrorOnDescribeTopics: fmt.Errorf("error"),
+				T:                             t,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ReconcileTopicPartitions(tt.admin, zap.NewNop(), "topic-name-1", config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReconcileTopicPartitions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var rfMismatch ReplicationFactorMismatch
+			if errors.As(err, &rfMismatch) != tt.wantRFMismatch {
+				t.Errorf("ReconcileTopicPartitions() error = %v, wantRFMismatch %v", err, tt.wantRFMismatch)
+			}
+			if tt.admin.CreatePartitionsCalled != tt.wantCreatePartitions {
+				t.Errorf("CreatePartitions called = %v, want %v", tt.admin.CreatePartitionsCalled, tt.wantCreatePartitions)
+			}
+		})
+	}
+}
+
+func TestPlanTopicChanges(t *testing.T) {
+	metadata := func(partitions int) []*sarama.TopicMetadata {
+		m := &sarama.TopicMetadata{Name: "topic-name-1"}
+		for i := 0; i < partitions; i++ {
+			m.Partitions = append(m.Partitions, &sarama.PartitionMetadata{ID: int32(i), Replicas: make([]int32, 3)})
+		}
+		return []*sarama.TopicMetadata{m}
+	}
+
+	retention := "1000"
+	config := &TopicConfig{
+		TopicDetail: sarama.TopicDetail{
+			NumPartitions:     10,
+			ReplicationFactor: 3,
+			ConfigEntries:     map[string]*string{RetentionMsTopicConfigKey: &retention},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		admin   *kafkatesting.MockKafkaClusterAdmin
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "topic not present",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopics: []string{"topic-name-1"},
+				T:              t,
+			},
+			want: []string{"create topic topic-name-1 with 10 partitions and replication factor 3"},
+		},
+		{
+			name: "topic in sync",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:                      "topic-name-1",
+				ExpectedTopics:                         []string{"topic-name-1"},
+				ExpectedTopicsMetadataOnDescribeTopics: metadata(10),
+				ExpectedConfigEntriesOnDescribeConfig: []sarama.ConfigEntry{
+					{Name: RetentionMsTopicConfigKey, Value: retention},
+				},
+				T: t,
+			},
+		},
+		{
+			name: "topic config and partitions changed",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:                      "topic-name-1",
+				ExpectedTopics:                         []string{"topic-name-1"},
+				ExpectedTopicsMetadataOnDescribeTopics: metadata(5),
+				ExpectedConfigEntriesOnDescribeConfig: []sarama.ConfigEntry{
+					{Name: RetentionMsTopicConfigKey, Value: "2000"},
+				},
+				T: t,
+			},
+			want: []string{
+				"alter config of topic topic-name-1",
+				"increase partitions of topic topic-name-1 from 5 to 10",
+			},
+		},
+		{
+			name: "describe topics error",
+			admin: &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopics:                []string{"topic-name-1"},
+				ExpectedErrorOnDescribeTopics: errors.New("failed to describe topics"),
+				T:                             t,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlanTopicChanges(tt.admin, "topic-name-1", config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlanTopicChanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.False(t, tt.admin.IncrementalAlterConfigCalled, "unexpected IncrementalAlterConfig call")
+			assert.False(t, tt.admin.CreatePartitionsCalled, "unexpected CreatePartitions call")
+		})
+	}
+}
+
+func TestValidateTopicConfig(t *testing.T) {
+	metadata := func(partitions, replicas int) []*sarama.TopicMetadata {
+		m := &sarama.TopicMetadata{Name: "topic-name-1"}
+		for i := 0; i < partitions; i++ {
+			m.Partitions = append(m.Partitions, &sarama.PartitionMetadata{ID: int32(i), Replicas: make([]int32, replicas)})
+		}
+		return []*sarama.TopicMetadata{m}
+	}
+
+	retention := "1000"
+	cleanupPolicy := "compact"
+	config := &TopicConfig{
+		TopicDetail: sarama.TopicDetail{
+			NumPartitions:     10,
+			ReplicationFactor: 3,
+			ConfigEntries: map[string]*string{
+				RetentionMsTopicConfigKey:   &retention,
+				CleanupPolicyTopicConfigKey: &cleanupPolicy,
+			},
+		},
+	}
+	inSyncConfigEntries := []sarama.ConfigEntry{
+		{Name: RetentionMsTopicConfigKey, Value: retention},
+		{Name: CleanupPolicyTopicConfigKey, Value: cleanupPolicy},
+	}
+
+	tests := []struct {
+		name     string
+		metadata []*sarama.TopicMetadata
+		entries  []sarama.ConfigEntry
+		want     []Discrepancy
+		wantErr  error
+	}{
+		{
+			name:     "in sync",
+			metadata: metadata(10, 3),
+			entries:  inSyncConfigEntries,
+		},
+		{
+			name:     "more partitions than desired",
+			metadata: metadata(12, 3),
+			entries:  inSyncConfigEntries,
+		},
+		{
+			name:     "fewer partitions than desired",
+			metadata: metadata(5, 3),
+			entries:  inSyncConfigEntries,
+			want:     []Discrepancy{{Kind: PartitionsDiscrepancy, Desired: "10", Actual: "5"}},
+		},
+		{
+			name:     "different replication factor",
+			metadata: metadata(10, 1),
+			entries:  inSyncConfigEntries,
+			want:     []Discrepancy{{Kind: ReplicationFactorDiscrepancy, Desired: "3", Actual: "1"}},
+		},
+		{
+			name:     "different config entry",
+			metadata: metadata(10, 3),
+			entries: []sarama.ConfigEntry{
+				{Name: RetentionMsTopicConfigKey, Value: "2000"},
+				{Name: CleanupPolicyTopicConfigKey, Value: cleanupPolicy},
+			},
+			want: []Discrepancy{{Kind: ConfigEntryDiscrepancy, ConfigEntry: RetentionMsTopicConfigKey, Desired: retention, Actual: "2000"}},
+		},
+		{
+			name:     "missing config entry",
+			metadata: metadata(10, 3),
+			entries:  []sarama.ConfigEntry{{Name: RetentionMsTopicConfigKey, Value: retention}},
+			want:     []Discrepancy{{Kind: ConfigEntryDiscrepancy, ConfigEntry: CleanupPolicyTopicConfigKey, Desired: cleanupPolicy}},
+		},
+		{
+			name:     "Kafka defaulted config entries ignored",
+			metadata: metadata(10, 3),
+			entries: describedTopicConfigEntries(
+				sarama.ConfigEntry{Name: RetentionMsTopicConfigKey, Value: retention},
+				sarama.ConfigEntry{Name: CleanupPolicyTopicConfigKey, Value: cleanupPolicy},
+			),
+		},
+		{
+			name:     "Kafka defaulted config entry",
+			metadata: metadata(10, 3),
+			entries:  describedTopicConfigEntries(sarama.ConfigEntry{Name: RetentionMsTopicConfigKey, Value: retention}),
+			want:     []Discrepancy{{Kind: ConfigEntryDiscrepancy, ConfigEntry: CleanupPolicyTopicConfigKey, Desired: cleanupPolicy, Actual: "delete"}},
+		},
+		{
+			name:     "every discrepancy",
+			metadata: metadata(5, 1),
+			want: []Discrepancy{
+				{Kind: PartitionsDiscrepancy, Desired: "10", Actual: "5"},
+				{Kind: ReplicationFactorDiscrepancy, Desired: "3", Actual: "1"},
+				{Kind: ConfigEntryDiscrepancy, ConfigEntry: CleanupPolicyTopicConfigKey, Desired: cleanupPolicy},
+				{Kind: ConfigEntryDiscrepancy, ConfigEntry: RetentionMsTopicConfigKey, Desired: retention},
+			},
+		},
+		{
+			name:    "topic not present",
+			wantErr: InvalidOrNotPresentTopic{Topic: "topic-name-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admin := &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName:                      "topic-name-1",
+				ExpectedTopics:                         []string{"topic-name-1"},
+				ExpectedTopicsMetadataOnDescribeTopics: tt.metadata,
+				ExpectedConfigEntriesOnDescribeConfig:  tt.entries,
+				T:                                      t,
+			}
+			got, err := ValidateTopicConfig(admin, "topic-name-1", config)
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	require.Equal(t, "5 partitions, expected at least 10", Discrepancy{Kind: PartitionsDiscrepancy, Desired: "10", Actual: "5"}.String())
+	require.Equal(t, "replication factor 1, expected 3", Discrepancy{Kind: ReplicationFactorDiscrepancy, Desired: "3", Actual: "1"}.String())
+	require.Equal(t, `config retention.ms="2000", expected "1000"`, Discrepancy{Kind: ConfigEntryDiscrepancy, ConfigEntry: RetentionMsTopicConfigKey, Desired: "1000", Actual: "2000"}.String())
+}
+
+func TestCheckTopicPartitionsAndReplicationFactor(t *testing.T) {
+	config := &TopicConfig{
+		TopicDetail: sarama.TopicDetail{
+			NumPartitions:     2,
+			ReplicationFactor: 3,
+		},
+	}
+
+	partition := func(id int32, replicas int) *sarama.PartitionMetadata {
+		return &sarama.PartitionMetadata{ID: id, Replicas: make([]int32, replicas)}
+	}
+
+	tests := []struct {
+		name         string
+		metadata     []*sarama.TopicMetadata
+		wantErr      error
+		wantMismatch bool
+	}{
+		{
+			name: "compatible",
+			metadata: []*sarama.TopicMetadata{
+				{Name: "topic-name-1", Partitions: []*sarama.PartitionMetadata{partition(0, 3), partition(1, 3)}},
+			},
+		},
+		{
+			name: "more partitions than configured",
+			metadata: []*sarama.TopicMetadata{
+				{Name: "topic-name-1", Partitions: []*sarama.PartitionMetadata{partition(0, 3), partition(1, 3), partition(2, 3)}},
+			},
+		},
+		{
+			name: "fewer partitions than configured",
+			metadata: []*sarama.TopicMetadata{
+				{Name: "topic-name-1", Partitions: []*sarama.PartitionMetadata{partition(0, 3)}},
+			},
+			wantErr:      PartitionsMismatch{Topic: "topic-name-1", Desired: 2, Actual: 1},
+			wantMismatch: true,
+		},
+		{
+			name: "different replication factor",
+			metadata: []*sarama.TopicMetadata{
+				{Name: "topic-name-1", Partitions: []*sarama.PartitionMetadata{partition(0, 1), partition(1, 1)}},
+			},
+			wantErr:      ReplicationFactorMismatch{Topic: "topic-name-1", Desired: 3, Actual: 1},
+			wantMismatch: true,
+		},
+		{
+			name:    "topic not present",
+			wantErr: InvalidOrNotPresentTopic{Topic: "topic-name-1"},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := TopicConfig{
-				TopicDetail:      tt.fields.TopicDetail,
-				BootstrapServers: tt.fields.BootstrapServers,
-			}
-			if got := c.GetBootstrapServers(); got != tt.want {
-				t.Errorf("GetBootstrapServers() = %v, want %v", got, tt.want)
+			admin := &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopics:                         []string{"topic-name-1"},
+				ExpectedTopicsMetadataOnDescribeTopics: tt.metadata,
+				T:                                      t,
 			}
+			err := CheckTopicPartitionsAndReplicationFactor(admin, "topic-name-1", config)
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantMismatch, IsTopicConfigMismatch(err))
 		})
 	}
 }
 
-func TestDeleteTopic(t *testing.T) {
-	type args struct {
-		admin sarama.ClusterAdmin
-		topic string
+func TestCheckTopicWritable(t *testing.T) {
+	minInSyncReplicas := "2"
+	config := &TopicConfig{
+		TopicDetail: sarama.TopicDetail{
+			NumPartitions:     2,
+			ReplicationFactor: 3,
+			ConfigEntries:     map[string]*string{MinInSyncReplicasTopicConfigKey: &minInSyncReplicas},
+		},
 	}
+
+	partition := func(id int32, leader int32, isr int) *sarama.PartitionMetadata {
+		return &sarama.PartitionMetadata{ID: id, Leader: leader, Replicas: make([]int32, 3), Isr: make([]int32, isr)}
+	}
+
 	tests := []struct {
-		name    string
-		args    args
-		want    string
-		wantErr bool
+		name          string
+		config        *TopicConfig
+		metadata      []*sarama.TopicMetadata
+		wantErr       error
+		wantUnhealthy bool
 	}{
 		{
-			name: "no errors",
-			args: args{
-				admin: &kafkatesting.MockKafkaClusterAdmin{
-					ExpectedTopicName: "topic-name",
-					T:                 t,
-				},
-				topic: "topic-name",
+			name:   "writable",
+			config: config,
+			metadata: []*sarama.TopicMetadata{
+				{Name: "topic-name-1", Partitions: []*sarama.PartitionMetadata{partition(0, 1, 3), partition(1, 2, 2)}},
 			},
-			want:    "topic-name",
-			wantErr: false,
 		},
 		{
-			name: "unknown error",
-			args: args{
-				admin: &kafkatesting.MockKafkaClusterAdmin{
-					ExpectedTopicName:  "topic-name",
-					ErrorOnDeleteTopic: fmt.Errorf("failed to delete topic topic-name"),
-					T:                  t,
-				},
-				topic: "topic-name",
+			name:   "partition without leader",
+			config: config,
+			metadata: []*sarama.TopicMetadata{
+				{Name: "topic-name-1", Partitions: []*sarama.PartitionMetadata{partition(0, -1, 0), partition(1, 2, 3)}},
 			},
-			want:    "topic-name",
-			wantErr: true,
+			wantErr:       UnhealthyTopic{Topic: "topic-name-1", MinInSyncReplicas: 2, LeaderlessPartitions: []int32{0}},
+			wantUnhealthy: true,
 		},
 		{
-			name: "unknown topic or partition error (external)",
-			args: args{
-				admin: &kafkatesting.MockKafkaClusterAdmin{
-					ExpectedTopicName:  "topic-name",
-					ErrorOnDeleteTopic: sarama.ErrUnknownTopicOrPartition,
-					T:                  t,
-				},
-				topic: "topic-name",
+			name:   "under-replicated partition",
+			config: config,
+			metadata: []*sarama.TopicMetadata{
+				{Name: "topic-name-1", Partitions: []*sarama.PartitionMetadata{partition(0, 1, 3), partition(1, 2, 1)}},
 			},
-			want:    "topic-name",
-			wantErr: false,
+			wantErr:       UnhealthyTopic{Topic: "topic-name-1", MinInSyncReplicas: 2, UnderReplicatedPartitions: []int32{1}},
+			wantUnhealthy: true,
 		},
 		{
-			name: "unknown topic or partition error (internal)",
-			args: args{
-				admin: &kafkatesting.MockKafkaClusterAdmin{
-					ExpectedTopicName: "topic-name",
-					ErrorOnDeleteTopic: &sarama.TopicError{
-						Err: sarama.ErrUnknownTopicOrPartition,
-					},
-					T: t,
-				},
-				topic: "topic-name",
+			name:   "min.insync.replicas not set",
+			config: &TopicConfig{TopicDetail: sarama.TopicDetail{NumPartitions: 2, ReplicationFactor: 3}},
+			metadata: []*sarama.TopicMetadata{
+				{Name: "topic-name-1", Partitions: []*sarama.PartitionMetadata{partition(0, 1, 1), partition(1, 2, 1)}},
 			},
-			want:    "topic-name",
-			wantErr: false,
+		},
+		{
+			name:    "topic not present",
+			config:  config,
+			wantErr: InvalidOrNotPresentTopic{Topic: "topic-name-1"},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := DeleteTopic(tt.args.admin, tt.args.topic)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("DeleteTopic() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if got != tt.want {
-				t.Errorf("DeleteTopic() got = %v, want %v", got, tt.want)
+			admin := &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopics:                         []string{"topic-name-1"},
+				ExpectedTopicsMetadataOnDescribeTopics: tt.metadata,
+				T:                                      t,
 			}
+			err := CheckTopicWritable(admin, "topic-name-1", tt.config)
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.wantUnhealthy, IsUnhealthyTopic(err))
 		})
 	}
 }
 
-func TestCreateTopicTopicAlreadyExists(t *testing.T) {
-
-	b := &eventing.Broker{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "bname",
-			Namespace: "bnamespace",
+func TestIsTopicPresent(t *testing.T) {
+	tests := []struct {
+		name        string
+		metadata    []*sarama.TopicMetadata
+		describeErr error
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name: "topic present",
+			metadata: []*sarama.TopicMetadata{
+				{Name: "topic-name-1", Partitions: []*sarama.PartitionMetadata{{}}},
+			},
+			want: true,
 		},
-	}
-	topic := BrokerTopic("", b)
-	errMsg := "topic already exists"
-
-	ca := &kafkatesting.MockKafkaClusterAdmin{
-		ExpectedTopicName:   topic,
-		ExpectedTopicDetail: sarama.TopicDetail{},
-		ErrorOnCreateTopic: &sarama.TopicError{
-			Err:    sarama.ErrTopicAlreadyExists,
-			ErrMsg: &errMsg,
+		{
+			name: "topic not present",
+			metadata: []*sarama.TopicMetadata{
+				{Name: "topic-name-1", Err: sarama.ErrUnknownTopicOrPartition},
+			},
+		},
+		{
+			name: "other topic present",
+			metadata: []*sarama.TopicMetadata{
+				{Name: "topic-name-2", Partitions: []*sarama.PartitionMetadata{{}}},
+			},
+		},
+		{
+			name:        "describe topics failure",
+			describeErr: sarama.ErrOutOfBrokers,
+			wantErr:     true,
 		},
-		T: t,
 	}
-
-	topicRet, err := CreateTopicIfDoesntExist(ca, zap.NewNop(), topic, &TopicConfig{})
-
-	assert.Equal(t, topicRet, topic, "expected topic %s go %s", topic, topicRet)
-	assert.Nil(t, err, "expected nil error on topic already exists")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admin := &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopics:                         []string{"topic-name-1"},
+				ExpectedTopicsMetadataOnDescribeTopics: tt.metadata,
+				ExpectedErrorOnDescribeTopics:          tt.describeErr,
+				T:                                      t,
+			}
+			got, err := IsTopicPresent(admin, "topic-name-1")
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.want, got)
+		})
+	}
 }
 
 func TestNewClusterAdminClientFuncIsTopicPresent(t *testing.T) {
@@ -441,6 +1466,176 @@ func TestTopicConfigFromConfigMap(t *testing.T) {
 				BootstrapServers: []string{"server1:9092", "server2:9092"},
 			},
 		},
+		{
+			name: "Topic config enforced",
+			data: map[string]string{
+				"default.topic.partitions":         "5",
+				"default.topic.replication.factor": "8",
+				"bootstrap.servers":                "server1:9092",
+				"topic.config.enforce":             "true",
+			},
+			want: TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					NumPartitions:     5,
+					ReplicationFactor: 8,
+				},
+				BootstrapServers: []string{"server1:9092"},
+				EnforceConfig:    true,
+			},
+		},
+		{
+			name: "With failover bootstrap servers",
+			data: map[string]string{
+				"default.topic.partitions":         "5",
+				"default.topic.replication.factor": "8",
+				"bootstrap.servers":                "server1:9092, server2:9092",
+				"bootstrap.servers.failover":       "dr1:9092,dr2:9092; ;dr3:9092",
+			},
+			want: TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					NumPartitions:     5,
+					ReplicationFactor: 8,
+				},
+				BootstrapServers:         []string{"server1:9092", "server2:9092"},
+				FailoverBootstrapServers: [][]string{{"dr1:9092", "dr2:9092"}, {"dr3:9092"}},
+			},
+		},
+		{
+			name: "With min.insync.replicas",
+			data: map[string]string{
+				"default.topic.partitions":                 "5",
+				"default.topic.replication.factor":         "3",
+				"default.topic.config.min.insync.replicas": " 2 ",
+				"bootstrap.servers":                        "server1:9092, server2:9092",
+			},
+			want: TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					NumPartitions:     5,
+					ReplicationFactor: 3,
+					ConfigEntries:     map[string]*string{"min.insync.replicas": pointer.String("2")},
+				},
+				BootstrapServers: []string{"server1:9092", "server2:9092"},
+			},
+		},
+		{
+			name: "min.insync.replicas greater than replication factor - not allowed",
+			data: map[string]string{
+				"default.topic.partitions":                 "5",
+				"default.topic.replication.factor":         "3",
+				"default.topic.config.min.insync.replicas": "4",
+				"bootstrap.servers":                        "server1:9092, server2:9092",
+			},
+			wantErr: true,
+		},
+		{
+			name: "min.insync.replicas not a number - not allowed",
+			data: map[string]string{
+				"default.topic.partitions":                 "5",
+				"default.topic.replication.factor":         "3",
+				"default.topic.config.min.insync.replicas": "all",
+				"bootstrap.servers":                        "server1:9092, server2:9092",
+			},
+			wantErr: true,
+		},
+		{
+			name: "min.insync.replicas zero - not allowed",
+			data: map[string]string{
+				"default.topic.partitions":                 "5",
+				"default.topic.replication.factor":         "3",
+				"default.topic.config.min.insync.replicas": "0",
+				"bootstrap.servers":                        "server1:9092, server2:9092",
+			},
+			wantErr: true,
+		},
+		{
+			name: "With cleanup.policy",
+			data: map[string]string{
+				"default.topic.partitions":            "5",
+				"default.topic.replication.factor":    "3",
+				"default.topic.config.cleanup.policy": "compact",
+				"bootstrap.servers":                   "server1:9092, server2:9092",
+			},
+			want: TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					NumPartitions:     5,
+					ReplicationFactor: 3,
+					ConfigEntries:     map[string]*string{"cleanup.policy": pointer.String("compact")},
+				},
+				BootstrapServers: []string{"server1:9092", "server2:9092"},
+			},
+		},
+		{
+			name: "With max.message.bytes",
+			data: map[string]string{
+				"default.topic.partitions":               "5",
+				"default.topic.replication.factor":       "3",
+				"default.topic.config.max.message.bytes": " 2097152 ",
+				"bootstrap.servers":                      "server1:9092, server2:9092",
+			},
+			want: TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					NumPartitions:     5,
+					ReplicationFactor: 3,
+					ConfigEntries:     map[string]*string{"max.message.bytes": pointer.String("2097152")},
+				},
+				BootstrapServers: []string{"server1:9092", "server2:9092"},
+			},
+		},
+		{
+			name: "With cleanup.policy compact and delete, and min.insync.replicas",
+			data: map[string]string{
+				"default.topic.partitions":                 "5",
+				"default.topic.replication.factor":         "3",
+				"default.topic.config.cleanup.policy":      "delete, compact",
+				"default.topic.config.min.insync.replicas": "2",
+				"bootstrap.servers":                        "server1:9092, server2:9092",
+			},
+			want: TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					NumPartitions:     5,
+					ReplicationFactor: 3,
+					ConfigEntries: map[string]*string{
+						"cleanup.policy":      pointer.String("compact,delete"),
+						"min.insync.replicas": pointer.String("2"),
+					},
+				},
+				BootstrapServers: []string{"server1:9092", "server2:9092"},
+			},
+		},
+		{
+			name: "With custom topic configs",
+			data: map[string]string{
+				"default.topic.partitions":                 "5",
+				"default.topic.replication.factor":         "3",
+				"default.topic.config.min.insync.replicas": "2",
+				"default.topic.config.segment.ms":          "3600000",
+				"default.topic.config.max.message.bytes":   " 2097152 ",
+				"default.topic.config.":                    "ignored",
+				"bootstrap.servers":                        "server1:9092, server2:9092",
+			},
+			want: TopicConfig{
+				TopicDetail: sarama.TopicDetail{
+					NumPartitions:     5,
+					ReplicationFactor: 3,
+					ConfigEntries: map[string]*string{
+						"min.insync.replicas": pointer.String("2"),
+						"segment.ms":          pointer.String("3600000"),
+						"max.message.bytes":   pointer.String("2097152"),
+					},
+				},
+				BootstrapServers: []string{"server1:9092", "server2:9092"},
+			},
+		},
+		{
+			name: "cleanup.policy unknown - not allowed",
+			data: map[string]string{
+				"default.topic.partitions":            "5",
+				"default.topic.replication.factor":    "3",
+				"default.topic.config.cleanup.policy": "archive",
+				"bootstrap.servers":                   "server1:9092, server2:9092",
+			},
+			wantErr: true,
+		},
 		{
 			name: "Missing keys 'default.topic.partitions' - not allowed",
 			data: map[string]string{
@@ -488,3 +1683,117 @@ func TestTopicConfigFromConfigMap(t *testing.T) {
 		})
 	}
 }
+
+func TestTopicConfigFromConfigMapInvalidTopicConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		wantErr InvalidTopicConfig
+	}{
+		{
+			name: "replication factor not an integer",
+			data: map[string]string{
+				"default.topic.partitions":         "5",
+				"default.topic.replication.factor": "three",
+				"bootstrap.servers":                "server1:9092",
+			},
+			wantErr: InvalidTopicConfig{
+				ConfigMap: "knative-eventing/kafka-broker-config",
+				Key:       "default.topic.replication.factor",
+				Value:     "three",
+				Reason:    "is not a valid integer",
+			},
+		},
+		{
+			name: "topic config enforce not a boolean",
+			data: map[string]string{
+				"default.topic.partitions":         "5",
+				"default.topic.replication.factor": "3",
+				"bootstrap.servers":                "server1:9092",
+				"topic.config.enforce":             "yes please",
+			},
+			wantErr: InvalidTopicConfig{
+				ConfigMap: "knative-eventing/kafka-broker-config",
+				Key:       "topic.config.enforce",
+				Value:     "yes please",
+				Reason:    "must be true or false",
+			},
+		},
+		{
+			name: "partitions missing",
+			data: map[string]string{
+				"default.topic.replication.factor": "3",
+				"bootstrap.servers":                "server1:9092",
+			},
+			wantErr: InvalidTopicConfig{
+				ConfigMap: "knative-eventing/kafka-broker-config",
+				Key:       "default.topic.partitions",
+				Missing:   true,
+				Reason:    "must be greater than 0",
+			},
+		},
+		{
+			name: "min.insync.replicas greater than replication factor",
+			data: map[string]string{
+				"default.topic.partitions":                 "5",
+				"default.topic.replication.factor":         "3",
+				"default.topic.config.min.insync.replicas": "4",
+				"bootstrap.servers":                        "server1:9092",
+			},
+			wantErr: InvalidTopicConfig{
+				ConfigMap: "knative-eventing/kafka-broker-config",
+				Key:       "default.topic.config.min.insync.replicas",
+				Value:     "4",
+				Reason:    "must be between 1 and the replication factor 3",
+			},
+		},
+		{
+			name: "max.message.bytes not positive",
+			data: map[string]string{
+				"default.topic.partitions":               "5",
+				"default.topic.replication.factor":       "3",
+				"default.topic.config.max.message.bytes": "-1",
+				"bootstrap.servers":                      "server1:9092",
+			},
+			wantErr: InvalidTopicConfig{
+				ConfigMap: "knative-eventing/kafka-broker-config",
+				Key:       "default.topic.config.max.message.bytes",
+				Value:     "-1",
+				Reason:    "must be a positive integer",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "knative-eventing", Name: "kafka-broker-config"},
+				Data:       tt.data,
+			}
+
+			_, err := TopicConfigFromConfigMap(zap.NewNop(), cm)
+			require.Equal(t, tt.wantErr, err)
+			require.True(t, IsInvalidTopicConfig(fmt.Errorf("wrapped: %w", err)))
+		})
+	}
+}
+
+func TestInvalidTopicConfigRedactsCredentials(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "knative-eventing", Name: "kafka-broker-config"},
+		Data:       map[string]string{"sasl.password": "my-password"},
+	}
+
+	err := newInvalidTopicConfig(cm, "sasl.password", "is not valid")
+
+	require.Equal(t, "<redacted>", err.Value)
+	require.NotContains(t, err.Error(), "my-password")
+}
+
+func TestTopicConfigMaxMessageBytes(t *testing.T) {
+	require.Equal(t, uint32(0), TopicConfig{}.MaxMessageBytes())
+	require.Equal(t, uint32(2097152), TopicConfig{
+		TopicDetail: sarama.TopicDetail{
+			ConfigEntries: map[string]*string{MaxMessageBytesTopicConfigKey: pointer.String("2097152")},
+		},
+	}.MaxMessageBytes())
+}
@@ -74,7 +74,7 @@ func NewAsyncWithTLS(ctx context.Context, port string, IPsLister IPsLister, enqu
 }
 
 func (a *asyncProber) Probe(ctx context.Context, addressable Addressable, expected Status) Status {
-	address := addressable.Address
+	address := addressable.probeAddress()
 	IPs, err := a.IPsLister(addressable)
 	if err != nil {
 		a.logger.Error("Failed to list IPs", zap.Error(err))
@@ -479,3 +479,32 @@ xEvjwqWqH5moiuvwopm9glqr72wZh+6YO+qD8MsS3u7fqjRFJHlDiadNlra2S1kv
 tJMpubIHfZ5aeaCE
 -----END CERTIFICATE-----`)
 }
+
+func TestAsyncProberProbeAddress(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	paths := make(chan string, 10)
+	s := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		paths <- r.URL.Path
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+	u, _ := url.Parse(s.URL)
+
+	var IPsLister IPsLister = func(addressable Addressable) ([]string, error) {
+		return []string{"127.0.0.1"}, nil
+	}
+	prober := NewAsync(ctx, s.Client(), u.Port(), IPsLister, func(key types.NamespacedName) {})
+
+	addressable := Addressable{
+		Address:      &url.URL{Scheme: "http", Path: "/b1/b1"},
+		ProbeAddress: &url.URL{Scheme: "http", Path: "/healthz"},
+		ResourceKey:  types.NamespacedName{Namespace: "b1", Name: "b1"},
+	}
+	require.Eventually(t, func() bool {
+		return prober.Probe(ctx, addressable, StatusReady) == StatusReady
+	}, 5*time.Second, 250*time.Millisecond)
+	require.Equal(t, "/healthz", <-paths)
+}
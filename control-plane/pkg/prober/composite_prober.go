@@ -57,6 +57,12 @@ func (c *compositeProber) Probe(ctx context.Context, addressable NewAddressable,
 			ResourceKey: addressable.ResourceKey,
 			Address:     addr.URL.URL(),
 		}
+		if addressable.ProbePath != "" {
+			probeAddress := *oldAddressable.Address
+			probeAddress.Path = addressable.ProbePath
+			probeAddress.RawPath = ""
+			oldAddressable.ProbeAddress = &probeAddress
+		}
 		if addr.URL.Scheme == "https" {
 			status = c.httpsProber.Probe(ctx, oldAddressable, expected)
 		} else if addr.URL.Scheme == "http" {
@@ -426,3 +426,49 @@ func TestCompositeProberNoTLS(t *testing.T) {
 	}
 
 }
+
+func TestCompositeProberProbePath(t *testing.T) {
+	tt := []struct {
+		name             string
+		probePath        string
+		wantProbeAddress string
+	}{
+		{
+			name:             "no probe path",
+			wantProbeAddress: "http://broker-ingress.knative-eventing.svc.cluster.local/ns/b1",
+		},
+		{
+			name:             "probe path",
+			probePath:        "/healthz",
+			wantProbeAddress: "http://broker-ingress.knative-eventing.svc.cluster.local/healthz",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var got Addressable
+			p := &compositeProber{
+				httpProber: Func(func(ctx context.Context, addressable Addressable, expected Status) Status {
+					got = addressable
+					return expected
+				}),
+				httpsProber: Func(func(ctx context.Context, addressable Addressable, expected Status) Status {
+					return StatusUnknown
+				}),
+			}
+
+			address := duckv1.Addressable{URL: apis.HTTP("broker-ingress.knative-eventing.svc.cluster.local")}
+			address.URL.Path = "/ns/b1"
+			status := p.Probe(context.Background(), NewAddressable{
+				AddressStatus: &duckv1.AddressStatus{Address: &address, Addresses: []duckv1.Addressable{address}},
+				ProbePath:     tc.probePath,
+				ResourceKey:   types.NamespacedName{Namespace: "ns", Name: "b1"},
+			}, StatusReady)
+
+			require.Equal(t, StatusReady, status)
+			require.Equal(t, "http://broker-ingress.knative-eventing.svc.cluster.local/ns/b1", got.Address.String())
+			require.Equal(t, tc.wantProbeAddress, got.probeAddress().String())
+		})
+	}
+}
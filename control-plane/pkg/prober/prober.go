@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,10 +34,20 @@ import (
 type Addressable struct {
 	// Addressable address.
 	Address *url.URL
+	// ProbeAddress is the address probed, when it's nil Address is probed.
+	ProbeAddress *url.URL
 	// Resource key.
 	ResourceKey types.NamespacedName
 }
 
+// probeAddress returns the address to probe.
+func (a Addressable) probeAddress() *url.URL {
+	if a.ProbeAddress != nil {
+		return a.ProbeAddress
+	}
+	return a.Address
+}
+
 // EnqueueFunc enqueues the given provided resource key.
 type EnqueueFunc func(key types.NamespacedName)
 
@@ -52,6 +63,8 @@ type Prober interface {
 type NewAddressable struct {
 	// Addressable status
 	AddressStatus *duckv1.AddressStatus
+	// ProbePath is the path probed on each address, when it's empty the addresses are probed as they are.
+	ProbePath string
 	// Resource key
 	ResourceKey types.NamespacedName
 }
@@ -95,9 +108,32 @@ type httpClient interface {
 	Do(r *http.Request) (*http.Response, error)
 }
 
+type timeoutKey struct{}
+
+// WithTimeout returns a copy of ctx that makes probe requests time out after the given timeout.
+//
+// The timeout is propagated through the context, so that it can be changed at every Probe call, a non-positive
+// timeout leaves probe requests bound to ctx only.
+func WithTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, timeout)
+}
+
+func timeoutFromContext(ctx context.Context) time.Duration {
+	if v, ok := ctx.Value(timeoutKey{}).(time.Duration); ok {
+		return v
+	}
+	return 0
+}
+
 func probe(ctx context.Context, client httpClient, logger *zap.Logger, address string) Status {
 	logger.Debug("Sending probe request")
 
+	if timeout := timeoutFromContext(ctx); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	r, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
 	if err != nil {
 		logger.Error("Failed to create request", zap.Error(err))
@@ -18,11 +18,15 @@ package prober
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
+	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -41,6 +45,22 @@ func TestFuncProbe(t *testing.T) {
 	require.Equal(t, int32(1), calls.Load())
 }
 
+func TestProbeWithTimeout(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	ctx := WithTimeout(context.Background(), 10*time.Millisecond)
+
+	status := probe(ctx, http.DefaultClient, zap.NewNop(), s.URL)
+	require.Equal(t, StatusUnknownErr, status, status.String())
+}
+
 func TestIPsListerFromService(t *testing.T) {
 	tests := []struct {
 		name    string
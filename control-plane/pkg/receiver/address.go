@@ -18,8 +18,10 @@ package receiver
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
@@ -62,3 +64,26 @@ func HTTPSAddress(host string, object metav1.Object, caCerts string) duckv1.Addr
 	httpsAddress.URL.Path = fmt.Sprintf("/%s/%s", object.GetNamespace(), object.GetName())
 	return httpsAddress
 }
+
+// ServiceHost returns the cluster IP of the given service for the given IP family, so that it can be used as the host
+// of an address.
+func ServiceHost(svc *corev1.Service, family corev1.IPFamily) (string, error) {
+	clusterIPs := svc.Spec.ClusterIPs
+	if len(clusterIPs) == 0 && svc.Spec.ClusterIP != "" {
+		clusterIPs = []string{svc.Spec.ClusterIP}
+	}
+	for _, clusterIP := range clusterIPs {
+		ip := net.ParseIP(clusterIP)
+		if ip == nil {
+			continue
+		}
+		isIPv6 := ip.To4() == nil
+		if isIPv6 && family == corev1.IPv6Protocol {
+			return "[" + ip.String() + "]", nil
+		}
+		if !isIPv6 && family == corev1.IPv4Protocol {
+			return ip.String(), nil
+		}
+	}
+	return "", fmt.Errorf("service %s/%s has no %s cluster IP", svc.GetNamespace(), svc.GetName(), family)
+}
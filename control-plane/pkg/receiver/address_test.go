@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	eventing "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/eventing/v1alpha1"
@@ -74,3 +75,67 @@ func TestHTTPAddress(t *testing.T) {
 	require.Contains(t, httpAddress.URL.Path, ks.GetNamespace())
 	require.Contains(t, httpAddress.URL.Path, ks.GetName())
 }
+
+func TestServiceHost(t *testing.T) {
+	dualStack := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ingress"},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:  "10.0.0.1",
+			ClusterIPs: []string{"10.0.0.1", "fd00::1"},
+		},
+	}
+	singleStack := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ingress"},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "10.0.0.1",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		svc     *corev1.Service
+		family  corev1.IPFamily
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "dual stack IPv4",
+			svc:    dualStack,
+			family: corev1.IPv4Protocol,
+			want:   "10.0.0.1",
+		},
+		{
+			name:   "dual stack IPv6",
+			svc:    dualStack,
+			family: corev1.IPv6Protocol,
+			want:   "[fd00::1]",
+		},
+		{
+			name:   "single stack IPv4",
+			svc:    singleStack,
+			family: corev1.IPv4Protocol,
+			want:   "10.0.0.1",
+		},
+		{
+			name:    "single stack IPv6",
+			svc:     singleStack,
+			family:  corev1.IPv6Protocol,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ServiceHost(tt.svc, tt.family)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+
+			url := Address(got, tt.svc)
+			require.Equal(t, tt.want, url.Host)
+		})
+	}
+}
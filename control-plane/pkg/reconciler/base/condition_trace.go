@@ -0,0 +1,132 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+// ConditionTransition is a change of a condition made by a StatusConditionManager, the status of cleared conditions
+// is empty.
+type ConditionTransition struct {
+	Type    apis.ConditionType
+	Status  corev1.ConditionStatus
+	Reason  string
+	Message string
+}
+
+func (t ConditionTransition) String() string {
+	if t.Status == "" {
+		return fmt.Sprintf("%s cleared", t.Type)
+	}
+	if t.Reason == "" {
+		return fmt.Sprintf("%s=%s", t.Type, t.Status)
+	}
+	return fmt.Sprintf("%s=%s (%s)", t.Type, t.Status, t.Reason)
+}
+
+// ConditionTrace records, in order, the condition transitions made by the StatusConditionManager of a reconciliation,
+// so that tests can assert the path taken by a reconciliation and so that it can be logged.
+//
+// Only the conditions set through the StatusConditionManager are recorded, the transitions of the happy condition
+// that follow from them aren't.
+type ConditionTrace struct {
+	Transitions []ConditionTransition
+}
+
+// String returns the transitions separated by commas.
+func (t *ConditionTrace) String() string {
+	transitions := make([]string, 0, len(t.Transitions))
+	for _, transition := range t.Transitions {
+		transitions = append(transitions, transition.String())
+	}
+	return strings.Join(transitions, ", ")
+}
+
+type conditionTraceKey struct{}
+
+// WithConditionTrace returns a context making the StatusConditionManager of reconciliations record their condition
+// transitions in the given trace.
+func WithConditionTrace(ctx context.Context, trace *ConditionTrace) context.Context {
+	return context.WithValue(ctx, conditionTraceKey{}, trace)
+}
+
+// GetConditionTrace returns the condition trace of the given context, it's nil when condition transitions aren't
+// recorded.
+func GetConditionTrace(ctx context.Context) *ConditionTrace {
+	trace, _ := ctx.Value(conditionTraceKey{}).(*ConditionTrace)
+	return trace
+}
+
+// tracingConditionManager records the condition transitions made through the embedded ConditionManager.
+type tracingConditionManager struct {
+	apis.ConditionManager
+	trace *ConditionTrace
+}
+
+func (m *tracingConditionManager) SetCondition(condition apis.Condition) {
+	m.record(condition.Type, func() { m.ConditionManager.SetCondition(condition) })
+}
+
+func (m *tracingConditionManager) ClearCondition(t apis.ConditionType) error {
+	var err error
+	m.record(t, func() { err = m.ConditionManager.ClearCondition(t) })
+	return err
+}
+
+func (m *tracingConditionManager) MarkTrue(t apis.ConditionType) {
+	m.record(t, func() { m.ConditionManager.MarkTrue(t) })
+}
+
+func (m *tracingConditionManager) MarkTrueWithReason(t apis.ConditionType, reason, messageFormat string, messageA ...interface{}) {
+	m.record(t, func() { m.ConditionManager.MarkTrueWithReason(t, reason, messageFormat, messageA...) })
+}
+
+func (m *tracingConditionManager) MarkUnknown(t apis.ConditionType, reason, messageFormat string, messageA ...interface{}) {
+	m.record(t, func() { m.ConditionManager.MarkUnknown(t, reason, messageFormat, messageA...) })
+}
+
+func (m *tracingConditionManager) MarkFalse(t apis.ConditionType, reason, messageFormat string, messageA ...interface{}) {
+	m.record(t, func() { m.ConditionManager.MarkFalse(t, reason, messageFormat, messageA...) })
+}
+
+// record runs the given change of the given condition and records the transition, if the condition changed.
+func (m *tracingConditionManager) record(t apis.ConditionType, change func()) {
+	before := transitionOf(t, m.GetCondition(t))
+	change()
+	after := transitionOf(t, m.GetCondition(t))
+	if after != before {
+		m.trace.Transitions = append(m.trace.Transitions, after)
+	}
+}
+
+func transitionOf(t apis.ConditionType, condition *apis.Condition) ConditionTransition {
+	if condition == nil {
+		return ConditionTransition{Type: t}
+	}
+	return ConditionTransition{
+		Type:    t,
+		Status:  condition.Status,
+		Reason:  condition.Reason,
+		Message: condition.Message,
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
+)
+
+func TestConditionTrace(t *testing.T) {
+	trace := &base.ConditionTrace{}
+	ctx := base.WithConditionTrace(context.Background(), trace)
+	require.Same(t, trace, base.GetConditionTrace(ctx))
+	require.Nil(t, base.GetConditionTrace(context.Background()))
+
+	manager := base.StatusConditionManager{
+		Object:   &eventing.Broker{},
+		Recorder: record.NewFakeRecorder(10),
+		Trace:    base.GetConditionTrace(ctx),
+	}
+
+	manager.DataPlaneAvailable()
+	// Unchanged conditions aren't recorded.
+	manager.DataPlaneAvailable()
+	manager.TopicConfigIgnored("topic", []string{"retention.ms"})
+	manager.TopicConfigApplied()
+	// Clearing a condition that isn't set isn't recorded.
+	manager.TopicConfigApplied()
+	_ = manager.DataPlaneNotAvailable()
+
+	assert.Equal(t, []base.ConditionTransition{
+		{Type: base.ConditionDataPlaneAvailable, Status: corev1.ConditionTrue},
+		{
+			Type:    base.ConditionTopicConfigIgnored,
+			Status:  corev1.ConditionTrue,
+			Reason:  base.ReasonTopicConfigIgnored,
+			Message: "Topic topic is externally managed, the topic configs retention.ms aren't applied to it",
+		},
+		{Type: base.ConditionTopicConfigIgnored},
+		{
+			Type:    base.ConditionDataPlaneAvailable,
+			Status:  corev1.ConditionFalse,
+			Reason:  base.ReasonDataPlaneNotAvailable,
+			Message: base.MessageDataPlaneNotAvailable,
+		},
+	}, trace.Transitions)
+
+	assert.Equal(t, "DataPlaneAvailable=True, TopicConfigIgnored=True (TopicConfigIgnored), TopicConfigIgnored cleared, DataPlaneAvailable=False (Data plane not available)", trace.String())
+}
+
+func TestConditionTraceNotSet(t *testing.T) {
+	broker := &eventing.Broker{}
+	manager := base.StatusConditionManager{
+		Object:   broker,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	manager.DataPlaneAvailable()
+	assert.True(t, broker.Status.GetCondition(base.ConditionDataPlaneAvailable).IsTrue())
+}
@@ -0,0 +1,313 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+)
+
+var (
+	// contractUpdateCoalescers are the coalescers of the contract config maps updates, they're shared by the
+	// reconcilers updating the same contract config map, so that their updates are coalesced together.
+	contractUpdateCoalescers   = make(map[contractUpdateCoalescerKey]*contractUpdateCoalescer)
+	contractUpdateCoalescersMu sync.Mutex
+)
+
+type contractUpdateCoalescerKey struct {
+	client    kubernetes.Interface
+	format    string
+	namespace string
+	name      string
+}
+
+// contractUpdateCoalescer batches the changes made to a contract within a coalescing window and writes them to the
+// contract config map at once.
+type contractUpdateCoalescer struct {
+	key contractUpdateCoalescerKey
+
+	mu      sync.Mutex
+	pending *contractUpdateBatch
+}
+
+// contractUpdateBatch is a set of contract changes written to the contract config map at once.
+type contractUpdateBatch struct {
+	deltas []*resourceDelta
+	done   chan struct{}
+
+	// contract and configMap are the contract and the config map written, they're set along with err once done is
+	// closed.
+	contract  *contract.Contract
+	configMap *corev1.ConfigMap
+	err       error
+}
+
+func getContractUpdateCoalescer(key contractUpdateCoalescerKey) *contractUpdateCoalescer {
+	contractUpdateCoalescersMu.Lock()
+	defer contractUpdateCoalescersMu.Unlock()
+
+	c, ok := contractUpdateCoalescers[key]
+	if !ok {
+		c = &contractUpdateCoalescer{key: key}
+		contractUpdateCoalescers[key] = c
+	}
+	return c
+}
+
+// coalesceDataPlaneConfigMapUpdate writes the changes made to the contract stored in the given config map along with
+// the changes made by other reconcilers within the ContractUpdateCoalesceWindow.
+//
+// It returns once the changes are written, the given contract and config map are then set to the written ones, so
+// that callers propagate the written contract generation to the data plane pods.
+func (r *Reconciler) coalesceDataPlaneConfigMapUpdate(ctx context.Context, ct *contract.Contract, configMap *corev1.ConfigMap) error {
+	original, err := r.GetDataPlaneConfigMapData(logging.FromContext(ctx).Desugar(), configMap)
+	if err != nil {
+		// Changes are computed against the contract stored in the config map, a contract rebuilt from a corrupted
+		// one is written as is.
+		_, err := r.writeDataPlaneConfigMap(ctx, ct, configMap)
+		return err
+	}
+
+	c := getContractUpdateCoalescer(contractUpdateCoalescerKey{
+		client:    r.KubeClient,
+		format:    r.ContractConfigMapFormat,
+		namespace: configMap.Namespace,
+		name:      configMap.Name,
+	})
+	batch := c.add(ctx, r, newContractDeltas(original, ct))
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if batch.err != nil {
+		return batch.err
+	}
+
+	proto.Reset(ct)
+	proto.Merge(ct, batch.contract)
+	batch.configMap.DeepCopyInto(configMap)
+	return nil
+}
+
+// add adds the given changes to the pending batch, the first change of a batch schedules its write after the
+// coalescing window.
+func (c *contractUpdateCoalescer) add(ctx context.Context, r *Reconciler, deltas []*resourceDelta) *contractUpdateBatch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pending == nil {
+		batch := &contractUpdateBatch{done: make(chan struct{})}
+		c.pending = batch
+
+		// The batch is written on behalf of every reconciler having changes in it, so it's not bound to the context
+		// of the first one.
+		flushCtx := logging.WithLogger(context.Background(), logging.FromContext(ctx))
+		time.AfterFunc(r.ContractUpdateCoalesceWindow, func() {
+			c.flush(flushCtx, r, batch)
+		})
+	}
+	c.pending.deltas = append(c.pending.deltas, deltas...)
+	return c.pending
+}
+
+func (c *contractUpdateCoalescer) flush(ctx context.Context, r *Reconciler, batch *contractUpdateBatch) {
+	c.mu.Lock()
+	if c.pending == batch {
+		c.pending = nil
+	}
+	c.mu.Unlock()
+
+	defer close(batch.done)
+
+	logger := logging.FromContext(ctx).Desugar()
+
+	batch.err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := r.KubeClient.CoreV1().ConfigMaps(c.key.namespace).Get(ctx, c.key.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current, err := r.GetDataPlaneConfigMapData(logger, configMap)
+		if err != nil {
+			return err
+		}
+
+		ct := proto.Clone(current).(*contract.Contract)
+		for _, d := range batch.deltas {
+			d.apply(ct)
+		}
+		if proto.Equal(ct, current) {
+			batch.contract, batch.configMap = ct, configMap
+			return nil
+		}
+		ct.Generation = current.Generation + 1
+
+		updated, err := r.writeDataPlaneConfigMap(ctx, ct, configMap)
+		if err != nil {
+			return err
+		}
+		batch.contract, batch.configMap = ct, updated
+		return nil
+	})
+
+	logger.Debug("Coalesced contract updates",
+		zap.String("configmap", c.key.namespace+"/"+c.key.name),
+		zap.Int("changes", len(batch.deltas)),
+		zap.Error(batch.err),
+	)
+}
+
+// resourceDelta is the change made to a contract resource.
+//
+// Egresses are tracked one by one, since the egresses of a resource are changed by several reconcilers (for
+// example, by the reconcilers of the triggers of a broker).
+type resourceDelta struct {
+	uid     string
+	removed bool
+	// resource is the resource without its egresses, it's nil when only its egresses changed.
+	resource        *contract.Resource
+	egresses        []*contract.Egress
+	removedEgresses []string
+}
+
+// newContractDeltas returns the changes made to the original contract to get the updated one.
+func newContractDeltas(original, updated *contract.Contract) []*resourceDelta {
+	originals := make(map[string]*contract.Resource, len(original.Resources))
+	for _, resource := range original.Resources {
+		originals[resource.Uid] = resource
+	}
+
+	var deltas []*resourceDelta
+	uids := sets.NewString()
+	for _, resource := range updated.Resources {
+		uids.Insert(resource.Uid)
+		if d := newResourceDelta(originals[resource.Uid], resource); d != nil {
+			deltas = append(deltas, d)
+		}
+	}
+	for _, resource := range original.Resources {
+		if !uids.Has(resource.Uid) {
+			deltas = append(deltas, &resourceDelta{uid: resource.Uid, removed: true})
+		}
+	}
+	return deltas
+}
+
+// newResourceDelta returns the change made to the original resource to get the updated one, original is nil for
+// added resources and the returned delta is nil when the resource didn't change.
+func newResourceDelta(original, updated *contract.Resource) *resourceDelta {
+	d := &resourceDelta{uid: updated.Uid}
+	if original == nil || !proto.Equal(withoutEgresses(original), withoutEgresses(updated)) {
+		d.resource = withoutEgresses(updated)
+	}
+
+	originalEgresses := make(map[string]*contract.Egress, len(original.GetEgresses()))
+	for _, egress := range original.GetEgresses() {
+		originalEgresses[egress.Uid] = egress
+	}
+	uids := sets.NewString()
+	for _, egress := range updated.Egresses {
+		uids.Insert(egress.Uid)
+		if o, ok := originalEgresses[egress.Uid]; !ok || !proto.Equal(o, egress) {
+			d.egresses = append(d.egresses, proto.Clone(egress).(*contract.Egress))
+		}
+	}
+	for _, egress := range original.GetEgresses() {
+		if !uids.Has(egress.Uid) {
+			d.removedEgresses = append(d.removedEgresses, egress.Uid)
+		}
+	}
+
+	if d.resource == nil && len(d.egresses) == 0 && len(d.removedEgresses) == 0 {
+		return nil
+	}
+	return d
+}
+
+func withoutEgresses(resource *contract.Resource) *contract.Resource {
+	r := proto.Clone(resource).(*contract.Resource)
+	r.Egresses = nil
+	return r
+}
+
+// apply applies the change to the given contract.
+func (d *resourceDelta) apply(ct *contract.Contract) {
+	idx := -1
+	for i, resource := range ct.Resources {
+		if resource.Uid == d.uid {
+			idx = i
+			break
+		}
+	}
+
+	if d.removed {
+		if idx >= 0 {
+			ct.Resources = append(ct.Resources[:idx], ct.Resources[idx+1:]...)
+		}
+		return
+	}
+
+	if idx < 0 {
+		if d.resource == nil {
+			// Only the egresses of a resource removed in the meantime changed.
+			return
+		}
+		ct.Resources = append(ct.Resources, proto.Clone(d.resource).(*contract.Resource))
+		idx = len(ct.Resources) - 1
+	} else if d.resource != nil {
+		egresses := ct.Resources[idx].Egresses
+		ct.Resources[idx] = proto.Clone(d.resource).(*contract.Resource)
+		ct.Resources[idx].Egresses = egresses
+	}
+
+	resource := ct.Resources[idx]
+	removed := sets.NewString(d.removedEgresses...)
+	egresses := resource.Egresses[:0]
+	for _, egress := range resource.Egresses {
+		if !removed.Has(egress.Uid) {
+			egresses = append(egresses, egress)
+		}
+	}
+	resource.Egresses = egresses
+
+	for _, egress := range d.egresses {
+		found := false
+		for i, e := range resource.Egresses {
+			if e.Uid == egress.Uid {
+				resource.Egresses[i] = proto.Clone(egress).(*contract.Egress)
+				found = true
+				break
+			}
+		}
+		if !found {
+			resource.Egresses = append(resource.Egresses, proto.Clone(egress).(*contract.Egress))
+		}
+	}
+}
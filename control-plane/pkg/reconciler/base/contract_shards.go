@@ -0,0 +1,114 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ContractConfigMapShardCount returns the number of config maps the contract is sharded across, it's 1 when the
+// contract isn't sharded.
+func (r *Reconciler) ContractConfigMapShardCount() int {
+	if r.ContractConfigMapShards < 1 {
+		return 1
+	}
+	return r.ContractConfigMapShards
+}
+
+// ContractConfigMapShard returns the shard of the contract holding the resource of the given object.
+//
+// Objects are assigned to a shard by hash of their namespace and name, rather than of their UID, so that an object
+// created again with the same name, and the objects referencing it by name, are assigned to the same shard.
+func (r *Reconciler) ContractConfigMapShard(obj metav1.Object) int {
+	shards := r.ContractConfigMapShardCount()
+	if shards == 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(obj.GetNamespace() + "/" + obj.GetName()))
+	return int(h.Sum32() % uint32(shards))
+}
+
+// ContractConfigMapShardName returns the name of the config map of the given contract shard, the first shard is
+// ContractConfigMapName so that enabling sharding keeps the existing contract config map.
+func (r *Reconciler) ContractConfigMapShardName(shard int) string {
+	if shard == 0 {
+		return r.ContractConfigMapName
+	}
+	return fmt.Sprintf("%s-%d", r.ContractConfigMapName, shard)
+}
+
+// IsContractConfigMap returns true if the given object is the config map of a contract shard.
+func (r *Reconciler) IsContractConfigMap(obj interface{}) bool {
+	cm, ok := obj.(metav1.Object)
+	if !ok || cm.GetNamespace() != r.DataPlaneConfigMapNamespace {
+		return false
+	}
+	return r.contractConfigMapShardOf(cm.GetName()) >= 0
+}
+
+// contractConfigMapShardOf returns the shard of the contract config map with the given name, or -1 when it isn't a
+// contract config map.
+func (r *Reconciler) contractConfigMapShardOf(name string) int {
+	for shard := 0; shard < r.ContractConfigMapShardCount(); shard++ {
+		if r.ContractConfigMapShardName(shard) == name {
+			return shard
+		}
+	}
+	return -1
+}
+
+// GetOrCreateDataPlaneConfigMapShard returns the config map of the given contract shard, it's created when it
+// doesn't exist.
+func (r *Reconciler) GetOrCreateDataPlaneConfigMapShard(ctx context.Context, shard int) (*corev1.ConfigMap, error) {
+	return r.getOrCreateDataPlaneConfigMap(ctx, r.ContractConfigMapShardName(shard))
+}
+
+// VolumeGenerationAnnotationKeyForShard returns the data plane pods annotation holding the volume generation of the
+// given contract shard.
+func VolumeGenerationAnnotationKeyForShard(shard int) string {
+	if shard == 0 {
+		return VolumeGenerationAnnotationKey
+	}
+	return fmt.Sprintf("%s-%d", VolumeGenerationAnnotationKey, shard)
+}
+
+// UpdateDispatcherPodsShardAnnotation is like UpdateDispatcherPodsAnnotation, but it updates the volume generation
+// of the given contract shard.
+func (r *Reconciler) UpdateDispatcherPodsShardAnnotation(ctx context.Context, logger *zap.Logger, shard int, volumeGeneration uint64) error {
+	pods, err := r.PodLister.Pods(r.DataPlaneNamespace).List(r.dispatcherSelector())
+	if err != nil {
+		return fmt.Errorf("failed to list dispatcher pods in namespace %s: %w", r.DataPlaneNamespace, err)
+	}
+	return r.updatePodsAnnotation(ctx, logger, "dispatcher", VolumeGenerationAnnotationKeyForShard(shard), volumeGeneration, pods)
+}
+
+// UpdateReceiverPodsShardAnnotation is like UpdateReceiverPodsAnnotation, but it updates the volume generation of
+// the given contract shard.
+func (r *Reconciler) UpdateReceiverPodsShardAnnotation(ctx context.Context, logger *zap.Logger, shard int, volumeGeneration uint64) error {
+	pods, err := r.PodLister.Pods(r.DataPlaneNamespace).List(r.ReceiverSelector())
+	if err != nil {
+		return fmt.Errorf("failed to list receiver pods in namespace %s: %w", r.DataPlaneNamespace, err)
+	}
+	return r.updatePodsAnnotation(ctx, logger, "receiver", VolumeGenerationAnnotationKeyForShard(shard), volumeGeneration, pods)
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
+	"knative.dev/pkg/logging"
+	reconcilertesting "knative.dev/pkg/reconciler/testing"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
+)
+
+func TestContractConfigMapShard(t *testing.T) {
+	r := &base.Reconciler{
+		DataPlaneConfigMapNamespace: "knative-eventing",
+		ContractConfigMapName:       "kafka-broker-brokers-triggers",
+	}
+	obj := &metav1.ObjectMeta{Namespace: "ns", Name: "broker"}
+
+	assert.Equal(t, 1, r.ContractConfigMapShardCount())
+	assert.Equal(t, 0, r.ContractConfigMapShard(obj))
+
+	r.ContractConfigMapShards = 4
+	shards := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		obj := &metav1.ObjectMeta{Namespace: "ns", Name: fmt.Sprintf("broker-%d", i)}
+		shard := r.ContractConfigMapShard(obj)
+		require.True(t, shard >= 0 && shard < 4, "unexpected shard %d", shard)
+		// Objects are assigned to a shard by namespace and name, regardless of their UID.
+		obj.UID = "uid"
+		require.Equal(t, shard, r.ContractConfigMapShard(obj))
+		shards[shard] = true
+	}
+	assert.Len(t, shards, 4)
+
+	assert.Equal(t, "kafka-broker-brokers-triggers", r.ContractConfigMapShardName(0))
+	assert.Equal(t, "kafka-broker-brokers-triggers-3", r.ContractConfigMapShardName(3))
+
+	isContractConfigMap := func(namespace, name string) bool {
+		return r.IsContractConfigMap(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}})
+	}
+	assert.True(t, isContractConfigMap("knative-eventing", "kafka-broker-brokers-triggers"))
+	assert.True(t, isContractConfigMap("knative-eventing", "kafka-broker-brokers-triggers-3"))
+	assert.False(t, isContractConfigMap("knative-eventing", "kafka-broker-brokers-triggers-4"))
+	assert.False(t, isContractConfigMap("ns", "kafka-broker-brokers-triggers"))
+}
+
+func TestGetOrCreateDataPlaneConfigMapShard(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	r := &base.Reconciler{
+		KubeClient:                  kubeclient.Get(ctx),
+		DataPlaneConfigMapNamespace: "knative-eventing",
+		ContractConfigMapName:       "kafka-broker-brokers-triggers",
+		ContractConfigMapShards:     2,
+	}
+
+	cm, err := r.GetOrCreateDataPlaneConfigMapShard(ctx, 1)
+	require.Nil(t, err)
+	assert.Equal(t, "kafka-broker-brokers-triggers-1", cm.Name)
+	assert.Equal(t, "knative-eventing", cm.Namespace)
+}
+
+func TestUpdatePodsShardAnnotation(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	addRunningPod(podinformer.Get(ctx).Informer().GetStore(), kubeclient.Get(ctx), base.BrokerReceiverLabel)
+
+	r := &base.Reconciler{
+		PodLister:               podinformer.Get(ctx).Lister(),
+		KubeClient:              kubeclient.Get(ctx),
+		ReceiverLabel:           base.BrokerReceiverLabel,
+		ContractConfigMapShards: 2,
+	}
+
+	err := r.UpdateReceiverPodsShardAnnotation(ctx, logging.FromContext(ctx).Desugar(), 1, 3)
+	require.Nil(t, err)
+
+	pod, err := kubeclient.Get(ctx).CoreV1().Pods("ns").Get(ctx, "pod", metav1.GetOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, "3", pod.Annotations[base.VolumeGenerationAnnotationKeyForShard(1)])
+	assert.NotContains(t, pod.Annotations, base.VolumeGenerationAnnotationKey)
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/metrics"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+)
+
+const (
+	// resourceKindLabel is the metric label for the kind of resource whose reconciliation updated the contract.
+	resourceKindLabel = "resource_kind"
+	// contractConfigMapLabel is the metric label for the namespace/name of the contract config map.
+	contractConfigMapLabel = "contract_config_map"
+)
+
+var (
+	// contractUpdatesM is the number of updates of the contract config map.
+	contractUpdatesM = stats.Int64(
+		"contract_config_map_updates",
+		"Number of updates of the data plane contract config map",
+		stats.UnitDimensionless,
+	)
+	// contractGenerationM is the generation of the contract stored in the contract config map.
+	contractGenerationM = stats.Int64(
+		"contract_generation",
+		"Generation of the data plane contract",
+		stats.UnitDimensionless,
+	)
+	// contractResourcesM is the number of resources of the contract stored in the contract config map.
+	contractResourcesM = stats.Int64(
+		"contract_resources",
+		"Number of resources in the data plane contract",
+		stats.UnitDimensionless,
+	)
+
+	resourceKindKey      = tag.MustNewKey(resourceKindLabel)
+	contractConfigMapKey = tag.MustNewKey(contractConfigMapLabel)
+)
+
+func init() {
+	tagKeys := []tag.Key{resourceKindKey, contractConfigMapKey}
+	err := view.Register(
+		&view.View{
+			Description: contractUpdatesM.Description(),
+			Measure:     contractUpdatesM,
+			Aggregation: view.Count(),
+			TagKeys:     tagKeys,
+		},
+		&view.View{
+			Description: contractGenerationM.Description(),
+			Measure:     contractGenerationM,
+			Aggregation: view.LastValue(),
+			TagKeys:     tagKeys,
+		},
+		&view.View{
+			Description: contractResourcesM.Description(),
+			Measure:     contractResourcesM,
+			Aggregation: view.LastValue(),
+			TagKeys:     tagKeys,
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// recordContractUpdate records an update of the given contract config map with the given contract.
+func (r *Reconciler) recordContractUpdate(ctx context.Context, ct *contract.Contract, configMap *corev1.ConfigMap) error {
+	ctx, err := tag.New(ctx,
+		tag.Insert(resourceKindKey, r.ResourceKind),
+		tag.Insert(contractConfigMapKey, configMap.Namespace+"/"+configMap.Name),
+	)
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, contractUpdatesM.M(1))
+	metrics.Record(ctx, contractGenerationM.M(int64(ct.GetGeneration())))
+	metrics.Record(ctx, contractResourcesM.M(int64(len(ct.GetResources()))))
+	return nil
+}
@@ -20,17 +20,21 @@ package base
 import (
 	"fmt"
 	"net/url"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/reconciler"
 
 	sources "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/sources/v1beta1"
 
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/prober"
 )
 
@@ -42,6 +46,12 @@ const (
 	ConditionConfigParsed            apis.ConditionType = "ConfigParsed"
 	ConditionInitialOffsetsCommitted apis.ConditionType = "InitialOffsetsCommitted"
 	ConditionProbeSucceeded          apis.ConditionType = "ProbeSucceeded"
+	ConditionTopicConfigSynced       apis.ConditionType = "TopicConfigSynced"
+	ConditionConfigPresent           apis.ConditionType = "ConfigPresent"
+	ConditionPaused                  apis.ConditionType = "Paused"
+	ConditionTopicConfigIgnored      apis.ConditionType = "TopicConfigIgnored"
+	ConditionIngressReachable        apis.ConditionType = "IngressReachable"
+	ConditionAuthSecretProtected     apis.ConditionType = "AuthSecretProtected"
 )
 
 var IngressConditionSet = apis.NewLivingConditionSet(
@@ -67,7 +77,33 @@ const (
 	ReasonDataPlaneNotAvailable  = "Data plane not available"
 	MessageDataPlaneNotAvailable = "Did you install the data plane for this component?"
 
-	ReasonTopicNotPresentOrInvalid = "Topic is not present or invalid"
+	ReasonTopicNotPresentOrInvalid  = "Topic is not present or invalid"
+	ReasonWaitingForExternalTopic   = "WaitingForExternalTopic"
+	ReasonTopicCreated              = "TopicCreated"
+	ReasonExternalTopicAdopted      = "ExternalTopicAdopted"
+	ReasonTopicAdopted              = "TopicAdopted"
+	ReasonKafkaClusterUnavailable   = "KafkaClusterUnavailable"
+	ReasonTopicUnhealthy            = "TopicUnhealthy"
+	ReasonExternalTopicNotPermitted = "ExternalTopicNotPermitted"
+	ReasonAuthSecretNotFound        = "AuthSecretNotFound"
+	ReasonConfigRebuilt             = "ConfigRebuiltFromStatusAnnotations"
+	ReasonTopicUnmanaged            = "TopicUnmanaged"
+	ReasonReconcilePaused           = "ReconcilePaused"
+	ReasonTopicConfigIgnored        = "TopicConfigIgnored"
+	ReasonTopicNameCollision        = "TopicNameCollision"
+	ReasonIngressUnreachable        = "IngressUnreachable"
+	ReasonSecretFinalizersDisabled  = "SecretFinalizersDisabled"
+
+	ReasonTopicCreationTransientFailure = "TopicCreationTransientFailure"
+	ReasonTopicCreationPermanentFailure = "TopicCreationPermanentFailure"
+	ReasonTopicAuthorizationFailed      = "TopicAuthorizationFailed"
+
+	// TransientTopicFailureRequeueDelay is the delay after which a resource is reconciled again when its topic
+	// creation failed with a transient error.
+	TransientTopicFailureRequeueDelay = 10 * time.Second
+	// PermanentTopicFailureRequeueDelay is the delay after which a resource is reconciled again when its topic
+	// creation failed with a permanent error, the resource is also reconciled again once updated.
+	PermanentTopicFailureRequeueDelay = 5 * time.Minute
 )
 
 type Object interface {
@@ -84,15 +120,28 @@ type StatusConditionManager struct {
 	BootstrapServers string
 
 	Recorder record.EventRecorder
+
+	// Trace, when set, records the condition transitions made by the manager.
+	Trace *ConditionTrace
+}
+
+// conditions returns the condition manager of the object, it records the condition transitions in the Trace, when
+// set.
+func (manager *StatusConditionManager) conditions() apis.ConditionManager {
+	conditions := manager.Object.GetConditionSet().Manage(manager.Object.GetStatus())
+	if manager.Trace == nil {
+		return conditions
+	}
+	return &tracingConditionManager{ConditionManager: conditions, trace: manager.Trace}
 }
 
 func (manager *StatusConditionManager) DataPlaneAvailable() {
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkTrue(ConditionDataPlaneAvailable)
+	manager.conditions().MarkTrue(ConditionDataPlaneAvailable)
 }
 
 func (manager *StatusConditionManager) DataPlaneNotAvailable() reconciler.Event {
 
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkFalse(
+	manager.conditions().MarkFalse(
 		ConditionDataPlaneAvailable,
 		ReasonDataPlaneNotAvailable,
 		MessageDataPlaneNotAvailable,
@@ -103,7 +152,7 @@ func (manager *StatusConditionManager) DataPlaneNotAvailable() reconciler.Event
 
 func (manager *StatusConditionManager) FailedToGetConfigMap(err error) reconciler.Event {
 
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkFalse(
+	manager.conditions().MarkFalse(
 		ConditionConfigMapUpdated,
 		fmt.Sprintf(
 			"Failed to get ConfigMap: %s",
@@ -118,7 +167,7 @@ func (manager *StatusConditionManager) FailedToGetConfigMap(err error) reconcile
 
 func (manager *StatusConditionManager) FailedToGetDataFromConfigMap(err error) reconciler.Event {
 
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkFalse(
+	manager.conditions().MarkFalse(
 		ConditionConfigMapUpdated,
 		fmt.Sprintf(
 			"Failed to get contract data from ConfigMap: %s",
@@ -133,7 +182,7 @@ func (manager *StatusConditionManager) FailedToGetDataFromConfigMap(err error) r
 
 func (manager *StatusConditionManager) FailedToUpdateConfigMap(err error) reconciler.Event {
 
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkFalse(
+	manager.conditions().MarkFalse(
 		ConditionConfigMapUpdated,
 		fmt.Sprintf("Failed to update ConfigMap: %s", manager.Env.DataPlaneConfigMapAsString()),
 		"%s",
@@ -145,16 +194,48 @@ func (manager *StatusConditionManager) FailedToUpdateConfigMap(err error) reconc
 
 func (manager *StatusConditionManager) ConfigMapUpdated() {
 
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkTrueWithReason(
+	manager.conditions().MarkTrueWithReason(
 		ConditionConfigMapUpdated,
 		fmt.Sprintf("Config map %s updated", manager.Env.DataPlaneConfigMapAsString()),
 		"",
 	)
 }
 
+// FailedToCreateTopic marks the topic as not ready, the failure reason tells whether the topic creation is expected
+// to succeed later or requires user action (see kafka.ClassifyTopicError).
+//
+// Transient failures are retried after TransientTopicFailureRequeueDelay, permanent failures are retried after
+// PermanentTopicFailureRequeueDelay and unknown failures follow the usual rate limited requeue.
 func (manager *StatusConditionManager) FailedToCreateTopic(topic string, err error) reconciler.Event {
 
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkFalse(
+	if kafka.IsAuthorizationError(err) {
+		manager.TopicAuthorizationFailed("create", []string{topic}, err)
+		return controller.NewRequeueAfter(PermanentTopicFailureRequeueDelay)
+	}
+
+	switch kafka.ClassifyTopicError(err) {
+	case kafka.TopicErrorTransient:
+		manager.conditions().MarkFalse(
+			ConditionTopicReady,
+			ReasonTopicCreationTransientFailure,
+			"Failed to create topic %s, retrying: %v",
+			topic,
+			err,
+		)
+		return controller.NewRequeueAfter(TransientTopicFailureRequeueDelay)
+
+	case kafka.TopicErrorPermanent:
+		message := fmt.Sprintf("Failed to create topic %s, fix the topic config or the Kafka cluster permissions: %v", topic, err)
+		manager.conditions().MarkFalse(
+			ConditionTopicReady,
+			ReasonTopicCreationPermanentFailure,
+			message,
+		)
+		manager.Recorder.Event(manager.Object, corev1.EventTypeWarning, ReasonTopicCreationPermanentFailure, message)
+		return controller.NewRequeueAfter(PermanentTopicFailureRequeueDelay)
+	}
+
+	manager.conditions().MarkFalse(
 		ConditionTopicReady,
 		fmt.Sprintf("Failed to create topic: %s", topic),
 		"%v",
@@ -164,10 +245,179 @@ func (manager *StatusConditionManager) FailedToCreateTopic(topic string, err err
 	return fmt.Errorf("failed to create topic: %s: %w", topic, err)
 }
 
+func (manager *StatusConditionManager) FailedToUpdateTopicConfig(topic string, err error) reconciler.Event {
+
+	manager.conditions().MarkFalse(
+		ConditionTopicReady,
+		fmt.Sprintf("Failed to update topic config: %s", topic),
+		"%v",
+		err,
+	)
+
+	return fmt.Errorf("failed to update topic config: %s: %w", topic, err)
+}
+
+// TopicConfigNotSynced sets a warning condition describing why the topic config can't be reconciled.
+// This condition doesn't affect the readiness of the object.
+func (manager *StatusConditionManager) TopicConfigNotSynced(topic string, err error) {
+
+	manager.conditions().SetCondition(apis.Condition{
+		Type:     ConditionTopicConfigSynced,
+		Status:   corev1.ConditionFalse,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   fmt.Sprintf("Topic %s config not synced", topic),
+		Message:  err.Error(),
+	})
+}
+
+// TopicReplicationFactorDegraded records that the topic has been created with a lower replication factor than the
+// desired one since the Kafka cluster doesn't have enough brokers, it doesn't affect the readiness of the object.
+func (manager *StatusConditionManager) TopicReplicationFactorDegraded(topic string, replicationFactor, desiredReplicationFactor int16) {
+
+	manager.conditions().SetCondition(apis.Condition{
+		Type:     ConditionTopicConfigSynced,
+		Status:   corev1.ConditionFalse,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   fmt.Sprintf("Topic %s replication factor degraded", topic),
+		Message: fmt.Sprintf("topic created with replication factor %d instead of %d since the Kafka cluster doesn't have enough brokers, durability is degraded",
+			replicationFactor, desiredReplicationFactor),
+	})
+}
+
+// ConfigRebuilt records that the config ConfigMap doesn't exist and the object is operating on the config rebuilt
+// from its status annotations, so that the ConfigMap is restored, it doesn't affect the readiness of the object.
+func (manager *StatusConditionManager) ConfigRebuilt(namespace, name string) {
+
+	manager.conditions().SetCondition(apis.Condition{
+		Type:     ConditionConfigPresent,
+		Status:   corev1.ConditionFalse,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   ReasonConfigRebuilt,
+		Message:  fmt.Sprintf("ConfigMap %s/%s not found, using the config rebuilt from the status annotations", namespace, name),
+	})
+
+	manager.Recorder.Eventf(
+		manager.Object,
+		corev1.EventTypeWarning,
+		ReasonConfigRebuilt,
+		"ConfigMap %s/%s not found, using the config rebuilt from the status annotations",
+		namespace,
+		name,
+	)
+}
+
+func (manager *StatusConditionManager) ConfigPresent() {
+	_ = manager.conditions().ClearCondition(ConditionConfigPresent)
+}
+
+func (manager *StatusConditionManager) ReconcilePaused(annotation string) {
+
+	paused := manager.conditions().GetCondition(ConditionPaused).IsTrue()
+
+	manager.conditions().SetCondition(apis.Condition{
+		Type:     ConditionPaused,
+		Status:   corev1.ConditionTrue,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   ReasonReconcilePaused,
+		Message:  fmt.Sprintf("Reconciliation is paused by the %s annotation, changes aren't applied until it's removed", annotation),
+	})
+
+	if !paused {
+		manager.Recorder.Eventf(
+			manager.Object,
+			corev1.EventTypeWarning,
+			ReasonReconcilePaused,
+			"Reconciliation is paused by the %s annotation",
+			annotation,
+		)
+	}
+}
+
+func (manager *StatusConditionManager) ReconcileResumed() {
+	_ = manager.conditions().ClearCondition(ConditionPaused)
+}
+
+// TopicConfigIgnored records that the given topic configs aren't applied to the given externally managed topic, so
+// that users don't believe they take effect, it doesn't affect the readiness of the object.
+func (manager *StatusConditionManager) TopicConfigIgnored(topic string, configs []string) {
+
+	ignored := manager.conditions().GetCondition(ConditionTopicConfigIgnored).IsTrue()
+
+	message := fmt.Sprintf("Topic %s is externally managed, the topic configs %s aren't applied to it", topic, strings.Join(configs, ", "))
+	manager.conditions().SetCondition(apis.Condition{
+		Type:     ConditionTopicConfigIgnored,
+		Status:   corev1.ConditionTrue,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   ReasonTopicConfigIgnored,
+		Message:  message,
+	})
+
+	if !ignored {
+		manager.Recorder.Event(manager.Object, corev1.EventTypeWarning, ReasonTopicConfigIgnored, message)
+	}
+}
+
+func (manager *StatusConditionManager) TopicConfigApplied() {
+	_ = manager.conditions().ClearCondition(ConditionTopicConfigIgnored)
+}
+
+// IngressUnreachable records that the given advertised address isn't reachable from the controller, so that DNS and
+// networking misconfigurations that the data plane probes don't catch are visible, it doesn't affect the readiness of
+// the object.
+func (manager *StatusConditionManager) IngressUnreachable(address *apis.URL, err error) {
+
+	unreachable := manager.conditions().GetCondition(ConditionIngressReachable).IsFalse()
+
+	message := fmt.Sprintf("Address %s is advertised but it isn't reachable from the controller: %v", address, err)
+	manager.conditions().SetCondition(apis.Condition{
+		Type:     ConditionIngressReachable,
+		Status:   corev1.ConditionFalse,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   ReasonIngressUnreachable,
+		Message:  message,
+	})
+
+	if !unreachable {
+		manager.Recorder.Event(manager.Object, corev1.EventTypeWarning, ReasonIngressUnreachable, message)
+	}
+}
+
+func (manager *StatusConditionManager) IngressReachable() {
+	_ = manager.conditions().ClearCondition(ConditionIngressReachable)
+}
+
+// AuthSecretNotProtected records that the given auth secret doesn't get a finalizer, so that users know that deleting
+// it before the object may leave behind the object topic, it doesn't affect the readiness of the object.
+func (manager *StatusConditionManager) AuthSecretNotProtected(namespace, name string) {
+
+	notProtected := manager.conditions().GetCondition(ConditionAuthSecretProtected).IsFalse()
+
+	message := fmt.Sprintf("Secret finalizers are disabled, deleting the auth secret %s/%s before this resource may prevent its topic from being deleted", namespace, name)
+	manager.conditions().SetCondition(apis.Condition{
+		Type:     ConditionAuthSecretProtected,
+		Status:   corev1.ConditionFalse,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   ReasonSecretFinalizersDisabled,
+		Message:  message,
+	})
+
+	if !notProtected {
+		manager.Recorder.Event(manager.Object, corev1.EventTypeWarning, ReasonSecretFinalizersDisabled, message)
+	}
+}
+
+func (manager *StatusConditionManager) AuthSecretProtected() {
+	_ = manager.conditions().ClearCondition(ConditionAuthSecretProtected)
+}
+
+func (manager *StatusConditionManager) TopicConfigSynced() {
+	_ = manager.conditions().ClearCondition(ConditionTopicConfigSynced)
+}
+
 func (manager *StatusConditionManager) TopicReady(topic string) {
 
 	if owner, ok := manager.Object.GetStatus().Annotations[TopicOwnerAnnotation]; ok {
-		manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkTrueWithReason(
+		manager.conditions().MarkTrueWithReason(
 			ConditionTopicReady,
 			fmt.Sprintf("Topic %s (owner %s)", topic, owner),
 			"",
@@ -176,16 +426,27 @@ func (manager *StatusConditionManager) TopicReady(topic string) {
 		return
 	}
 
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkTrueWithReason(
+	manager.conditions().MarkTrueWithReason(
 		ConditionTopicReady,
 		fmt.Sprintf("Topic %s created", topic),
 		"",
 	)
 }
 
+// TopicUnmanaged marks the topic as ready without checking it, since topics are provisioned out-of-band.
+func (manager *StatusConditionManager) TopicUnmanaged(topic string) {
+
+	manager.conditions().MarkTrueWithReason(
+		ConditionTopicReady,
+		ReasonTopicUnmanaged,
+		"Topic %s isn't managed, it must be created, configured and deleted out-of-band",
+		topic,
+	)
+}
+
 func (manager *StatusConditionManager) FailedToGetBrokerAuthSecret(err error) reconciler.Event {
 
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkFalse(
+	manager.conditions().MarkFalse(
 		ConditionTopicReady,
 		"Failed to get broker auth secret",
 		"%v",
@@ -195,6 +456,21 @@ func (manager *StatusConditionManager) FailedToGetBrokerAuthSecret(err error) re
 	return fmt.Errorf("failed to get broker auth secret: %w", err)
 }
 
+// AuthSecretNotFound marks the topic as not ready since the auth secret referenced by the config doesn't exist, for
+// example, because it has been force-deleted.
+func (manager *StatusConditionManager) AuthSecretNotFound(namespace, name string) reconciler.Event {
+
+	manager.conditions().MarkFalse(
+		ConditionTopicReady,
+		ReasonAuthSecretNotFound,
+		"auth secret %s/%s not found",
+		namespace,
+		name,
+	)
+
+	return fmt.Errorf("auth secret %s/%s not found", namespace, name)
+}
+
 func (manager *StatusConditionManager) Addressable(address *url.URL) {
 	manager.SetAddress(&apis.URL{
 		Scheme:      address.Scheme,
@@ -208,7 +484,7 @@ func (manager *StatusConditionManager) Addressable(address *url.URL) {
 		Fragment:    address.Fragment,
 		RawFragment: address.RawFragment,
 	})
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkTrue(ConditionAddressable)
+	manager.conditions().MarkTrue(ConditionAddressable)
 	manager.ProbesStatusReady()
 }
 
@@ -226,6 +502,38 @@ func (manager *StatusConditionManager) FailedToUpdateDispatcherPodsAnnotation(er
 	)
 }
 
+func (manager *StatusConditionManager) TopicCreated(topic string, numPartitions int32, replicationFactor int16) {
+	manager.Recorder.Eventf(
+		manager.Object,
+		corev1.EventTypeNormal,
+		ReasonTopicCreated,
+		"Topic %s created with %d partitions and replication factor %d",
+		topic,
+		numPartitions,
+		replicationFactor,
+	)
+}
+
+func (manager *StatusConditionManager) ExternalTopicAdopted(topic string) {
+	manager.Recorder.Eventf(
+		manager.Object,
+		corev1.EventTypeNormal,
+		ReasonExternalTopicAdopted,
+		"External topic %s adopted",
+		topic,
+	)
+}
+
+func (manager *StatusConditionManager) TopicAdopted(topic string) {
+	manager.Recorder.Eventf(
+		manager.Object,
+		corev1.EventTypeNormal,
+		ReasonTopicAdopted,
+		"Adopted existing topic %s",
+		topic,
+	)
+}
+
 func (manager *StatusConditionManager) FailedToUpdateReceiverPodsAnnotation(err error) reconciler.Event {
 
 	return fmt.Errorf("failed to update receiver pods annotation: %w", err)
@@ -233,7 +541,7 @@ func (manager *StatusConditionManager) FailedToUpdateReceiverPodsAnnotation(err
 
 func (manager *StatusConditionManager) FailedToResolveConfig(err error) reconciler.Event {
 
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkFalse(
+	manager.conditions().MarkFalse(
 		ConditionConfigParsed,
 		fmt.Sprintf("%v", err),
 		"",
@@ -243,11 +551,16 @@ func (manager *StatusConditionManager) FailedToResolveConfig(err error) reconcil
 }
 
 func (manager *StatusConditionManager) ConfigResolved() {
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkTrue(ConditionConfigParsed)
+	manager.conditions().MarkTrue(ConditionConfigParsed)
 }
 
 func (manager *StatusConditionManager) TopicsNotPresentOrInvalidErr(topics []string, err error) error {
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkFalse(
+	if kafka.IsAuthorizationError(err) {
+		manager.TopicAuthorizationFailed("describe", topics, err)
+		return fmt.Errorf("not authorized to describe topics %v: %w", topics, err)
+	}
+
+	manager.conditions().MarkFalse(
 		ConditionTopicReady,
 		ReasonTopicNotPresentOrInvalid,
 		"topics %v: %s",
@@ -258,8 +571,33 @@ func (manager *StatusConditionManager) TopicsNotPresentOrInvalidErr(topics []str
 	return fmt.Errorf("topics %v not present or invalid: %w", topics, err)
 }
 
+// TopicAuthorizationFailed marks the topic as not ready since the Kafka cluster refused the given topic operation, so
+// that users can tell apart missing ACLs from connectivity issues.
+func (manager *StatusConditionManager) TopicAuthorizationFailed(operation string, topics []string, err error) {
+	message := fmt.Sprintf("Not authorized to %s topics %v, grant the required ACLs to the Kafka client: %v", operation, topics, err)
+	manager.conditions().MarkFalse(
+		ConditionTopicReady,
+		ReasonTopicAuthorizationFailed,
+		message,
+	)
+	manager.Recorder.Event(manager.Object, corev1.EventTypeWarning, ReasonTopicAuthorizationFailed, message)
+}
+
+// TopicNameCollision marks the topic as not ready since its name collides with the topic of another resource, the
+// topic isn't created so that the resources don't silently share a topic.
+func (manager *StatusConditionManager) TopicNameCollision(topic string, err error) error {
+	manager.conditions().MarkFalse(
+		ConditionTopicReady,
+		ReasonTopicNameCollision,
+		"Topic %s refused: %v",
+		topic,
+		err,
+	)
+	return fmt.Errorf("topic %s refused: %w", topic, err)
+}
+
 func (manager *StatusConditionManager) TopicsNotPresentOrInvalid(topics []string) error {
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkFalse(
+	manager.conditions().MarkFalse(
 		ConditionTopicReady,
 		ReasonTopicNotPresentOrInvalid,
 		"Check topics %v configuration",
@@ -268,8 +606,54 @@ func (manager *StatusConditionManager) TopicsNotPresentOrInvalid(topics []string
 	return fmt.Errorf("topics %v not present or invalid: check topic configuration", topics)
 }
 
+// KafkaClusterUnavailable marks the topic not ready without connecting to the Kafka cluster, since the previous
+// connection attempts failed.
+func (manager *StatusConditionManager) KafkaClusterUnavailable(err error) {
+	manager.conditions().MarkFalse(
+		ConditionTopicReady,
+		ReasonKafkaClusterUnavailable,
+		"%v",
+		err,
+	)
+}
+
+// TopicUnhealthy marks the topic not ready when it exists but it isn't writable, for example, because some
+// partitions are under-replicated.
+func (manager *StatusConditionManager) TopicUnhealthy(topic string, err error) reconciler.Event {
+	manager.conditions().MarkFalse(
+		ConditionTopicReady,
+		ReasonTopicUnhealthy,
+		"%v",
+		err,
+	)
+	return fmt.Errorf("topic %s is unhealthy: %w", topic, err)
+}
+
+func (manager *StatusConditionManager) ExternalTopicNotPermitted(topic string) error {
+	manager.conditions().MarkFalse(
+		ConditionTopicReady,
+		ReasonExternalTopicNotPermitted,
+		"external topic %s not permitted by policy",
+		topic,
+	)
+	return fmt.Errorf("external topic %s not permitted by policy", topic)
+}
+
+func (manager *StatusConditionManager) WaitingForExternalTopic(topic string, err error) {
+	message := fmt.Sprintf("waiting for external topic %s", topic)
+	if err != nil {
+		message = fmt.Sprintf("%s: %v", message, err)
+	}
+
+	manager.conditions().MarkUnknown(
+		ConditionTopicReady,
+		ReasonWaitingForExternalTopic,
+		message,
+	)
+}
+
 func (manager *StatusConditionManager) InitialOffsetNotCommitted(err error) error {
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkFalse(
+	manager.conditions().MarkFalse(
 		ConditionInitialOffsetsCommitted,
 		"InitialOffsetsNotCommitted",
 		err.Error(),
@@ -278,15 +662,15 @@ func (manager *StatusConditionManager) InitialOffsetNotCommitted(err error) erro
 }
 
 func (manager *StatusConditionManager) InitialOffsetsCommitted() {
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkTrue(ConditionInitialOffsetsCommitted)
+	manager.conditions().MarkTrue(ConditionInitialOffsetsCommitted)
 }
 
 func (manager *StatusConditionManager) SinkResolved() {
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkTrue(sources.KafkaConditionSinkProvided)
+	manager.conditions().MarkTrue(sources.KafkaConditionSinkProvided)
 }
 
 func (manager *StatusConditionManager) FailedToResolveSink(err error) error {
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkFalse(
+	manager.conditions().MarkFalse(
 		sources.KafkaConditionSinkProvided,
 		"FailedToResolveSink",
 		err.Error(),
@@ -295,13 +679,23 @@ func (manager *StatusConditionManager) FailedToResolveSink(err error) error {
 }
 
 func (manager *StatusConditionManager) ProbesStatusNotReady(status prober.Status) {
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkFalse(
+	manager.conditions().MarkFalse(
 		ConditionProbeSucceeded,
 		"ProbeStatus",
 		fmt.Sprintf("status: %s", status.String()),
 	)
 }
 
+// ProbesStatusBelowThreshold marks the probe condition false until the given number of consecutive successful probes
+// reaches the given threshold.
+func (manager *StatusConditionManager) ProbesStatusBelowThreshold(count, threshold int) {
+	manager.conditions().MarkFalse(
+		ConditionProbeSucceeded,
+		"ProbeStatus",
+		fmt.Sprintf("status: %s, %d of %d consecutive probes succeeded", prober.StatusReady.String(), count, threshold),
+	)
+}
+
 func (manager *StatusConditionManager) ProbesStatusReady() {
-	manager.Object.GetConditionSet().Manage(manager.Object.GetStatus()).MarkTrue(ConditionProbeSucceeded)
+	manager.conditions().MarkTrue(ConditionProbeSucceeded)
 }
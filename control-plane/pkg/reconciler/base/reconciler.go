@@ -18,8 +18,13 @@ package base
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -30,11 +35,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/pointer"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
 	"knative.dev/pkg/tracker"
 
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
 	coreconfig "knative.dev/eventing-kafka-broker/control-plane/pkg/core/config"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/security"
@@ -47,6 +58,17 @@ const (
 	// config map key of the contract config map.
 	ConfigMapDataKey = "data"
 
+	// ContractChecksumAnnotation is the contract config map annotation holding the SHA-256 checksum of the contract,
+	// it's used to detect corrupted contracts.
+	ContractChecksumAnnotation = "kafka.eventing.knative.dev/contract.checksum"
+
+	// ContractRebuildAnnotation is the contract config map annotation counting the rebuilds of the contract.
+	ContractRebuildAnnotation = "kafka.eventing.knative.dev/contract.rebuild"
+
+	// ConflictRetryRequeueDelay is the delay after which a resource is reconciled again once its conflict retry
+	// budget is exhausted.
+	ConflictRetryRequeueDelay = time.Second
+
 	// label for selecting broker dispatcher pods.
 	BrokerDispatcherLabel = "kafka-broker-dispatcher"
 	// label for selecting broker receiver pods.
@@ -63,6 +85,12 @@ const (
 	// label for selecting channel receiver pods.
 	ChannelReceiverLabel = "kafka-channel-receiver"
 
+	// resource kinds used to tag the contract metrics.
+	BrokerResourceKind  = "broker"
+	TriggerResourceKind = "trigger"
+	ChannelResourceKind = "channel"
+	SinkResourceKind    = "sink"
+
 	// volume generation annotation data plane pods.
 	VolumeGenerationAnnotationKey = "volumeGeneration"
 
@@ -89,7 +117,24 @@ type Reconciler struct {
 	DispatcherLabel string
 	ReceiverLabel   string
 
+	// DispatcherPodSelector and ReceiverPodSelector select the data plane pods instead of the app label set to
+	// DispatcherLabel and ReceiverLabel, when set, see ParseDataPlanePodSelectors.
+	DispatcherPodSelector labels.Selector
+	ReceiverPodSelector   labels.Selector
+
 	DataPlaneConfigMapTransformer ConfigMapOption
+
+	// ResourceKind is the kind of resources reconciled (for example, broker), it's used to tag the contract
+	// metrics.
+	ResourceKind string
+
+	// ContractUpdateCoalesceWindow is the window within which the contract config map updates are coalesced into a
+	// single write, updates aren't coalesced when it's not positive.
+	ContractUpdateCoalesceWindow time.Duration
+
+	// ContractConfigMapShards is the number of config maps the contract is sharded across, see
+	// ContractConfigMapShard, the contract isn't sharded when it's lower than 2.
+	ContractConfigMapShards int
 }
 
 func (r *Reconciler) IsReceiverRunning() bool {
@@ -124,14 +169,20 @@ type ConfigMapOption func(cm *corev1.ConfigMap)
 
 func NoopConfigmapOption(cm *corev1.ConfigMap) {}
 
+// GetOrCreateDataPlaneConfigMap returns the contract config map, it's the config map of the first shard when the
+// contract is sharded.
 func (r *Reconciler) GetOrCreateDataPlaneConfigMap(ctx context.Context) (*corev1.ConfigMap, error) {
+	return r.getOrCreateDataPlaneConfigMap(ctx, r.ContractConfigMapName)
+}
+
+func (r *Reconciler) getOrCreateDataPlaneConfigMap(ctx context.Context, name string) (*corev1.ConfigMap, error) {
 
 	cm, err := r.KubeClient.CoreV1().
 		ConfigMaps(r.DataPlaneConfigMapNamespace).
-		Get(ctx, r.ContractConfigMapName, metav1.GetOptions{})
+		Get(ctx, name, metav1.GetOptions{})
 
 	if apierrors.IsNotFound(err) {
-		cm, err = r.createDataPlaneConfigMap(ctx)
+		cm, err = r.createDataPlaneConfigMap(ctx, name)
 	}
 
 	if r.DataPlaneConfigMapTransformer != nil {
@@ -141,11 +192,11 @@ func (r *Reconciler) GetOrCreateDataPlaneConfigMap(ctx context.Context) (*corev1
 	return cm, err
 }
 
-func (r *Reconciler) createDataPlaneConfigMap(ctx context.Context) (*corev1.ConfigMap, error) {
+func (r *Reconciler) createDataPlaneConfigMap(ctx context.Context, name string) (*corev1.ConfigMap, error) {
 	cm := &corev1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      r.ContractConfigMapName,
+			Name:      name,
 			Namespace: r.DataPlaneConfigMapNamespace,
 		},
 		BinaryData: map[string][]byte{
@@ -184,6 +235,10 @@ func (r *Reconciler) GetDataPlaneConfigMapData(logger *zap.Logger, dataPlaneConf
 	return GetDataPlaneConfigMapData(logger, dataPlaneConfigMap, r.ContractConfigMapFormat)
 }
 
+// GetDataPlaneConfigMapData extracts contract from the given config map.
+//
+// A ContractCorruptedError is returned when the contract can't be unmarshalled or when it doesn't match the checksum
+// stored in the config map.
 func GetDataPlaneConfigMapData(logger *zap.Logger, dataPlaneConfigMap *corev1.ConfigMap, format string) (*contract.Contract, error) {
 
 	dataPlaneDataRaw, hasData := dataPlaneConfigMap.BinaryData[ConfigMapDataKey]
@@ -200,6 +255,12 @@ func GetDataPlaneConfigMapData(logger *zap.Logger, dataPlaneConfigMap *corev1.Co
 		return &contract.Contract{}, nil
 	}
 
+	if checksum, ok := dataPlaneConfigMap.GetAnnotations()[ContractChecksumAnnotation]; ok && checksum != ContractChecksum(dataPlaneDataRaw) {
+		logger.Error("Contract checksum mismatch", zap.String("expected", checksum), zap.String("actual", ContractChecksum(dataPlaneDataRaw)))
+
+		return nil, &ContractCorruptedError{msg: fmt.Sprintf("contract checksum mismatch: expected %s, got %s", checksum, ContractChecksum(dataPlaneDataRaw))}
+	}
+
 	ct := &contract.Contract{}
 	var err error
 
@@ -225,13 +286,89 @@ func GetDataPlaneConfigMapData(logger *zap.Logger, dataPlaneConfigMap *corev1.Co
 		logger.Error("Failed to unmarshal contract", zap.Any("content", dataPlaneDataRaw), zap.Error(err))
 
 		// let the caller decide if it want to continue or fail on an error.
-		return ct, fmt.Errorf("failed to unmarshal contract: '%s'", dataPlaneDataRaw)
+		return ct, &ContractCorruptedError{msg: fmt.Sprintf("failed to unmarshal contract: '%s'", dataPlaneDataRaw)}
 	}
 
 	return ct, nil
 }
 
+// ContractCorruptedError is the error returned when the contract stored in the contract config map is corrupted.
+type ContractCorruptedError struct {
+	msg string
+}
+
+func (e *ContractCorruptedError) Error() string {
+	return e.msg
+}
+
+// IsContractCorrupted returns true if the given error, or any error it wraps, is a ContractCorruptedError.
+func IsContractCorrupted(err error) bool {
+	var corrupted *ContractCorruptedError
+	return errors.As(err, &corrupted)
+}
+
+// ContractChecksum returns the checksum of the given contract config map data.
+func ContractChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// NewRebuiltContract returns an empty contract to rebuild the corrupted contract stored in the given config map and
+// increments the rebuild count of the config map.
+//
+// The generation of the returned contract is the highest volume generation of the data plane pods, so that the data
+// plane doesn't skip the rebuilt contract once its generation is incremented.
+func (r *Reconciler) NewRebuiltContract(contractConfigMap *corev1.ConfigMap) *contract.Contract {
+	var rebuilds uint64
+	if v, ok := contractConfigMap.GetAnnotations()[ContractRebuildAnnotation]; ok {
+		rebuilds, _ = strconv.ParseUint(v /* base */, 10 /* bitSize */, 64)
+	}
+	annotations := contractConfigMap.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[ContractRebuildAnnotation] = fmt.Sprint(rebuilds + 1)
+	contractConfigMap.SetAnnotations(annotations)
+
+	volumeGenerationAnnotationKey := VolumeGenerationAnnotationKey
+	if shard := r.contractConfigMapShardOf(contractConfigMap.GetName()); shard > 0 {
+		volumeGenerationAnnotationKey = VolumeGenerationAnnotationKeyForShard(shard)
+	}
+
+	ct := &contract.Contract{}
+	for _, selector := range []labels.Selector{r.ReceiverSelector(), r.dispatcherSelector()} {
+		pods, err := r.PodLister.Pods(r.DataPlaneNamespace).List(selector)
+		if err != nil {
+			continue
+		}
+		for _, pod := range pods {
+			v, err := strconv.ParseUint(pod.GetAnnotations()[volumeGenerationAnnotationKey] /* base */, 10 /* bitSize */, 64)
+			if err == nil && v > ct.Generation {
+				ct.Generation = v
+			}
+		}
+	}
+	return ct
+}
+
+// IsContractRebuilt returns true if the contract stored in the new config map has been rebuilt since the old config map.
+func IsContractRebuilt(oldConfigMap, newConfigMap *corev1.ConfigMap) bool {
+	return oldConfigMap.GetAnnotations()[ContractRebuildAnnotation] != newConfigMap.GetAnnotations()[ContractRebuildAnnotation]
+}
+
+// UpdateDataPlaneConfigMap writes the given contract to the given config map.
+//
+// When ContractUpdateCoalesceWindow is set, the changes made to the contract stored in the config map are coalesced
+// with the changes made by other reconcilers and it returns once they're written.
 func (r *Reconciler) UpdateDataPlaneConfigMap(ctx context.Context, contract *contract.Contract, configMap *corev1.ConfigMap) error {
+	if r.ContractUpdateCoalesceWindow > 0 {
+		return r.coalesceDataPlaneConfigMapUpdate(ctx, contract, configMap)
+	}
+	_, err := r.writeDataPlaneConfigMap(ctx, contract, configMap)
+	return err
+}
+
+func (r *Reconciler) writeDataPlaneConfigMap(ctx context.Context, contract *contract.Contract, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
 
 	var data []byte
 	var err error
@@ -241,10 +378,10 @@ func (r *Reconciler) UpdateDataPlaneConfigMap(ctx context.Context, contract *con
 	case Json:
 		data, err = protojson.Marshal(contract)
 	default:
-		return fmt.Errorf("unknown contract format %s", r.ContractConfigMapFormat)
+		return nil, fmt.Errorf("unknown contract format %s", r.ContractConfigMapFormat)
 	}
 	if err != nil {
-		return fmt.Errorf("failed to marshal contract: %w", err)
+		return nil, fmt.Errorf("failed to marshal contract: %w", err)
 	}
 
 	// Update config map data.
@@ -253,32 +390,66 @@ func (r *Reconciler) UpdateDataPlaneConfigMap(ctx context.Context, contract *con
 	}
 	configMap.BinaryData[ConfigMapDataKey] = data
 
-	_, err = r.KubeClient.CoreV1().ConfigMaps(configMap.Namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	annotations := configMap.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[ContractChecksumAnnotation] = ContractChecksum(data)
+	configMap.SetAnnotations(annotations)
+
+	updated, err := r.KubeClient.CoreV1().ConfigMaps(configMap.Namespace).Update(ctx, configMap, metav1.UpdateOptions{})
 	if err != nil {
 		// Return the same error, so that we can handle conflicting updates.
-		return err
+		return nil, err
 	}
 
-	return nil
+	if err := r.recordContractUpdate(ctx, contract, configMap); err != nil {
+		logging.FromContext(ctx).Warnw("Failed to record contract update metrics", zap.Error(err))
+	}
+
+	return updated, nil
 }
 
 func (r *Reconciler) UpdateDispatcherPodsAnnotation(ctx context.Context, logger *zap.Logger, volumeGeneration uint64) error {
-	pods, errors := r.PodLister.Pods(r.DataPlaneNamespace).List(r.dispatcherSelector())
+	selector := r.dispatcherSelector()
+	pods, errors := r.PodLister.Pods(r.DataPlaneNamespace).List(selector)
 	if errors != nil {
 		return fmt.Errorf("failed to list dispatcher pods in namespace %s: %w", r.DataPlaneNamespace, errors)
 	}
+	logPodsSelected(logger, "dispatcher", selector, r.DispatcherPodSelector != nil, pods)
 	return r.UpdatePodsAnnotation(ctx, logger, "dispatcher", volumeGeneration, pods)
 }
 
 func (r *Reconciler) UpdateReceiverPodsAnnotation(ctx context.Context, logger *zap.Logger, volumeGeneration uint64) error {
-	pods, errors := r.PodLister.Pods(r.DataPlaneNamespace).List(r.ReceiverSelector())
+	selector := r.ReceiverSelector()
+	pods, errors := r.PodLister.Pods(r.DataPlaneNamespace).List(selector)
 	if errors != nil {
 		return fmt.Errorf("failed to list receiver pods in namespace %s: %w", r.DataPlaneNamespace, errors)
 	}
+	logPodsSelected(logger, "receiver", selector, r.ReceiverPodSelector != nil, pods)
 	return r.UpdatePodsAnnotation(ctx, logger, "receiver", volumeGeneration, pods)
 }
 
+// logPodsSelected logs the number of data plane pods matched by the given selector, a configured selector
+// matching no pods is likely a mis-selection leaving the intended pods with a stale contract, so it's logged as a
+// warning.
+func logPodsSelected(logger *zap.Logger, component string, selector labels.Selector, configured bool, pods []*corev1.Pod) {
+	fields := []zap.Field{
+		zap.String("selector", selector.String()),
+		zap.Int("pods", len(pods)),
+	}
+	if configured && len(pods) == 0 {
+		logger.Warn("No "+component+" pods matched the configured selector", fields...)
+		return
+	}
+	logger.Debug("Selected "+component+" pods", fields...)
+}
+
 func (r *Reconciler) UpdatePodsAnnotation(ctx context.Context, logger *zap.Logger, component string, volumeGeneration uint64, pods []*corev1.Pod) error {
+	return r.updatePodsAnnotation(ctx, logger, component, VolumeGenerationAnnotationKey, volumeGeneration, pods)
+}
+
+func (r *Reconciler) updatePodsAnnotation(ctx context.Context, logger *zap.Logger, component string, annotationKey string, volumeGeneration uint64, pods []*corev1.Pod) error {
 
 	var errors error
 
@@ -287,6 +458,7 @@ func (r *Reconciler) UpdatePodsAnnotation(ctx context.Context, logger *zap.Logge
 		logger.Debug(
 			"Update "+component+" pod annotation",
 			zap.String("pod", fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)),
+			zap.String("annotation", annotationKey),
 			zap.Uint64("volumeGeneration", volumeGeneration),
 		)
 
@@ -299,7 +471,7 @@ func (r *Reconciler) UpdatePodsAnnotation(ctx context.Context, logger *zap.Logge
 		}
 
 		// Check whether pod's annotation is the expected one.
-		if v, ok := annotations[VolumeGenerationAnnotationKey]; ok {
+		if v, ok := annotations[annotationKey]; ok {
 			v, err := strconv.ParseUint(v /* base */, 10 /* bitSize */, 64)
 			if err == nil && v == volumeGeneration {
 				// Volume generation already matches the expected volume generation number.
@@ -307,7 +479,7 @@ func (r *Reconciler) UpdatePodsAnnotation(ctx context.Context, logger *zap.Logge
 			}
 		}
 
-		annotations[VolumeGenerationAnnotationKey] = fmt.Sprint(volumeGeneration)
+		annotations[annotationKey] = fmt.Sprint(volumeGeneration)
 		pod.SetAnnotations(annotations)
 
 		if _, err := r.KubeClient.CoreV1().Pods(pod.Namespace).Update(ctx, pod, metav1.UpdateOptions{}); err != nil {
@@ -319,13 +491,54 @@ func (r *Reconciler) UpdatePodsAnnotation(ctx context.Context, logger *zap.Logge
 }
 
 func (r *Reconciler) ReceiverSelector() labels.Selector {
+	if r.ReceiverPodSelector != nil {
+		return r.ReceiverPodSelector
+	}
 	return labels.SelectorFromSet(map[string]string{"app": r.ReceiverLabel})
 }
 
 func (r *Reconciler) dispatcherSelector() labels.Selector {
+	if r.DispatcherPodSelector != nil {
+		return r.DispatcherPodSelector
+	}
 	return labels.SelectorFromSet(map[string]string{"app": r.DispatcherLabel})
 }
 
+// ParseDataPlanePodSelectors parses the receiver and dispatcher pod selectors of the given env, selectors that
+// aren't configured are nil.
+func ParseDataPlanePodSelectors(env config.Env) (receiver labels.Selector, dispatcher labels.Selector, err error) {
+	receiver, err = parsePodSelector(env.ReceiverPodSelector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid receiver pod selector: %w", err)
+	}
+	dispatcher, err = parsePodSelector(env.DispatcherPodSelector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid dispatcher pod selector: %w", err)
+	}
+	return receiver, dispatcher, nil
+}
+
+// ValidateDataPlanePodSelectors validates the receiver and dispatcher pod selectors, when configured.
+func ValidateDataPlanePodSelectors(env config.Env) error {
+	_, _, err := ParseDataPlanePodSelectors(env)
+	return err
+}
+
+func parsePodSelector(selector string) (labels.Selector, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+	s, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("%q: %w", selector, err)
+	}
+	if s.Empty() {
+		// An empty selector would select every pod of the data plane namespace.
+		return nil, fmt.Errorf("%q: empty selector", selector)
+	}
+	return s, nil
+}
+
 func (r *Reconciler) SecretProviderFunc() security.SecretProviderFunc {
 	return security.DefaultSecretProviderFunc(r.SecretLister, r.KubeClient)
 }
@@ -358,6 +571,62 @@ func (r *Reconciler) TrackConfigMap(cm *corev1.ConfigMap, parent metav1.Object)
 	return r.Tracker.TrackReference(ref, parent)
 }
 
+// ConfigMapContentHash returns the hex encoded sha256 hash of the data and binary data of the given config map.
+//
+// Metadata, like labels and annotations, isn't part of the hash since reconcilers only consume the config map content.
+func ConfigMapContentHash(cm *corev1.ConfigMap) string {
+	h := sha256.New()
+	for _, k := range sets.StringKeySet(cm.Data).List() {
+		fmt.Fprintf(h, "%d:%s%d:%s", len(k), k, len(cm.Data[k]), cm.Data[k])
+	}
+	h.Write([]byte{0})
+	for _, k := range sets.StringKeySet(cm.BinaryData).List() {
+		fmt.Fprintf(h, "%d:%s%d:", len(k), k, len(cm.BinaryData[k]))
+		h.Write(cm.BinaryData[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// OnConfigMapContentChanged returns an event handler calling the given handler on config map additions and deletions,
+// and on config map updates only when the content hash of the config map changed, so that tracked config maps don't
+// trigger reconciles when only their metadata changed.
+func OnConfigMapContentChanged(handler func(obj interface{})) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: handler,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldCm, ok := oldObj.(*corev1.ConfigMap)
+			if !ok {
+				handler(newObj)
+				return
+			}
+			newCm, ok := newObj.(*corev1.ConfigMap)
+			if !ok || ConfigMapContentHash(oldCm) != ConfigMapContentHash(newCm) {
+				handler(newObj)
+			}
+		},
+		DeleteFunc: handler,
+	}
+}
+
+// RetryOnConflict runs fn until it doesn't return a conflict error, using the conflict retry backoff of the given env.
+//
+// When the conflict retry budget of the env is configured and it's exhausted, it returns a requeue error rather than
+// the conflict error, so that the worker isn't blocked and the work queue stays healthy under contention.
+func RetryOnConflict(env *config.Env, fn func() error) error {
+	var deadline time.Time
+	if env.ConflictRetryMaxDuration > 0 {
+		deadline = time.Now().Add(env.ConflictRetryMaxDuration)
+	}
+
+	err := retry.OnError(env.ConflictRetryBackoff(), func(err error) bool {
+		return apierrors.IsConflict(err) && (deadline.IsZero() || time.Now().Before(deadline))
+	}, fn)
+	if apierrors.IsConflict(err) && env.HasConflictRetryBudget() {
+		return controller.NewRequeueAfter(ConflictRetryRequeueDelay)
+	}
+	return err
+}
+
 func (r *Reconciler) OnDeleteObserver(obj interface{}) {
 	if r.Tracker != nil {
 		r.Tracker.OnDeletedObserver(obj)
@@ -18,6 +18,8 @@ package base_test
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -25,19 +27,26 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
 	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/fake"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	reconcilertesting "knative.dev/pkg/reconciler/testing"
 	"knative.dev/pkg/tracker"
 
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
 )
@@ -179,6 +188,97 @@ func TestUpdateDataPlaneConfigMap(t *testing.T) {
 
 	err = r.UpdateDataPlaneConfigMap(ctx, ct, cm)
 	require.Nil(t, err)
+
+	got, err := r.GetDataPlaneConfigMapData(logging.FromContext(ctx).Desugar(), cm)
+	require.Nil(t, err)
+	require.Len(t, got.Resources, 1)
+	require.Equal(t, base.ContractChecksum(cm.BinaryData[base.ConfigMapDataKey]), cm.Annotations[base.ContractChecksumAnnotation])
+}
+
+func TestUpdateDataPlaneConfigMapCoalesced(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "name",
+		},
+		BinaryData: map[string][]byte{base.ConfigMapDataKey: []byte("")},
+	}
+	_, err := kubeclient.Get(ctx).CoreV1().ConfigMaps(cm.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+	require.Nil(t, err)
+
+	r := &base.Reconciler{
+		KubeClient:              kubeclient.Get(ctx),
+		ContractConfigMapFormat: base.Json,
+	}
+	err = r.UpdateDataPlaneConfigMap(ctx, &contract.Contract{
+		Generation: 1,
+		Resources:  []*contract.Resource{{Uid: "1", Egresses: []*contract.Egress{{Uid: "a"}}}},
+	}, cm)
+	require.Nil(t, err)
+
+	r.ContractUpdateCoalesceWindow = 100 * time.Millisecond
+	client := kubeclient.Get(ctx).(*fakekubeclient.Clientset)
+	client.ClearActions()
+
+	updates := []func(ct *contract.Contract){
+		func(ct *contract.Contract) {
+			ct.Resources[0].Egresses = append(ct.Resources[0].Egresses, &contract.Egress{Uid: "b"})
+		},
+		func(ct *contract.Contract) {
+			ct.Resources = append(ct.Resources, &contract.Resource{Uid: "2"})
+		},
+		func(ct *contract.Contract) {
+			ct.Resources[0].Egresses = ct.Resources[0].Egresses[1:]
+		},
+	}
+
+	logger := logging.FromContext(ctx).Desugar()
+	contracts := make([]*contract.Contract, len(updates))
+	errs := make([]error, len(updates))
+	var wg sync.WaitGroup
+	for i, update := range updates {
+		configMap := cm.DeepCopy()
+		ct, err := r.GetDataPlaneConfigMapData(logger, configMap)
+		require.Nil(t, err)
+		update(ct)
+		ct.IncrementGeneration()
+		contracts[i] = ct
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = r.UpdateDataPlaneConfigMap(ctx, contracts[i], configMap)
+		}(i)
+	}
+	wg.Wait()
+
+	want := &contract.Contract{
+		Generation: 2,
+		Resources: []*contract.Resource{
+			{Uid: "1", Egresses: []*contract.Egress{{Uid: "b"}}},
+			{Uid: "2"},
+		},
+	}
+	for i := range updates {
+		require.Nil(t, errs[i])
+		require.True(t, proto.Equal(want, contracts[i]), "contract %d: %v", i, contracts[i])
+	}
+
+	got, err := client.CoreV1().ConfigMaps(cm.Namespace).Get(ctx, cm.Name, metav1.GetOptions{})
+	require.Nil(t, err)
+	ct, err := r.GetDataPlaneConfigMapData(logger, got)
+	require.Nil(t, err)
+	require.True(t, proto.Equal(want, ct), "stored contract: %v", ct)
+
+	updateActions := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "update" {
+			updateActions++
+		}
+	}
+	require.Equal(t, 1, updateActions)
 }
 
 func TestGetDataPlaneConfigMapDataCorrupted(t *testing.T) {
@@ -200,6 +300,71 @@ func TestGetDataPlaneConfigMapDataCorrupted(t *testing.T) {
 	require.Equal(t, uint64(0), got.Generation)
 }
 
+func TestGetDataPlaneConfigMapDataChecksumMismatch(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	r := &base.Reconciler{
+		KubeClient:              kubeclient.Get(ctx),
+		ContractConfigMapFormat: base.Json,
+	}
+
+	b, err := protojson.Marshal(&contract.Contract{Generation: 1})
+	require.Nil(t, err)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{base.ContractChecksumAnnotation: base.ContractChecksum([]byte("previous"))},
+		},
+		BinaryData: map[string][]byte{
+			base.ConfigMapDataKey: b,
+		},
+	}
+
+	got, err := r.GetDataPlaneConfigMapData(logging.FromContext(ctx).Desugar(), cm)
+	require.True(t, base.IsContractCorrupted(err), err)
+	require.Nil(t, got)
+
+	cm.Annotations[base.ContractChecksumAnnotation] = base.ContractChecksum(b)
+
+	got, err = r.GetDataPlaneConfigMapData(logging.FromContext(ctx).Desugar(), cm)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), got.Generation)
+}
+
+func TestNewRebuiltContract(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	store := podinformer.Get(ctx).Informer().GetStore()
+	for _, pod := range []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "receiver", Namespace: "ns", Labels: map[string]string{"app": base.BrokerReceiverLabel}, Annotations: map[string]string{base.VolumeGenerationAnnotationKey: "4"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dispatcher", Namespace: "ns", Labels: map[string]string{"app": base.BrokerDispatcherLabel}, Annotations: map[string]string{base.VolumeGenerationAnnotationKey: "7"}}},
+	} {
+		require.Nil(t, store.Add(pod))
+	}
+
+	r := &base.Reconciler{
+		PodLister:          podinformer.Get(ctx).Lister(),
+		DataPlaneNamespace: "ns",
+		ReceiverLabel:      base.BrokerReceiverLabel,
+		DispatcherLabel:    base.BrokerDispatcherLabel,
+	}
+
+	oldConfigMap := &corev1.ConfigMap{}
+	newConfigMap := oldConfigMap.DeepCopy()
+
+	ct := r.NewRebuiltContract(newConfigMap)
+	require.Equal(t, uint64(7), ct.Generation)
+	require.Len(t, ct.Resources, 0)
+	require.Equal(t, "1", newConfigMap.Annotations[base.ContractRebuildAnnotation])
+	require.True(t, base.IsContractRebuilt(oldConfigMap, newConfigMap))
+
+	oldConfigMap = newConfigMap.DeepCopy()
+	r.NewRebuiltContract(newConfigMap)
+	require.Equal(t, "2", newConfigMap.Annotations[base.ContractRebuildAnnotation])
+	require.True(t, base.IsContractRebuilt(oldConfigMap, newConfigMap))
+	require.False(t, base.IsContractRebuilt(newConfigMap, newConfigMap.DeepCopy()))
+}
+
 func TestUpdateReceiverPodAnnotation(t *testing.T) {
 	ctx, _ := reconcilertesting.SetupFakeContext(t)
 
@@ -232,6 +397,103 @@ func TestUpdateDispatcherPodAnnotation(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestUpdateReceiverPodAnnotationWithPodSelector(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	for _, pool := range []string{"a", "b"} {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod-" + pool,
+				Namespace: "ns",
+				Labels:    map[string]string{"app": base.BrokerReceiverLabel, "pool": pool},
+			},
+		}
+		require.Nil(t, podinformer.Get(ctx).Informer().GetStore().Add(pod))
+		_, err := kubeclient.Get(ctx).CoreV1().Pods("ns").Create(ctx, pod, metav1.CreateOptions{})
+		require.Nil(t, err)
+	}
+
+	receiverPodSelector, _, err := base.ParseDataPlanePodSelectors(config.Env{ReceiverPodSelector: "app=" + base.BrokerReceiverLabel + ",pool=a"})
+	require.Nil(t, err)
+
+	r := &base.Reconciler{
+		PodLister:           podinformer.Get(ctx).Lister(),
+		KubeClient:          kubeclient.Get(ctx),
+		DataPlaneNamespace:  "ns",
+		ReceiverLabel:       base.BrokerReceiverLabel,
+		ReceiverPodSelector: receiverPodSelector,
+	}
+
+	err = r.UpdateReceiverPodsAnnotation(ctx, logging.FromContext(ctx).Desugar(), 1)
+	require.Nil(t, err)
+
+	podA, err := kubeclient.Get(ctx).CoreV1().Pods("ns").Get(ctx, "pod-a", metav1.GetOptions{})
+	require.Nil(t, err)
+	require.Equal(t, "1", podA.Annotations[base.VolumeGenerationAnnotationKey])
+
+	podB, err := kubeclient.Get(ctx).CoreV1().Pods("ns").Get(ctx, "pod-b", metav1.GetOptions{})
+	require.Nil(t, err)
+	require.NotContains(t, podB.Annotations, base.VolumeGenerationAnnotationKey)
+}
+
+func TestParseDataPlanePodSelectors(t *testing.T) {
+	tests := []struct {
+		name           string
+		env            config.Env
+		wantReceiver   string
+		wantDispatcher string
+		wantErr        bool
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "configured",
+			env: config.Env{
+				ReceiverPodSelector:   "app=kafka-broker-receiver,pool in (a, b)",
+				DispatcherPodSelector: "app=kafka-broker-dispatcher,pool=a",
+			},
+			wantReceiver:   "app=kafka-broker-receiver,pool in (a,b)",
+			wantDispatcher: "app=kafka-broker-dispatcher,pool=a",
+		},
+		{
+			name:    "invalid receiver pod selector",
+			env:     config.Env{ReceiverPodSelector: "app in (a"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid dispatcher pod selector",
+			env:     config.Env{DispatcherPodSelector: "app in a"},
+			wantErr: true,
+		},
+		{
+			name:    "selector selecting every pod",
+			env:     config.Env{DispatcherPodSelector: ","},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver, dispatcher, err := base.ParseDataPlanePodSelectors(tt.env)
+			require.Equal(t, tt.wantErr, err != nil, err)
+			require.Equal(t, tt.wantErr, base.ValidateDataPlanePodSelectors(tt.env) != nil)
+			if tt.wantErr {
+				return
+			}
+			if tt.wantReceiver == "" {
+				require.Nil(t, receiver)
+			} else {
+				require.Equal(t, tt.wantReceiver, receiver.String())
+			}
+			if tt.wantDispatcher == "" {
+				require.Nil(t, dispatcher)
+			} else {
+				require.Equal(t, tt.wantDispatcher, dispatcher.String())
+			}
+		})
+	}
+}
+
 func TestTrackConfigMap(t *testing.T) {
 
 	r := &base.Reconciler{
@@ -252,6 +514,109 @@ func TestTrackConfigMap(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestConfigMapContentHash(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "name"},
+		Data:       map[string]string{"default.topic.partitions": "10", "default.topic.replication.factor": "3"},
+	}
+	hash := base.ConfigMapContentHash(cm)
+
+	labeled := cm.DeepCopy()
+	labeled.Labels = map[string]string{"app": "kafka"}
+	assert.Equal(t, hash, base.ConfigMapContentHash(labeled))
+
+	changed := cm.DeepCopy()
+	changed.Data["default.topic.partitions"] = "20"
+	assert.NotEqual(t, hash, base.ConfigMapContentHash(changed))
+
+	binary := cm.DeepCopy()
+	binary.BinaryData = map[string][]byte{"default.topic.partitions": []byte("10")}
+	assert.NotEqual(t, hash, base.ConfigMapContentHash(binary))
+}
+
+func TestOnConfigMapContentChanged(t *testing.T) {
+	calls := 0
+	handler := base.OnConfigMapContentChanged(func(interface{}) { calls++ })
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "name"},
+		Data:       map[string]string{"bootstrap.servers": "kafka-1:9092"},
+	}
+
+	handler.OnAdd(cm)
+	require.Equal(t, 1, calls)
+
+	labeled := cm.DeepCopy()
+	labeled.Labels = map[string]string{"app": "kafka"}
+	handler.OnUpdate(cm, labeled)
+	require.Equal(t, 1, calls, "expected metadata only updates to be skipped")
+
+	changed := labeled.DeepCopy()
+	changed.Data["bootstrap.servers"] = "kafka-2:9092"
+	handler.OnUpdate(labeled, changed)
+	require.Equal(t, 2, calls)
+
+	handler.OnDelete(changed)
+	require.Equal(t, 3, calls)
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "brokers"}, "name", errors.New("conflict"))
+
+	tests := []struct {
+		name         string
+		env          *config.Env
+		err          error
+		wantAttempts int
+		wantRequeue  bool
+	}{
+		{
+			name:         "no budget",
+			env:          &config.Env{},
+			err:          conflict,
+			wantAttempts: retry.DefaultBackoff.Steps,
+		},
+		{
+			name:         "max attempts budget",
+			env:          &config.Env{ConflictRetryMaxAttempts: 2},
+			err:          conflict,
+			wantAttempts: 2,
+			wantRequeue:  true,
+		},
+		{
+			name:         "max duration budget",
+			env:          &config.Env{ConflictRetryMaxDuration: time.Nanosecond},
+			err:          conflict,
+			wantAttempts: 1,
+			wantRequeue:  true,
+		},
+		{
+			name:         "not a conflict",
+			env:          &config.Env{ConflictRetryMaxAttempts: 2},
+			err:          errors.New("failed"),
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := base.RetryOnConflict(tt.env, func() error {
+				attempts++
+				return tt.err
+			})
+
+			require.Equal(t, tt.wantAttempts, attempts)
+			requeue, delay := controller.IsRequeueKey(err)
+			require.Equal(t, tt.wantRequeue, requeue)
+			if tt.wantRequeue {
+				require.Equal(t, base.ConflictRetryRequeueDelay, delay)
+			} else {
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
 func TestTrackSecret(t *testing.T) {
 
 	r := &base.Reconciler{
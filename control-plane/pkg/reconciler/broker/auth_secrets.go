@@ -0,0 +1,147 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/security"
+)
+
+// reconcileAuthSecrets resolves the auth secrets with the given names referenced by the security.AuthSecretNamesKey of
+// the broker config, like the single auth secret, each of them is tracked. It returns the auth context merging their
+// credentials and the secrets, which get the auth secret finalizer from the caller.
+func (r *Reconciler) reconcileAuthSecrets(ctx context.Context, logger *zap.Logger, broker *eventing.Broker, namespace string, names []string, statusConditionManager base.StatusConditionManager) (*security.NetSpecAuthContext, []*corev1.Secret, error) {
+	secrets := make([]*corev1.Secret, 0, len(names))
+	for _, name := range names {
+		secret, err := r.SecretProviderFunc()(ctx, namespace, name)
+		if apierrors.IsNotFound(err) {
+			return nil, nil, r.authSecretNotFound(broker, namespace, name, statusConditionManager)
+		}
+		if err != nil {
+			return nil, nil, statusConditionManager.FailedToGetBrokerAuthSecret(err)
+		}
+		if secret.DeletionTimestamp != nil && !containsFinalizerSecret(secret, r.finalizerSecret(broker)) {
+			// Our finalizer has been removed externally, the secret is going away.
+			return nil, nil, r.authSecretNotFound(broker, namespace, name, statusConditionManager)
+		}
+		secrets = append(secrets, secret)
+	}
+
+	authContext, err := security.ResolveAuthContextFromSecrets(secrets)
+	if err != nil {
+		return nil, nil, statusConditionManager.FailedToGetBrokerAuthSecret(err)
+	}
+
+	for _, secret := range secrets {
+		logger.Debug("Secret reference",
+			zap.String("name", secret.Name),
+			zap.String("namespace", secret.Namespace),
+		)
+		if err := r.TrackSecret(secret, broker); err != nil {
+			return nil, nil, fmt.Errorf("failed to track secret: %w", err)
+		}
+	}
+	return authContext, secrets, nil
+}
+
+// existingAuthSecrets returns the auth secrets with the given names that still exist, so that the finalizer of the
+// broker is removed from them even when some of them are gone.
+func (r *Reconciler) existingAuthSecrets(ctx context.Context, namespace string, names []string) ([]*corev1.Secret, error) {
+	secrets := make([]*corev1.Secret, 0, len(names))
+	for _, name := range names {
+		secret, err := r.SecretProviderFunc()(ctx, namespace, name)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return secrets, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+// authSecretsFromReferences returns the auth secrets with the given references and the secret used to connect to the
+// Kafka cluster with them: the auth secret itself when there is a single one, the virtual secret merging them
+// otherwise.
+func (r *Reconciler) authSecretsFromReferences(ctx context.Context, refs []types.NamespacedName) ([]*corev1.Secret, *corev1.Secret, error) {
+	secrets := make([]*corev1.Secret, 0, len(refs))
+	for _, ref := range refs {
+		secret, err := r.SecretProviderFunc()(ctx, ref.Namespace, ref.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get secret %s: %w", ref, err)
+		}
+		secrets = append(secrets, secret)
+	}
+	secret, err := connectionSecret(secrets)
+	return secrets, secret, err
+}
+
+// connectionSecret returns the secret used to connect to the Kafka cluster with the given auth secrets, if any.
+func connectionSecret(secrets []*corev1.Secret) (*corev1.Secret, error) {
+	switch len(secrets) {
+	case 0:
+		return nil, nil
+	case 1:
+		return secrets[0], nil
+	}
+	authContext, err := security.ResolveAuthContextFromSecrets(secrets)
+	if err != nil {
+		return nil, err
+	}
+	return authContext.VirtualSecret, nil
+}
+
+// authSecretReferences returns the references of the auth secrets of the given broker recorded in its status
+// annotations.
+func authSecretReferences(broker *eventing.Broker) []types.NamespacedName {
+	names, _ := security.AuthSecretNames(broker.Status.Annotations)
+	if name := broker.Status.Annotations[security.AuthSecretNameKey]; len(names) == 0 && name != "" {
+		names = []string{name}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	namespace := kafka.BrokerConfigNamespace(broker)
+	refs := make([]types.NamespacedName, 0, len(names))
+	for _, name := range names {
+		refs = append(refs, types.NamespacedName{Namespace: namespace, Name: name})
+	}
+	return refs
+}
+
+// setMultiAuthSecret sets the auth of the given broker resource to the auth secrets of the given auth context, if
+// any.
+func setMultiAuthSecret(resource *contract.Resource, authContext *security.NetSpecAuthContext) {
+	if authContext == nil {
+		return
+	}
+	resource.Auth = &contract.Resource_MultiAuthSecret{
+		MultiAuthSecret: authContext.MultiSecretReference,
+	}
+}
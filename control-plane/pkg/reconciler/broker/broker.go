@@ -17,20 +17,35 @@
 package broker
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"math"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
+	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	corelisters "k8s.io/client-go/listers/core/v1"
-	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/tools/record"
+	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/eventing/pkg/apis/feature"
+	brokerreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1/broker"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/network"
@@ -38,6 +53,8 @@ import (
 	"knative.dev/pkg/resolver"
 
 	apisconfig "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/config"
+	kafkaeventing "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/eventing/v1alpha1"
+	kafkasource "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
 	coreconfig "knative.dev/eventing-kafka-broker/control-plane/pkg/core/config"
@@ -54,6 +71,117 @@ const (
 	// ExternalTopicAnnotation for using external kafka topic for the broker
 	ExternalTopicAnnotation = "kafka.eventing.knative.dev/external.topic"
 
+	// DryRunStatusAnnotation is the status annotation reporting the changes that the reconciler would apply when
+	// running in dry run mode
+	DryRunStatusAnnotation = "dry.run.actions"
+
+	// NamespaceDefaultsConfigMapName is the name of the ConfigMap holding the defaults of the brokers in its
+	// namespace, its bootstrap.servers key is used for brokers whose config doesn't specify bootstrap servers
+	NamespaceDefaultsConfigMapName = "kafka-broker-namespace-defaults"
+
+	// ActiveBootstrapServersStatusAnnotation is the status annotation recording the bootstrap servers of the Kafka
+	// cluster in use when failover bootstrap servers are configured
+	ActiveBootstrapServersStatusAnnotation = "active.bootstrap.servers"
+
+	// KafkaClusterIDStatusAnnotation is the status annotation recording the id of the Kafka cluster the broker is
+	// connected to, it's set when DescribeKafkaCluster is set
+	KafkaClusterIDStatusAnnotation = "kafka.cluster.id"
+
+	// DeliveryRetryStatusAnnotation, DeliveryBackoffPolicyStatusAnnotation and DeliveryBackoffDelayStatusAnnotation
+	// are the status annotations recording the retry policy of the broker egresses programmed into the data plane
+	// contract, defaults included, they're set when the broker delivery spec sets retries
+	DeliveryRetryStatusAnnotation         = "delivery.retry"
+	DeliveryBackoffPolicyStatusAnnotation = "delivery.backoff.policy"
+	DeliveryBackoffDelayStatusAnnotation  = "delivery.backoff.delay"
+
+	// DefaultBackoffDelayStatusAnnotation is the status annotation recording the default backoff delay applied to the
+	// broker egresses, it's set when the broker delivery spec sets retries without a backoff delay
+	DefaultBackoffDelayStatusAnnotation = "delivery.backoff.delay.default"
+
+	// TopicFinalizedStatusAnnotation is the status annotation recording that the topic of a deleted broker has been
+	// finalized while the auth secret finalizer removal failed, so that the next finalization only removes it.
+	TopicFinalizedStatusAnnotation = "topic.finalized"
+
+	// TopicFinalizationPendingStatusAnnotation is the status annotation recording that a deleted broker has been
+	// removed from the contract while its topic hasn't been finalized yet, so that later finalizations still finalize it.
+	TopicFinalizationPendingStatusAnnotation = "topic.finalization.pending"
+
+	// TopicDeletePolicyAnnotation for choosing whether the broker topic is deleted or retained when the broker is
+	// deleted, supported values are TopicDeletePolicyDelete (default) and TopicDeletePolicyRetain
+	TopicDeletePolicyAnnotation = "kafka.eventing.knative.dev/topic.delete.policy"
+	TopicDeletePolicyDelete     = "Delete"
+	TopicDeletePolicyRetain     = "Retain"
+
+	// SharedTopicAnnotation designates the broker as sharing the named topic with the other brokers having the same
+	// annotation value, brokers sharing a topic are differentiated by their ingress path and the shared topic is
+	// never deleted when a broker is deleted
+	SharedTopicAnnotation = "kafka.eventing.knative.dev/shared.topic"
+
+	// TopicRecreateAnnotation for deleting and recreating the broker topic once, it's a nonce, like a timestamp,
+	// and every new value recreates the topic, external and shared topics are never recreated
+	TopicRecreateAnnotation = "kafka.eventing.knative.dev/topic.recreate"
+
+	// TopicRecreateStatusAnnotation is the status annotation recording the last processed TopicRecreateAnnotation
+	// value
+	TopicRecreateStatusAnnotation = "topic.recreate"
+
+	// TopicRetentionMsAnnotation for overriding the retention.ms config of the broker topic
+	TopicRetentionMsAnnotation = "kafka.eventing.knative.dev/topic.retention.ms"
+
+	// TopicTargetThroughputAnnotation for setting the expected throughput, in events per second, of the broker, the
+	// number of partitions of the broker topic is computed from it and the configured partition capacity
+	TopicTargetThroughputAnnotation = "kafka.eventing.knative.dev/topic.target.throughput"
+
+	// TopicPartitionsStatusAnnotation is the status annotation recording the number of partitions computed from the
+	// TopicTargetThroughputAnnotation, and how it has been computed
+	TopicPartitionsStatusAnnotation = "topic.partitions"
+
+	// DefaultBackoffDelayAnnotation for overriding the default backoff delay, as an ISO-8601 duration, used when the
+	// broker delivery spec doesn't set a backoff delay
+	DefaultBackoffDelayAnnotation = "kafka.eventing.knative.dev/default.backoff.delay"
+
+	// DataPlaneAvailabilityGateAnnotation for overriding whether the broker reconciliation fails, hard, or continues,
+	// soft, while the receiver isn't running
+	DataPlaneAvailabilityGateAnnotation = "kafka.eventing.knative.dev/data.plane.availability.gate"
+
+	// DataPlaneAvailabilityGateHard and DataPlaneAvailabilityGateSoft are the supported values of the
+	// DataPlaneAvailabilityGateAnnotation.
+	DataPlaneAvailabilityGateHard = "hard"
+	DataPlaneAvailabilityGateSoft = "soft"
+
+	// DeliveryOrderAnnotation for setting the delivery order, ordered or unordered, of the broker egresses
+	DeliveryOrderAnnotation = "kafka.eventing.knative.dev/delivery.order"
+
+	// DeadLetterSinkContentModeAnnotation for setting the content mode, binary or structured, used to send events to
+	// the dead letter sink of the broker and of its triggers
+	DeadLetterSinkContentModeAnnotation = "kafka.eventing.knative.dev/dead.letter.sink.content.mode"
+
+	// IngressContentModeAnnotation for setting the content mode, binary or structured, of the contract ingress of the
+	// broker, so that the receiver uses it for the events of the broker instead of its default
+	IngressContentModeAnnotation = "kafka.eventing.knative.dev/ingress.content.mode"
+
+	// ReconcilePausedAnnotation for pausing the reconciliation of the broker, when it's true the broker topic and
+	// contract resource are left untouched, so the data plane keeps serving the broker, until it's removed
+	ReconcilePausedAnnotation = "kafka.eventing.knative.dev/reconcile.paused"
+
+	// DeliveryBackoffJitterAnnotation for setting the jitter applied to the retry backoff delays of the broker and of
+	// its triggers, supported values are BackoffJitterNone (default), BackoffJitterFull and BackoffJitterEqual
+	DeliveryBackoffJitterAnnotation = "kafka.eventing.knative.dev/delivery.backoff.jitter"
+	BackoffJitterNone               = "none"
+	BackoffJitterFull               = "full"
+	BackoffJitterEqual              = "equal"
+
+	// ProducerAcksAnnotation for setting the acks required by the ingress producer of the broker, supported values are
+	// ProducerAcksNone, ProducerAcksLeader and ProducerAcksAll, the data plane default is used when it's not set
+	ProducerAcksAnnotation = "kafka.eventing.knative.dev/producer.acks"
+	ProducerAcksNone       = "0"
+	ProducerAcksLeader     = "1"
+	ProducerAcksAll        = "all"
+
+	// IngressPathAnnotation for setting the path the broker is served at by the ingress, it defaults to
+	// /<namespace>/<name> and it can't collide with the path of other brokers
+	IngressPathAnnotation = "kafka.eventing.knative.dev/ingress.path"
+
 	// ConsumerConfigKey is the key for Kafka Broker consumer configurations
 	ConsumerConfigKey = "config-kafka-broker-consumer.properties"
 
@@ -64,6 +192,30 @@ const (
 	caCertsSecretKey = "ca.crt"
 )
 
+const (
+	// probeThresholdRequeueDelay is the delay before probing again a broker whose probes haven't reached the ready
+	// threshold yet.
+	probeThresholdRequeueDelay = time.Second
+
+	// topicRecreateRequeueDelay is the delay before creating again a recreated topic whose deletion hasn't
+	// completed yet.
+	topicRecreateRequeueDelay = time.Second
+
+	// topicDeletionConfirmationPollInterval is the interval between the checks of the deletion of a finalized broker
+	// topic, and topicDeletionConfirmationRequeueDelay is the delay before finalizing again a broker whose topic
+	// deletion hasn't been confirmed within the TopicDeletionConfirmationTimeout.
+	topicDeletionConfirmationPollInterval = 500 * time.Millisecond
+	topicDeletionConfirmationRequeueDelay = 5 * time.Second
+
+	// probeNotReadyRequeueInitialDelay and probeNotReadyRequeueMaxDelay bound the exponential backoff of the requeues
+	// of brokers whose probes aren't ready, in case the prober never notifies the status change.
+	probeNotReadyRequeueInitialDelay = 5 * time.Second
+	probeNotReadyRequeueMaxDelay     = 5 * time.Minute
+	// probeNotReadyRequeueJitter is the maximum fraction of the delay added to it, so that brokers probing the same
+	// ingress aren't requeued all at once, for example, after a restart.
+	probeNotReadyRequeueJitter = 0.5
+)
+
 type Reconciler struct {
 	*base.Reconciler
 	*config.Env
@@ -72,24 +224,106 @@ type Reconciler struct {
 
 	ConfigMapLister corelisters.ConfigMapLister
 
+	// ServiceLister is used to get the cluster IPs of the ingress service when IngressIPFamily is set.
+	ServiceLister corelisters.ServiceLister
+
 	// NewKafkaClusterAdminClient creates new sarama ClusterAdmin. It's convenient to add this as Reconciler field so that we can
 	// mock the function used during the reconciliation loop.
 	NewKafkaClusterAdminClient kafka.NewClusterAdminClientFunc
 
+	// NewKafkaClient creates new sarama Client, it's used to get the broker topic lag when TopicLagMetricsEnabled
+	// is set and when deleted brokers are drained.
+	NewKafkaClient kafka.NewClientFunc
+
+	// NewConsumerGroupLagProvider, when set, creates the lag providers of the broker triggers consumer groups instead of
+	// NewKafkaClient.
+	NewConsumerGroupLagProvider func(bootstrapServers []string, config *sarama.Config) (kafka.ConsumerGroupLagProvider, error)
+
+	// ClusterAdminPool, when set, is used in place of NewKafkaClusterAdminClient to reuse Kafka cluster admin
+	// clients across reconciliations.
+	ClusterAdminPool *kafka.ClusterAdminPool
+
+	// ClusterAdminCircuitBreaker, when set, short-circuits the creation of Kafka cluster admin clients for clusters
+	// that repeatedly failed. It's checked by the ClusterAdminPool, when set, so it must be the one of the pool.
+	ClusterAdminCircuitBreaker *kafka.CircuitBreaker
+
+	// ConnectivityTracker, when set, records the results of the attempts to create Kafka cluster admin clients, so
+	// that the controller reports the connectivity to the Kafka clusters.
+	ConnectivityTracker *kafka.ConnectivityTracker
+
+	// DescribeKafkaCluster, when set, is used to describe the Kafka cluster the cluster admin client is connected to,
+	// so that the cluster id is logged and recorded in the KafkaClusterIDStatusAnnotation.
+	DescribeKafkaCluster kafka.DescribeClusterFunc
+
+	// DialerFactory, when set, creates the dialer used by Kafka cluster admin clients to connect to the cluster, for
+	// example to reach it through a proxy or with a custom TLS dialer.
+	DialerFactory kafka.DialerFactoryFunc
+
+	// TopicMetadataCache, when set, caches the topic metadata described by Kafka cluster admin clients across
+	// reconciliations.
+	TopicMetadataCache *kafka.TopicMetadataCache
+
+	// DynamicClient is used to get the Strimzi resources referenced by brokers when StrimziIntegrationEnabled is set.
+	DynamicClient dynamic.Interface
+
+	// BrokerTopicTemplate, when set, is used in place of the brokers topic template feature flag to name broker
+	// topics.
+	BrokerTopicTemplate *template.Template
+
+	// ExternalTopicPolicy, when set, restricts the external topics that brokers may reference.
+	ExternalTopicPolicy *ExternalTopicPolicy
+
+	// NamespaceTopicPrefixes, when set, are prepended to the name of the topics of the brokers of their namespace.
+	NamespaceTopicPrefixes NamespaceTopicPrefixes
+
+	// CheckIngressReachability, when set, checks that the advertised broker address is reachable from the controller.
+	CheckIngressReachability IngressReachabilityCheckFunc
+
+	// ResourceMutator, when set, adjusts the contract resource of every broker, see WithResourceMutator.
+	ResourceMutator ResourceMutator
+
+	// BrokerLister is used to validate that brokers sharing a topic agree on the topic config.
+	BrokerLister eventinglisters.BrokerLister
+
+	// ResyncBrokers, when set, enqueues all brokers, it's used to add the brokers resources back to a rebuilt
+	// contract.
+	ResyncBrokers func()
+
+	// BootstrapServers are the controller-wide default bootstrap servers, they're used for brokers whose config
+	// doesn't specify bootstrap servers when the broker namespace doesn't have a default either.
 	BootstrapServers string
 
+	// StatusUpdateThrottle, when set, throttles the status updates of brokers.
+	StatusUpdateThrottle *StatusUpdateThrottle
+
+	// FastPath, when set, skips the topic reconciliation of brokers that haven't changed since their last full
+	// reconciliation.
+	FastPath *ReconcileFastPath
+
 	Prober            prober.NewProber
 	Counter           *counter.Counter
 	KafkaFeatureFlags *apisconfig.KafkaFeatureFlags
 }
 
 func (r *Reconciler) ReconcileKind(ctx context.Context, broker *eventing.Broker) reconciler.Event {
-	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		return r.reconcileKind(ctx, broker)
+	status := broker.Status.DeepCopy()
+	diagnostics := &brokerDiagnostics{}
+	err := base.RetryOnConflict(r.Env, func() error {
+		*diagnostics = brokerDiagnostics{}
+		return r.reconcileKind(ctx, broker, diagnostics)
 	})
+	if r.Env.DiagnosticsStatusAnnotationEnabled {
+		setDiagnosticsStatusAnnotation(ctx, broker, diagnostics, err)
+	} else {
+		delete(broker.Status.Annotations, DiagnosticsStatusAnnotation)
+	}
+	if trace := base.GetConditionTrace(ctx); trace != nil {
+		kafkalogging.CreateReconcileMethodLogger(ctx, broker).Debug("Condition transitions", zap.Stringer("transitions", trace))
+	}
+	return r.throttleStatusUpdate(ctx, broker, status, err)
 }
 
-func (r *Reconciler) reconcileKind(ctx context.Context, broker *eventing.Broker) reconciler.Event {
+func (r *Reconciler) reconcileKind(ctx context.Context, broker *eventing.Broker, diagnostics *brokerDiagnostics) reconciler.Event {
 	logger := kafkalogging.CreateReconcileMethodLogger(ctx, broker)
 
 	statusConditionManager := base.StatusConditionManager{
@@ -97,72 +331,223 @@ func (r *Reconciler) reconcileKind(ctx context.Context, broker *eventing.Broker)
 		SetAddress: broker.Status.SetAddress,
 		Env:        r.Env,
 		Recorder:   controller.GetEventRecorder(ctx),
+		Trace:      base.GetConditionTrace(ctx),
+	}
+
+	if !r.Env.DryRun {
+		// The report of a previous dry run is stale.
+		delete(broker.Status.Annotations, DryRunStatusAnnotation)
 	}
 
+	if broker.GetAnnotations()[ReconcilePausedAnnotation] == "true" {
+		logger.Debug("Reconciliation paused")
+		statusConditionManager.ReconcilePaused(ReconcilePausedAnnotation)
+		return nil
+	}
+	statusConditionManager.ReconcileResumed()
+
 	// Get contract config map. Do this in advance, otherwise
 	// the dataplane pods that need volume mounts to the contract configmap
 	// will get stuck and will never be ready.
-	contractConfigMap, err := r.GetOrCreateDataPlaneConfigMap(ctx)
+	shard := r.ContractConfigMapShard(broker)
+	contractConfigMap, err := r.GetOrCreateDataPlaneConfigMapShard(ctx, shard)
 	if err != nil {
 		return statusConditionManager.FailedToGetConfigMap(err)
 	}
 
 	logger.Debug("Got contract config map")
 
-	if !r.IsReceiverRunning() {
+	softDataPlaneAvailabilityGate, err := r.isDataPlaneAvailabilityGateSoft(broker)
+	if err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	// Dispatcher pods aren't required to be running since their updates are soft, see the volume generation
+	// annotation update of dispatcher pods below.
+	if r.IsReceiverRunning() {
+		statusConditionManager.DataPlaneAvailable()
+	} else if !softDataPlaneAvailabilityGate {
 		return statusConditionManager.DataPlaneNotAvailable()
+	} else {
+		// Keep the last known data plane availability to not flap the broker status while the receiver restarts.
+		logger.Info("Receiver isn't running, continuing since the data plane availability gate is soft")
 	}
-	statusConditionManager.DataPlaneAvailable()
 
+	phases := newReconcilePhaseTimer(ctx, logger)
+	defer phases.end()
+	defer func() {
+		diagnostics.phase = phases.last
+	}()
+
+	phases.begin(configReconcilePhase)
 	brokerConfig, err := r.brokerConfigMap(logger, broker)
 	if err != nil && !apierrors.IsNotFound(err) {
-		return statusConditionManager.FailedToResolveConfig(err)
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
 	}
+	brokerConfigRebuilt := apierrors.IsNotFound(err)
+
+	strimziConfig, err := r.strimziClusterConfig(ctx, broker)
+	if err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	brokerConfig = withStrimziBootstrapServers(brokerConfig, strimziConfig)
 
 	topicConfig, err := r.topicConfig(logger, broker, brokerConfig)
 	if err != nil {
-		return statusConditionManager.FailedToResolveConfig(err)
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	if brokerConfigRebuilt {
+		// The config has been rebuilt from the status annotations, the ConfigMap might have been deleted by mistake.
+		statusConditionManager.ConfigRebuilt(kafka.BrokerConfigNamespace(broker), broker.Spec.Config.Name)
+	} else {
+		statusConditionManager.ConfigPresent()
+	}
+	if err := topicConfigFromAnnotations(broker, topicConfig); err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	if err := r.topicPartitionsFromThroughput(broker, topicConfig); err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	if _, err := DefaultBackoffDelayMs(broker, r.DefaultBackoffDelayMs); err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	if _, err := DeliveryOrder(broker); err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	if _, err := DeadLetterSinkContentMode(broker); err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	if _, err := DeliveryBackoffJitter(broker); err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	if _, err := IngressContentMode(broker); err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	if _, err := IngressFilter(broker); err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	if _, err := ProducerAcks(broker); err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	authSecretNames, err := security.AuthSecretNames(brokerConfig.Data)
+	if err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	ingressPath, err := IngressPath(broker)
+	if err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
+	}
+	if err := r.validateIngressPathCollision(broker, ingressPath); err != nil {
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
 	}
 	statusConditionManager.ConfigResolved()
+	r.Counter.Del(configResolutionFailureCounterKey(broker))
 
-	if err := r.TrackConfigMap(brokerConfig, broker); err != nil {
+	if err := r.trackBrokerConfig(broker, brokerConfig); err != nil {
 		return fmt.Errorf("failed to track broker config: %w", err)
 	}
 
 	logger.Debug("config resolved", zap.Any("config", topicConfig))
 
-	secret, err := security.Secret(ctx, &security.MTConfigMapSecretLocator{ConfigMap: brokerConfig, UseNamespaceInConfigmap: false}, r.SecretProviderFunc())
+	phases.begin(secretReconcilePhase)
+	var secret *corev1.Secret
+	var authContext *security.NetSpecAuthContext
+	// authSecrets are the auth secrets getting the finalizer of the broker, once it's known that the broker isn't
+	// reconciled in dry run mode.
+	var authSecrets []*corev1.Secret
+	if strimziConfig != nil {
+		// The Strimzi secrets are owned by Strimzi, so they don't get the auth secret finalizer.
+		secret = strimziConfig.AuthContext.VirtualSecret
+		if err := r.trackStrimziSecrets(broker, strimziConfig); err != nil {
+			return fmt.Errorf("failed to track secret: %w", err)
+		}
+	} else if len(authSecretNames) > 0 {
+		authContext, authSecrets, err = r.reconcileAuthSecrets(ctx, logger, broker, brokerConfig.Namespace, authSecretNames, statusConditionManager)
+		if err != nil {
+			return err
+		}
+		secret = authContext.VirtualSecret
+	} else {
+		secretLocator := &security.MTConfigMapSecretLocator{ConfigMap: brokerConfig, UseNamespaceInConfigmap: false}
+		secret, err = security.Secret(ctx, secretLocator, r.SecretProviderFunc())
+		if apierrors.IsNotFound(err) {
+			return r.authSecretNotFound(broker, brokerConfig.Namespace, brokerConfig.Data[security.AuthSecretNameKey], statusConditionManager)
+		}
+		if err != nil {
+			return statusConditionManager.FailedToGetBrokerAuthSecret(err)
+		}
+		if secret != nil && secret.DeletionTimestamp != nil && !containsFinalizerSecret(secret, r.finalizerSecret(broker)) {
+			// Our finalizer has been removed externally, the secret is going away.
+			return r.authSecretNotFound(broker, brokerConfig.Namespace, brokerConfig.Data[security.AuthSecretNameKey], statusConditionManager)
+		}
+		if secret != nil {
+			logger.Debug("Secret reference",
+				zap.String("apiVersion", secret.APIVersion),
+				zap.String("name", secret.Name),
+				zap.String("namespace", secret.Namespace),
+				zap.String("kind", secret.Kind),
+			)
+			authSecrets = []*corev1.Secret{secret}
+		}
+
+		if err := r.TrackSecret(secret, broker); err != nil {
+			return fmt.Errorf("failed to track secret: %w", err)
+		}
+	}
+	if secret != nil && strimziConfig == nil && r.Env.SecretFinalizersDisabled {
+		statusConditionManager.AuthSecretNotProtected(secret.Namespace, secret.Name)
+	} else {
+		statusConditionManager.AuthSecretProtected()
+	}
+
+	trustBundleRef, trustBundle, err := r.trustBundle(broker, brokerConfig)
 	if err != nil {
-		return statusConditionManager.FailedToGetBrokerAuthSecret(err)
+		return r.failedToResolveConfig(logger, broker, statusConditionManager, err)
 	}
-	if secret != nil {
-		logger.Debug("Secret reference",
-			zap.String("apiVersion", secret.APIVersion),
-			zap.String("name", secret.Name),
-			zap.String("namespace", secret.Namespace),
-			zap.String("kind", secret.Kind),
-		)
 
-		if err := r.addFinalizerSecret(ctx, finalizerSecret(broker), secret); err != nil {
+	// get security option for Sarama with secret and trust bundle info in it
+	securityOption := security.NewSaramaSecurityOptionWithTrustBundle(secret, trustBundle)
+
+	if r.Env.DryRun {
+		phases.end()
+		return r.reconcileKindDryRun(ctx, logger, broker, brokerConfig, contractConfigMap, secret, trustBundleRef, authContext, securityOption, statusConditionManager, topicConfig)
+	}
+
+	for _, authSecret := range authSecrets {
+		if err := r.addFinalizerSecret(ctx, r.finalizerSecret(broker), authSecret); err != nil {
 			return err
 		}
 	}
 
-	// get security option for Sarama with secret info in it
-	securityOption := security.NewSaramaSecurityOptionFromSecret(secret)
-
-	if err := r.TrackSecret(secret, broker); err != nil {
-		return fmt.Errorf("failed to track secret: %w", err)
+	// The config rebuilt from the status annotations might be missing keys, so it isn't a reliable input.
+	var fastPathHash string
+	if r.FastPath != nil && !brokerConfigRebuilt {
+		fastPathHash, err = fastPathInputsHash(broker, brokerConfig, topicConfig, secret, trustBundleRef)
+		if err != nil {
+			logger.Debug("Failed to hash reconcile inputs", zap.Error(err))
+		}
 	}
 
-	topic, err := r.reconcileBrokerTopic(broker, securityOption, statusConditionManager, topicConfig, logger)
-	if err != nil {
-		return err
+	phases.begin(topicReconcilePhase)
+	topic, activeBootstrapServers, fastPath := r.fastPathTopic(logger, broker, contractConfigMap, fastPathHash)
+	if fastPath {
+		logger.Debug("Nothing changed since the last reconciliation, skipping the topic reconciliation", zap.String("topic", topic))
+		// Keep programming the failover cluster, if any, the topic reconciliation would have fallen back to.
+		topicConfig.BootstrapServers = activeBootstrapServers
+	} else {
+		if err := r.reconcilePendingTopicDeletions(ctx, logger, broker, contractConfigMap, statusConditionManager); err != nil {
+			return err
+		}
+
+		topic, err = r.reconcileBrokerTopic(broker, secret, trustBundleRef, securityOption, statusConditionManager, topicConfig, logger)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Get contract data.
-	ct, err := r.GetDataPlaneConfigMapData(logger, contractConfigMap)
-	if err != nil && ct == nil {
+	phases.begin(contractReconcilePhase)
+	ct, err := r.contractFromConfigMap(ctx, logger, broker, contractConfigMap)
+	if err != nil {
 		return statusConditionManager.FailedToGetDataFromConfigMap(err)
 	}
 
@@ -173,11 +558,16 @@ func (r *Reconciler) reconcileKind(ctx context.Context, broker *eventing.Broker)
 	if err != nil {
 		return statusConditionManager.FailedToResolveConfig(err)
 	}
+	setStrimziAuth(brokerResource, strimziConfig)
+	setMultiAuthSecret(brokerResource, authContext)
+	brokerResource.TrustBundle = trustBundleRef
+	brokerResource.Config = brokerConfigReference(brokerConfig)
 	coreconfig.SetDeadLetterSinkURIFromEgressConfig(&broker.Status.DeliveryStatus, brokerResource.EgressConfig)
 
-	brokerIndex := coreconfig.FindResource(ct, broker.UID)
+	brokerIndex := r.findBrokerResource(logger, ct, broker)
 	// Update contract data with the new contract configuration
 	coreconfig.SetResourceEgressesFromContract(ct, brokerResource, brokerIndex)
+	setBrokerReferenceVersion(ct, brokerResource, brokerIndex, broker)
 	changed := coreconfig.AddOrUpdateResourceConfig(ct, brokerResource, brokerIndex, logger)
 
 	logger.Debug("Change detector", zap.Int("changed", changed))
@@ -196,6 +586,24 @@ func (r *Reconciler) reconcileKind(ctx context.Context, broker *eventing.Broker)
 		logger.Debug("Contract config map updated")
 	}
 	statusConditionManager.ConfigMapUpdated()
+	phases.end()
+
+	if r.Env.ContractResourceStatusAnnotationEnabled {
+		summary, err := contractResourceSummaryJSON(brokerResource)
+		if err != nil {
+			return err
+		}
+		broker.Status.Annotations[ContractResourceStatusAnnotation] = summary
+	} else {
+		delete(broker.Status.Annotations, ContractResourceStatusAnnotation)
+	}
+
+	setDeliveryStatusAnnotations(broker, brokerResource.EgressConfig)
+	reportDefaultBackoffDelay(ctx, broker, brokerResource.EgressConfig)
+
+	if r.Env.TopicLagMetricsEnabled {
+		r.reportBrokerTopicLag(ctx, logger, broker, topic, securityOption, topicConfig, brokerResource.Egresses)
+	}
 
 	// We update receiver and dispatcher pods annotation regardless of our contract changed or not due to the fact
 	// that in a previous reconciliation we might have failed to update one of our data plane pod annotation, so we want
@@ -208,7 +616,8 @@ func (r *Reconciler) reconcileKind(ctx context.Context, broker *eventing.Broker)
 	// the update even if here eventually means seconds or minutes after the actual update.
 
 	// Update volume generation annotation of receiver pods
-	if err := r.UpdateReceiverPodsAnnotation(ctx, logger, ct.Generation); err != nil {
+	phases.begin(podsAnnotationReconcilePhase)
+	if err := r.UpdateReceiverPodsShardAnnotation(ctx, logger, shard, ct.Generation); err != nil {
 		logger.Error("Failed to update receiver pod annotation", zap.Error(
 			statusConditionManager.FailedToUpdateReceiverPodsAnnotation(err),
 		))
@@ -218,7 +627,7 @@ func (r *Reconciler) reconcileKind(ctx context.Context, broker *eventing.Broker)
 	logger.Debug("Updated receiver pod annotation")
 
 	// Update volume generation annotation of dispatcher pods
-	if err := r.UpdateDispatcherPodsAnnotation(ctx, logger, ct.Generation); err != nil {
+	if err := r.UpdateDispatcherPodsShardAnnotation(ctx, logger, shard, ct.Generation); err != nil {
 		// Failing to update dispatcher pods annotation leads to config map refresh delayed by several seconds.
 		// Since the dispatcher side is the consumer side, we don't lose availability, and we can consider the Broker
 		// ready. So, log out the error and move on to the next step.
@@ -231,8 +640,13 @@ func (r *Reconciler) reconcileKind(ctx context.Context, broker *eventing.Broker)
 	} else {
 		logger.Debug("Updated dispatcher pod annotation")
 	}
+	phases.end()
 
-	ingressHost := network.GetServiceHostname(r.Env.IngressName, r.DataPlaneNamespace)
+	phases.begin(addressReconcilePhase)
+	ingressHost, err := r.ingressHost()
+	if err != nil {
+		return err
+	}
 
 	transportEncryptionFlags := feature.FromContext(ctx)
 	var addressableStatus duckv1.AddressStatus
@@ -242,8 +656,8 @@ func (r *Reconciler) reconcileKind(ctx context.Context, broker *eventing.Broker)
 			return err
 		}
 
-		httpAddress := receiver.HTTPAddress(ingressHost, broker)
-		httpsAddress := receiver.HTTPSAddress(ingressHost, broker, caCerts)
+		httpAddress := withIngressPath(receiver.HTTPAddress(ingressHost, broker), ingressPath)
+		httpsAddress := withIngressPath(receiver.HTTPSAddress(ingressHost, broker, caCerts), ingressPath)
 		addressableStatus.Address = &httpAddress
 		addressableStatus.Addresses = []duckv1.Addressable{httpAddress, httpsAddress}
 	} else if transportEncryptionFlags.IsStrictTransportEncryption() {
@@ -252,26 +666,42 @@ func (r *Reconciler) reconcileKind(ctx context.Context, broker *eventing.Broker)
 			return err
 		}
 
-		httpsAddress := receiver.HTTPSAddress(ingressHost, broker, caCerts)
+		httpsAddress := withIngressPath(receiver.HTTPSAddress(ingressHost, broker, caCerts), ingressPath)
 		addressableStatus.Address = &httpsAddress
 		addressableStatus.Addresses = []duckv1.Addressable{httpsAddress}
 	} else {
-		httpAddress := receiver.HTTPAddress(ingressHost, broker)
+		httpAddress := withIngressPath(receiver.HTTPAddress(ingressHost, broker), ingressPath)
 		addressableStatus.Address = &httpAddress
 		addressableStatus.Addresses = []duckv1.Addressable{httpAddress}
 	}
 
 	proberAddressable := prober.NewAddressable{
 		AddressStatus: &addressableStatus,
+		// Only readiness is checked on the health path, the finalizer probes the broker address since it's the one
+		// that stops being served once the broker is removed from the data plane.
+		ProbePath: r.Env.ProbePath,
 		ResourceKey: types.NamespacedName{
 			Namespace: broker.GetNamespace(),
 			Name:      broker.GetName(),
 		},
 	}
 
-	if status := r.Prober.Probe(ctx, proberAddressable, prober.StatusReady); status != prober.StatusReady {
+	probeCtx := prober.WithTimeout(ctx, r.probeTimeout())
+	status := r.Prober.Probe(probeCtx, proberAddressable, prober.StatusReady)
+	diagnostics.ProbeStatus = status.String()
+	if status != prober.StatusReady {
+		r.Counter.Del(probeCounterKey(broker))
 		statusConditionManager.ProbesStatusNotReady(status)
-		return nil // Object will get re-queued once probe status changes.
+		// Object will get re-queued once probe status changes, requeue it anyway in case the change is never notified.
+		return controller.NewRequeueAfter(r.probeNotReadyRequeueDelay(broker))
+	}
+	r.Counter.Del(probeNotReadyCounterKey(broker))
+	// A broker that is already ready stays ready, consecutive probes are only required to become ready.
+	if !broker.Status.GetCondition(base.ConditionProbeSucceeded).IsTrue() {
+		if count, threshold, reached := r.probeThresholdReached(broker); !reached {
+			statusConditionManager.ProbesStatusBelowThreshold(count, threshold)
+			return controller.NewRequeueAfter(probeThresholdRequeueDelay)
+		}
 	}
 	statusConditionManager.ProbesStatusReady()
 
@@ -279,203 +709,798 @@ func (r *Reconciler) reconcileKind(ctx context.Context, broker *eventing.Broker)
 	broker.Status.Addresses = addressableStatus.Addresses
 	broker.GetConditionSet().Manage(broker.GetStatus()).MarkTrue(base.ConditionAddressable)
 
+	if r.CheckIngressReachability != nil {
+		if err := r.CheckIngressReachability(ctx, *addressableStatus.Address); err != nil {
+			logger.Warn("Broker address isn't reachable from the controller", zap.Error(err))
+			statusConditionManager.IngressUnreachable(addressableStatus.Address.URL, err)
+		} else {
+			statusConditionManager.IngressReachable()
+		}
+	}
+
+	if fastPathHash != "" && !fastPath {
+		r.FastPath.Record(broker, fastPathHash, topic, topicConfig.BootstrapServers, brokerResource)
+	}
+
 	return nil
 }
 
-func (r *Reconciler) reconcileBrokerTopic(broker *eventing.Broker, securityOption kafka.ConfigOption, statusConditionManager base.StatusConditionManager, topicConfig *kafka.TopicConfig, logger *zap.Logger) (string, reconciler.Event) {
+func (r *Reconciler) reconcileBrokerTopic(broker *eventing.Broker, secret *corev1.Secret, trustBundleRef *contract.TrustBundleReference, securityOption kafka.ConfigOption, statusConditionManager base.StatusConditionManager, topicConfig *kafka.TopicConfig, logger *zap.Logger) (string, reconciler.Event) {
+	if r.Env.UnmanagedTopics {
+		return r.reconcileUnmanagedBrokerTopic(broker, statusConditionManager)
+	}
 
-	saramaConfig, err := kafka.GetSaramaConfig(securityOption)
+	saramaConfig, err := r.clusterAdminSaramaConfig(securityOption)
 	if err != nil {
 		return "", statusConditionManager.FailedToResolveConfig(fmt.Errorf("error getting cluster admin config: %w", err))
 	}
 
-	kafkaClusterAdminClient, err := r.NewKafkaClusterAdminClient(topicConfig.BootstrapServers, saramaConfig)
+	kafkaClusterAdminClient, err := r.newKafkaClusterAdminClientWithFailover(broker, secret, trustBundleRef, saramaConfig, topicConfig, statusConditionManager.Recorder)
+	if circuitOpen, ok := kafka.IsCircuitOpen(err); ok {
+		statusConditionManager.KafkaClusterUnavailable(err)
+		return "", controller.NewRequeueAfter(circuitOpen.RetryAfter)
+	}
 	if err != nil {
 		return "", statusConditionManager.FailedToResolveConfig(fmt.Errorf("cannot obtain Kafka cluster admin, %w", err))
 	}
 	defer kafkaClusterAdminClient.Close()
 
+	r.describeKafkaCluster(broker, kafkaClusterAdminClient, saramaConfig, topicConfig, logger)
+
 	// if we have a custom topic annotation
 	// the topic is externally manged and we do NOT need to create it
 	topicName, externalTopic := isExternalTopic(broker)
 	if externalTopic {
+		if !r.ExternalTopicPolicy.Permits(topicName) {
+			return "", statusConditionManager.ExternalTopicNotPermitted(topicName)
+		}
+
 		isPresentAndValid, err := kafka.AreTopicsPresentAndValid(kafkaClusterAdminClient, topicName)
-		if err != nil {
-			return "", statusConditionManager.TopicsNotPresentOrInvalidErr([]string{topicName}, err)
+		if err != nil || !isPresentAndValid {
+			// The topic might be provisioned out-of-band, give it some time before failing.
+			if delay, retry := r.externalTopicPresenceCheckBackoff(broker); retry {
+				statusConditionManager.WaitingForExternalTopic(topicName, err)
+				return "", controller.NewRequeueAfter(delay)
+			}
 		}
-		if !isPresentAndValid {
-			// The topic might be invalid.
-			return "", statusConditionManager.TopicsNotPresentOrInvalid([]string{topicName})
+		if err != nil || !isPresentAndValid {
+			notPresent := err == nil || kafka.IsInvalidOrNotPresentTopic(err)
+			if err != nil {
+				err = statusConditionManager.TopicsNotPresentOrInvalidErr([]string{topicName}, err)
+			} else {
+				// The topic might be invalid.
+				err = statusConditionManager.TopicsNotPresentOrInvalid([]string{topicName})
+			}
+			// Topics provisioned by slow external pipelines take a while to appear, so don't check them at the
+			// default requeue rate.
+			if notPresent && r.Env.ExternalTopicNotFoundRequeueDelay > 0 {
+				logger.Debug("External topic not present", zap.String("topic", topicName), zap.Error(err))
+				return "", controller.NewRequeueAfter(r.Env.ExternalTopicNotFoundRequeueDelay)
+			}
+			return "", err
+		}
+		r.Counter.Del(externalTopicCounterKey(broker))
+		if broker.Status.Annotations[kafka.TopicAnnotation] != topicName {
+			statusConditionManager.ExternalTopicAdopted(topicName)
+		}
+
+		if ignored := r.externalTopicIgnoredConfigs(topicConfig); len(ignored) > 0 {
+			logger.Warn("External topic configs ignored", zap.String("topic", topicName), zap.Strings("configs", ignored))
+			statusConditionManager.TopicConfigIgnored(topicName, ignored)
+		} else {
+			statusConditionManager.TopicConfigApplied()
+		}
+
+		// An external topic incompatible with the broker config is a common misconfiguration, for example, a single
+		// partition topic limits the throughput of the broker, so let users know.
+		err = kafka.CheckTopicPartitionsAndReplicationFactor(kafkaClusterAdminClient, topicName, topicConfig)
+		if kafka.IsTopicConfigMismatch(err) {
+			logger.Warn("External topic config mismatch", zap.Error(err))
+			statusConditionManager.TopicConfigNotSynced(topicName, err)
+		} else if err != nil {
+			return "", statusConditionManager.TopicsNotPresentOrInvalidErr([]string{topicName}, err)
+		} else {
+			statusConditionManager.TopicConfigSynced()
 		}
 	} else {
+		statusConditionManager.TopicConfigApplied()
+
 		// no external topic, we create it
-		var existingTopic bool
-		// check if the broker already has reconciled with a topic name and use that if it exists
-		// otherwise, we create a new topic name from the broker topic template
-		if topicName, existingTopic = broker.Status.Annotations[kafka.TopicAnnotation]; !existingTopic {
-			topicName, err = r.KafkaFeatureFlags.ExecuteBrokersTopicTemplate(broker.ObjectMeta)
-			if err != nil {
-				return "", statusConditionManager.TopicsNotPresentOrInvalidErr([]string{topicName}, err)
+		topicName, err = r.brokerTopicName(broker)
+		if err != nil {
+			return "", statusConditionManager.TopicsNotPresentOrInvalidErr([]string{topicName}, err)
+		}
+
+		if sharedTopic, ok := isSharedTopic(broker); ok {
+			if err := r.validateSharedTopic(broker, sharedTopic, topicConfig); err != nil {
+				return "", statusConditionManager.TopicsNotPresentOrInvalidErr([]string{sharedTopic}, err)
 			}
 		}
+		if err := r.validateTopicNameCollision(broker, topicName); err != nil {
+			return "", statusConditionManager.TopicNameCollision(topicName, err)
+		}
 
-		topic, err := kafka.CreateTopicIfDoesntExist(kafkaClusterAdminClient, logger, topicName, topicConfig)
+		topic := topicName
+		recreating, err := r.deleteTopicIfRecreateRequested(statusConditionManager.Recorder, logger, kafkaClusterAdminClient, broker, topic)
 		if err != nil {
 			return "", statusConditionManager.FailedToCreateTopic(topic, err)
 		}
+		created, replicationFactor, err := r.createTopicIfAbsent(kafkaClusterAdminClient, logger, topic, topicConfig)
+		if err != nil {
+			return "", statusConditionManager.FailedToCreateTopic(topic, err)
+		}
+		if recreating && !created {
+			// Kafka deletes topics asynchronously, so the topic might still exist, it's created again once its
+			// deletion completes.
+			return "", controller.NewRequeueAfter(topicRecreateRequeueDelay)
+		}
+		if created {
+			statusConditionManager.TopicCreated(topic, topicConfig.TopicDetail.NumPartitions, replicationFactor)
+		} else if _, ok := broker.Status.Annotations[kafka.TopicAnnotation]; !ok && r.Env.TopicReuseByName {
+			statusConditionManager.TopicAdopted(topic)
+		}
+
+		// the topic might have been created with a different config (for example, the broker retention annotation
+		// has been changed), so make sure the topic config matches the desired one.
+		if err := r.alterBrokerTopicConfig(statusConditionManager.Recorder, logger, kafkaClusterAdminClient, broker, topic, topicConfig); err != nil {
+			return "", statusConditionManager.FailedToUpdateTopicConfig(topic, err)
+		}
+
+		// operators might have changed the number of partitions or the replication factor in the broker config.
+		err = kafka.ReconcileTopicPartitions(kafkaClusterAdminClient, logger, topic, topicConfig)
+		if kafka.IsTopicConfigMismatch(err) {
+			// The replication factor can't be changed online, we don't fail the broker but we let users know.
+			logger.Warn("Topic replication factor mismatch", zap.Error(err))
+			statusConditionManager.TopicConfigNotSynced(topic, err)
+		} else if err != nil {
+			return "", statusConditionManager.FailedToUpdateTopicConfig(topic, err)
+		} else {
+			statusConditionManager.TopicConfigSynced()
+		}
+		if replicationFactor < topicConfig.TopicDetail.ReplicationFactor {
+			statusConditionManager.TopicReplicationFactorDegraded(topic, replicationFactor, topicConfig.TopicDetail.ReplicationFactor)
+		}
+	}
+
+	if r.Env.TopicWritabilityCheckEnabled {
+		// An existing topic isn't enough for producing events, a partition without a leader or with fewer in-sync
+		// replicas than min.insync.replicas rejects writes.
+		if err := kafka.CheckTopicWritable(kafkaClusterAdminClient, topicName, topicConfig); kafka.IsUnhealthyTopic(err) {
+			return "", statusConditionManager.TopicUnhealthy(topicName, err)
+		} else if err != nil {
+			return "", statusConditionManager.TopicsNotPresentOrInvalidErr([]string{topicName}, err)
+		}
 	}
 
 	statusConditionManager.TopicReady(topicName)
 	logger.Debug("Topic created", zap.Any("topic", topicName))
 
 	broker.Status.Annotations[kafka.TopicAnnotation] = topicName
+	if len(topicConfig.FailoverBootstrapServers) > 0 {
+		broker.Status.Annotations[ActiveBootstrapServersStatusAnnotation] = topicConfig.GetBootstrapServers()
+	} else {
+		delete(broker.Status.Annotations, ActiveBootstrapServersStatusAnnotation)
+	}
 
 	return topicName, nil
 }
 
-func (r *Reconciler) FinalizeKind(ctx context.Context, broker *eventing.Broker) reconciler.Event {
-	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		return r.finalizeKind(ctx, broker)
-	})
+// externalTopicIgnoredConfigs returns the sorted topic configs, set with the broker config or annotations, that
+// aren't applied to external topics since they're managed out-of-band.
+//
+// The number of partitions and the replication factor are only checked against external topics, and
+// min.insync.replicas is used by the topic writability check when enabled.
+func (r *Reconciler) externalTopicIgnoredConfigs(topicConfig *kafka.TopicConfig) []string {
+	ignored := make([]string, 0, len(topicConfig.TopicDetail.ConfigEntries))
+	for name := range topicConfig.TopicDetail.ConfigEntries {
+		if name == kafka.MinInSyncReplicasTopicConfigKey && r.Env.TopicWritabilityCheckEnabled {
+			continue
+		}
+		ignored = append(ignored, name)
+	}
+	sort.Strings(ignored)
+	return ignored
 }
 
-func (r *Reconciler) finalizeKind(ctx context.Context, broker *eventing.Broker) reconciler.Event {
-	logger := kafkalogging.CreateFinalizeMethodLogger(ctx, broker)
-
-	if err := r.deleteResourceFromContractConfigMap(ctx, logger, broker); err != nil {
-		return err
+// reconcileUnmanagedBrokerTopic resolves the broker topic name without calling the Kafka cluster, since in
+// UnmanagedTopics mode topics are provisioned out-of-band.
+func (r *Reconciler) reconcileUnmanagedBrokerTopic(broker *eventing.Broker, statusConditionManager base.StatusConditionManager) (string, reconciler.Event) {
+	topicName, err := r.unmanagedBrokerTopicName(broker, statusConditionManager)
+	if err != nil {
+		return "", err
 	}
 
-	broker.Status.Address = nil
+	statusConditionManager.TopicUnmanaged(topicName)
+	statusConditionManager.TopicConfigSynced()
 
-	ingressHost := network.GetServiceHostname(r.Env.IngressName, r.Reconciler.DataPlaneNamespace)
+	broker.Status.Annotations[kafka.TopicAnnotation] = topicName
+	delete(broker.Status.Annotations, ActiveBootstrapServersStatusAnnotation)
 
-	//  Rationale: after deleting a topic closing a producer ends up blocking and requesting metadata for max.block.ms
-	//  because topic metadata aren't available anymore.
-	// 	See (under discussions KIPs, unlikely to be accepted as they are):
-	// 	- https://cwiki.apache.org/confluence/pages/viewpage.action?pageId=181306446
-	// 	- https://cwiki.apache.org/confluence/display/KAFKA/KIP-286%3A+producer.send%28%29+should+not+block+on+metadata+update
-	address := receiver.HTTPAddress(ingressHost, broker)
-	proberAddressable := prober.NewAddressable{
-		AddressStatus: &duckv1.AddressStatus{
-			Address: &address,
-		},
-		ResourceKey: types.NamespacedName{
-			Namespace: broker.GetNamespace(),
-			Name:      broker.GetName(),
-		},
-	}
-	status := r.Prober.Probe(ctx, proberAddressable, prober.StatusNotReady)
-	if status != prober.StatusNotReady && status != prober.StatusUnknownErr {
-		// Return a requeueKeyError that doesn't generate an event and it re-queues the object
-		// for a new reconciliation.
-		return controller.NewRequeueAfter(5 * time.Second)
-	}
+	return topicName, nil
+}
 
-	brokerConfig, err := r.brokerConfigMap(logger, broker)
-	if err != nil && !apierrors.IsNotFound(err) {
-		return err
-	}
-	// If the broker config data is empty we simply return,
-	// as the configuration may already be gone
-	if len(brokerConfig.Data) == 0 {
-		return nil
+// unmanagedBrokerTopicName returns the name of the topic of the given broker in UnmanagedTopics mode.
+func (r *Reconciler) unmanagedBrokerTopicName(broker *eventing.Broker, statusConditionManager base.StatusConditionManager) (string, reconciler.Event) {
+	if topicName, ok := isExternalTopic(broker); ok {
+		if !r.ExternalTopicPolicy.Permits(topicName) {
+			return "", statusConditionManager.ExternalTopicNotPermitted(topicName)
+		}
+		return topicName, nil
 	}
 
-	secret, err := security.Secret(ctx, &security.MTConfigMapSecretLocator{ConfigMap: brokerConfig, UseNamespaceInConfigmap: false}, r.SecretProviderFunc())
+	topicName, err := r.brokerTopicName(broker)
 	if err != nil {
-		// If we can not get the referenced secret,
-		// let us try for a bit before we give up.
-		brokerUUID := string(broker.GetUID())
-		if r.Counter.Inc(brokerUUID) <= 5 {
-			return controller.NewRequeueAfter(5 * time.Second)
-		}
+		return "", statusConditionManager.TopicsNotPresentOrInvalidErr([]string{topicName}, err)
 	}
+	return topicName, nil
+}
 
-	if secret != nil {
-		logger.Debug("Secret reference",
-			zap.String("apiVersion", secret.APIVersion),
-			zap.String("name", secret.Name),
-			zap.String("namespace", secret.Namespace),
-			zap.String("kind", secret.Kind),
-		)
+// findBrokerResource returns the index of the contract resource of the given broker.
+//
+// When TopicReuseByName is set, a broker created again with the same namespace and name adopts the resource of the
+// previous broker.
+func (r *Reconciler) findBrokerResource(logger *zap.Logger, ct *contract.Contract, broker *eventing.Broker) int {
+	brokerIndex := coreconfig.FindResource(ct, broker.UID)
+	if brokerIndex == coreconfig.NoResource && r.Env.TopicReuseByName {
+		brokerIndex = coreconfig.FindResourceByReference(ct, broker.GetNamespace(), broker.GetName())
+		if brokerIndex != coreconfig.NoResource {
+			logger.Debug("Adopting contract resource", zap.String("uid", ct.Resources[brokerIndex].Uid))
+		}
 	}
+	return brokerIndex
+}
 
-	// External topics are not managed by the broker,
-	// therefore we do not delete them
-	_, externalTopic := isExternalTopic(broker)
-	if !externalTopic {
-		topicConfig, err := r.topicConfig(logger, broker, brokerConfig)
-		if err != nil {
-
-			// On finalize, we fail to get a valid config, we can safely ignore and return nil
-			// no further actions are needed since we are also not putting the finalizer on given secret
-			// if we are not having a valid topic config
-			if strings.Contains(err.Error(), "validating topic config") {
-				return nil
-			} else {
-				return fmt.Errorf("failed to resolve broker config: %w", err)
-			}
+// setBrokerReferenceVersion sets the reference version of the given broker resource to the broker resource version,
+// and the config reference version to the broker config resource version, so that the data plane can tell which
+// versions of the broker and of its config the resource has been built from.
+//
+// The resource version changes on every broker update, including status updates, and on every config update, even
+// the ones not affecting the resource, so the versions of the contract resource at the given index are kept when
+// nothing else changed, which doesn't bump the contract generation.
+func setBrokerReferenceVersion(ct *contract.Contract, resource *contract.Resource, index int, broker *eventing.Broker) {
+	configVersion := resource.GetConfig().GetVersion()
+	if index != coreconfig.NoResource {
+		resource.Reference.Version = ct.Resources[index].GetReference().GetVersion()
+		if resource.Config != nil {
+			resource.Config.Version = ct.Resources[index].GetConfig().GetVersion()
 		}
-
-		// get security option for Sarama with secret info in it
-		securityOption := security.NewSaramaSecurityOptionFromSecret(secret)
-		err = r.finalizeNonExternalBrokerTopic(broker, securityOption, topicConfig, logger)
-
-		// if finalizeNonExternalBrokerTopic returns error that kafka is not reachable!
-		if err != nil {
-			if strings.Contains(err.Error(), "cannot obtain Kafka") {
-
-				// If the kafka cluster is not reachable we give it a few more retries, to see if there was
-				// some temporary network issue and requeue the finalization.
-				// If we tried often enough and the kafka cluster is still not reachable,
-				// we return nil and delete the broker
-				brokerUUID := string(broker.GetUID())
-				if r.Counter.Inc(brokerUUID) <= 5 {
-					return controller.NewRequeueAfter(5 * time.Second)
-				} else {
-					r.Counter.Del(brokerUUID) // clean up the reference from the counter
-					logger.Error("Error reaching Kafka cluster", zap.Error(err))
-					return nil
-				}
-			}
-			return err
+		if coreconfig.ResourcesEqual(ct.Resources[index], resource) {
+			return
 		}
 	}
-
-	if err := r.removeFinalizerSecret(ctx, finalizerSecret(broker), secret); err != nil {
-		return err
+	resource.Reference.Version = broker.GetResourceVersion()
+	if resource.Config != nil {
+		resource.Config.Version = configVersion
 	}
+}
 
-	return nil
+// brokerConfigReference returns the reference of the given broker config, or nil when the broker config isn't a
+// ConfigMap stored in the cluster, like Secret based configs and ConfigMaps rebuilt from the broker status
+// annotations, which have no version.
+func brokerConfigReference(brokerConfig *corev1.ConfigMap) *contract.Reference {
+	if brokerConfig == nil || brokerConfig.GetResourceVersion() == "" {
+		return nil
+	}
+	return &contract.Reference{
+		Uuid:      string(brokerConfig.GetUID()),
+		Namespace: brokerConfig.GetNamespace(),
+		Name:      brokerConfig.GetName(),
+		Version:   brokerConfig.GetResourceVersion(),
+	}
 }
 
-func (r *Reconciler) deleteResourceFromContractConfigMap(ctx context.Context, logger *zap.Logger, broker *eventing.Broker) error {
-	// Get contract config map.
-	contractConfigMap, err := r.GetOrCreateDataPlaneConfigMap(ctx)
-	// Handles https://github.com/knative-sandbox/eventing-kafka-broker/issues/2893
+// deleteTopicIfRecreateRequested deletes the broker topic when the TopicRecreateAnnotation value differs from the last
+// processed one, recorded in the TopicRecreateStatusAnnotation status annotation, so that the topic is recreated once.
+//
+// It returns true if the topic has been deleted.
+func (r *Reconciler) deleteTopicIfRecreateRequested(recorder record.EventRecorder, logger *zap.Logger, admin sarama.ClusterAdmin, broker *eventing.Broker, topic string) (bool, error) {
+	nonce, ok := broker.GetAnnotations()[TopicRecreateAnnotation]
+	if !ok || nonce == "" || nonce == broker.Status.Annotations[TopicRecreateStatusAnnotation] {
+		return false, nil
+	}
+	if broker.Status.Annotations == nil {
+		broker.Status.Annotations = make(map[string]string, 1)
+	}
+	if _, ok := isSharedTopic(broker); ok {
+		logger.Warn("Ignoring topic recreation of a shared topic", zap.String("topic", topic), zap.String("nonce", nonce))
+		broker.Status.Annotations[TopicRecreateStatusAnnotation] = nonce
+		return false, nil
+	}
+	if _, ok := broker.Status.Annotations[kafka.TopicAnnotation]; !ok {
+		// The topic hasn't been created yet, so there is nothing to recreate.
+		broker.Status.Annotations[TopicRecreateStatusAnnotation] = nonce
+		return false, nil
+	}
+
+	logger.Warn("Recreating topic", zap.String("topic", topic), zap.String("nonce", nonce))
+	recorder.Eventf(broker, corev1.EventTypeWarning, "TopicRecreate",
+		"Deleting topic %s to recreate it as requested by %s %q, events in the topic are lost", topic, TopicRecreateAnnotation, nonce)
+
+	if _, err := kafka.DeleteTopic(admin, topic); err != nil {
+		return false, err
+	}
+	broker.Status.Annotations[TopicRecreateStatusAnnotation] = nonce
+	return true, nil
+}
+
+// createTopicIfAbsent creates the broker topic, falling back to a replication factor clamped to the number of
+// available brokers when ReplicationFactorFallbackEnabled is set, and with a rack aware replica assignment when
+// RackAwareReplicaAssignmentEnabled is set.
+//
+// It returns the replication factor the topic has been created with.
+func (r *Reconciler) createTopicIfAbsent(admin sarama.ClusterAdmin, logger *zap.Logger, topic string, topicConfig *kafka.TopicConfig) (bool, int16, error) {
+	// Kafka clusters in read-only or maintenance mode reject topic creations while existing topics keep working, so
+	// the creation is only attempted when the topic doesn't exist or when its metadata can't be described.
+	if present, err := kafka.IsTopicPresent(admin, topic); err != nil {
+		logger.Debug("Failed to check topic presence before creating it", zap.String("topic", topic), zap.Error(err))
+	} else if present {
+		logger.Debug("Topic already exists", zap.String("topic", topic))
+		return false, topicConfig.TopicDetail.ReplicationFactor, nil
+	}
+
+	topicConfig.RackAwareReplicaAssignmentEnabled = r.Env.RackAwareReplicaAssignmentEnabled
+	if r.Env.ReplicationFactorFallbackEnabled {
+		return kafka.CreateTopicIfAbsentWithReplicationFactorFallback(admin, logger, topic, topicConfig)
+	}
+	created, err := kafka.CreateTopicIfAbsent(admin, logger, topic, topicConfig)
+	return created, topicConfig.TopicDetail.ReplicationFactor, err
+}
+
+// brokerTopicName returns the name of the topic managed by the broker.
+//
+// If the broker has already been reconciled with a topic, the same topic is used, otherwise the topic name is
+// created from BrokerTopicTemplate, when configured, or from the brokers topic template feature flag, prefixed with
+// the topic prefix of the broker namespace, if any.
+func (r *Reconciler) brokerTopicName(broker *eventing.Broker) (string, error) {
+	if topicName, ok := isSharedTopic(broker); ok {
+		return topicName, nil
+	}
+	if topicName, ok := broker.Status.Annotations[kafka.TopicAnnotation]; ok {
+		return topicName, nil
+	}
+	prefix := r.NamespaceTopicPrefixes.Prefix(broker.GetNamespace())
+	if r.BrokerTopicTemplate != nil {
+		var topicName bytes.Buffer
+		if err := r.BrokerTopicTemplate.Execute(&topicName, broker.ObjectMeta); err != nil {
+			return "", fmt.Errorf("unable to execute broker topic template: %w", err)
+		}
+		return prefix + topicName.String(), nil
+	}
+	topicName, err := r.KafkaFeatureFlags.ExecuteBrokersTopicTemplate(broker.ObjectMeta)
+	if err != nil {
+		return "", err
+	}
+	return prefix + topicName, nil
+}
+
+// reconcileKindDryRun computes the changes that reconcileKind would apply to the broker topic and to the data plane
+// contract, and it reports them through the DryRunStatusAnnotation status annotation and an event without applying
+// them.
+func (r *Reconciler) reconcileKindDryRun(ctx context.Context, logger *zap.Logger, broker *eventing.Broker, brokerConfig *corev1.ConfigMap, contractConfigMap *corev1.ConfigMap, secret *corev1.Secret, trustBundleRef *contract.TrustBundleReference, authContext *security.NetSpecAuthContext, securityOption kafka.ConfigOption, statusConditionManager base.StatusConditionManager, topicConfig *kafka.TopicConfig) reconciler.Event {
+	topic, actions, err := r.planBrokerTopic(broker, secret, trustBundleRef, securityOption, statusConditionManager, topicConfig)
+	if err != nil {
+		return err
+	}
+
+	ct, err := r.GetDataPlaneConfigMapData(logger, contractConfigMap)
+	if err != nil {
+		return statusConditionManager.FailedToGetDataFromConfigMap(err)
+	}
+
+	brokerResource, err := r.reconcilerBrokerResource(ctx, topic, broker, secret, topicConfig)
+	if err != nil {
+		return statusConditionManager.FailedToResolveConfig(err)
+	}
+	setMultiAuthSecret(brokerResource, authContext)
+	brokerResource.Config = brokerConfigReference(brokerConfig)
+
+	// ct is our own copy of the contract, changing it doesn't update the data plane config map.
+	brokerIndex := r.findBrokerResource(logger, ct, broker)
+	coreconfig.SetResourceEgressesFromContract(ct, brokerResource, brokerIndex)
+	setBrokerReferenceVersion(ct, brokerResource, brokerIndex, broker)
+	if coreconfig.AddOrUpdateResourceConfig(ct, brokerResource, brokerIndex, logger) == coreconfig.ResourceChanged {
+		if brokerIndex == coreconfig.NoResource {
+			actions = append(actions, fmt.Sprintf("add broker resource to contract config map %s", contractConfigMap.Name))
+		} else {
+			actions = append(actions, fmt.Sprintf("update broker resource in contract config map %s", contractConfigMap.Name))
+		}
+	}
+
+	summary := "no changes"
+	if len(actions) > 0 {
+		summary = strings.Join(actions, "; ")
+	}
+	logger.Info("Dry run", zap.Strings("actions", actions))
+
+	broker.Status.Annotations[DryRunStatusAnnotation] = summary
+	statusConditionManager.Recorder.Eventf(broker, corev1.EventTypeNormal, "DryRun", "Dry run: %s", summary)
+
+	return nil
+}
+
+// finalizeKindDryRun computes the changes that finalizeKind would apply to the broker topic and to the data plane
+// contract, and it reports them through an event without applying them. The broker is released, so its topic and
+// its contract resource are left behind.
+func (r *Reconciler) finalizeKindDryRun(ctx context.Context, logger *zap.Logger, broker *eventing.Broker) reconciler.Event {
+	contractConfigMap, err := r.GetOrCreateDataPlaneConfigMapShard(ctx, r.ContractConfigMapShard(broker))
+	if err != nil {
+		return fmt.Errorf("failed to get contract config map: %w", err)
+	}
+	ct, err := r.GetDataPlaneConfigMapData(logger, contractConfigMap)
+	if err != nil {
+		return fmt.Errorf("failed to get contract data: %w", err)
+	}
+
+	var actions []string
+	if r.findBrokerResource(logger, ct, broker) != coreconfig.NoResource {
+		actions = append(actions, fmt.Sprintf("remove broker resource from contract config map %s", contractConfigMap.Name))
+	}
+
+	_, externalTopic := isExternalTopic(broker)
+	_, sharedTopic := isSharedTopic(broker)
+	if !externalTopic && !sharedTopic && !r.Env.UnmanagedTopics && r.topicDeletePolicy(broker) != TopicDeletePolicyRetain {
+		topic, err := r.brokerTopicName(broker)
+		if err != nil {
+			return err
+		}
+		if r.Env.TopicDeletionGracePeriod > 0 {
+			actions = append(actions, fmt.Sprintf("schedule deletion of topic %s", topic))
+		} else {
+			actions = append(actions, fmt.Sprintf("delete topic %s", topic))
+		}
+	}
+
+	summary := "no changes"
+	if len(actions) > 0 {
+		summary = strings.Join(actions, "; ")
+	}
+	logger.Info("Dry run", zap.Strings("actions", actions))
+	controller.GetEventRecorder(ctx).Eventf(broker, corev1.EventTypeNormal, "DryRun", "Dry run: %s", summary)
+
+	return nil
+}
+
+// planBrokerTopic is the dry run counterpart of reconcileBrokerTopic, it returns the broker topic and the changes
+// that reconcileBrokerTopic would apply to it.
+func (r *Reconciler) planBrokerTopic(broker *eventing.Broker, secret *corev1.Secret, trustBundleRef *contract.TrustBundleReference, securityOption kafka.ConfigOption, statusConditionManager base.StatusConditionManager, topicConfig *kafka.TopicConfig) (string, []string, reconciler.Event) {
+	if r.Env.UnmanagedTopics {
+		topicName, err := r.unmanagedBrokerTopicName(broker, statusConditionManager)
+		return topicName, nil, err
+	}
+
+	saramaConfig, err := r.clusterAdminSaramaConfig(securityOption)
+	if err != nil {
+		return "", nil, statusConditionManager.FailedToResolveConfig(fmt.Errorf("error getting cluster admin config: %w", err))
+	}
+
+	kafkaClusterAdminClient, err := r.newKafkaClusterAdminClientWithFailover(broker, secret, trustBundleRef, saramaConfig, topicConfig, statusConditionManager.Recorder)
+	if err != nil {
+		return "", nil, statusConditionManager.FailedToResolveConfig(fmt.Errorf("cannot obtain Kafka cluster admin, %w", err))
+	}
+	defer kafkaClusterAdminClient.Close()
+
+	topicName, externalTopic := isExternalTopic(broker)
+	if externalTopic {
+		// External topics are never altered.
+		isPresentAndValid, err := kafka.AreTopicsPresentAndValid(kafkaClusterAdminClient, topicName)
+		if err != nil {
+			return "", nil, statusConditionManager.TopicsNotPresentOrInvalidErr([]string{topicName}, err)
+		}
+		if !isPresentAndValid {
+			return "", nil, statusConditionManager.TopicsNotPresentOrInvalid([]string{topicName})
+		}
+		return topicName, nil, nil
+	}
+
+	topicName, err = r.brokerTopicName(broker)
+	if err != nil {
+		return "", nil, statusConditionManager.TopicsNotPresentOrInvalidErr([]string{topicName}, err)
+	}
+
+	actions, err := kafka.PlanTopicChanges(kafkaClusterAdminClient, topicName, topicConfig)
+	if err != nil {
+		return "", nil, statusConditionManager.TopicsNotPresentOrInvalidErr([]string{topicName}, err)
+	}
+
+	return topicName, actions, nil
+}
+
+func (r *Reconciler) FinalizeKind(ctx context.Context, broker *eventing.Broker) reconciler.Event {
+	return base.RetryOnConflict(r.Env, func() error {
+		return r.finalizeKind(ctx, broker)
+	})
+}
+
+func (r *Reconciler) finalizeKind(ctx context.Context, broker *eventing.Broker) (event reconciler.Event) {
+	logger := kafkalogging.CreateFinalizeMethodLogger(ctx, broker)
+
+	if r.StatusUpdateThrottle != nil {
+		r.StatusUpdateThrottle.Forget(broker.GetUID())
+	}
+	if r.FastPath != nil {
+		r.FastPath.Forget(broker.GetUID())
+	}
+
+	if r.Env.DryRun {
+		return r.finalizeKindDryRun(ctx, logger, broker)
+	}
+
+	// Drained brokers stop accepting events while their triggers keep consuming the topic until it's deleted.
+	drain := r.drainsBroker(broker)
+	drainedResource, err := r.finalizeContractResource(ctx, logger, broker, drain)
+	if err != nil {
+		return err
+	}
+	inContract := drainedResource != nil
+	if inContract {
+		// This finalization removed the broker from the contract, when it stops short of finalizing the topic, record
+		// it so that the next finalizations don't skip the topic.
+		defer func() {
+			if event == nil {
+				return
+			}
+			if broker.Status.Annotations == nil {
+				broker.Status.Annotations = make(map[string]string, 1)
+			}
+			broker.Status.Annotations[TopicFinalizationPendingStatusAnnotation] = "true"
+		}()
+	}
+
+	broker.Status.Address = nil
+
+	ingressHost, err := r.ingressHost()
+	if err != nil {
+		// The probe only depends on the address path, so don't block the finalization.
+		logger.Warn("Failed to get ingress host", zap.Error(err))
+		ingressHost = network.GetServiceHostname(r.Env.IngressName, r.Reconciler.DataPlaneNamespace)
+	}
+
+	//  Rationale: after deleting a topic closing a producer ends up blocking and requesting metadata for max.block.ms
+	//  because topic metadata aren't available anymore.
+	// 	See (under discussions KIPs, unlikely to be accepted as they are):
+	// 	- https://cwiki.apache.org/confluence/pages/viewpage.action?pageId=181306446
+	// 	- https://cwiki.apache.org/confluence/display/KAFKA/KIP-286%3A+producer.send%28%29+should+not+block+on+metadata+update
+	address := receiver.HTTPAddress(ingressHost, broker)
+	if ingressPath, err := IngressPath(broker); err == nil {
+		// The broker has been served at the overridden path, if any, so that's the one that stops being served.
+		address = withIngressPath(address, ingressPath)
+	}
+	proberAddressable := prober.NewAddressable{
+		AddressStatus: &duckv1.AddressStatus{
+			Address: &address,
+		},
+		ResourceKey: types.NamespacedName{
+			Namespace: broker.GetNamespace(),
+			Name:      broker.GetName(),
+		},
+	}
+	status := r.Prober.Probe(prober.WithTimeout(ctx, r.probeTimeout()), proberAddressable, prober.StatusNotReady)
+	if status != prober.StatusNotReady && status != prober.StatusUnknownErr {
+		r.Counter.Del(probeCounterKey(broker))
+		// Return a requeueKeyError that doesn't generate an event and it re-queues the object
+		// for a new reconciliation.
+		return controller.NewRequeueAfter(5 * time.Second)
+	}
+	if _, _, reached := r.probeThresholdReached(broker); !reached {
+		return controller.NewRequeueAfter(probeThresholdRequeueDelay)
+	}
+
+	// The broker isn't in the contract and the data plane doesn't serve it, so unless a previous finalization removed
+	// it from the contract without finalizing the topic, skip resolving the broker config and dialing the Kafka cluster.
+	if !inContract && (broker.Status.Annotations[TopicFinalizationPendingStatusAnnotation] != "true" ||
+		broker.Status.Annotations[TopicFinalizedStatusAnnotation] == "true") {
+		return r.removeFinalizerSecretFromStatus(ctx, broker)
+	}
+
+	brokerConfig, err := r.brokerConfigMap(logger, broker)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	// If the broker config data is empty we simply return,
+	// as the configuration may already be gone
+	if len(brokerConfig.Data) == 0 {
+		return r.deleteDrainedResourceFromContractConfigMap(ctx, logger, broker, drain)
+	}
+
+	strimziConfig, err := r.strimziClusterConfig(ctx, broker)
+	if err != nil {
+		return fmt.Errorf("failed to resolve broker config: %w", err)
+	}
+	brokerConfig = withStrimziBootstrapServers(brokerConfig, strimziConfig)
+
+	var secret *corev1.Secret
+	// authSecrets are the auth secrets with the finalizer of the broker.
+	var authSecrets []*corev1.Secret
+	if strimziConfig != nil {
+		secret = strimziConfig.AuthContext.VirtualSecret
+	} else {
+		if names, _ := security.AuthSecretNames(brokerConfig.Data); len(names) > 0 {
+			authSecrets, err = r.existingAuthSecrets(ctx, brokerConfig.Namespace, names)
+			if err == nil {
+				secret, err = connectionSecret(authSecrets)
+			}
+		} else {
+			secret, err = security.Secret(ctx, &security.MTConfigMapSecretLocator{ConfigMap: brokerConfig, UseNamespaceInConfigmap: false}, r.SecretProviderFunc())
+			if secret != nil {
+				authSecrets = []*corev1.Secret{secret}
+			}
+		}
+		if err != nil {
+			// If we can not get the referenced secret,
+			// let us try for a bit before we give up.
+			brokerUUID := string(broker.GetUID())
+			if r.Counter.Inc(brokerUUID) <= 5 {
+				return controller.NewRequeueAfter(5 * time.Second)
+			}
+		}
+	}
+
+	if secret != nil {
+		logger.Debug("Secret reference",
+			zap.String("apiVersion", secret.APIVersion),
+			zap.String("name", secret.Name),
+			zap.String("namespace", secret.Namespace),
+			zap.String("kind", secret.Kind),
+		)
+	}
+
+	// External topics are not managed by the broker,
+	// therefore we do not delete them
+	// Shared topics are used by other brokers, therefore we do not delete them either
+	// Unmanaged topics are deleted out-of-band
+	_, externalTopic := isExternalTopic(broker)
+	_, sharedTopic := isSharedTopic(broker)
+	if !externalTopic && !sharedTopic && !r.Env.UnmanagedTopics {
+		topicConfig, err := r.topicConfig(logger, broker, brokerConfig)
+		if err != nil {
+
+			// On finalize, we fail to get a valid config, we can safely ignore and return nil
+			// no further actions are needed since we are also not putting the finalizer on given secret
+			// if we are not having a valid topic config
+			if kafka.IsInvalidTopicConfig(err) {
+				return r.deleteDrainedResourceFromContractConfigMap(ctx, logger, broker, drain)
+			} else {
+				return fmt.Errorf("failed to resolve broker config: %w", err)
+			}
+		}
+
+		policy := r.topicDeletePolicy(broker)
+		logger.Info("Broker topic delete policy", zap.String("policy", policy))
+		controller.GetEventRecorder(ctx).Eventf(broker, corev1.EventTypeNormal, "TopicDeletePolicy", "Topic delete policy: %s", policy)
+
+		if policy == TopicDeletePolicyRetain {
+			return r.removeFinalizerSecretOnceTopicFinalized(ctx, broker, authSecrets)
+		}
+
+		// The trust bundle might be gone already, in which case the system's root CA set is used.
+		trustBundleRef, trustBundle, err := r.trustBundle(broker, brokerConfig)
+		if err != nil {
+			logger.Warn("Failed to resolve trust bundle, finalizing the topic without it", zap.Error(err))
+		}
+
+		// get security option for Sarama with secret and trust bundle info in it
+		securityOption := security.NewSaramaSecurityOptionWithTrustBundle(secret, trustBundle)
+
+		if drain {
+			if event := r.drainBrokerTopic(ctx, logger, broker, drainedResource, securityOption, topicConfig); event != nil {
+				return event
+			}
+			if err := r.deleteDrainedResourceFromContractConfigMap(ctx, logger, broker, drain); err != nil {
+				return err
+			}
+		}
+
+		if r.Env.TopicDeletionGracePeriod > 0 && strimziConfig == nil {
+			// The auth secret finalizer is removed once the topic is deleted.
+			// The topics of brokers referencing a Strimzi Kafka cluster are deleted immediately, since deferred
+			// deletions only record an auth secret.
+			return r.scheduleBrokerTopicDeletion(ctx, logger, broker, authSecrets, topicConfig)
+		}
+
+		err = r.finalizeNonExternalBrokerTopic(ctx, broker, secret, trustBundleRef, securityOption, topicConfig, logger)
+
+		// if finalizeNonExternalBrokerTopic returns error that kafka is not reachable!
+		if err != nil {
+			if strings.Contains(err.Error(), "cannot obtain Kafka") {
+
+				// If the kafka cluster is not reachable we give it a few more retries, to see if there was
+				// some temporary network issue and requeue the finalization.
+				// If we tried often enough and the kafka cluster is still not reachable,
+				// we return nil and delete the broker
+				brokerUUID := string(broker.GetUID())
+				if r.Counter.Inc(brokerUUID) <= 5 {
+					return controller.NewRequeueAfter(5 * time.Second)
+				} else {
+					r.Counter.Del(brokerUUID) // clean up the reference from the counter
+					logger.Error("Error reaching Kafka cluster", zap.Error(err))
+					return nil
+				}
+			}
+			return err
+		}
+	}
+
+	return r.removeFinalizerSecretOnceTopicFinalized(ctx, broker, authSecrets)
+}
+
+// removeFinalizerSecretOnceTopicFinalized removes the auth secret finalizer from the given auth secrets once the broker
+// topic has been finalized, when the removal fails, the broker is marked with TopicFinalizedStatusAnnotation so that
+// the next finalization skips the topic finalization.
+func (r *Reconciler) removeFinalizerSecretOnceTopicFinalized(ctx context.Context, broker *eventing.Broker, secrets []*corev1.Secret) error {
+	for _, secret := range secrets {
+		if err := r.removeFinalizerSecret(ctx, r.finalizerSecret(broker), secret); err != nil {
+			if broker.Status.Annotations == nil {
+				broker.Status.Annotations = make(map[string]string, 1)
+			}
+			broker.Status.Annotations[TopicFinalizedStatusAnnotation] = "true"
+			return err
+		}
+	}
+	return nil
+}
+
+// removeFinalizerSecretFromStatus removes the auth secret finalizer from the auth secrets recorded in the broker status
+// annotations, without resolving the broker config.
+func (r *Reconciler) removeFinalizerSecretFromStatus(ctx context.Context, broker *eventing.Broker) error {
+	for _, ref := range authSecretReferences(broker) {
+		secret, err := r.SecretProviderFunc()(ctx, ref.Namespace, ref.Name)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get secret %s: %w", ref, err)
+		}
+		if err := r.removeFinalizerSecret(ctx, r.finalizerSecret(broker), secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteResourceFromContractConfigMap deletes the broker resource from the contract, it returns true if the contract
+// had a resource for the broker.
+func (r *Reconciler) deleteResourceFromContractConfigMap(ctx context.Context, logger *zap.Logger, broker *eventing.Broker) (bool, error) {
+	resource, err := r.finalizeContractResource(ctx, logger, broker, false)
+	return resource != nil, err
+}
+
+// finalizeContractResource deletes the broker resource from the contract, or only its ingress when the broker is
+// drained, it returns the broker resource the contract had, if any.
+func (r *Reconciler) finalizeContractResource(ctx context.Context, logger *zap.Logger, broker *eventing.Broker, drain bool) (*contract.Resource, error) {
+	// Get contract config map.
+	shard := r.ContractConfigMapShard(broker)
+	contractConfigMap, err := r.GetOrCreateDataPlaneConfigMapShard(ctx, shard)
+	// Handles https://github.com/knative-sandbox/eventing-kafka-broker/issues/2893
 	// When the system namespace is deleted while we're running there is no point in
 	// trying to delete the resource from the ConfigMap since the entire ConfigMap
 	// is gone.
 	if apierrors.IsForbidden(err) {
-		return nil
+		return nil, nil
 	}
 	if err != nil {
-		return fmt.Errorf("failed to get contract config map %s: %w", r.DataPlaneConfigMapAsString(), err)
+		return nil, fmt.Errorf("failed to get contract config map %s: %w", r.DataPlaneConfigMapAsString(), err)
 	}
 
 	logger.Debug("Got contract config map")
 
 	// Get contract data.
-	ct, err := r.GetDataPlaneConfigMapData(logger, contractConfigMap)
+	ct, err := r.contractFromConfigMap(ctx, logger, broker, contractConfigMap)
 	if err != nil {
-		return fmt.Errorf("failed to get contract: %w", err)
+		return nil, fmt.Errorf("failed to get contract: %w", err)
 	}
 
 	logger.Debug("Got contract data from config map", zap.Any(base.ContractLogKey, ct))
 
-	if err := r.DeleteResource(ctx, logger, broker.GetUID(), ct, contractConfigMap); err != nil {
-		return err
+	var resource *contract.Resource
+	if index := coreconfig.FindResource(ct, broker.GetUID()); index != coreconfig.NoResource {
+		resource = ct.Resources[index]
+	}
+	if drain {
+		if err := r.removeIngressFromContract(ctx, logger, resource, ct, contractConfigMap); err != nil {
+			return nil, err
+		}
+	} else if err := r.DeleteResource(ctx, logger, broker.GetUID(), ct, contractConfigMap); err != nil {
+		return nil, err
 	}
 
 	// We update receiver and dispatcher pods annotation regardless of our contract changed or not due to the fact
@@ -483,136 +1508,455 @@ func (r *Reconciler) deleteResourceFromContractConfigMap(ctx context.Context, lo
 	// to update anyway remaining annotations with the contract generation that was saved in the CM.
 	// Note: if there aren't changes to be done at the pod annotation level, we just skip the update.
 
-	// Update volume generation annotation of receiver pods
-	if err := r.UpdateReceiverPodsAnnotation(ctx, logger, ct.Generation); err != nil {
-		return err
+	// Update volume generation annotation of receiver pods
+	if err := r.UpdateReceiverPodsShardAnnotation(ctx, logger, shard, ct.Generation); err != nil {
+		return nil, err
+	}
+	// Update volume generation annotation of dispatcher pods
+	if err := r.UpdateDispatcherPodsShardAnnotation(ctx, logger, shard, ct.Generation); err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (r *Reconciler) finalizeNonExternalBrokerTopic(ctx context.Context, broker *eventing.Broker, secret *corev1.Secret, trustBundleRef *contract.TrustBundleReference, securityOption kafka.ConfigOption, topicConfig *kafka.TopicConfig, logger *zap.Logger) reconciler.Event {
+	saramaConfig, err := r.clusterAdminSaramaConfig(securityOption)
+	if err != nil {
+		// even in error case, we return `normal`, since we are fine with leaving the
+		// topic undeleted e.g. when we lose connection
+		return fmt.Errorf("error getting cluster admin sarama config: %w", err)
+	}
+
+	kafkaClusterAdminClient, err := r.newKafkaClusterAdminClientWithFailover(broker, secret, trustBundleRef, saramaConfig, topicConfig, controller.GetEventRecorder(ctx))
+	if err != nil {
+		// even in error case, we return `normal`, since we are fine with leaving the
+		// topic undeleted e.g. when we lose connection
+		return fmt.Errorf("cannot obtain Kafka cluster admin, %w", err)
+	}
+	defer kafkaClusterAdminClient.Close()
+
+	topicName, err := r.finalizedBrokerTopicName(broker)
+	if err != nil {
+		return err
+	}
+	topic, err := kafka.DeleteTopic(kafkaClusterAdminClient, topicName)
+	if err != nil {
+		if kafka.IsAuthorizationError(err) {
+			controller.GetEventRecorder(ctx).Eventf(broker, corev1.EventTypeWarning, base.ReasonTopicAuthorizationFailed,
+				"Not authorized to delete topic %s, grant the required ACLs to the Kafka client: %v", topicName, err)
+		}
+		return err
+	}
+
+	if timeout := r.Env.TopicDeletionConfirmationTimeout; timeout > 0 {
+		deleted, err := kafka.WaitTopicDeleted(kafkaClusterAdminClient, topic, timeout, topicDeletionConfirmationPollInterval)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			logger.Debug("Topic deletion not confirmed yet", zap.String("topic", topic))
+			return controller.NewRequeueAfter(topicDeletionConfirmationRequeueDelay)
+		}
+	}
+
+	logger.Debug("Topic deleted", zap.String("topic", topic))
+	return nil
+}
+
+// finalizedBrokerTopicName returns the name of the topic of the given broker being finalized.
+func (r *Reconciler) finalizedBrokerTopicName(broker *eventing.Broker) (string, error) {
+	topicName, ok := broker.Status.Annotations[kafka.TopicAnnotation]
+	if !ok && r.BrokerTopicTemplate == nil {
+		return "", fmt.Errorf("no topic annotated on broker")
+	}
+	if !ok {
+		// The topic name is derived from the configured template, so we can still clean up a topic that might have
+		// been created without being recorded in the broker status.
+		return r.brokerTopicName(broker)
+	}
+	return topicName, nil
+}
+
+// clusterAdminSaramaConfig returns the config of the Kafka cluster admin clients with the configured request
+// timeouts applied.
+func (r *Reconciler) clusterAdminSaramaConfig(securityOption kafka.ConfigOption) (*sarama.Config, error) {
+	return kafka.GetSaramaConfig(
+		securityOption,
+		kafka.TimeoutsConfigOption(r.Env.ClusterAdminDialTimeout, r.Env.ClusterAdminReadTimeout, r.Env.ClusterAdminMetadataTimeout),
+	)
+}
+
+// newKafkaClusterAdminClient returns a Kafka cluster admin client from the ClusterAdminPool, when configured, or a
+// new one otherwise.
+func (r *Reconciler) newKafkaClusterAdminClient(bootstrapServers []string, secret *corev1.Secret, trustBundleRef *contract.TrustBundleReference, config *sarama.Config) (sarama.ClusterAdmin, error) {
+	// The ClusterAdminPool checks the circuit breaker itself, so that the errors of the pooled clients are recorded.
+	if r.ClusterAdminCircuitBreaker == nil || r.ClusterAdminPool != nil {
+		admin, err := r.createKafkaClusterAdminClient(bootstrapServers, secret, trustBundleRef, config)
+		r.recordKafkaConnectivity(bootstrapServers, err)
+		return admin, err
+	}
+
+	if err := r.ClusterAdminCircuitBreaker.Allow(bootstrapServers); err != nil {
+		return nil, err
+	}
+	admin, err := r.createKafkaClusterAdminClient(bootstrapServers, secret, trustBundleRef, config)
+	r.recordKafkaConnectivity(bootstrapServers, err)
+	if err != nil {
+		r.ClusterAdminCircuitBreaker.RecordFailure(bootstrapServers)
+		return nil, err
+	}
+	r.ClusterAdminCircuitBreaker.RecordSuccess(bootstrapServers)
+	return admin, nil
+}
+
+func (r *Reconciler) createKafkaClusterAdminClient(bootstrapServers []string, secret *corev1.Secret, trustBundleRef *contract.TrustBundleReference, config *sarama.Config) (sarama.ClusterAdmin, error) {
+	config, err := kafka.WithDialer(r.DialerFactory, bootstrapServers, config)
+	if err != nil {
+		return nil, err
+	}
+	var admin sarama.ClusterAdmin
+	if r.ClusterAdminPool != nil {
+		admin, err = r.ClusterAdminPool.Get(bootstrapServers, secret, trustBundleRef, config)
+	} else {
+		admin, err = r.NewKafkaClusterAdminClient(bootstrapServers, config)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if r.TopicMetadataCache != nil {
+		return r.TopicMetadataCache.Wrap(admin, bootstrapServers), nil
+	}
+	return admin, nil
+}
+
+// newKafkaClusterAdminClientWithFailover returns a Kafka cluster admin client for the first reachable cluster among
+// the primary and the failover clusters of the given topic config.
+//
+// topicConfig.BootstrapServers is set to the bootstrap servers of the reachable cluster, so that the contract
+// reflects the active cluster.
+func (r *Reconciler) newKafkaClusterAdminClientWithFailover(broker *eventing.Broker, secret *corev1.Secret, trustBundleRef *contract.TrustBundleReference, config *sarama.Config, topicConfig *kafka.TopicConfig, recorder record.EventRecorder) (sarama.ClusterAdmin, error) {
+	var err error
+	clusters := topicConfig.AllBootstrapServers()
+	for i, bootstrapServers := range clusters {
+		var admin sarama.ClusterAdmin
+		admin, err = r.newKafkaClusterAdminClient(bootstrapServers, secret, trustBundleRef, config)
+		if err == nil {
+			topicConfig.BootstrapServers = bootstrapServers
+			return admin, nil
+		}
+		if i+1 < len(clusters) && recorder != nil {
+			recorder.Eventf(broker, corev1.EventTypeWarning, "BootstrapServersFailover",
+				"failed to connect to Kafka cluster %s, falling back to %s: %v",
+				kafka.BootstrapServersCommaSeparated(bootstrapServers),
+				kafka.BootstrapServersCommaSeparated(clusters[i+1]),
+				err,
+			)
+		}
+	}
+	return nil, err
+}
+
+// describeKafkaCluster logs the connection metadata of the given cluster admin client and records the id of the
+// Kafka cluster in the KafkaClusterIDStatusAnnotation.
+//
+// Failing to describe the cluster doesn't fail the reconciliation, since the cluster id is informational.
+func (r *Reconciler) describeKafkaCluster(broker *eventing.Broker, admin sarama.ClusterAdmin, config *sarama.Config, topicConfig *kafka.TopicConfig, logger *zap.Logger) {
+	if r.DescribeKafkaCluster == nil {
+		return
+	}
+
+	// Credentials are never logged, only the security protocol and the SASL mechanism.
+	fields := []zap.Field{
+		zap.String("bootstrapServers", topicConfig.GetBootstrapServers()),
+		zap.Bool("tls", config.Net.TLS.Enable),
+		zap.Bool("sasl", config.Net.SASL.Enable),
+	}
+	if config.Net.SASL.Enable {
+		fields = append(fields, zap.String("saslMechanism", string(config.Net.SASL.Mechanism)))
+	}
+
+	description, err := r.DescribeKafkaCluster(admin, config)
+	if err != nil {
+		logger.Debug("Failed to describe Kafka cluster", append(fields, zap.Error(err))...)
+		return
+	}
+	logger.Debug("Kafka cluster admin client connected", append(fields,
+		zap.Strings("brokers", description.Brokers),
+		zap.Int32("controllerId", description.ControllerID),
+		zap.String("clusterId", description.ClusterID),
+	)...)
+
+	if description.ClusterID == "" {
+		delete(broker.Status.Annotations, KafkaClusterIDStatusAnnotation)
+		return
+	}
+	broker.Status.Annotations[KafkaClusterIDStatusAnnotation] = description.ClusterID
+}
+
+// contractFromConfigMap returns the contract stored in the given contract config map.
+//
+// When the stored contract is corrupted, it returns a new empty contract and all brokers are reconciled again to add
+// their resources back to it.
+func (r *Reconciler) contractFromConfigMap(ctx context.Context, logger *zap.Logger, broker *eventing.Broker, contractConfigMap *corev1.ConfigMap) (*contract.Contract, error) {
+	ct, err := r.GetDataPlaneConfigMapData(logger, contractConfigMap)
+	if !base.IsContractCorrupted(err) {
+		return ct, err
+	}
+
+	logger.Warn("Contract is corrupted, rebuilding it", zap.Error(err))
+	controller.GetEventRecorder(ctx).Eventf(broker, corev1.EventTypeWarning, "ContractRebuild",
+		"Contract config map %s/%s is corrupted, rebuilding it from all brokers", contractConfigMap.Namespace, contractConfigMap.Name)
+
+	if r.ResyncBrokers != nil {
+		r.ResyncBrokers()
+	}
+	return r.NewRebuiltContract(contractConfigMap), nil
+}
+
+func (r *Reconciler) brokerConfigMap(logger *zap.Logger, broker *eventing.Broker) (*corev1.ConfigMap, error) {
+	logger.Debug("broker config", zap.Any("broker.spec.config", broker.Spec.Config))
+
+	if kafka.IsBrokerConfigSecret(broker) {
+		return kafka.BrokerConfigFromSecret(r.SecretLister, broker)
+	}
+
+	cm, err := kafka.BrokerConfigMapWithFallback(r.ConfigMapLister, broker, r.Env.BrokerConfigFallbackNamespace)
+	if err == nil {
+		source := "broker namespace"
+		if broker.Spec.Config.Namespace != "" {
+			source = "config namespace"
+		} else if cm.Namespace != broker.Namespace {
+			source = "fallback namespace"
+		}
+		logger.Debug("Broker config resolved", zap.String("namespace", cm.Namespace), zap.String("source", source))
+	}
+	return cm, err
+}
+
+// trackBrokerConfig tracks the ConfigMap or the Secret holding the broker config.
+func (r *Reconciler) trackBrokerConfig(broker *eventing.Broker, brokerConfig *corev1.ConfigMap) error {
+	if brokerConfig != nil && kafka.IsBrokerConfigSecret(broker) {
+		return r.TrackSecret(&corev1.Secret{ObjectMeta: brokerConfig.ObjectMeta}, broker)
+	}
+	if brokerConfig != nil && brokerConfig.Namespace != kafka.BrokerConfigNamespace(broker) {
+		// The config has been found in the fallback namespace, track the broker namespace ConfigMap too since it
+		// takes precedence once created.
+		brokerNamespaceConfig := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: kafka.BrokerConfigNamespace(broker), Name: brokerConfig.Name}}
+		if err := r.TrackConfigMap(brokerNamespaceConfig, broker); err != nil {
+			return err
+		}
+	}
+	return r.TrackConfigMap(brokerConfig, broker)
+}
+
+// withDefaultBootstrapServers returns the given broker config with the default bootstrap servers when the config
+// doesn't specify them, the namespace default takes precedence over the controller default.
+func (r *Reconciler) withDefaultBootstrapServers(logger *zap.Logger, broker *eventing.Broker, brokerConfig *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	if brokerConfig == nil || len(brokerConfig.Data) == 0 {
+		// The broker config is gone, there is nothing to default.
+		return brokerConfig, nil
+	}
+	if bootstrapServers := strings.TrimSpace(brokerConfig.Data[kafka.BootstrapServersConfigMapKey]); bootstrapServers != "" {
+		logger.Debug("Bootstrap servers resolved", zap.String("bootstrapServers", bootstrapServers), zap.String("source", "broker config"))
+		return brokerConfig, nil
+	}
+
+	namespaceDefaults := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: broker.Namespace, Name: NamespaceDefaultsConfigMapName}}
+	// Track the namespace defaults even when they don't exist, so that brokers are reconciled when they're created.
+	if err := r.TrackConfigMap(namespaceDefaults, broker); err != nil {
+		return nil, fmt.Errorf("failed to track namespace defaults: %w", err)
+	}
+
+	source, bootstrapServers := "controller default", r.BootstrapServers
+	cm, err := r.ConfigMapLister.ConfigMaps(namespaceDefaults.Namespace).Get(namespaceDefaults.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get configmap %s/%s: %w", namespaceDefaults.Namespace, namespaceDefaults.Name, err)
 	}
-	// Update volume generation annotation of dispatcher pods
-	if err := r.UpdateDispatcherPodsAnnotation(ctx, logger, ct.Generation); err != nil {
-		return err
+	if err == nil && strings.TrimSpace(cm.Data[kafka.BootstrapServersConfigMapKey]) != "" {
+		source, bootstrapServers = "namespace default", strings.TrimSpace(cm.Data[kafka.BootstrapServersConfigMapKey])
+	}
+	if bootstrapServers == "" {
+		return brokerConfig, nil
 	}
 
-	return nil
+	logger.Debug("Bootstrap servers resolved", zap.String("bootstrapServers", bootstrapServers), zap.String("source", source))
+
+	brokerConfig = brokerConfig.DeepCopy()
+	brokerConfig.Data[kafka.BootstrapServersConfigMapKey] = bootstrapServers
+	return brokerConfig, nil
 }
 
-func (r *Reconciler) finalizeNonExternalBrokerTopic(broker *eventing.Broker, securityOption kafka.ConfigOption, topicConfig *kafka.TopicConfig, logger *zap.Logger) reconciler.Event {
-	saramaConfig, err := kafka.GetSaramaConfig(securityOption)
+func (r *Reconciler) topicConfig(logger *zap.Logger, broker *eventing.Broker, brokerConfig *corev1.ConfigMap) (*kafka.TopicConfig, error) {
+	brokerConfig, err := r.withDefaultBootstrapServers(logger, broker, brokerConfig)
 	if err != nil {
-		// even in error case, we return `normal`, since we are fine with leaving the
-		// topic undeleted e.g. when we lose connection
-		return fmt.Errorf("error getting cluster admin sarama config: %w", err)
+		return nil, err
 	}
 
-	kafkaClusterAdminClient, err := r.NewKafkaClusterAdminClient(topicConfig.BootstrapServers, saramaConfig)
+	topicConfig, err := kafka.TopicConfigFromConfigMap(logger, brokerConfig)
 	if err != nil {
-		// even in error case, we return `normal`, since we are fine with leaving the
-		// topic undeleted e.g. when we lose connection
-		return fmt.Errorf("cannot obtain Kafka cluster admin, %w", err)
+		// Check if the rebuilt CM is empty
+		if brokerConfig != nil && len(brokerConfig.Data) == 0 {
+			return nil, fmt.Errorf("unable to rebuild topic config, failed to get configmap %s/%s", kafka.BrokerConfigNamespace(broker), broker.Spec.Config.Name)
+		}
+		return nil, fmt.Errorf("unable to build topic config from configmap: %w", err)
 	}
-	defer kafkaClusterAdminClient.Close()
 
-	topicName, ok := broker.Status.Annotations[kafka.TopicAnnotation]
-	if !ok {
-		return fmt.Errorf("no topic annotated on broker")
-	}
-	topic, err := kafka.DeleteTopic(kafkaClusterAdminClient, topicName)
-	if err != nil {
-		return err
+	if kafka.IsBrokerConfigSecret(broker) {
+		storeConfigMapAsStatusAnnotation(broker, secretBrokerConfigStatus(brokerConfig))
+	} else {
+		storeConfigMapAsStatusAnnotation(broker, brokerConfig)
 	}
 
-	logger.Debug("Topic deleted", zap.String("topic", topic))
-	return nil
+	return topicConfig, nil
 }
 
-func (r *Reconciler) brokerNamespace(broker *eventing.Broker) string {
-	namespace := broker.Spec.Config.Namespace
-	if namespace == "" {
-		// Namespace not specified, use broker namespace.
-		namespace = broker.Namespace
+// topicConfigFromAnnotations applies the topic configurations overridden by the broker annotations to the given
+// topic config.
+func topicConfigFromAnnotations(broker *eventing.Broker, topicConfig *kafka.TopicConfig) error {
+	retention, ok := broker.GetAnnotations()[TopicRetentionMsAnnotation]
+	if !ok {
+		return nil
 	}
-	return namespace
-}
 
-func (r *Reconciler) brokerConfigMap(logger *zap.Logger, broker *eventing.Broker) (*corev1.ConfigMap, error) {
-	logger.Debug("broker config", zap.Any("broker.spec.config", broker.Spec.Config))
+	retentionMs, err := strconv.ParseInt(retention, 10, 64)
+	if err != nil || retentionMs < 0 {
+		return fmt.Errorf("invalid %s annotation value %q: expected a non-negative number of milliseconds", TopicRetentionMsAnnotation, retention)
+	}
 
-	if strings.ToLower(broker.Spec.Config.Kind) != "configmap" {
-		return nil, fmt.Errorf("supported config Kind: ConfigMap - got %s", broker.Spec.Config.Kind)
+	if topicConfig.TopicDetail.ConfigEntries == nil {
+		topicConfig.TopicDetail.ConfigEntries = make(map[string]*string, 1)
 	}
+	retention = strconv.FormatInt(retentionMs, 10)
+	topicConfig.TopicDetail.ConfigEntries[kafka.RetentionMsTopicConfigKey] = &retention
 
-	namespace := r.brokerNamespace(broker)
+	return nil
+}
 
-	// There might be cases where the ConfigMap is deleted before the Broker.
-	// In these cases, we rebuild the ConfigMap from broker status annotations.
-	//
-	// These annotations aren't guaranteed to be there or valid since there might
-	// be other actions messing with them, so when we try to rebuild the ConfigMap's
-	// data but the guess is wrong or data are invalid we return the
-	// `StatusReasonNotFound` error instead of the error generated from the fake
-	// "re-built" ConfigMap.
+// topicPartitionsFromThroughput sets the number of partitions of the broker topic to the number of partitions
+// required to sustain the TopicTargetThroughputAnnotation, when set, and records it in the
+// TopicPartitionsStatusAnnotation.
+//
+// The number of partitions of an existing topic is only ever increased, so lowering the target throughput doesn't
+// remove partitions.
+func (r *Reconciler) topicPartitionsFromThroughput(broker *eventing.Broker, topicConfig *kafka.TopicConfig) error {
+	throughput, ok := broker.GetAnnotations()[TopicTargetThroughputAnnotation]
+	if !ok {
+		delete(broker.Status.Annotations, TopicPartitionsStatusAnnotation)
+		return nil
+	}
 
-	cm, getCmError := r.ConfigMapLister.ConfigMaps(namespace).Get(broker.Spec.Config.Name)
-	if getCmError != nil && !apierrors.IsNotFound(getCmError) {
-		return cm, fmt.Errorf("failed to get configmap %s/%s: %w", namespace, broker.Spec.Config.Name, getCmError)
+	eventsPerSecond, err := strconv.ParseInt(throughput, 10, 64)
+	if err != nil || eventsPerSecond <= 0 {
+		return fmt.Errorf("invalid %s annotation value %q: expected a positive number of events per second", TopicTargetThroughputAnnotation, throughput)
 	}
-	if apierrors.IsNotFound(getCmError) {
-		// will at least return an empty CM
-		cm = rebuildCMFromStatusAnnotations(broker)
+	capacity := int64(r.Env.PartitionCapacityEventsPerSecond)
+	if capacity <= 0 {
+		return fmt.Errorf("the %s annotation requires the partition capacity to be configured", TopicTargetThroughputAnnotation)
 	}
 
-	return cm, getCmError
-}
+	partitions := (eventsPerSecond + capacity - 1) / capacity
+	if partitions > math.MaxInt32 {
+		partitions = math.MaxInt32
+	}
+	topicConfig.TopicDetail.NumPartitions = int32(partitions)
 
-func (r *Reconciler) topicConfig(logger *zap.Logger, broker *eventing.Broker, brokerConfig *corev1.ConfigMap) (*kafka.TopicConfig, error) {
-	topicConfig, err := kafka.TopicConfigFromConfigMap(logger, brokerConfig)
-	if err != nil {
-		// Check if the rebuilt CM is empty
-		if brokerConfig != nil && len(brokerConfig.Data) == 0 {
-			return nil, fmt.Errorf("unable to rebuild topic config, failed to get configmap %s/%s", r.brokerNamespace(broker), broker.Spec.Config.Name)
-		}
-		return nil, fmt.Errorf("unable to build topic config from configmap: %w - ConfigMap data: %v", err, brokerConfig.Data)
+	if broker.Status.Annotations == nil {
+		broker.Status.Annotations = make(map[string]string, 1)
 	}
+	broker.Status.Annotations[TopicPartitionsStatusAnnotation] = fmt.Sprintf(
+		"%d partitions for a target throughput of %d events/s with a partition capacity of %d events/s",
+		partitions, eventsPerSecond, capacity,
+	)
+	return nil
+}
 
-	storeConfigMapAsStatusAnnotation(broker, brokerConfig)
+// reconcilerStatusAnnotations are the broker status annotations set by the reconciler, as opposed to the ones
+// copied from the broker ConfigMap.
+var reconcilerStatusAnnotations = sets.NewString(
+	kafka.TopicAnnotation,
+	base.TopicOwnerAnnotation,
+	DryRunStatusAnnotation,
+	ActiveBootstrapServersStatusAnnotation,
+	KafkaClusterIDStatusAnnotation,
+	ContractResourceStatusAnnotation,
+	DiagnosticsStatusAnnotation,
+	TopicFinalizedStatusAnnotation,
+	TopicFinalizationPendingStatusAnnotation,
+	TopicRecreateStatusAnnotation,
+	TopicPartitionsStatusAnnotation,
+	TopicConfigStatusAnnotation,
+	DeliveryRetryStatusAnnotation,
+	DeliveryBackoffPolicyStatusAnnotation,
+	DeliveryBackoffDelayStatusAnnotation,
+	DefaultBackoffDelayStatusAnnotation,
+	DrainStartedStatusAnnotation,
+)
 
-	return topicConfig, nil
+// secretBrokerConfigStatusKeys are the keys of Secret based broker configs stored in the broker status annotations,
+// trigger reconcilers need them to connect to the Kafka cluster and bootstrap servers are part of the data plane
+// contract anyway.
+var secretBrokerConfigStatusKeys = []string{
+	kafka.BootstrapServersConfigMapKey,
+	security.AuthSecretNameKey,
+	security.AuthSecretNamesKey,
+}
+
+// secretBrokerConfigStatus returns the part of the given Secret based broker config stored in the broker status
+// annotations, the other keys aren't stored since Secret based configs are sensitive, which means that Secret based
+// configs can't be rebuilt from the status annotations.
+func secretBrokerConfigStatus(brokerConfig *corev1.ConfigMap) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{Data: make(map[string]string, len(secretBrokerConfigStatusKeys))}
+	for _, k := range secretBrokerConfigStatusKeys {
+		if v, ok := brokerConfig.Data[k]; ok {
+			cm.Data[k] = v
+		}
+	}
+	return cm
 }
 
 // Save ConfigMap's data into broker annotations, to prevent issue when the ConfigMap itself is being deleted
+//
+// Keys removed from the ConfigMap are removed from the annotations too, so that a ConfigMap rebuilt from the
+// annotations doesn't use stale values.
 func storeConfigMapAsStatusAnnotation(broker *eventing.Broker, cm *corev1.ConfigMap) {
 	if broker.Status.Annotations == nil {
 		broker.Status.Annotations = make(map[string]string, len(cm.Data))
 	}
 
+	for k := range broker.Status.Annotations {
+		if _, ok := cm.Data[k]; !ok && !reconcilerStatusAnnotations.Has(k) {
+			delete(broker.Status.Annotations, k)
+		}
+	}
+
 	for k, v := range cm.Data {
 		broker.Status.Annotations[k] = v
 	}
 }
 
-// Creates the Broker ConfigMap from the status annotation, if any present
-func rebuildCMFromStatusAnnotations(br *eventing.Broker) *corev1.ConfigMap {
-	cm := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: br.Spec.Config.Namespace,
-			Name:      br.Spec.Config.Name,
-		},
+func (r *Reconciler) reconcilerBrokerResource(ctx context.Context, topic string, broker *eventing.Broker, secret *corev1.Secret, config *kafka.TopicConfig) (*contract.Resource, error) {
+	ingressPath, err := IngressPath(broker)
+	if err != nil {
+		return nil, err
 	}
-	for k, v := range br.Status.Annotations {
-		if cm.Data == nil {
-			cm.Data = make(map[string]string, len(br.Status.Annotations))
-		}
-		cm.Data[k] = v
+	ingressContentMode, err := IngressContentMode(broker)
+	if err != nil {
+		return nil, err
+	}
+	ingressFilter, err := IngressFilter(broker)
+	if err != nil {
+		return nil, err
 	}
-	return cm
-}
 
-func (r *Reconciler) reconcilerBrokerResource(ctx context.Context, topic string, broker *eventing.Broker, secret *corev1.Secret, config *kafka.TopicConfig) (*contract.Resource, error) {
 	resource := &contract.Resource{
 		Uid:    string(broker.UID),
 		Topics: []string{topic},
 		Ingress: &contract.Ingress{
-			Path:                       receiver.PathFromObject(broker),
+			ContentMode:                ingressContentMode,
+			Path:                       ingressPath,
 			EnableAutoCreateEventTypes: feature.FromContext(ctx).IsEnabled(feature.EvenTypeAutoCreate),
+			Filter:                     ingressFilter,
 		},
 		BootstrapServers: config.GetBootstrapServers(),
 		Reference: &contract.Reference{
@@ -622,6 +1966,12 @@ func (r *Reconciler) reconcilerBrokerResource(ctx context.Context, topic string,
 		},
 	}
 
+	if _, externalTopic := isExternalTopic(broker); !externalTopic {
+		// The receiver rejects events larger than the topic max.message.bytes early, instead of failing to produce
+		// them, the config of external topics isn't managed so their limit is left to Kafka.
+		resource.Ingress.MaxMessageBytes = config.MaxMessageBytes()
+	}
+
 	if secret != nil {
 		resource.Auth = &contract.Resource_AuthSecret{
 			AuthSecret: &contract.Reference{
@@ -633,13 +1983,374 @@ func (r *Reconciler) reconcilerBrokerResource(ctx context.Context, topic string,
 		}
 	}
 
-	egressConfig, err := coreconfig.EgressConfigFromDelivery(ctx, r.Resolver, broker, broker.Spec.Delivery, r.DefaultBackoffDelayMs)
+	defaultBackoffDelayMs, err := DefaultBackoffDelayMs(broker, r.DefaultBackoffDelayMs)
+	if err != nil {
+		return nil, err
+	}
+
+	egressConfig, err := coreconfig.EgressConfigFromDelivery(ctx, r.Resolver, broker, broker.Spec.Delivery, defaultBackoffDelayMs)
 	if err != nil {
 		return nil, err
 	}
 	resource.EgressConfig = egressConfig
 
-	return resource, nil
+	deadLetterContentMode, err := DeadLetterSinkContentMode(broker)
+	if err != nil {
+		return nil, err
+	}
+	if egressConfig.GetDeadLetter() != "" {
+		egressConfig.DeadLetterContentMode = deadLetterContentMode
+	}
+
+	backoffJitter, err := DeliveryBackoffJitter(broker)
+	if err != nil {
+		return nil, err
+	}
+	if egressConfig.GetRetry() > 0 {
+		egressConfig.BackoffJitter = backoffJitter
+	}
+
+	deliveryOrder, err := DeliveryOrder(broker)
+	if err != nil {
+		return nil, err
+	}
+	if _, externalTopic := isExternalTopic(broker); deliveryOrder == contract.DeliveryOrder_ORDERED && externalTopic {
+		// External topics are written by producers whose partitioning scheme is unknown, so events that should be
+		// ordered might be spread across partitions.
+		controller.GetEventRecorder(ctx).Eventf(broker, corev1.EventTypeWarning, "DeliveryOrderNotGuaranteed",
+			"Ordered delivery requires a deterministic partitioning scheme, external topic %s isn't partitioned by the broker, falling back to unordered delivery", topic)
+		deliveryOrder = contract.DeliveryOrder_UNORDERED
+	}
+	resource.DeliveryOrder = deliveryOrder
+
+	producerAcks, err := ProducerAcks(broker)
+	if err != nil {
+		return nil, err
+	}
+	resource.ProducerAcks = producerAcks
+
+	return r.mutateResource(ctx, broker, resource)
+}
+
+// setDeliveryStatusAnnotations records the retry policy of the given egress config, as programmed into the data plane
+// contract, in the broker status annotations, so that users can check how their delivery spec has been resolved.
+func setDeliveryStatusAnnotations(broker *eventing.Broker, egressConfig *contract.EgressConfig) {
+	if egressConfig.GetRetry() == 0 {
+		delete(broker.Status.Annotations, DeliveryRetryStatusAnnotation)
+		delete(broker.Status.Annotations, DeliveryBackoffPolicyStatusAnnotation)
+		delete(broker.Status.Annotations, DeliveryBackoffDelayStatusAnnotation)
+		return
+	}
+
+	policy := eventingduck.BackoffPolicyExponential
+	if egressConfig.GetBackoffPolicy() == contract.BackoffPolicy_Linear {
+		policy = eventingduck.BackoffPolicyLinear
+	}
+	broker.Status.Annotations[DeliveryRetryStatusAnnotation] = strconv.FormatUint(uint64(egressConfig.GetRetry()), 10)
+	broker.Status.Annotations[DeliveryBackoffPolicyStatusAnnotation] = string(policy)
+	broker.Status.Annotations[DeliveryBackoffDelayStatusAnnotation] = (time.Duration(egressConfig.GetBackoffDelay()) * time.Millisecond).String()
+}
+
+// reportDefaultBackoffDelay records the default backoff delay applied to the given egress config, when the broker
+// delivery spec sets retries without a backoff delay, in the DefaultBackoffDelayStatusAnnotation status annotation,
+// and it emits an event the first time a given default is applied.
+func reportDefaultBackoffDelay(ctx context.Context, broker *eventing.Broker, egressConfig *contract.EgressConfig) {
+	if egressConfig.GetRetry() == 0 || broker.Spec.Delivery.BackoffDelay != nil {
+		delete(broker.Status.Annotations, DefaultBackoffDelayStatusAnnotation)
+		return
+	}
+
+	delay := (time.Duration(egressConfig.GetBackoffDelay()) * time.Millisecond).String()
+	if broker.Status.Annotations[DefaultBackoffDelayStatusAnnotation] == delay {
+		return
+	}
+	broker.Status.Annotations[DefaultBackoffDelayStatusAnnotation] = delay
+	controller.GetEventRecorder(ctx).Eventf(broker, corev1.EventTypeNormal, "DefaultBackoffDelayApplied",
+		"Spec.Delivery.BackoffDelay isn't set, applied the default backoff delay %s to retries", delay)
+}
+
+// isDataPlaneAvailabilityGateSoft returns whether the given broker is reconciled while the receiver isn't running, it's
+// the DataPlaneAvailabilityGateAnnotation value, when set, or the SoftDataPlaneAvailabilityGate option.
+func (r *Reconciler) isDataPlaneAvailabilityGateSoft(broker *eventing.Broker) (bool, error) {
+	gate, ok := broker.GetAnnotations()[DataPlaneAvailabilityGateAnnotation]
+	if !ok {
+		return r.SoftDataPlaneAvailabilityGate, nil
+	}
+
+	switch gate {
+	case DataPlaneAvailabilityGateHard:
+		return false, nil
+	case DataPlaneAvailabilityGateSoft:
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid %s annotation value %q: expected %s or %s", DataPlaneAvailabilityGateAnnotation, gate, DataPlaneAvailabilityGateHard, DataPlaneAvailabilityGateSoft)
+}
+
+// DeliveryOrder returns the delivery order of the given broker egresses, it's the DeliveryOrderAnnotation value, when
+// set, or the unordered delivery.
+func DeliveryOrder(broker *eventing.Broker) (contract.DeliveryOrder, error) {
+	order, ok := broker.GetAnnotations()[DeliveryOrderAnnotation]
+	if !ok {
+		return contract.DeliveryOrder_UNORDERED, nil
+	}
+
+	switch kafkasource.DeliveryOrdering(order) {
+	case kafkasource.Unordered:
+		return contract.DeliveryOrder_UNORDERED, nil
+	case kafkasource.Ordered:
+		return contract.DeliveryOrder_ORDERED, nil
+	}
+	return contract.DeliveryOrder_UNORDERED, fmt.Errorf("invalid %s annotation value %q: expected %s or %s", DeliveryOrderAnnotation, order, kafkasource.Unordered, kafkasource.Ordered)
+}
+
+// DeadLetterSinkContentMode returns the content mode used to send events to the dead letter sink of the given broker
+// and of its triggers, it's the DeadLetterSinkContentModeAnnotation value, when set, or the binary content mode.
+func DeadLetterSinkContentMode(broker *eventing.Broker) (contract.ContentMode, error) {
+	mode, ok := broker.GetAnnotations()[DeadLetterSinkContentModeAnnotation]
+	if !ok {
+		return contract.ContentMode_BINARY, nil
+	}
+
+	switch mode {
+	case kafkaeventing.ModeBinary:
+		return contract.ContentMode_BINARY, nil
+	case kafkaeventing.ModeStructured:
+		return contract.ContentMode_STRUCTURED, nil
+	}
+	return contract.ContentMode_BINARY, fmt.Errorf("invalid %s annotation value %q: expected %s or %s", DeadLetterSinkContentModeAnnotation, mode, kafkaeventing.ModeBinary, kafkaeventing.ModeStructured)
+}
+
+// IngressContentMode returns the content mode of the contract ingress of the given broker, it's the
+// IngressContentModeAnnotation value, when set, or the binary content mode, which leaves the content mode to the
+// receiver default.
+func IngressContentMode(broker *eventing.Broker) (contract.ContentMode, error) {
+	mode, ok := broker.GetAnnotations()[IngressContentModeAnnotation]
+	if !ok {
+		return contract.ContentMode_BINARY, nil
+	}
+
+	switch mode {
+	case kafkaeventing.ModeBinary:
+		return contract.ContentMode_BINARY, nil
+	case kafkaeventing.ModeStructured:
+		return contract.ContentMode_STRUCTURED, nil
+	}
+	return contract.ContentMode_BINARY, fmt.Errorf("invalid %s annotation value %q: expected %s or %s", IngressContentModeAnnotation, mode, kafkaeventing.ModeBinary, kafkaeventing.ModeStructured)
+}
+
+// DeliveryBackoffJitter returns the jitter applied to the retry backoff delays of the given broker and of its
+// triggers, it's the DeliveryBackoffJitterAnnotation value, when set, or no jitter.
+func DeliveryBackoffJitter(broker *eventing.Broker) (contract.BackoffJitter, error) {
+	jitter, ok := broker.GetAnnotations()[DeliveryBackoffJitterAnnotation]
+	if !ok {
+		return contract.BackoffJitter_NoJitter, nil
+	}
+
+	switch jitter {
+	case BackoffJitterNone:
+		return contract.BackoffJitter_NoJitter, nil
+	case BackoffJitterFull:
+		return contract.BackoffJitter_FullJitter, nil
+	case BackoffJitterEqual:
+		return contract.BackoffJitter_EqualJitter, nil
+	}
+	return contract.BackoffJitter_NoJitter, fmt.Errorf("invalid %s annotation value %q: expected %s, %s or %s", DeliveryBackoffJitterAnnotation, jitter, BackoffJitterNone, BackoffJitterFull, BackoffJitterEqual)
+}
+
+// ProducerAcks returns the acks required by the ingress producer of the given broker, it's the ProducerAcksAnnotation
+// value, when set, or the data plane default acks.
+func ProducerAcks(broker *eventing.Broker) (contract.ProducerAcks, error) {
+	acks, ok := broker.GetAnnotations()[ProducerAcksAnnotation]
+	if !ok {
+		return contract.ProducerAcks_DefaultAcks, nil
+	}
+
+	switch acks {
+	case ProducerAcksNone:
+		return contract.ProducerAcks_NoAcks, nil
+	case ProducerAcksLeader:
+		return contract.ProducerAcks_LeaderAcks, nil
+	case ProducerAcksAll:
+		return contract.ProducerAcks_AllAcks, nil
+	}
+	return contract.ProducerAcks_DefaultAcks, fmt.Errorf("invalid %s annotation value %q: expected %s, %s or %s", ProducerAcksAnnotation, acks, ProducerAcksNone, ProducerAcksLeader, ProducerAcksAll)
+}
+
+// IngressPath returns the path the given broker is served at by the ingress, it's the IngressPathAnnotation value,
+// when set, or /<namespace>/<name>.
+func IngressPath(broker *eventing.Broker) (string, error) {
+	ingressPath, ok := broker.GetAnnotations()[IngressPathAnnotation]
+	if !ok {
+		return receiver.PathFromObject(broker), nil
+	}
+
+	u, err := url.Parse(ingressPath)
+	if err != nil || u.Path != ingressPath || ingressPath == "/" || !strings.HasPrefix(ingressPath, "/") || path.Clean(ingressPath) != ingressPath {
+		return "", fmt.Errorf("invalid %s annotation value %q: expected an absolute and clean path, like /<segment>[/<segment>...]", IngressPathAnnotation, ingressPath)
+	}
+	return ingressPath, nil
+}
+
+// withIngressPath returns the given address served at the given ingress path.
+func withIngressPath(address duckv1.Addressable, ingressPath string) duckv1.Addressable {
+	address.URL.Path = ingressPath
+	return address
+}
+
+// IngressPathCollision is the error returned when the ingress path of a broker collides with the ingress path of
+// another broker.
+type IngressPathCollision struct {
+	Path   string
+	Broker types.NamespacedName
+}
+
+func (c IngressPathCollision) Error() string {
+	return fmt.Sprintf("ingress path %s collides with the ingress path of broker %s", c.Path, c.Broker)
+}
+
+// validateIngressPathCollision checks that the given ingress path of the broker isn't the ingress path of other brokers
+// of the same class, since the ingress wouldn't know which broker requests are sent to.
+//
+// Only the broker overriding its ingress path is refused, brokers keeping the default path are always served at it.
+func (r *Reconciler) validateIngressPathCollision(broker *eventing.Broker, ingressPath string) error {
+	if _, ok := broker.GetAnnotations()[IngressPathAnnotation]; !ok || r.BrokerLister == nil {
+		return nil
+	}
+	brokers, err := r.BrokerLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list brokers to check ingress path %s collisions: %w", ingressPath, err)
+	}
+	for _, b := range brokers {
+		if b.UID == broker.UID || b.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if b.GetAnnotations()[brokerreconciler.ClassAnnotationKey] != broker.GetAnnotations()[brokerreconciler.ClassAnnotationKey] {
+			continue
+		}
+		other, err := IngressPath(b)
+		if err != nil {
+			continue
+		}
+		if other == ingressPath {
+			return IngressPathCollision{
+				Path:   ingressPath,
+				Broker: types.NamespacedName{Namespace: b.Namespace, Name: b.Name},
+			}
+		}
+	}
+	return nil
+}
+
+// DefaultBackoffDelayMs returns the default backoff delay of the given broker egresses, it's the
+// DefaultBackoffDelayAnnotation value, when set, or the given default backoff delay.
+func DefaultBackoffDelayMs(broker *eventing.Broker, defaultBackoffDelayMs uint64) (uint64, error) {
+	backoffDelay, ok := broker.GetAnnotations()[DefaultBackoffDelayAnnotation]
+	if !ok {
+		return defaultBackoffDelayMs, nil
+	}
+
+	delay, err := coreconfig.DurationMillisFromISO8601String(&backoffDelay, defaultBackoffDelayMs)
+	if err != nil || delay == 0 {
+		return 0, fmt.Errorf("invalid %s annotation value %q: expected a positive ISO-8601 duration (for example, PT0.2S)", DefaultBackoffDelayAnnotation, backoffDelay)
+	}
+	return delay, nil
+}
+
+// externalTopicPresenceCheckBackoff returns the delay before checking again the presence of the external topic, and
+// whether the presence check should be retried at all.
+func (r *Reconciler) externalTopicPresenceCheckBackoff(broker *eventing.Broker) (time.Duration, bool) {
+	if r.ExternalTopicPresenceCheckAttempts <= 0 {
+		return 0, false
+	}
+	attempt := r.Counter.Inc(externalTopicCounterKey(broker))
+	if attempt > r.ExternalTopicPresenceCheckAttempts {
+		return 0, false
+	}
+	return r.Env.ExternalTopicPresenceCheckDelay(attempt), true
+}
+
+// probeNotReadyRequeueDelay returns the jittered delay before reconciling again a broker whose probes aren't ready,
+// it doubles at every consecutive not ready probe up to probeNotReadyRequeueMaxDelay.
+func (r *Reconciler) probeNotReadyRequeueDelay(broker *eventing.Broker) time.Duration {
+	attempt := r.Counter.Inc(probeNotReadyCounterKey(broker))
+	delay := probeNotReadyRequeueInitialDelay
+	for i := 1; i < attempt && delay < probeNotReadyRequeueMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > probeNotReadyRequeueMaxDelay {
+		delay = probeNotReadyRequeueMaxDelay
+	}
+	return wait.Jitter(delay, probeNotReadyRequeueJitter)
+}
+
+// ingressHost returns the host of the broker addresses, it's the ingress service hostname, or the ingress service
+// cluster IP of the IngressIPFamily when set, so that both the prober and the broker status use the same address.
+func (r *Reconciler) ingressHost() (string, error) {
+	if r.Env.IngressIPFamily == "" {
+		return network.GetServiceHostname(r.Env.IngressName, r.Reconciler.DataPlaneNamespace), nil
+	}
+	svc, err := r.ServiceLister.Services(r.Reconciler.DataPlaneNamespace).Get(r.Env.IngressName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get ingress service %s/%s: %w", r.Reconciler.DataPlaneNamespace, r.Env.IngressName, err)
+	}
+	return receiver.ServiceHost(svc, corev1.IPFamily(r.Env.IngressIPFamily))
+}
+
+// probeTimeout returns the timeout of probe requests, the Kafka features config map takes precedence over the
+// controller environment since it's watched and changes apply without restarting the controller.
+func (r *Reconciler) probeTimeout() time.Duration {
+	if timeout := r.KafkaFeatureFlags.ControllerProberTimeout(); timeout > 0 {
+		return timeout
+	}
+	return r.Env.ProbeTimeout
+}
+
+// probeReadyThreshold returns the number of consecutive probe results with the expected status required before
+// considering the probe successful.
+func (r *Reconciler) probeReadyThreshold() int {
+	if threshold := r.KafkaFeatureFlags.ControllerProberReadyThreshold(); threshold > 0 {
+		return threshold
+	}
+	return r.Env.ProbeReadyThreshold
+}
+
+// probeThresholdReached counts a probe result with the expected status for the given broker, and it returns the
+// number of consecutive results, the threshold and whether the threshold has been reached.
+func (r *Reconciler) probeThresholdReached(broker *eventing.Broker) (int, int, bool) {
+	threshold := r.probeReadyThreshold()
+	if threshold <= 1 {
+		return 1, threshold, true
+	}
+	count := r.Counter.Inc(probeCounterKey(broker))
+	return count, threshold, count >= threshold
+}
+
+func probeCounterKey(broker *eventing.Broker) string {
+	return "probe/" + string(broker.GetUID())
+}
+
+func probeNotReadyCounterKey(broker *eventing.Broker) string {
+	return "probe-not-ready/" + string(broker.GetUID())
+}
+
+func externalTopicCounterKey(broker *eventing.Broker) string {
+	return "external-topic/" + string(broker.GetUID())
+}
+
+// topicDeletePolicy returns the delete policy of the topic of the given broker, when the annotation is not set the
+// topic is deleted, unless TopicReuseByName is set so that a broker created again with the same namespace and name
+// adopts it.
+func (r *Reconciler) topicDeletePolicy(broker *eventing.Broker) string {
+	switch broker.Annotations[TopicDeletePolicyAnnotation] {
+	case TopicDeletePolicyRetain:
+		return TopicDeletePolicyRetain
+	case TopicDeletePolicyDelete:
+		return TopicDeletePolicyDelete
+	}
+	if r.Env.TopicReuseByName {
+		return TopicDeletePolicyRetain
+	}
+	return TopicDeletePolicyDelete
 }
 
 func isExternalTopic(broker *eventing.Broker) (string, bool) {
@@ -647,7 +2358,122 @@ func isExternalTopic(broker *eventing.Broker) (string, bool) {
 	return topicAnnotationValue, ok
 }
 
+func isSharedTopic(broker *eventing.Broker) (string, bool) {
+	topic, ok := broker.Annotations[SharedTopicAnnotation]
+	return topic, ok && topic != ""
+}
+
+// SharedTopicPartitionsMismatch is returned when brokers sharing a topic don't agree on the number of partitions of
+// the topic.
+type SharedTopicPartitionsMismatch struct {
+	Topic      string
+	Broker     types.NamespacedName
+	Partitions string
+	Desired    int32
+}
+
+func (m SharedTopicPartitionsMismatch) Error() string {
+	return fmt.Sprintf("brokers sharing topic %s must agree on the number of partitions: broker %s has %s partitions, desired %d partitions",
+		m.Topic, m.Broker, m.Partitions, m.Desired)
+}
+
+// validateSharedTopic checks that the other brokers sharing the given topic agree on its number of partitions, brokers
+// that haven't been reconciled yet are ignored.
+func (r *Reconciler) validateSharedTopic(broker *eventing.Broker, topic string, topicConfig *kafka.TopicConfig) error {
+	if r.BrokerLister == nil {
+		return nil
+	}
+	brokers, err := r.BrokerLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list brokers sharing topic %s: %w", topic, err)
+	}
+	desired := strconv.FormatInt(int64(topicConfig.TopicDetail.NumPartitions), 10)
+	for _, b := range brokers {
+		if b.UID == broker.UID || b.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if shared, ok := isSharedTopic(b); !ok || shared != topic {
+			continue
+		}
+		partitions, ok := b.Status.Annotations[kafka.DefaultTopicNumPartitionConfigMapKey]
+		if !ok || partitions == desired {
+			continue
+		}
+		return SharedTopicPartitionsMismatch{
+			Topic:      topic,
+			Broker:     types.NamespacedName{Namespace: b.Namespace, Name: b.Name},
+			Partitions: partitions,
+			Desired:    topicConfig.TopicDetail.NumPartitions,
+		}
+	}
+	return nil
+}
+
+// TopicNameCollision is returned when the topic of a broker collides with the topic of another broker, either because
+// they have the same name or because their names differ only by case or by '.' and '_' (see kafka.TopicNamesCollide).
+type TopicNameCollision struct {
+	Topic      string
+	Broker     types.NamespacedName
+	OtherTopic string
+}
+
+func (c TopicNameCollision) Error() string {
+	return fmt.Sprintf("topic %s collides with topic %s of broker %s", c.Topic, c.OtherTopic, c.Broker)
+}
+
+// validateTopicNameCollision checks that the given topic of the broker doesn't collide with the topic other brokers
+// have been reconciled with.
+//
+// Brokers sharing the same topic don't collide, and a broker already reconciled with the given topic keeps it, so that
+// only the broker computing a colliding topic name is refused.
+func (r *Reconciler) validateTopicNameCollision(broker *eventing.Broker, topic string) error {
+	if r.BrokerLister == nil || broker.Status.Annotations[kafka.TopicAnnotation] == topic {
+		return nil
+	}
+	brokers, err := r.BrokerLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list brokers to check topic %s name collisions: %w", topic, err)
+	}
+	sharedTopic, shared := isSharedTopic(broker)
+	for _, b := range brokers {
+		if b.UID == broker.UID || b.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if _, ok := isExternalTopic(b); ok {
+			continue
+		}
+		other, ok := b.Status.Annotations[kafka.TopicAnnotation]
+		if !ok || other == "" {
+			continue
+		}
+		if otherSharedTopic, ok := isSharedTopic(b); ok && shared && otherSharedTopic == sharedTopic {
+			continue
+		}
+		if other == topic || kafka.TopicNamesCollide(other, topic) {
+			return TopicNameCollision{
+				Topic:      topic,
+				Broker:     types.NamespacedName{Namespace: b.Namespace, Name: b.Name},
+				OtherTopic: other,
+			}
+		}
+	}
+	return nil
+}
+
+// authSecretNotFound marks the broker as not ready since the auth secret referenced by its config doesn't exist, the
+// missing secret is tracked so that the broker is reconciled again once the secret is created again.
+func (r *Reconciler) authSecretNotFound(broker *eventing.Broker, namespace, name string, statusConditionManager base.StatusConditionManager) error {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	if err := r.TrackSecret(secret, broker); err != nil {
+		return fmt.Errorf("failed to track secret: %w", err)
+	}
+	return statusConditionManager.AuthSecretNotFound(namespace, name)
+}
+
 func (r *Reconciler) addFinalizerSecret(ctx context.Context, finalizer string, secret *corev1.Secret) error {
+	if r.Env.SecretFinalizersDisabled {
+		return nil
+	}
 	if !containsFinalizerSecret(secret, finalizer) {
 		secret := secret.DeepCopy() // Do not modify informer copy.
 		secret.Finalizers = append(secret.Finalizers, finalizer)
@@ -660,7 +2486,7 @@ func (r *Reconciler) addFinalizerSecret(ctx context.Context, finalizer string, s
 }
 
 func (r *Reconciler) removeFinalizerSecret(ctx context.Context, finalizer string, secret *corev1.Secret) error {
-	if secret != nil {
+	if secret != nil && !r.Env.SecretFinalizersDisabled {
 		newFinalizers := make([]string, 0, len(secret.Finalizers))
 		for _, f := range secret.Finalizers {
 			if f != finalizer {
@@ -691,8 +2517,10 @@ func containsFinalizerSecret(secret *corev1.Secret, finalizer string) bool {
 	return false
 }
 
-func finalizerSecret(object metav1.Object) string {
-	return fmt.Sprintf("%s/%s", "kafka.eventing", object.GetUID())
+// finalizerSecret returns the finalizer added to the auth secret of the given object, it's prefixed with the
+// configured secret finalizer prefix.
+func (r *Reconciler) finalizerSecret(object metav1.Object) string {
+	return fmt.Sprintf("%s/%s", r.Env.GetSecretFinalizerPrefix(), object.GetUID())
 }
 
 func (r *Reconciler) getCaCerts() (string, error) {
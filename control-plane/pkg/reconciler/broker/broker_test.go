@@ -17,11 +17,16 @@
 package broker_test // different package name due to import cycles. (broker -> testing -> broker)
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
+	"strings"
 	"testing"
 	"text/template"
+	"time"
 
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/counter"
 
@@ -38,6 +43,8 @@ import (
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
 
 	"github.com/Shopify/sarama"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -45,6 +52,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	clientgotesting "k8s.io/client-go/testing"
 	apisconfig "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/config"
+	kafkaeventing "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/eventing/v1alpha1"
+	kafkasource "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/sources/v1beta1"
 	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/eventing/pkg/apis/feature"
 	"knative.dev/eventing/pkg/eventingtls/eventingtlstesting"
@@ -67,6 +76,7 @@ import (
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
 	. "knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/broker"
 	. "knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/testing"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/security"
 )
 
 const (
@@ -75,6 +85,24 @@ const (
 	ExpectedTopicDetail    = "expectedTopicDetail"
 	testProber             = "testProber"
 	externalTopic          = "externalTopic"
+	topicMetadata          = "topicMetadata"
+	partitionsCount        = "partitionsCount"
+	unreachableCluster     = "unreachableCluster"
+	circuitBreaker         = "circuitBreaker"
+	externalTopicPolicy    = "externalTopicPolicy"
+	maxReplicationFactor   = "maxReplicationFactor"
+	clusterBrokers         = "clusterBrokers"
+	describeCluster        = "describeCluster"
+	listTopics             = "listTopics"
+	topicPrefixes          = "topicPrefixes"
+	resourceMutator        = "resourceMutator"
+	ingressReachability    = "ingressReachability"
+	consumerGroupLags      = "consumerGroupLags"
+	topicConfigEntries     = "topicConfigEntries"
+	reconcileFastPath      = "reconcileFastPath"
+	topicConfigAlter       = "topicConfigAlter"
+	noTopicConfigAlter     = "noTopicConfigAlter"
+	noClusterAdmin         = "noClusterAdmin"
 
 	kafkaFeatureFlags = "kafka-feature-flags"
 )
@@ -94,12 +122,36 @@ var (
 		fmt.Sprintf(`Updated %q finalizers`, BrokerName),
 	)
 
+	topicCreatedEvent = func(topic string) string {
+		return Eventf(
+			corev1.EventTypeNormal,
+			base.ReasonTopicCreated,
+			"Topic %s created with %d partitions and replication factor %d",
+			topic, 20, 5,
+		)
+	}
+
+	defaultBackoffDelayAppliedEvent = func(delay string) string {
+		return Eventf(
+			corev1.EventTypeNormal,
+			"DefaultBackoffDelayApplied",
+			"Spec.Delivery.BackoffDelay isn't set, applied the default backoff delay %s to retries",
+			delay,
+		)
+	}
+
+	topicDeletePolicyEvent = func(policy string) string {
+		return Eventf(corev1.EventTypeNormal, "TopicDeletePolicy", "Topic delete policy: %s", policy)
+	}
+
 	brokerAddress = &apis.URL{
 		Scheme: "http",
 		Host:   network.GetServiceHostname(DefaultEnv.IngressName, DefaultEnv.SystemNamespace),
 		Path:   fmt.Sprintf("/%s/%s", BrokerNamespace, BrokerName),
 	}
 
+	ingressUnreachableError = fmt.Errorf("dial tcp: lookup kafka-broker-ingress: no such host")
+
 	createTopicError = fmt.Errorf("failed to create topic")
 	deleteTopicError = fmt.Errorf("failed to delete topic")
 
@@ -155,6 +207,7 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
@@ -207,13 +260,12 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 					),
 				},
 			},
-		}, {
-			Name: "Reconciled normal - with external topic",
+		},
+		{
+			Name: "Reconciled normal - max message bytes",
 			Objects: []runtime.Object{
-				NewBroker(
-					WithExternalTopic(ExternalTopicName),
-				),
-				BrokerConfig(bootstrapServers, 20, 5),
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5, WithMaxMessageBytes("2097152")),
 				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
 				NewService(),
 				BrokerReceiverPod(env.SystemNamespace, map[string]string{
@@ -228,14 +280,15 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources: []*contract.Resource{
 						{
 							Uid:              BrokerUUID,
-							Topics:           []string{ExternalTopicName},
-							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName), MaxMessageBytes: 2097152},
 							BootstrapServers: bootstrapServers,
 							Reference:        BrokerReference(),
 						},
@@ -257,16 +310,17 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithExternalTopic(ExternalTopicName),
 						reconcilertesting.WithInitBrokerConditions,
 						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigParsed,
-						StatusExternalBrokerTopicReady(ExternalTopicName),
+						StatusBrokerTopicReady,
 						BrokerAddressable(&env),
 						StatusBrokerProbeSucceeded,
 						BrokerConfigMapAnnotations(),
-						WithTopicStatusAnnotation(ExternalTopicName),
+						WithMaxMessageBytesStatusAnnotation("2097152"),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithTopicConfigStatusAnnotation(`{"max.message.bytes":"2097152"}`),
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -281,35 +335,55 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 					),
 				},
 			},
-
 			OtherTestData: map[string]interface{}{
-				externalTopic: ExternalTopicName,
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+					ConfigEntries:     map[string]*string{kafka.MaxMessageBytesTopicConfigKey: pointer.String("2097152")},
+				},
 			},
 		},
 		{
-			Name: "external topic not present or invalid",
+			Name: "Reconciled normal - topic created concurrently by another replica",
 			Objects: []runtime.Object{
-				NewBroker(
-					WithExternalTopic("my-not-present-topic"),
-				),
+				NewBroker(),
 				BrokerConfig(bootstrapServers, 20, 5),
-				BrokerReceiverPod(env.SystemNamespace, nil),
-				BrokerDispatcherPod(env.SystemNamespace, nil),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
 			},
-			Key:     testKey,
-			WantErr: true,
+			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
-				Eventf(
-					corev1.EventTypeWarning,
-					"InternalError",
-					"topics %v not present or invalid: invalid topic %s",
-					[]string{"my-not-present-topic"}, "my-not-present-topic",
-				),
 			},
-			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
-			WantCreates: []runtime.Object{
-				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchFinalizers(),
@@ -317,38 +391,47 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithExternalTopic("my-not-present-topic"),
 						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigParsed,
-						StatusExternalBrokerTopicNotPresentOrInvalid("my-not-present-topic"),
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
 						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
 					),
 				},
 			},
 			OtherTestData: map[string]interface{}{
-				externalTopic: "my-not-present-topic",
+				wantErrorOnCreateTopic: sarama.ErrTopicAlreadyExists,
 			},
 		},
 		{
-			Name: "Reconciled failed - probe " + prober.StatusNotReady.String(),
+			Name: "Reconciled normal - receiver not running - soft data plane availability gate",
 			Objects: []runtime.Object{
-				NewBroker(),
+				NewBroker(
+					WithDataPlaneAvailabilityGateAnnotation(DataPlaneAvailabilityGateSoft),
+				),
 				BrokerConfig(bootstrapServers, 20, 5),
 				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
 				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "0",
-					"annotation_to_preserve":           "value_to_preserve",
-				}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "0",
-					"annotation_to_preserve":           "value_to_preserve",
-				}),
 			},
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
@@ -363,14 +446,6 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 					},
 					Generation: 1,
 				}),
-				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "1",
-					"annotation_to_preserve":           "value_to_preserve",
-				}),
-				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "1",
-					"annotation_to_preserve":           "value_to_preserve",
-				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchFinalizers(),
@@ -378,40 +453,67 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
+						WithDataPlaneAvailabilityGateAnnotation(DataPlaneAvailabilityGateSoft),
 						reconcilertesting.WithInitBrokerConditions,
 						StatusBrokerConfigMapUpdatedReady(&env),
-						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigParsed,
 						StatusBrokerTopicReady,
-						StatusBrokerProbeFailed(prober.StatusNotReady),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
 						BrokerConfigMapAnnotations(),
 						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
 					),
 				},
 			},
-			OtherTestData: map[string]interface{}{
-				testProber: probertesting.MockNewProber(prober.StatusNotReady),
-			},
 		},
 		{
-			Name: "Reconciled failed - probe " + prober.StatusUnknown.String(),
+			Name: "Reconciled normal - corrupted contract rebuilt",
 			Objects: []runtime.Object{
 				NewBroker(),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:    "stale",
+							Topics: []string{"stale"},
+						},
+					},
+					Generation: 3,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, func(cm *corev1.ConfigMap) {
+					cm.Annotations[base.ContractChecksumAnnotation] = base.ContractChecksum([]byte("previous"))
+				}),
 				NewService(),
 				BrokerReceiverPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "0",
+					base.VolumeGenerationAnnotationKey: "3",
 					"annotation_to_preserve":           "value_to_preserve",
 				}),
 				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "0",
+					base.VolumeGenerationAnnotationKey: "3",
 					"annotation_to_preserve":           "value_to_preserve",
 				}),
 			},
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+				Eventf(
+					corev1.EventTypeWarning,
+					"ContractRebuild",
+					"Contract config map %s/%s is corrupted, rebuilding it from all brokers",
+					env.DataPlaneConfigMapNamespace,
+					env.ContractConfigMapName,
+				),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
@@ -424,14 +526,16 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 							Reference:        BrokerReference(),
 						},
 					},
-					Generation: 1,
+					Generation: 4,
+				}, func(cm *corev1.ConfigMap) {
+					cm.Annotations[base.ContractRebuildAnnotation] = "1"
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "1",
+					base.VolumeGenerationAnnotationKey: "4",
 					"annotation_to_preserve":           "value_to_preserve",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "1",
+					base.VolumeGenerationAnnotationKey: "4",
 					"annotation_to_preserve":           "value_to_preserve",
 				}),
 			},
@@ -446,33 +550,47 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigParsed,
 						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
 						BrokerConfigMapAnnotations(),
 						WithTopicStatusAnnotation(BrokerTopic()),
-						StatusBrokerProbeFailed(prober.StatusUnknown),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
 					),
 				},
 			},
-			OtherTestData: map[string]interface{}{
-				testProber: probertesting.MockNewProber(prober.StatusUnknown),
-			},
 		},
 		{
-			Name: "Reconciled normal - with DLS",
+			Name: "Reconciled normal - key removed from broker config pruned from status annotations",
 			Objects: []runtime.Object{
 				NewBroker(
-					WithDelivery(),
+					BrokerConfigMapAnnotations(),
+					WithTopicStatusAnnotation(BrokerTopic()),
+					WithFailoverBootstrapServersStatusAnnotation("kafka-dr:9092"),
 				),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapFromContract(&contract.Contract{
-					Generation: 1,
-				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
 				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "3"}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
 			},
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
@@ -480,19 +598,18 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 						{
 							Uid:              BrokerUUID,
 							Topics:           []string{BrokerTopic()},
-							Ingress:          &contract.Ingress{ContentMode: contract.ContentMode_BINARY, Path: receiver.Path(BrokerNamespace, BrokerName)},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
 							BootstrapServers: bootstrapServers,
-							EgressConfig:     &contract.EgressConfig{DeadLetter: ServiceURL},
 							Reference:        BrokerReference(),
 						},
 					},
-					Generation: 2,
+					Generation: 1,
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "1",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "1",
 				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
@@ -501,17 +618,15 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithDelivery(),
 						reconcilertesting.WithInitBrokerConditions,
 						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerDataPlaneAvailable,
-						StatusBrokerTopicReady,
-						BrokerConfigMapAnnotations(),
-						WithTopicStatusAnnotation(BrokerTopic()),
 						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
 						BrokerAddressable(&env),
 						StatusBrokerProbeSucceeded,
-						BrokerDLSResolved(ServiceURL),
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -526,20 +641,23 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 					),
 				},
 			},
-		},
-		{
-			Name: "Reconciled normal - with DLS - no DLS ref namespace",
+		}, {
+			Name: "Reconciled normal - with external topic",
 			Objects: []runtime.Object{
 				NewBroker(
-					WithDelivery(WithNoDeadLetterSinkNamespace),
+					WithExternalTopic(ExternalTopicName),
 				),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapFromContract(&contract.Contract{
-					Generation: 1,
-				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
-				NewService(WithServiceNamespace(BrokerNamespace)),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "3"}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
 			},
 			Key: testKey,
 			WantEvents: []string{
@@ -550,20 +668,21 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 					Resources: []*contract.Resource{
 						{
 							Uid:              BrokerUUID,
-							Topics:           []string{BrokerTopic()},
+							Topics:           []string{ExternalTopicName},
 							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
 							BootstrapServers: bootstrapServers,
-							EgressConfig:     &contract.EgressConfig{DeadLetter: ServiceURLFrom(BrokerNamespace, ServiceName)},
 							Reference:        BrokerReference(),
 						},
 					},
-					Generation: 2,
+					Generation: 1,
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
@@ -572,17 +691,16 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithDelivery(WithNoDeadLetterSinkNamespace),
+						WithExternalTopic(ExternalTopicName),
 						reconcilertesting.WithInitBrokerConditions,
 						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerDataPlaneAvailable,
-						StatusBrokerTopicReady,
-						BrokerConfigMapAnnotations(),
-						WithTopicStatusAnnotation(BrokerTopic()),
 						StatusBrokerConfigParsed,
+						StatusExternalBrokerTopicReady(ExternalTopicName),
 						BrokerAddressable(&env),
 						StatusBrokerProbeSucceeded,
-						BrokerDLSResolved(ServiceURLFrom(BrokerNamespace, ServiceName)),
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(ExternalTopicName),
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -597,82 +715,48 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 					),
 				},
 			},
+
+			OtherTestData: map[string]interface{}{
+				externalTopic: ExternalTopicName,
+			},
 		},
 		{
-			Name: "Failed to create topic",
-			Objects: []runtime.Object{
-				NewBroker(),
-				BrokerConfig(bootstrapServers, 20, 5),
-				BrokerReceiverPod(env.SystemNamespace, nil),
-				BrokerDispatcherPod(env.SystemNamespace, nil),
-			},
-			Key:     testKey,
-			WantErr: true,
-			WantEvents: []string{
-				finalizerUpdatedEvent,
-				Eventf(
-					corev1.EventTypeWarning,
-					"InternalError",
-					"failed to create topic: %s: %v",
-					BrokerTopic(), createTopicError,
-				),
-			},
-			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
-			WantCreates: []runtime.Object{
-				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
-			},
-			WantPatches: []clientgotesting.PatchActionImpl{
-				patchFinalizers(),
-			},
-			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
-				{
-					Object: NewBroker(
-						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerDataPlaneAvailable,
-						StatusBrokerConfigParsed,
-						StatusBrokerFailedToCreateTopic,
-						BrokerConfigMapAnnotations(),
-					),
-				},
-			},
-			OtherTestData: map[string]interface{}{
-				wantErrorOnCreateTopic: createTopicError,
-			},
-		},
-		{
-			Name: "Config map not found - create config map",
+			Name: "Reconciled normal - external topic with ordered delivery falls back to unordered delivery",
 			Objects: []runtime.Object{
 				NewBroker(
-					WithDelivery(),
+					WithExternalTopic(ExternalTopicName),
+					WithDeliveryOrderAnnotation(string(kafkasource.Ordered)),
 				),
 				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
 				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "2"}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "2"}),
-				&corev1.ConfigMap{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: env.DataPlaneConfigMapNamespace,
-						Name:      env.ContractConfigMapName + "a", // Use a different name
-					},
-				},
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
 			},
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
-			},
-			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
-			WantCreates: []runtime.Object{
-				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				Eventf(
+					corev1.EventTypeWarning,
+					"DeliveryOrderNotGuaranteed",
+					"Ordered delivery requires a deterministic partitioning scheme, external topic %s isn't partitioned by the broker, falling back to unordered delivery",
+					ExternalTopicName,
+				),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources: []*contract.Resource{
 						{
 							Uid:              BrokerUUID,
-							Topics:           []string{BrokerTopic()},
+							Topics:           []string{ExternalTopicName},
 							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
 							BootstrapServers: bootstrapServers,
-							EgressConfig:     &contract.EgressConfig{DeadLetter: ServiceURL},
 							Reference:        BrokerReference(),
 						},
 					},
@@ -680,9 +764,11 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
 					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
 					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
@@ -691,17 +777,17 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithDelivery(),
+						WithExternalTopic(ExternalTopicName),
+						WithDeliveryOrderAnnotation(string(kafkasource.Ordered)),
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerConfigParsed,
-						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigMapUpdatedReady(&env),
-						StatusBrokerTopicReady,
-						BrokerConfigMapAnnotations(),
-						WithTopicStatusAnnotation(BrokerTopic()),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusExternalBrokerTopicReady(ExternalTopicName),
 						BrokerAddressable(&env),
 						StatusBrokerProbeSucceeded,
-						BrokerDLSResolved(ServiceURL),
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(ExternalTopicName),
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -716,29 +802,49 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 					),
 				},
 			},
+
+			OtherTestData: map[string]interface{}{
+				externalTopic: ExternalTopicName,
+			},
 		},
 		{
-			Name: "Reconciled normal - config map not readable",
+			Name: "Reconciled normal - adopt external topic",
 			Objects: []runtime.Object{
-				NewBroker(),
+				NewBroker(
+					WithExternalTopic(ExternalTopicName),
+					func(broker *eventing.Broker) {
+						// The external topic hasn't been adopted yet.
+						delete(broker.Status.Annotations, kafka.TopicAnnotation)
+					},
+				),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, []byte(`{"hello": "world"}`)),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
 				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
 				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 			},
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeNormal,
+					base.ReasonExternalTopicAdopted,
+					"External topic %s adopted",
+					ExternalTopicName,
+				),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources: []*contract.Resource{
 						{
 							Uid:              BrokerUUID,
-							Topics:           []string{BrokerTopic()},
+							Topics:           []string{ExternalTopicName},
 							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
 							BootstrapServers: bootstrapServers,
 							Reference:        BrokerReference(),
@@ -748,9 +854,11 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
 					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
 					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
@@ -759,15 +867,16 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
+						WithExternalTopic(ExternalTopicName),
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigParsed,
-						StatusBrokerTopicReady,
-						BrokerConfigMapAnnotations(),
-						WithTopicStatusAnnotation(BrokerTopic()),
+						StatusExternalBrokerTopicReady(ExternalTopicName),
 						BrokerAddressable(&env),
 						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(ExternalTopicName),
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -782,33 +891,25 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 					),
 				},
 			},
+
+			OtherTestData: map[string]interface{}{
+				externalTopic: ExternalTopicName,
+			},
 		},
 		{
-			Name: "Reconciled normal - preserve config map previous state",
+			Name: "Reconciled normal - external topic with fewer partitions than configured",
 			Objects: []runtime.Object{
-				NewBroker(),
+				NewBroker(
+					WithExternalTopic(ExternalTopicName),
+				),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapFromContract(&contract.Contract{
-					Resources: []*contract.Resource{
-						{
-							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
-							Topics:       []string{"my-existing-topic-a"},
-							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-						},
-						{
-							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44a",
-							Topics:       []string{"my-existing-topic-b"},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-						},
-					},
-				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
 				NewService(),
 				BrokerReceiverPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "0",
 				}),
 				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "0",
 				}),
 			},
 			Key: testKey,
@@ -818,20 +919,9 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources: []*contract.Resource{
-						{
-							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
-							Topics:       []string{"my-existing-topic-a"},
-							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-						},
-						{
-							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44a",
-							Topics:       []string{"my-existing-topic-b"},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-						},
 						{
 							Uid:              BrokerUUID,
-							Topics:           []string{BrokerTopic()},
+							Topics:           []string{ExternalTopicName},
 							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
 							BootstrapServers: bootstrapServers,
 							Reference:        BrokerReference(),
@@ -852,15 +942,20 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
+						WithExternalTopic(ExternalTopicName),
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigParsed,
-						StatusBrokerTopicReady,
-						BrokerConfigMapAnnotations(),
-						WithTopicStatusAnnotation(BrokerTopic()),
+						StatusExternalBrokerTopicReady(ExternalTopicName),
+						StatusBrokerTopicConfigNotSynced(ExternalTopicName, kafka.PartitionsMismatch{
+							Topic:   ExternalTopicName,
+							Desired: 20,
+							Actual:  1,
+						}),
 						BrokerAddressable(&env),
 						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -871,79 +966,43 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 							Name: pointer.String("http"),
 							URL:  brokerAddress,
 						}),
-						WithBrokerAddessable(),
 					),
 				},
 			},
+			OtherTestData: map[string]interface{}{
+				externalTopic: ExternalTopicName,
+				topicMetadata: []*sarama.TopicMetadata{
+					{
+						Name:       ExternalTopicName,
+						Partitions: []*sarama.PartitionMetadata{{ID: 0, Replicas: []int32{0, 1, 2, 3, 4}}},
+					},
+				},
+			},
 		},
 		{
-			Name: "Reconciled normal - update existing broker while preserving others",
+			Name: "external topic not present or invalid",
 			Objects: []runtime.Object{
 				NewBroker(
-					func(broker *eventing.Broker) {
-						broker.Spec.Delivery = &eventingduck.DeliverySpec{
-							DeadLetterSink: &duckv1.Destination{
-								URI: &apis.URL{
-									Scheme: "http",
-									Host:   "www.my-sink.com",
-									Path:   "/api",
-								},
-							},
-						}
-					},
+					WithExternalTopic("my-not-present-topic"),
 				),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapFromContract(&contract.Contract{
-					Resources: []*contract.Resource{
-						{
-							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
-							Topics:       []string{"my-existing-topic-a"},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-						},
-						{
-							Uid:          BrokerUUID,
-							Topics:       []string{BrokerTopic()},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-						},
-					},
-				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
-				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "5",
-				}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "5",
-				}),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
 			},
-			Key: testKey,
+			Key:     testKey,
+			WantErr: true,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"topics %v not present or invalid: invalid topic %s",
+					[]string{"my-not-present-topic"}, "my-not-present-topic",
+				),
 			},
-			WantUpdates: []clientgotesting.UpdateActionImpl{
-				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
-					Resources: []*contract.Resource{
-						{
-							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
-							Topics:       []string{"my-existing-topic-a"},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-						},
-						{
-							Uid:              BrokerUUID,
-							Topics:           []string{BrokerTopic()},
-							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-							BootstrapServers: bootstrapServers,
-							EgressConfig:     &contract.EgressConfig{DeadLetter: "http://www.my-sink.com/api"},
-							Reference:        BrokerReference(),
-						},
-					},
-					Generation: 1,
-				}),
-				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "1",
-				}),
-				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "1",
-				}),
+			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
+			WantCreates: []runtime.Object{
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchFinalizers(),
@@ -951,87 +1010,144 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						func(broker *eventing.Broker) {
-							broker.Spec.Delivery = &eventingduck.DeliverySpec{
-								DeadLetterSink: &duckv1.Destination{
-									URI: func() *apis.URL {
-										URL, _ := url.Parse("http://www.my-sink.com/api")
-										return (*apis.URL)(URL)
-									}(),
-								},
-							}
-						},
+						WithExternalTopic("my-not-present-topic"),
 						reconcilertesting.WithInitBrokerConditions,
 						StatusBrokerDataPlaneAvailable,
-						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerConfigParsed,
-						StatusBrokerTopicReady,
+						StatusExternalBrokerTopicNotPresentOrInvalid("my-not-present-topic"),
 						BrokerConfigMapAnnotations(),
-						WithTopicStatusAnnotation(BrokerTopic()),
-						BrokerAddressable(&env),
-						StatusBrokerProbeSucceeded,
-						BrokerDLSResolved("http://www.my-sink.com/api"),
-						WithBrokerAddresses([]duckv1.Addressable{
-							{
-								Name: pointer.String("http"),
-								URL:  brokerAddress,
-							},
-						}),
-						WithBrokerAddress(duckv1.Addressable{
-							Name: pointer.String("http"),
-							URL:  brokerAddress,
-						}),
-						WithBrokerAddessable(),
 					),
 				},
 			},
+			OtherTestData: map[string]interface{}{
+				externalTopic: "my-not-present-topic",
+			},
 		},
 		{
-			Name: "Reconciled normal - remove existing broker DLS while preserving others",
+			Name: "external topic not authorized",
 			Objects: []runtime.Object{
 				NewBroker(
-					func(broker *eventing.Broker) {
-						broker.Spec.Delivery = &eventingduck.DeliverySpec{}
-					},
+					WithExternalTopic("my-not-authorized-topic"),
 				),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapFromContract(&contract.Contract{
-					Resources: []*contract.Resource{
-						{
-							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
-							Topics:       []string{"my-existing-topic-a"},
-							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-						},
-						{
-							Uid:          BrokerUUID,
-							Topics:       []string{BrokerTopic()},
-							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-						},
-					},
-				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					base.ReasonTopicAuthorizationFailed,
+					"Not authorized to describe topics %v, grant the required ACLs to the Kafka client: failed to describe topic %s: %v",
+					[]string{"my-not-authorized-topic"}, "my-not-authorized-topic", sarama.ErrTopicAuthorizationFailed,
+				),
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"not authorized to describe topics %v: failed to describe topic %s: %v",
+					[]string{"my-not-authorized-topic"}, "my-not-authorized-topic", sarama.ErrTopicAuthorizationFailed,
+				),
+			},
+			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
+			WantCreates: []runtime.Object{
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithExternalTopic("my-not-authorized-topic"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusExternalBrokerTopicAuthorizationFailed("my-not-authorized-topic", fmt.Errorf("failed to describe topic %s: %w", "my-not-authorized-topic", sarama.ErrTopicAuthorizationFailed)),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				externalTopic: "my-not-authorized-topic",
+				topicMetadata: []*sarama.TopicMetadata{
+					{Name: "my-not-authorized-topic", Err: sarama.ErrTopicAuthorizationFailed},
+				},
+			},
+		},
+		{
+			Name: "external topic not permitted by policy",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithExternalTopic("other-tenant-topic"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"external topic %s not permitted by policy",
+					"other-tenant-topic",
+				),
+			},
+			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
+			WantCreates: []runtime.Object{
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithExternalTopic("other-tenant-topic"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusExternalBrokerTopicNotPermitted("other-tenant-topic"),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				externalTopic: "other-tenant-topic",
+				externalTopicPolicy: &ExternalTopicPolicy{
+					Allow: regexp.MustCompile("^(?:" + ExternalTopicName + ")$"),
+				},
+			},
+		},
+		{
+			Name: "Reconciled failed - probe " + prober.StatusNotReady.String(),
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
 				NewService(),
 				BrokerReceiverPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "5",
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "5",
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 			},
-			Key: testKey,
+			Key:     testKey,
+			WantErr: true,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources: []*contract.Resource{
-						{
-							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
-							Topics:       []string{"my-existing-topic-a"},
-							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-						},
 						{
 							Uid:              BrokerUUID,
 							Topics:           []string{BrokerTopic()},
@@ -1044,9 +1160,11 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
 					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
 					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
@@ -1055,75 +1173,46 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						func(broker *eventing.Broker) {
-							broker.Spec.Delivery = &eventingduck.DeliverySpec{}
-						},
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigParsed,
 						StatusBrokerTopicReady,
+						StatusBrokerProbeFailed(prober.StatusNotReady),
 						BrokerConfigMapAnnotations(),
 						WithTopicStatusAnnotation(BrokerTopic()),
-						BrokerAddressable(&env),
-						StatusBrokerProbeSucceeded,
-						WithBrokerAddresses([]duckv1.Addressable{
-							{
-								Name: pointer.String("http"),
-								URL:  brokerAddress,
-							},
-						}),
-						WithBrokerAddress(duckv1.Addressable{
-							Name: pointer.String("http"),
-							URL:  brokerAddress,
-						}),
-						WithBrokerAddessable(),
 					),
 				},
 			},
+			OtherTestData: map[string]interface{}{
+				testProber: probertesting.MockNewProber(prober.StatusNotReady),
+			},
 		},
 		{
-			Name: "Reconciled normal - increment volume generation",
+			Name: "Reconciled failed - probe " + prober.StatusUnknown.String(),
 			Objects: []runtime.Object{
 				NewBroker(),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapFromContract(&contract.Contract{
-					Resources: []*contract.Resource{
-						{
-							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
-							Topics:       []string{"my-existing-topic-a"},
-							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-						},
-						{
-							Uid:     BrokerUUID,
-							Topics:  []string{BrokerTopic()},
-							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-						},
-					},
-					Generation: 1,
-				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
 				NewService(),
 				BrokerReceiverPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "5",
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "5",
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 			},
-			Key: testKey,
+			Key:     testKey,
+			WantErr: true,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources: []*contract.Resource{
-						{
-							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
-							Topics:       []string{"my-existing-topic-a"},
-							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-						},
 						{
 							Uid:              BrokerUUID,
 							Topics:           []string{BrokerTopic()},
@@ -1132,13 +1221,15 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 							Reference:        BrokerReference(),
 						},
 					},
-					Generation: 2,
+					Generation: 1,
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
@@ -1148,176 +1239,58 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 				{
 					Object: NewBroker(
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
 						StatusBrokerConfigParsed,
 						StatusBrokerTopicReady,
 						BrokerConfigMapAnnotations(),
 						WithTopicStatusAnnotation(BrokerTopic()),
-						BrokerAddressable(&env),
-						StatusBrokerProbeSucceeded,
-						WithBrokerAddresses([]duckv1.Addressable{
-							{
-								Name: pointer.String("http"),
-								URL:  brokerAddress,
-							},
-						}),
-						WithBrokerAddress(duckv1.Addressable{
-							Name: pointer.String("http"),
-							URL:  brokerAddress,
-						}),
-						WithBrokerAddessable(),
+						StatusBrokerProbeFailed(prober.StatusUnknown),
 					),
 				},
 			},
+			OtherTestData: map[string]interface{}{
+				testProber: probertesting.MockNewProber(prober.StatusUnknown),
+			},
 		},
 		{
-			Name: "Failed to resolve DLS",
+			Name: "Reconciled normal - with DLS",
 			Objects: []runtime.Object{
 				NewBroker(
-					func(broker *eventing.Broker) {
-						broker.Spec.Delivery = &eventingduck.DeliverySpec{
-							DeadLetterSink: &duckv1.Destination{},
-						}
-					},
+					WithDelivery(),
 				),
 				BrokerConfig(bootstrapServers, 20, 5),
 				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "3"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources: []*contract.Resource{
-						{
-							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
-							Topics:       []string{"my-existing-topic-a"},
-							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
-							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-						},
 						{
 							Uid:              BrokerUUID,
 							Topics:           []string{BrokerTopic()},
-							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							Ingress:          &contract.Ingress{ContentMode: contract.ContentMode_BINARY, Path: receiver.Path(BrokerNamespace, BrokerName)},
 							BootstrapServers: bootstrapServers,
+							EgressConfig:     &contract.EgressConfig{DeadLetter: ServiceURL},
 							Reference:        BrokerReference(),
 						},
 					},
-					Generation: 1,
-				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "5",
-				}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "5",
-				}),
-			},
-			Key:     testKey,
-			WantErr: true,
-			WantEvents: []string{
-				finalizerUpdatedEvent,
-				Eventf(
-					corev1.EventTypeWarning,
-					"InternalError",
-					"failed to get contract configuration: failed to resolve Spec.Delivery.DeadLetterSink: %v",
-					"destination missing Ref and URI, expected at least one",
-				),
-			},
-			WantPatches: []clientgotesting.PatchActionImpl{
-				patchFinalizers(),
-			},
-			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
-				{
-					Object: NewBroker(
-						func(broker *eventing.Broker) {
-							broker.Spec.Delivery = &eventingduck.DeliverySpec{
-								DeadLetterSink: &duckv1.Destination{},
-							}
-						},
-						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerDataPlaneAvailable,
-						StatusBrokerConfigNotParsed("failed to resolve Spec.Delivery.DeadLetterSink: destination missing Ref and URI, expected at least one"),
-						StatusBrokerTopicReady,
-						BrokerConfigMapAnnotations(),
-						WithTopicStatusAnnotation(BrokerTopic()),
-					),
-				},
-			},
-		},
-		{
-			Name: "No bootstrap.servers provided",
-			Objects: []runtime.Object{
-				NewBroker(),
-				BrokerConfig("", 20, 5),
-				NewConfigMapFromContract(&contract.Contract{
-					Resources:  []*contract.Resource{},
-					Generation: 1,
-				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
-				BrokerReceiverPod(env.SystemNamespace, nil),
-				BrokerDispatcherPod(env.SystemNamespace, nil),
-			},
-			Key:     testKey,
-			WantErr: true,
-			WantEvents: []string{
-				finalizerUpdatedEvent,
-				Eventf(
-					corev1.EventTypeWarning,
-					"InternalError",
-					"failed to get contract configuration: unable to build topic config from configmap: error validating topic config from configmap invalid configuration - numPartitions: 20 - replicationFactor: 5 - bootstrapServers: [] - ConfigMap data: map[bootstrap.servers: default.topic.partitions:20 default.topic.replication.factor:5] - ConfigMap data: map[bootstrap.servers: default.topic.partitions:20 default.topic.replication.factor:5]",
-				),
-			},
-			WantPatches: []clientgotesting.PatchActionImpl{
-				patchFinalizers(),
-			},
-			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
-				{
-					Object: NewBroker(
-						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerDataPlaneAvailable,
-						StatusBrokerConfigNotParsed("unable to build topic config from configmap: error validating topic config from configmap invalid configuration - numPartitions: 20 - replicationFactor: 5 - bootstrapServers: [] - ConfigMap data: map[bootstrap.servers: default.topic.partitions:20 default.topic.replication.factor:5] - ConfigMap data: map[bootstrap.servers: default.topic.partitions:20 default.topic.replication.factor:5]"),
-					),
-				},
-			},
-		},
-		{
-			Name: "Reconciled normal - with broker config",
-			Objects: []runtime.Object{
-				NewBroker(
-					WithBrokerConfig(
-						KReference(BrokerConfig(bootstrapServers, 20, 5)),
-					),
-				),
-				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
-				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "0",
-					"annotation_to_preserve":           "value_to_preserve",
-				}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "3",
-					"annotation_to_preserve":           "value_to_preserve",
-				}),
-			},
-			Key: testKey,
-			WantEvents: []string{
-				finalizerUpdatedEvent,
-			},
-			WantUpdates: []clientgotesting.UpdateActionImpl{
-				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
-					Resources: []*contract.Resource{
-						{
-							Uid:              BrokerUUID,
-							Topics:           []string{BrokerTopic()},
-							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-							BootstrapServers: bootstrapServers,
-							Reference:        BrokerReference(),
-						},
-					},
-					Generation: 1,
+					Generation: 2,
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "1",
-					"annotation_to_preserve":           "value_to_preserve",
+					base.VolumeGenerationAnnotationKey: "2",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "1",
-					"annotation_to_preserve":           "value_to_preserve",
+					base.VolumeGenerationAnnotationKey: "2",
 				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
@@ -1326,18 +1299,17 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithBrokerConfig(
-							KReference(BrokerConfig(bootstrapServers, 20, 5)),
-						),
+						WithDelivery(),
 						reconcilertesting.WithInitBrokerConditions,
 						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerDataPlaneAvailable,
-						StatusBrokerConfigParsed,
 						StatusBrokerTopicReady,
 						BrokerConfigMapAnnotations(),
 						WithTopicStatusAnnotation(BrokerTopic()),
+						StatusBrokerConfigParsed,
 						BrokerAddressable(&env),
 						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -1352,38 +1324,27 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 					),
 				},
 			},
-			OtherTestData: map[string]interface{}{
-				ExpectedTopicDetail: sarama.TopicDetail{
-					NumPartitions:     20,
-					ReplicationFactor: 5,
-				},
-			},
 		},
 		{
-			Name: "Reconciled normal - with auth config",
+			Name: "Reconciled normal - with DLS - no DLS ref namespace",
 			Objects: []runtime.Object{
 				NewBroker(
-					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
-						BrokerAuthConfig("secret-1"),
-					))),
+					WithDelivery(WithNoDeadLetterSinkNamespace),
 				),
-				NewSSLSecret(ConfigMapNamespace, "secret-1"),
-				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1")),
-				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
-				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{
-					"annotation_to_preserve": "value_to_preserve",
-				}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
-					"annotation_to_preserve": "value_to_preserve",
-				}),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(WithServiceNamespace(BrokerNamespace)),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "3"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
 			},
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
-				SecretFinalizerUpdate("secret-1", SecretFinalizerName),
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources: []*contract.Resource{
 						{
@@ -1391,26 +1352,17 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 							Topics:           []string{BrokerTopic()},
 							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
 							BootstrapServers: bootstrapServers,
+							EgressConfig:     &contract.EgressConfig{DeadLetter: ServiceURLFrom(BrokerNamespace, ServiceName)},
 							Reference:        BrokerReference(),
-							Auth: &contract.Resource_AuthSecret{
-								AuthSecret: &contract.Reference{
-									Uuid:      SecretUUID,
-									Namespace: ConfigMapNamespace,
-									Name:      "secret-1",
-									Version:   SecretResourceVersion,
-								},
-							},
 						},
 					},
-					Generation: 1,
+					Generation: 2,
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "1",
-					"annotation_to_preserve":           "value_to_preserve",
+					base.VolumeGenerationAnnotationKey: "2",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "1",
-					"annotation_to_preserve":           "value_to_preserve",
+					base.VolumeGenerationAnnotationKey: "2",
 				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
@@ -1419,19 +1371,17 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
-							BrokerAuthConfig("secret-1"),
-						))),
+						WithDelivery(WithNoDeadLetterSinkNamespace),
 						reconcilertesting.WithInitBrokerConditions,
 						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerDataPlaneAvailable,
-						StatusBrokerConfigParsed,
 						StatusBrokerTopicReady,
 						BrokerConfigMapAnnotations(),
 						WithTopicStatusAnnotation(BrokerTopic()),
-						BrokerConfigMapSecretAnnotation("secret-1"),
+						StatusBrokerConfigParsed,
 						BrokerAddressable(&env),
 						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURLFrom(BrokerNamespace, ServiceName)),
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -1446,21 +1396,12 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 					),
 				},
 			},
-			OtherTestData: map[string]interface{}{
-				ExpectedTopicDetail: sarama.TopicDetail{
-					NumPartitions:     20,
-					ReplicationFactor: 5,
-				},
-			},
 		},
 		{
-			Name: "Failed to parse broker config - not found",
+			Name: "Failed to create topic",
 			Objects: []runtime.Object{
-				NewBroker(
-					WithBrokerConfig(
-						KReference(BrokerConfig(bootstrapServers, 20, 5)),
-					),
-				),
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
 				BrokerReceiverPod(env.SystemNamespace, nil),
 				BrokerDispatcherPod(env.SystemNamespace, nil),
 			},
@@ -1471,7 +1412,8 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 				Eventf(
 					corev1.EventTypeWarning,
 					"InternalError",
-					fmt.Sprintf(`failed to get contract configuration: unable to rebuild topic config, failed to get configmap %s/%s`, ConfigMapNamespace, ConfigMapName),
+					"failed to create topic: %s: %v",
+					BrokerTopic(), createTopicError,
 				),
 			},
 			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
@@ -1484,37 +1426,26 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithBrokerConfig(
-							KReference(BrokerConfig(bootstrapServers, 20, 5)),
-						),
 						reconcilertesting.WithInitBrokerConditions,
 						StatusBrokerDataPlaneAvailable,
-						StatusBrokerConfigNotParsed(fmt.Sprintf(`unable to rebuild topic config, failed to get configmap %s/%s`, ConfigMapNamespace, ConfigMapName)),
+						StatusBrokerConfigParsed,
+						StatusBrokerFailedToCreateTopic,
+						BrokerConfigMapAnnotations(),
 					),
 				},
 			},
+			OtherTestData: map[string]interface{}{
+				wantErrorOnCreateTopic: createTopicError,
+			},
 		},
 		{
-			Name: "Unsupported Kind as config",
+			Name: "Failed to create topic - delivery status annotations kept",
 			Objects: []runtime.Object{
 				NewBroker(
-					WithBrokerConfig(&duckv1.KReference{
-						Kind:       "Pod",
-						Namespace:  BrokerNamespace,
-						Name:       BrokerName,
-						APIVersion: "v1",
-					}),
+					WithRetry(pointer.Int32(10), &exponential, pointer.String("PT2S")),
+					WithDeliveryStatusAnnotations("10", "exponential", "2s"),
 				),
-				&corev1.Pod{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "Pod",
-						APIVersion: "v1",
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: BrokerNamespace,
-						Name:      BrokerName,
-					},
-				},
+				BrokerConfig(bootstrapServers, 20, 5),
 				BrokerReceiverPod(env.SystemNamespace, nil),
 				BrokerDispatcherPod(env.SystemNamespace, nil),
 			},
@@ -1525,7 +1456,8 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 				Eventf(
 					corev1.EventTypeWarning,
 					"InternalError",
-					"failed to get contract configuration: supported config Kind: ConfigMap - got Pod",
+					"failed to create topic: %s: %v",
+					BrokerTopic(), createTopicError,
 				),
 			},
 			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
@@ -1538,111 +1470,36 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithBrokerConfig(&duckv1.KReference{
-							Kind:       "Pod",
-							Namespace:  BrokerNamespace,
-							Name:       BrokerName,
-							APIVersion: "v1",
-						}),
+						WithRetry(pointer.Int32(10), &exponential, pointer.String("PT2S")),
 						reconcilertesting.WithInitBrokerConditions,
 						StatusBrokerDataPlaneAvailable,
-						StatusBrokerConfigNotParsed(`supported config Kind: ConfigMap - got Pod`),
+						StatusBrokerConfigParsed,
+						StatusBrokerFailedToCreateTopic,
+						BrokerConfigMapAnnotations(),
+						WithDeliveryStatusAnnotations("10", "exponential", "2s"),
 					),
 				},
 			},
+			OtherTestData: map[string]interface{}{
+				wantErrorOnCreateTopic: createTopicError,
+			},
 		},
 		{
-			Name: "Reconciled normal - keep all existing triggers",
+			Name: "Failed to create topic - transient error",
 			Objects: []runtime.Object{
-				NewBroker(
-					WithDelivery(),
-				),
+				NewBroker(),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapFromContract(&contract.Contract{
-					Resources: []*contract.Resource{
-						{
-							Uid:          BrokerUUID,
-							Topics:       []string{BrokerTopic()},
-							EgressConfig: &contract.EgressConfig{DeadLetter: ServiceURL},
-							Egresses: []*contract.Egress{
-								{
-									Filter: &contract.Filter{Attributes: map[string]string{
-										"source": "source1",
-									}},
-									Destination:   "http://example.com",
-									ConsumerGroup: TriggerUUID,
-								},
-								{
-									Filter: &contract.Filter{Attributes: map[string]string{
-										"source": "source1",
-									}},
-									Destination:   "http://example.com",
-									ConsumerGroup: TriggerUUID + "a",
-								},
-								{
-									Filter: &contract.Filter{Attributes: map[string]string{
-										"source": "source1",
-									}},
-									Destination:   "http://example.com",
-									ConsumerGroup: TriggerUUID + "b",
-								},
-							},
-							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-						},
-					},
-					Generation: 1,
-				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
-				NewService(),
 				BrokerReceiverPod(env.SystemNamespace, nil),
 				BrokerDispatcherPod(env.SystemNamespace, nil),
 			},
-			Key: testKey,
+			Key:     testKey,
+			WantErr: true,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
 			},
-			WantUpdates: []clientgotesting.UpdateActionImpl{
-				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
-					Resources: []*contract.Resource{
-						{
-							Uid:    BrokerUUID,
-							Topics: []string{BrokerTopic()},
-							Egresses: []*contract.Egress{
-								{
-									Filter: &contract.Filter{Attributes: map[string]string{
-										"source": "source1",
-									}},
-									Destination:   "http://example.com",
-									ConsumerGroup: TriggerUUID,
-								},
-								{
-									Filter: &contract.Filter{Attributes: map[string]string{
-										"source": "source1",
-									}},
-									Destination:   "http://example.com",
-									ConsumerGroup: TriggerUUID + "a",
-								},
-								{
-									Filter: &contract.Filter{Attributes: map[string]string{
-										"source": "source1",
-									}},
-									Destination:   "http://example.com",
-									ConsumerGroup: TriggerUUID + "b",
-								},
-							},
-							EgressConfig:     &contract.EgressConfig{DeadLetter: ServiceURL},
-							BootstrapServers: bootstrapServers,
-							Reference:        BrokerReference(),
-							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-						},
-					},
-					Generation: 2,
-				}),
-				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
-				}),
-				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
-				}),
+			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
+			WantCreates: []runtime.Object{
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchFinalizers(),
@@ -1650,37 +1507,25 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithDelivery(),
 						reconcilertesting.WithInitBrokerConditions,
 						StatusBrokerDataPlaneAvailable,
-						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerConfigParsed,
-						StatusBrokerTopicReady,
+						StatusBrokerTopicCreationTransientFailure(sarama.ErrNotController),
 						BrokerConfigMapAnnotations(),
-						WithTopicStatusAnnotation(BrokerTopic()),
-						BrokerAddressable(&env),
-						StatusBrokerProbeSucceeded,
-						BrokerDLSResolved(ServiceURL),
-						WithBrokerAddresses([]duckv1.Addressable{
-							{
-								Name: pointer.String("http"),
-								URL:  brokerAddress,
-							},
-						}),
-						WithBrokerAddress(duckv1.Addressable{
-							Name: pointer.String("http"),
-							URL:  brokerAddress,
-						}),
-						WithBrokerAddessable(),
 					),
 				},
 			},
+			OtherTestData: map[string]interface{}{
+				wantErrorOnCreateTopic: sarama.ErrNotController,
+			},
 		},
 		{
-			Name: "no data plane pods running",
+			Name: "Failed to create topic - permanent error",
 			Objects: []runtime.Object{
 				NewBroker(),
 				BrokerConfig(bootstrapServers, 20, 5),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
 			},
 			Key:     testKey,
 			WantErr: true,
@@ -1688,8 +1533,9 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 				finalizerUpdatedEvent,
 				Eventf(
 					corev1.EventTypeWarning,
-					"InternalError",
-					fmt.Sprintf("%s: %s", base.ReasonDataPlaneNotAvailable, base.MessageDataPlaneNotAvailable),
+					base.ReasonTopicCreationPermanentFailure,
+					"Failed to create topic %s, fix the topic config or the Kafka cluster permissions: %v",
+					BrokerTopic(), &sarama.TopicError{Err: sarama.ErrPolicyViolation},
 				),
 			},
 			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
@@ -1703,55 +1549,39 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 				{
 					Object: NewBroker(
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerDataPlaneNotAvailable,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicCreationPermanentFailure(&sarama.TopicError{Err: sarama.ErrPolicyViolation}),
+						BrokerConfigMapAnnotations(),
 					),
 				},
 			},
+			OtherTestData: map[string]interface{}{
+				wantErrorOnCreateTopic: &sarama.TopicError{Err: sarama.ErrPolicyViolation},
+			},
 		},
 		{
-			Name: "Reconciled normal - with retry config - exponential",
+			Name: "Failed to create topic - authorization error",
 			Objects: []runtime.Object{
-				NewBroker(
-					WithDelivery(),
-					WithRetry(pointer.Int32(10), &exponential, pointer.String("PT2S")),
-				),
+				NewBroker(),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapFromContract(&contract.Contract{
-					Generation: 1,
-				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
-				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
 			},
-			Key: testKey,
+			Key:     testKey,
+			WantErr: true,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					base.ReasonTopicAuthorizationFailed,
+					"Not authorized to create topics %v, grant the required ACLs to the Kafka client: %v",
+					[]string{BrokerTopic()}, &sarama.TopicError{Err: sarama.ErrTopicAuthorizationFailed},
+				),
 			},
-			WantUpdates: []clientgotesting.UpdateActionImpl{
-				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
-					Resources: []*contract.Resource{
-						{
-							Uid:              BrokerUUID,
-							Topics:           []string{BrokerTopic()},
-							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-							BootstrapServers: bootstrapServers,
-							Reference:        BrokerReference(),
-							EgressConfig: &contract.EgressConfig{
-								DeadLetter:    ServiceURL,
-								Retry:         10,
-								BackoffPolicy: contract.BackoffPolicy_Exponential,
-								BackoffDelay:  2000,
-							},
-						},
-					},
-					Generation: 2,
-				}),
-				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
-				}),
-				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
-				}),
+			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
+			WantCreates: []runtime.Object{
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchFinalizers(),
@@ -1759,51 +1589,43 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithDelivery(),
-						WithRetry(pointer.Int32(10), &exponential, pointer.String("PT2S")),
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerDataPlaneAvailable,
-						StatusBrokerTopicReady,
-						BrokerConfigMapAnnotations(),
-						WithTopicStatusAnnotation(BrokerTopic()),
 						StatusBrokerConfigParsed,
-						BrokerAddressable(&env),
-						StatusBrokerProbeSucceeded,
-						BrokerDLSResolved(ServiceURL),
-						WithBrokerAddresses([]duckv1.Addressable{
-							{
-								Name: pointer.String("http"),
-								URL:  brokerAddress,
-							},
-						}),
-						WithBrokerAddress(duckv1.Addressable{
-							Name: pointer.String("http"),
-							URL:  brokerAddress,
-						}),
-						WithBrokerAddessable(),
+						StatusBrokerTopicAuthorizationFailed("create", &sarama.TopicError{Err: sarama.ErrTopicAuthorizationFailed}),
+						BrokerConfigMapAnnotations(),
 					),
 				},
 			},
+			OtherTestData: map[string]interface{}{
+				wantErrorOnCreateTopic: &sarama.TopicError{Err: sarama.ErrTopicAuthorizationFailed},
+			},
 		},
 		{
-			Name: "Reconciled normal - with retry config - linear",
+			Name: "Config map not found - create config map",
 			Objects: []runtime.Object{
 				NewBroker(
 					WithDelivery(),
-					WithRetry(pointer.Int32(10), &linear, pointer.String("PT2S")),
 				),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapFromContract(&contract.Contract{
-					Generation: 1,
-				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
 				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "0"}),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "2"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "2"}),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: env.DataPlaneConfigMapNamespace,
+						Name:      env.ContractConfigMapName + "a", // Use a different name
+					},
+				},
 			},
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
+			WantCreates: []runtime.Object{
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
@@ -1813,22 +1635,17 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 							Topics:           []string{BrokerTopic()},
 							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
 							BootstrapServers: bootstrapServers,
+							EgressConfig:     &contract.EgressConfig{DeadLetter: ServiceURL},
 							Reference:        BrokerReference(),
-							EgressConfig: &contract.EgressConfig{
-								DeadLetter:    ServiceURL,
-								Retry:         10,
-								BackoffPolicy: contract.BackoffPolicy_Linear,
-								BackoffDelay:  2000,
-							},
 						},
 					},
-					Generation: 2,
+					Generation: 1,
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "1",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "1",
 				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
@@ -1838,14 +1655,13 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 				{
 					Object: NewBroker(
 						WithDelivery(),
-						WithRetry(pointer.Int32(10), &linear, pointer.String("PT2S")),
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerConfigParsed,
 						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerTopicReady,
 						BrokerConfigMapAnnotations(),
 						WithTopicStatusAnnotation(BrokerTopic()),
-						StatusBrokerConfigParsed,
 						BrokerAddressable(&env),
 						StatusBrokerProbeSucceeded,
 						BrokerDLSResolved(ServiceURL),
@@ -1865,23 +1681,28 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			},
 		},
 		{
-			Name: "Reconciled normal - with no retry num",
+			Name: "Reconciled normal - config map not readable",
 			Objects: []runtime.Object{
-				NewBroker(
-					WithDelivery(),
-					WithRetry(nil, &linear, pointer.String("PT2S")),
-				),
+				NewBroker(),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapFromContract(&contract.Contract{
-					Generation: 1,
-				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, []byte(`{"hello": "world"}`)),
 				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
 			},
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+				Eventf(
+					corev1.EventTypeWarning,
+					"ContractRebuild",
+					"Contract config map %s/%s is corrupted, rebuilding it from all brokers",
+					env.DataPlaneConfigMapNamespace,
+					env.ContractConfigMapName,
+				),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
@@ -1892,18 +1713,17 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
 							BootstrapServers: bootstrapServers,
 							Reference:        BrokerReference(),
-							EgressConfig: &contract.EgressConfig{
-								DeadLetter: ServiceURL,
-							},
 						},
 					},
-					Generation: 2,
+					Generation: 3,
+				}, func(cm *corev1.ConfigMap) {
+					cm.Annotations[base.ContractRebuildAnnotation] = "1"
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "3",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "3",
 				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
@@ -1912,18 +1732,15 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithDelivery(),
-						WithRetry(nil, &linear, pointer.String("PT2S")),
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerConfigParsed,
 						StatusBrokerTopicReady,
 						BrokerConfigMapAnnotations(),
 						WithTopicStatusAnnotation(BrokerTopic()),
-						StatusBrokerConfigParsed,
 						BrokerAddressable(&env),
 						StatusBrokerProbeSucceeded,
-						BrokerDLSResolved(ServiceURL),
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -1940,48 +1757,67 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			},
 		},
 		{
-			Name: "Reconciled normal - with retry config - no retry delay - use default delay",
+			Name: "Reconciled normal - preserve config map previous state",
 			Objects: []runtime.Object{
-				NewBroker(
-					WithDelivery(),
-					WithRetry(pointer.Int32(10), &linear, nil),
-				),
+				NewBroker(),
 				BrokerConfig(bootstrapServers, 20, 5),
 				NewConfigMapFromContract(&contract.Contract{
-					Generation: 1,
+					Resources: []*contract.Resource{
+						{
+							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
+							Topics:       []string{"my-existing-topic-a"},
+							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
+						},
+						{
+							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44a",
+							Topics:       []string{"my-existing-topic-b"},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
+						},
+					},
 				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
 				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
 			},
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources: []*contract.Resource{
+						{
+							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
+							Topics:       []string{"my-existing-topic-a"},
+							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
+						},
+						{
+							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44a",
+							Topics:       []string{"my-existing-topic-b"},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
+						},
 						{
 							Uid:              BrokerUUID,
 							Topics:           []string{BrokerTopic()},
 							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
 							BootstrapServers: bootstrapServers,
 							Reference:        BrokerReference(),
-							EgressConfig: &contract.EgressConfig{
-								DeadLetter:    ServiceURL,
-								Retry:         10,
-								BackoffPolicy: contract.BackoffPolicy_Linear,
-								BackoffDelay:  env.DefaultBackoffDelayMs,
-							},
 						},
 					},
-					Generation: 2,
+					Generation: 1,
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "1",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "2",
+					base.VolumeGenerationAnnotationKey: "1",
 				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
@@ -1990,18 +1826,15 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithDelivery(),
-						WithRetry(pointer.Int32(10), &linear, nil),
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerConfigParsed,
 						StatusBrokerTopicReady,
 						BrokerConfigMapAnnotations(),
 						WithTopicStatusAnnotation(BrokerTopic()),
-						StatusBrokerConfigParsed,
 						BrokerAddressable(&env),
 						StatusBrokerProbeSucceeded,
-						BrokerDLSResolved(ServiceURL),
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -2018,33 +1851,74 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			},
 		},
 		{
-			Name: "Reconciled normal - unchanged",
+			Name: "Reconciled normal - update existing broker while preserving others",
 			Objects: []runtime.Object{
 				NewBroker(
-					WithDelivery(),
-					BrokerConfigMapAnnotations(),
+					func(broker *eventing.Broker) {
+						broker.Spec.Delivery = &eventingduck.DeliverySpec{
+							DeadLetterSink: &duckv1.Destination{
+								URI: &apis.URL{
+									Scheme: "http",
+									Host:   "www.my-sink.com",
+									Path:   "/api",
+								},
+							},
+						}
+					},
 				),
 				BrokerConfig(bootstrapServers, 20, 5),
 				NewConfigMapFromContract(&contract.Contract{
 					Resources: []*contract.Resource{
 						{
-							Uid:              BrokerUUID,
-							Topics:           []string{BrokerTopic()},
-							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-							BootstrapServers: bootstrapServers,
-							Reference:        BrokerReference(),
-							EgressConfig:     &contract.EgressConfig{DeadLetter: ServiceURL},
+							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
+							Topics:       []string{"my-existing-topic-a"},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
+						},
+						{
+							Uid:          BrokerUUID,
+							Topics:       []string{BrokerTopic()},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
 						},
 					},
-					Generation: 1,
 				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
 				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "5",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "5",
+				}),
 			},
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
+							Topics:       []string{"my-existing-topic-a"},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
+						},
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							EgressConfig:     &contract.EgressConfig{DeadLetter: "http://www.my-sink.com/api"},
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchFinalizers(),
@@ -2052,17 +1926,26 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithDelivery(),
+						func(broker *eventing.Broker) {
+							broker.Spec.Delivery = &eventingduck.DeliverySpec{
+								DeadLetterSink: &duckv1.Destination{
+									URI: func() *apis.URL {
+										URL, _ := url.Parse("http://www.my-sink.com/api")
+										return (*apis.URL)(URL)
+									}(),
+								},
+							}
+						},
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerDataPlaneAvailable,
-						StatusBrokerTopicReady,
+						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerConfigParsed,
-						BrokerAddressable(&env),
-						StatusBrokerProbeSucceeded,
-						BrokerDLSResolved(ServiceURL),
+						StatusBrokerTopicReady,
 						BrokerConfigMapAnnotations(),
 						WithTopicStatusAnnotation(BrokerTopic()),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved("http://www.my-sink.com/api"),
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -2079,36 +1962,68 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			},
 		},
 		{
-			Name: "Reconciled normal - unchanged contract - changed data plane pods annotation",
+			Name: "Reconciled normal - remove existing broker DLS while preserving others",
 			Objects: []runtime.Object{
 				NewBroker(
-					WithDelivery(),
+					func(broker *eventing.Broker) {
+						broker.Spec.Delivery = &eventingduck.DeliverySpec{}
+					},
 				),
 				BrokerConfig(bootstrapServers, 20, 5),
 				NewConfigMapFromContract(&contract.Contract{
 					Resources: []*contract.Resource{
 						{
-							Uid:              BrokerUUID,
-							Topics:           []string{BrokerTopic()},
-							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
-							BootstrapServers: bootstrapServers,
-							Reference:        BrokerReference(),
-							EgressConfig:     &contract.EgressConfig{DeadLetter: ServiceURL},
+							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
+							Topics:       []string{"my-existing-topic-a"},
+							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
+						},
+						{
+							Uid:          BrokerUUID,
+							Topics:       []string{BrokerTopic()},
+							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
 						},
 					},
-					Generation: 1,
 				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
 				NewService(),
-				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "0"}),
-				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "0"}),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "5",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "5",
+				}),
 			},
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
-				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
-				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
+							Topics:       []string{"my-existing-topic-a"},
+							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
+						},
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchFinalizers(),
@@ -2116,17 +2031,18 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 				{
 					Object: NewBroker(
-						WithDelivery(),
+						func(broker *eventing.Broker) {
+							broker.Spec.Delivery = &eventingduck.DeliverySpec{}
+						},
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerDataPlaneAvailable,
-						StatusBrokerTopicReady,
+						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerConfigParsed,
-						BrokerAddressable(&env),
-						StatusBrokerProbeSucceeded,
-						BrokerDLSResolved(ServiceURL),
+						StatusBrokerTopicReady,
 						BrokerConfigMapAnnotations(),
 						WithTopicStatusAnnotation(BrokerTopic()),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -2141,46 +2057,65 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 					),
 				},
 			},
-		}, {
-			Name: "Reconciled normal - with custom topic template",
+		},
+		{
+			Name: "Reconciled normal - increment volume generation",
 			Objects: []runtime.Object{
 				NewBroker(),
 				BrokerConfig(bootstrapServers, 20, 5),
-				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
+							Topics:       []string{"my-existing-topic-a"},
+							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
+						},
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{BrokerTopic()},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
 				NewService(),
 				BrokerReceiverPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "0",
-					"annotation_to_preserve":           "value_to_preserve",
+					base.VolumeGenerationAnnotationKey: "5",
 				}),
 				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "0",
-					"annotation_to_preserve":           "value_to_preserve",
+					base.VolumeGenerationAnnotationKey: "5",
 				}),
 			},
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources: []*contract.Resource{
+						{
+							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
+							Topics:       []string{"my-existing-topic-a"},
+							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
+						},
 						{
 							Uid:              BrokerUUID,
-							Topics:           []string{CustomBrokerTopic(customBrokerTopicTemplate)},
+							Topics:           []string{BrokerTopic()},
 							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
 							BootstrapServers: bootstrapServers,
 							Reference:        BrokerReference(),
 						},
 					},
-					Generation: 1,
+					Generation: 2,
 				}),
 				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "1",
-					"annotation_to_preserve":           "value_to_preserve",
+					base.VolumeGenerationAnnotationKey: "2",
 				}),
 				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
-					base.VolumeGenerationAnnotationKey: "1",
-					"annotation_to_preserve":           "value_to_preserve",
+					base.VolumeGenerationAnnotationKey: "2",
 				}),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
@@ -2190,14 +2125,198 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 				{
 					Object: NewBroker(
 						reconcilertesting.WithInitBrokerConditions,
-						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigMapUpdatedReady(&env),
 						StatusBrokerConfigParsed,
-						StatusExternalBrokerTopicReady(CustomBrokerTopic(customBrokerTopicTemplate)),
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
 						BrokerAddressable(&env),
 						StatusBrokerProbeSucceeded,
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Failed to resolve DLS",
+			Objects: []runtime.Object{
+				NewBroker(
+					func(broker *eventing.Broker) {
+						broker.Spec.Delivery = &eventingduck.DeliverySpec{
+							DeadLetterSink: &duckv1.Destination{},
+						}
+					},
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:          "5384faa4-6bdf-428d-b6c2-d6f89ce1d44b",
+							Topics:       []string{"my-existing-topic-a"},
+							EgressConfig: &contract.EgressConfig{DeadLetter: "http://www.my-sink.com"},
+							Ingress:      &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "5",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "5",
+				}),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"failed to get contract configuration: failed to resolve Spec.Delivery.DeadLetterSink: %v",
+					"destination missing Ref and URI, expected at least one",
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						func(broker *eventing.Broker) {
+							broker.Spec.Delivery = &eventingduck.DeliverySpec{
+								DeadLetterSink: &duckv1.Destination{},
+							}
+						},
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed("failed to resolve Spec.Delivery.DeadLetterSink: destination missing Ref and URI, expected at least one"),
+						StatusBrokerTopicReady,
 						BrokerConfigMapAnnotations(),
-						WithTopicStatusAnnotation(CustomBrokerTopic(customBrokerTopicTemplate)),
+						WithTopicStatusAnnotation(BrokerTopic()),
+					),
+				},
+			},
+		},
+		{
+			Name: "No bootstrap.servers provided",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig("", 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"failed to get contract configuration: unable to build topic config from configmap: invalid topic config in configmap test-namespace-config-map/test-config-cm: key bootstrap.servers with value \"\" must contain at least one bootstrap server",
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed("unable to build topic config from configmap: invalid topic config in configmap test-namespace-config-map/test-config-cm: key bootstrap.servers with value \"\" must contain at least one bootstrap server"),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - with broker config",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(
+						KReference(BrokerConfig(bootstrapServers, 20, 5)),
+					),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "3",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(
+							KReference(BrokerConfig(bootstrapServers, 20, 5)),
+						),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
 						WithBrokerAddresses([]duckv1.Addressable{
 							{
 								Name: pointer.String("http"),
@@ -2212,54 +2331,8328 @@ func brokerReconciliation(t *testing.T, format string, env config.Env) {
 					),
 				},
 			},
-
 			OtherTestData: map[string]interface{}{
-				kafkaFeatureFlags: newKafkaFeaturesConfigFromMap(&corev1.ConfigMap{
-					Data: map[string]string{
-						"brokers.topic.template": "custom-broker-template.{{ .Namespace }}-{{ .Name }}",
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - with auth config",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+						BrokerAuthConfig("secret-1"),
+					))),
+				),
+				NewSSLSecret(ConfigMapNamespace, "secret-1"),
+				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1")),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				SecretFinalizerUpdate("secret-1", SecretFinalizerName),
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							Auth: &contract.Resource_AuthSecret{
+								AuthSecret: &contract.Reference{
+									Uuid:      SecretUUID,
+									Namespace: ConfigMapNamespace,
+									Name:      "secret-1",
+									Version:   SecretResourceVersion,
+								},
+							},
+						},
 					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
 				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+							BrokerAuthConfig("secret-1"),
+						))),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						BrokerConfigMapSecretAnnotation("secret-1"),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - with trust bundle",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+						BrokerAuthConfig("secret-1"),
+						BrokerTrustBundleConfig("ca-bundle"),
+					))),
+				),
+				NewSSLSecret(ConfigMapNamespace, "secret-1"),
+				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1"), BrokerTrustBundleConfig("ca-bundle")),
+				NewTrustBundle(ConfigMapNamespace, "ca-bundle", NewTrustBundleCA()),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				SecretFinalizerUpdate("secret-1", SecretFinalizerName),
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							Auth: &contract.Resource_AuthSecret{
+								AuthSecret: &contract.Reference{
+									Uuid:      SecretUUID,
+									Namespace: ConfigMapNamespace,
+									Name:      "secret-1",
+									Version:   SecretResourceVersion,
+								},
+							},
+							TrustBundle: &contract.TrustBundleReference{
+								Reference: &contract.Reference{
+									Uuid:      TrustBundleUUID,
+									Namespace: ConfigMapNamespace,
+									Name:      "ca-bundle",
+									Version:   TrustBundleResourceVersion,
+								},
+								Key: security.DefaultTrustBundleKey,
+							},
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+							BrokerAuthConfig("secret-1"),
+							BrokerTrustBundleConfig("ca-bundle"),
+						))),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						BrokerConfigMapSecretAnnotation("secret-1"),
+						BrokerConfigMapTrustBundleAnnotation("ca-bundle"),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+				},
+			},
+		},
+		{
+			Name: "Invalid trust bundle",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+						BrokerTrustBundleConfig("ca-bundle"),
+					))),
+				),
+				BrokerConfig(bootstrapServers, 20, 5, BrokerTrustBundleConfig("ca-bundle")),
+				NewTrustBundle(ConfigMapNamespace, "ca-bundle", "not a certificate"),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"failed to get contract configuration: invalid trust bundle %s/ca-bundle (key: %s): failed to decode PEM block 1",
+					ConfigMapNamespace, security.DefaultTrustBundleKey,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+							BrokerTrustBundleConfig("ca-bundle"),
+						))),
+						reconcilertesting.WithInitBrokerConditions,
+						BrokerConfigMapAnnotations(),
+						BrokerConfigMapTrustBundleAnnotation("ca-bundle"),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf("invalid trust bundle %s/ca-bundle (key: %s): failed to decode PEM block 1", ConfigMapNamespace, security.DefaultTrustBundleKey)),
+					),
+				},
+			},
+		},
+		{
+			Name: "Failed to parse broker config - not found",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(
+						KReference(BrokerConfig(bootstrapServers, 20, 5)),
+					),
+				),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					fmt.Sprintf(`failed to get contract configuration: unable to rebuild topic config, failed to get configmap %s/%s`, ConfigMapNamespace, ConfigMapName),
+				),
+			},
+			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
+			WantCreates: []runtime.Object{
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(
+							KReference(BrokerConfig(bootstrapServers, 20, 5)),
+						),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`unable to rebuild topic config, failed to get configmap %s/%s`, ConfigMapNamespace, ConfigMapName)),
+					),
+				},
+			},
+		},
+		{
+			Name: "Unsupported Kind as config",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(&duckv1.KReference{
+						Kind:       "Pod",
+						Namespace:  BrokerNamespace,
+						Name:       BrokerName,
+						APIVersion: "v1",
+					}),
+				),
+				&corev1.Pod{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "Pod",
+						APIVersion: "v1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: BrokerNamespace,
+						Name:      BrokerName,
+					},
+				},
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"failed to get contract configuration: supported config Kind: ConfigMap - got Pod",
+				),
+			},
+			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
+			WantCreates: []runtime.Object{
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(&duckv1.KReference{
+							Kind:       "Pod",
+							Namespace:  BrokerNamespace,
+							Name:       BrokerName,
+							APIVersion: "v1",
+						}),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(`supported config Kind: ConfigMap - got Pod`),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - keep all existing triggers",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:          BrokerUUID,
+							Topics:       []string{BrokerTopic()},
+							EgressConfig: &contract.EgressConfig{DeadLetter: ServiceURL},
+							Egresses: []*contract.Egress{
+								{
+									Filter: &contract.Filter{Attributes: map[string]string{
+										"source": "source1",
+									}},
+									Destination:   "http://example.com",
+									ConsumerGroup: TriggerUUID,
+								},
+								{
+									Filter: &contract.Filter{Attributes: map[string]string{
+										"source": "source1",
+									}},
+									Destination:   "http://example.com",
+									ConsumerGroup: TriggerUUID + "a",
+								},
+								{
+									Filter: &contract.Filter{Attributes: map[string]string{
+										"source": "source1",
+									}},
+									Destination:   "http://example.com",
+									ConsumerGroup: TriggerUUID + "b",
+								},
+							},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:    BrokerUUID,
+							Topics: []string{BrokerTopic()},
+							Egresses: []*contract.Egress{
+								{
+									Filter: &contract.Filter{Attributes: map[string]string{
+										"source": "source1",
+									}},
+									Destination:   "http://example.com",
+									ConsumerGroup: TriggerUUID,
+								},
+								{
+									Filter: &contract.Filter{Attributes: map[string]string{
+										"source": "source1",
+									}},
+									Destination:   "http://example.com",
+									ConsumerGroup: TriggerUUID + "a",
+								},
+								{
+									Filter: &contract.Filter{Attributes: map[string]string{
+										"source": "source1",
+									}},
+									Destination:   "http://example.com",
+									ConsumerGroup: TriggerUUID + "b",
+								},
+							},
+							EgressConfig:     &contract.EgressConfig{DeadLetter: ServiceURL},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "no data plane pods running",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					fmt.Sprintf("%s: %s", base.ReasonDataPlaneNotAvailable, base.MessageDataPlaneNotAvailable),
+				),
+			},
+			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
+			WantCreates: []runtime.Object{
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneNotAvailable,
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - with retry config - exponential",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					WithRetry(pointer.Int32(10), &exponential, pointer.String("PT2S")),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter:    ServiceURL,
+								Retry:         10,
+								BackoffPolicy: contract.BackoffPolicy_Exponential,
+								BackoffDelay:  2000,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						WithRetry(pointer.Int32(10), &exponential, pointer.String("PT2S")),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithDeliveryStatusAnnotations("10", "exponential", "2s"),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - with retry config - linear",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					WithRetry(pointer.Int32(10), &linear, pointer.String("PT2S")),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "0"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter:    ServiceURL,
+								Retry:         10,
+								BackoffPolicy: contract.BackoffPolicy_Linear,
+								BackoffDelay:  2000,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						WithRetry(pointer.Int32(10), &linear, pointer.String("PT2S")),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithDeliveryStatusAnnotations("10", "linear", "2s"),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - with no retry num",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					WithRetry(nil, &linear, pointer.String("PT2S")),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter: ServiceURL,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						WithRetry(nil, &linear, pointer.String("PT2S")),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - with retry config - no retry delay - use default delay",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					WithRetry(pointer.Int32(10), &linear, nil),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+				defaultBackoffDelayAppliedEvent("1s"),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter:    ServiceURL,
+								Retry:         10,
+								BackoffPolicy: contract.BackoffPolicy_Linear,
+								BackoffDelay:  env.DefaultBackoffDelayMs,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						WithRetry(pointer.Int32(10), &linear, nil),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithDeliveryStatusAnnotations("10", "linear", "1s"),
+						WithDefaultBackoffDelayStatusAnnotation("1s"),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - with retry config - no retry delay - default delay already reported",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					WithRetry(pointer.Int32(10), &linear, nil),
+					WithDefaultBackoffDelayStatusAnnotation("1s"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter:    ServiceURL,
+								Retry:         10,
+								BackoffPolicy: contract.BackoffPolicy_Linear,
+								BackoffDelay:  env.DefaultBackoffDelayMs,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						WithRetry(pointer.Int32(10), &linear, nil),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithDeliveryStatusAnnotations("10", "linear", "1s"),
+						WithDefaultBackoffDelayStatusAnnotation("1s"),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - with retry config - no retry delay - use default delay annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					WithRetry(pointer.Int32(10), &linear, nil),
+					WithDefaultBackoffDelayAnnotation("PT1.5S"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+				defaultBackoffDelayAppliedEvent("1.5s"),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter:    ServiceURL,
+								Retry:         10,
+								BackoffPolicy: contract.BackoffPolicy_Linear,
+								BackoffDelay:  1500,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						WithRetry(pointer.Int32(10), &linear, nil),
+						WithDefaultBackoffDelayAnnotation("PT1.5S"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithDeliveryStatusAnnotations("10", "linear", "1.5s"),
+						WithDefaultBackoffDelayStatusAnnotation("1.5s"),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - ordered delivery annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					WithRetry(pointer.Int32(10), &linear, nil),
+					WithDeliveryOrderAnnotation(string(kafkasource.Ordered)),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+				defaultBackoffDelayAppliedEvent("1s"),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							DeliveryOrder:    contract.DeliveryOrder_ORDERED,
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter:    ServiceURL,
+								Retry:         10,
+								BackoffPolicy: contract.BackoffPolicy_Linear,
+								BackoffDelay:  env.DefaultBackoffDelayMs,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						WithRetry(pointer.Int32(10), &linear, nil),
+						WithDeliveryOrderAnnotation(string(kafkasource.Ordered)),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithDeliveryStatusAnnotations("10", "linear", "1s"),
+						WithDefaultBackoffDelayStatusAnnotation("1s"),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - structured dead letter sink content mode annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					WithRetry(pointer.Int32(10), &linear, nil),
+					WithDeadLetterSinkContentModeAnnotation(kafkaeventing.ModeStructured),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+				defaultBackoffDelayAppliedEvent("1s"),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter:    ServiceURL,
+								Retry:         10,
+								BackoffPolicy: contract.BackoffPolicy_Linear,
+								BackoffDelay:  env.DefaultBackoffDelayMs,
+
+								DeadLetterContentMode: contract.ContentMode_STRUCTURED,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						WithRetry(pointer.Int32(10), &linear, nil),
+						WithDeadLetterSinkContentModeAnnotation(kafkaeventing.ModeStructured),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithDeliveryStatusAnnotations("10", "linear", "1s"),
+						WithDefaultBackoffDelayStatusAnnotation("1s"),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - structured ingress content mode annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					WithRetry(pointer.Int32(10), &linear, nil),
+					WithIngressContentModeAnnotation(kafkaeventing.ModeStructured),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+				defaultBackoffDelayAppliedEvent("1s"),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName), ContentMode: contract.ContentMode_STRUCTURED},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter:    ServiceURL,
+								Retry:         10,
+								BackoffPolicy: contract.BackoffPolicy_Linear,
+								BackoffDelay:  env.DefaultBackoffDelayMs,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						WithRetry(pointer.Int32(10), &linear, nil),
+						WithIngressContentModeAnnotation(kafkaeventing.ModeStructured),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithDeliveryStatusAnnotations("10", "linear", "1s"),
+						WithDefaultBackoffDelayStatusAnnotation("1s"),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - ingress filter annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					WithRetry(pointer.Int32(10), &linear, nil),
+					WithIngressFilterAnnotation("required=source,subject; types=com.example.created,com.example.deleted"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+				defaultBackoffDelayAppliedEvent("1s"),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:    BrokerUUID,
+							Topics: []string{BrokerTopic()},
+							Ingress: &contract.Ingress{
+								Path: receiver.Path(BrokerNamespace, BrokerName),
+								Filter: &contract.IngressFilter{
+									RequiredAttributes: []string{"source", "subject"},
+									AllowedTypes:       []string{"com.example.created", "com.example.deleted"},
+								},
+							},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter:    ServiceURL,
+								Retry:         10,
+								BackoffPolicy: contract.BackoffPolicy_Linear,
+								BackoffDelay:  env.DefaultBackoffDelayMs,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						WithRetry(pointer.Int32(10), &linear, nil),
+						WithIngressFilterAnnotation("required=source,subject; types=com.example.created,com.example.deleted"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithDeliveryStatusAnnotations("10", "linear", "1s"),
+						WithDefaultBackoffDelayStatusAnnotation("1s"),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - full backoff jitter annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					WithRetry(pointer.Int32(10), &linear, nil),
+					WithDeliveryBackoffJitterAnnotation(BackoffJitterFull),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+				defaultBackoffDelayAppliedEvent("1s"),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter:    ServiceURL,
+								Retry:         10,
+								BackoffPolicy: contract.BackoffPolicy_Linear,
+								BackoffDelay:  env.DefaultBackoffDelayMs,
+
+								BackoffJitter: contract.BackoffJitter_FullJitter,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						WithRetry(pointer.Int32(10), &linear, nil),
+						WithDeliveryBackoffJitterAnnotation(BackoffJitterFull),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithDeliveryStatusAnnotations("10", "linear", "1s"),
+						WithDefaultBackoffDelayStatusAnnotation("1s"),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - producer acks annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					WithProducerAcksAnnotation(ProducerAcksLeader),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter: ServiceURL,
+							},
+							ProducerAcks: contract.ProducerAcks_LeaderAcks,
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						WithProducerAcksAnnotation(ProducerAcksLeader),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - resource mutator",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			OtherTestData: map[string]interface{}{
+				resourceMutator: ResourceMutator(func(_ context.Context, broker *eventing.Broker, resource *contract.Resource) error {
+					resource.CloudEventOverrides = &contract.CloudEventOverrides{
+						Extensions: map[string]string{"tenant": broker.Namespace},
+					}
+					return nil
+				}),
+			},
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter: ServiceURL,
+							},
+							CloudEventOverrides: &contract.CloudEventOverrides{
+								Extensions: map[string]string{"tenant": BrokerNamespace},
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - ingress unreachable from the controller",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			OtherTestData: map[string]interface{}{
+				ingressReachability: IngressReachabilityCheckFunc(func(context.Context, duckv1.Addressable) error {
+					return ingressUnreachableError
+				}),
+			},
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+				Eventf(corev1.EventTypeWarning, base.ReasonIngressUnreachable,
+					"Address %s is advertised but it isn't reachable from the controller: %v", brokerAddress, ingressUnreachableError),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter: ServiceURL,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+						StatusBrokerIngressUnreachable(brokerAddress, ingressUnreachableError),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - ingress reachable from the controller",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					StatusBrokerIngressUnreachable(brokerAddress, ingressUnreachableError),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			OtherTestData: map[string]interface{}{
+				ingressReachability: IngressReachabilityCheckFunc(func(context.Context, duckv1.Addressable) error {
+					return nil
+				}),
+			},
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig: &contract.EgressConfig{
+								DeadLetter: ServiceURL,
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - unchanged",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+					BrokerConfigMapAnnotations(),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig:     &contract.EgressConfig{DeadLetter: ServiceURL},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - unchanged contract - changed data plane pods annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDelivery(),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							EgressConfig:     &contract.EgressConfig{DeadLetter: ServiceURL},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "0"}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "0"}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{base.VolumeGenerationAnnotationKey: "1"}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDelivery(),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerTopicReady,
+						StatusBrokerConfigParsed,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerDLSResolved(ServiceURL),
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		}, {
+			Name: "Reconciled normal - with custom topic template",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(CustomBrokerTopic(customBrokerTopicTemplate)),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{CustomBrokerTopic(customBrokerTopicTemplate)},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusExternalBrokerTopicReady(CustomBrokerTopic(customBrokerTopicTemplate)),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(CustomBrokerTopic(customBrokerTopicTemplate)),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+
+			OtherTestData: map[string]interface{}{
+				kafkaFeatureFlags: newKafkaFeaturesConfigFromMap(&corev1.ConfigMap{
+					Data: map[string]string{
+						"brokers.topic.template": "custom-broker-template.{{ .Namespace }}-{{ .Name }}",
+					},
+				}),
+			},
+		},
+		{
+			Name: "Reconciled normal - topic retention annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithTopicRetentionAnnotation("3600000"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithTopicRetentionAnnotation("3600000"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithTopicConfigStatusAnnotation(`{"retention.ms":"3600000"}`),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+					ConfigEntries: map[string]*string{
+						kafka.RetentionMsTopicConfigKey: pointer.String("3600000"),
+					},
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - existing topic with fewer partitions and different replication factor",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						StatusBrokerTopicConfigNotSynced(BrokerTopic(), kafka.ReplicationFactorMismatch{
+							Topic:   BrokerTopic(),
+							Desired: 5,
+							Actual:  3,
+						}),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				wantErrorOnCreateTopic: &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists},
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+				},
+				topicMetadata: []*sarama.TopicMetadata{
+					{
+						Name: BrokerTopic(),
+						Partitions: []*sarama.PartitionMetadata{
+							{ID: 0, Replicas: []int32{0, 1, 2}},
+							{ID: 1, Replicas: []int32{0, 1, 2}},
+						},
+					},
+				},
+				partitionsCount: int32(20),
+			},
+		},
+		{
+			Name: "Reconciled normal - primary cluster unreachable, failover cluster used",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5, WithFailoverBootstrapServers("kafka-dr:9092")),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"BootstrapServersFailover",
+					"failed to connect to Kafka cluster %s, falling back to %s: failed to connect to %s",
+					bootstrapServers, "kafka-dr:9092", bootstrapServers,
+				),
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: "kafka-dr:9092",
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithFailoverBootstrapServersStatusAnnotation("kafka-dr:9092"),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithActiveBootstrapServersStatusAnnotation("kafka-dr:9092"),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				unreachableCluster: bootstrapServers,
+			},
+		},
+		{
+			Name: "Invalid topic retention annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithTopicRetentionAnnotation("-1"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					`failed to get contract configuration: invalid %s annotation value "-1": expected a non-negative number of milliseconds`,
+					TopicRetentionMsAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithTopicRetentionAnnotation("-1"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`invalid %s annotation value "-1": expected a non-negative number of milliseconds`, TopicRetentionMsAnnotation)),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Invalid default backoff delay annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDefaultBackoffDelayAnnotation("1s"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					`failed to get contract configuration: invalid %s annotation value "1s": expected a positive ISO-8601 duration (for example, PT0.2S)`,
+					DefaultBackoffDelayAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDefaultBackoffDelayAnnotation("1s"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`invalid %s annotation value "1s": expected a positive ISO-8601 duration (for example, PT0.2S)`, DefaultBackoffDelayAnnotation)),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Invalid delivery order annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDeliveryOrderAnnotation("fifo"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					`failed to get contract configuration: invalid %s annotation value "fifo": expected unordered or ordered`,
+					DeliveryOrderAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDeliveryOrderAnnotation("fifo"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`invalid %s annotation value "fifo": expected unordered or ordered`, DeliveryOrderAnnotation)),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Invalid dead letter sink content mode annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDeadLetterSinkContentModeAnnotation("json"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					`failed to get contract configuration: invalid %s annotation value "json": expected binary or structured`,
+					DeadLetterSinkContentModeAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDeadLetterSinkContentModeAnnotation("json"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`invalid %s annotation value "json": expected binary or structured`, DeadLetterSinkContentModeAnnotation)),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Invalid ingress content mode annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithIngressContentModeAnnotation("json"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					`failed to get contract configuration: invalid %s annotation value "json": expected binary or structured`,
+					IngressContentModeAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithIngressContentModeAnnotation("json"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`invalid %s annotation value "json": expected binary or structured`, IngressContentModeAnnotation)),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Invalid ingress filter annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithIngressFilterAnnotation("types="),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					`failed to get contract configuration: invalid %s annotation value "types=": types clause: empty value`,
+					IngressFilterAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithIngressFilterAnnotation("types="),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`invalid %s annotation value "types=": types clause: empty value`, IngressFilterAnnotation)),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Invalid backoff jitter annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDeliveryBackoffJitterAnnotation("random"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					`failed to get contract configuration: invalid %s annotation value "random": expected none, full or equal`,
+					DeliveryBackoffJitterAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDeliveryBackoffJitterAnnotation("random"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`invalid %s annotation value "random": expected none, full or equal`, DeliveryBackoffJitterAnnotation)),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Invalid producer acks annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithProducerAcksAnnotation("random"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					`failed to get contract configuration: invalid %s annotation value "random": expected 0, 1 or all`,
+					ProducerAcksAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithProducerAcksAnnotation("random"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`invalid %s annotation value "random": expected 0, 1 or all`, ProducerAcksAnnotation)),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Invalid data plane availability gate annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDataPlaneAvailabilityGateAnnotation("strict"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					`failed to get contract configuration: invalid %s annotation value "strict": expected hard or soft`,
+					DataPlaneAvailabilityGateAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithDataPlaneAvailabilityGateAnnotation("strict"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`invalid %s annotation value "strict": expected hard or soft`, DataPlaneAvailabilityGateAnnotation)),
+					),
+				},
+			},
+		},
+	}
+
+	for i := range table {
+		table[i].Name = table[i].Name + " - " + format
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerExternalTopicPresenceCheckBackoff(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.ExternalTopicPresenceCheckAttempts = 3
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "external topic not present - waiting for external topic",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithExternalTopic("my-not-present-topic"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
+			WantCreates: []runtime.Object{
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithExternalTopic("my-not-present-topic"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusExternalBrokerTopicWaiting("my-not-present-topic"),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				externalTopic: "my-not-present-topic",
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerExternalTopicNotFoundRequeueDelay(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.ExternalTopicNotFoundRequeueDelay = 5 * time.Minute
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "external topic not present - requeued after the configured delay",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithExternalTopic("my-not-present-topic"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
+			WantCreates: []runtime.Object{
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithExternalTopic("my-not-present-topic"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusExternalBrokerTopicNotPresentOrInvalid("my-not-present-topic"),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				externalTopic: "my-not-present-topic",
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerDryRun(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.DryRun = true
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	dryRunActions := fmt.Sprintf(
+		"create topic %s with 20 partitions and replication factor 5; add broker resource to contract config map %s",
+		BrokerTopic(), env.ContractConfigMapName,
+	)
+
+	table := TableTest{
+		{
+			Name: "Dry run - report topic creation and contract update",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(corev1.EventTypeNormal, "DryRun", "Dry run: %s", dryRunActions),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						BrokerConfigMapAnnotations(),
+						WithDryRunStatusAnnotation(dryRunActions),
+					),
+				},
+			},
+		},
+		{
+			Name: "Dry run - unchanged",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithExternalTopic(ExternalTopicName),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{ExternalTopicName},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(corev1.EventTypeNormal, "DryRun", "Dry run: no changes"),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithExternalTopic(ExternalTopicName),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						BrokerConfigMapAnnotations(),
+						WithDryRunStatusAnnotation("no changes"),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				externalTopic: ExternalTopicName,
+			},
+		},
+		{
+			Name: "Dry run - auth secret finalizer not added",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+						BrokerAuthConfig("secret-1"),
+					))),
+				),
+				NewSSLSecret(ConfigMapNamespace, "secret-1"),
+				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1")),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(corev1.EventTypeNormal, "DryRun", "Dry run: %s", dryRunActions),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+							BrokerAuthConfig("secret-1"),
+						))),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						BrokerConfigMapAnnotations(),
+						BrokerConfigMapSecretAnnotation("secret-1"),
+						WithDryRunStatusAnnotation(dryRunActions),
+					),
+				},
+			},
+		},
+		{
+			Name: "Dry run - finalization reported",
+			Objects: []runtime.Object{
+				NewDeletedBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{BrokerTopic()},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "DryRun", "Dry run: remove broker resource from contract config map %s; delete topic %s",
+					env.ContractConfigMapName, BrokerTopic()),
+			},
+			OtherTestData: map[string]interface{}{
+				unreachableCluster: bootstrapServers,
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerDryRunDisabled(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - dry run report removed",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithDryRunStatusAnnotation("no changes"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerTopicTemplate(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.BrokerTopicTemplate = "team.{{ .Namespace }}.{{ .Name }}.events"
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	topic := fmt.Sprintf("team.%s.%s.events", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - with env topic template",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(topic),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{topic},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusExternalBrokerTopicReady(topic),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(topic),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Finalized normal - topic not annotated, delete topic from env topic template",
+			Objects: []runtime.Object{
+				NewDeletedBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{topic},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber: probertesting.MockNewProber(prober.StatusNotReady),
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerConditionTrace(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+	trace := &base.ConditionTrace{}
+
+	table := TableTest{
+		{
+			Name: "Invalid producer acks annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithProducerAcksAnnotation("random"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			Ctx:     base.WithConditionTrace(context.Background(), trace),
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					`failed to get contract configuration: invalid %s annotation value "random": expected 0, 1 or all`,
+					ProducerAcksAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			PostConditions: []func(*testing.T, *TableRow){
+				func(t *testing.T, _ *TableRow) {
+					require.Equal(t, []base.ConditionTransition{
+						{Type: base.ConditionDataPlaneAvailable, Status: corev1.ConditionTrue},
+						{
+							Type:   base.ConditionConfigParsed,
+							Status: corev1.ConditionFalse,
+							Reason: fmt.Sprintf(`invalid %s annotation value "random": expected 0, 1 or all`, ProducerAcksAnnotation),
+						},
+					}, trace.Transitions)
+				},
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithProducerAcksAnnotation("random"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`invalid %s annotation value "random": expected 0, 1 or all`, ProducerAcksAnnotation)),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerSecretFinalizersDisabled(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.SecretFinalizersDisabled = true
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - secret finalizer not added",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+						BrokerAuthConfig("secret-1"),
+					))),
+				),
+				NewSSLSecret(ConfigMapNamespace, "secret-1"),
+				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1")),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(corev1.EventTypeWarning, base.ReasonSecretFinalizersDisabled,
+					fmt.Sprintf("Secret finalizers are disabled, deleting the auth secret %s/%s before this resource may prevent its topic from being deleted", ConfigMapNamespace, "secret-1")),
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							Auth: &contract.Resource_AuthSecret{
+								AuthSecret: &contract.Reference{
+									Uuid:      SecretUUID,
+									Namespace: ConfigMapNamespace,
+									Name:      "secret-1",
+									Version:   SecretResourceVersion,
+								},
+							},
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+							BrokerAuthConfig("secret-1"),
+						))),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						BrokerConfigMapSecretAnnotation("secret-1"),
+						StatusBrokerAuthSecretNotProtected(ConfigMapNamespace, "secret-1"),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - secret finalizer not removed",
+			Objects: []runtime.Object{
+				NewDeletedBroker(
+					WithExternalTopic(ExternalTopicName),
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+						BrokerAuthConfig("secret-1"),
+					))),
+					BrokerConfigMapSecretAnnotation("secret-1"),
+				),
+				BrokerSecretWithFinalizer(ConfigMapNamespace, "secret-1", SecretFinalizerName),
+				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1")),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{BrokerTopic()},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber: probertesting.MockNewProber(prober.StatusNotReady),
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerFastPathFailover(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	// The rows share the fast path, the first one records the full reconciliation the second one relies on.
+	fastPath := NewReconcileFastPath(time.Hour)
+
+	failoverContract := &contract.Contract{
+		Resources: []*contract.Resource{
+			{
+				Uid:              BrokerUUID,
+				Topics:           []string{BrokerTopic()},
+				Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+				BootstrapServers: "kafka-dr:9092",
+				Reference:        BrokerReference(),
+			},
+		},
+		Generation: 1,
+	}
+	reconciledStatus := []reconcilertesting.BrokerOption{
+		reconcilertesting.WithInitBrokerConditions,
+		StatusBrokerConfigMapUpdatedReady(&env),
+		StatusBrokerDataPlaneAvailable,
+		StatusBrokerConfigParsed,
+		StatusBrokerTopicReady,
+		BrokerAddressable(&env),
+		StatusBrokerProbeSucceeded,
+		BrokerConfigMapAnnotations(),
+		WithFailoverBootstrapServersStatusAnnotation("kafka-dr:9092"),
+		WithTopicStatusAnnotation(BrokerTopic()),
+		WithActiveBootstrapServersStatusAnnotation("kafka-dr:9092"),
+		WithBrokerAddresses([]duckv1.Addressable{
+			{
+				Name: pointer.String("http"),
+				URL:  brokerAddress,
+			},
+		}),
+		WithBrokerAddress(duckv1.Addressable{
+			Name: pointer.String("http"),
+			URL:  brokerAddress,
+		}),
+	}
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - primary cluster unreachable, failover cluster used",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5, WithFailoverBootstrapServers("kafka-dr:9092")),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"BootstrapServersFailover",
+					"failed to connect to Kafka cluster %s, falling back to %s: failed to connect to %s",
+					bootstrapServers, "kafka-dr:9092", bootstrapServers,
+				),
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, failoverContract),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(reconciledStatus...),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				unreachableCluster: bootstrapServers,
+				reconcileFastPath:  fastPath,
+			},
+		},
+		{
+			// The failover event would be recorded again if the Kafka cluster admin was dialed.
+			Name: "Fast path - failover cluster kept",
+			Objects: []runtime.Object{
+				NewBroker(append([]reconcilertesting.BrokerOption{
+					func(broker *eventing.Broker) {
+						broker.Finalizers = []string{finalizerName}
+					},
+				}, reconciledStatus...)...),
+				BrokerConfig(bootstrapServers, 20, 5, WithFailoverBootstrapServers("kafka-dr:9092")),
+				NewConfigMapFromContract(failoverContract, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			Key: testKey,
+			OtherTestData: map[string]interface{}{
+				unreachableCluster: bootstrapServers,
+				reconcileFastPath:  fastPath,
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerMultipleAuthSecrets(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	tlsSecret := func() *corev1.Secret { return NewTLSClientCertSecret(ConfigMapNamespace, "secret-tls") }
+	saslSecret := func() *corev1.Secret { return NewSASLCredentialsSecret(ConfigMapNamespace, "secret-sasl") }
+	withFinalizer := func(secret *corev1.Secret) *corev1.Secret {
+		secret.Finalizers = append(secret.Finalizers, SecretFinalizerName)
+		return secret
+	}
+	authConfig := BrokerAuthSecretsConfig("secret-tls", "secret-sasl")
+
+	conflictingTLSSecret := tlsSecret()
+	conflictingTLSSecret.Data[security.ProtocolKey] = []byte(security.ProtocolSSL)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - TLS client cert and SASL credentials secrets",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5, authConfig))),
+				),
+				tlsSecret(),
+				saslSecret(),
+				BrokerConfig(bootstrapServers, 20, 5, authConfig),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				SecretUpdate(withFinalizer(tlsSecret())),
+				SecretUpdate(withFinalizer(saslSecret())),
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							Auth: &contract.Resource_MultiAuthSecret{
+								MultiAuthSecret: &contract.MultiSecretReference{
+									Protocol: contract.Protocol_SASL_SSL,
+									References: []*contract.SecretReference{
+										{
+											Reference: &contract.Reference{
+												Uuid:      SecretUUID,
+												Namespace: ConfigMapNamespace,
+												Name:      "secret-tls",
+												Version:   SecretResourceVersion,
+											},
+											KeyFieldReferences: []*contract.KeyFieldReference{
+												{SecretKey: security.CaCertificateKey, Field: contract.SecretField_CA_CRT},
+												{SecretKey: security.UserCertificate, Field: contract.SecretField_USER_CRT},
+												{SecretKey: security.UserKey, Field: contract.SecretField_USER_KEY},
+											},
+										},
+										{
+											Reference: &contract.Reference{
+												Uuid:      SecretUUID,
+												Namespace: ConfigMapNamespace,
+												Name:      "secret-sasl",
+												Version:   SecretResourceVersion,
+											},
+											KeyFieldReferences: []*contract.KeyFieldReference{
+												{SecretKey: security.SaslPasswordKey, Field: contract.SecretField_PASSWORD},
+												{SecretKey: security.SaslMechanismKey, Field: contract.SecretField_SASL_MECHANISM},
+												{SecretKey: security.SaslUserKey, Field: contract.SecretField_USER},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5, authConfig))),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						BrokerConfigMapSecretsAnnotation("secret-tls", "secret-sasl"),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+				},
+			},
+		},
+		{
+			Name: "Conflicting auth secrets",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5, authConfig))),
+				),
+				conflictingTLSSecret,
+				saslSecret(),
+				BrokerConfig(bootstrapServers, 20, 5, authConfig),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"failed to get broker auth secret: conflicting values of key %s in auth secrets %s and %s",
+					security.ProtocolKey, "secret-tls", "secret-sasl",
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5, authConfig))),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						BrokerConfigMapAnnotations(),
+						BrokerConfigMapSecretsAnnotation("secret-tls", "secret-sasl"),
+						func(broker *eventing.Broker) {
+							broker.GetConditionSet().Manage(broker.GetStatus()).MarkFalse(
+								base.ConditionTopicReady,
+								"Failed to get broker auth secret",
+								"conflicting values of key %s in auth secrets %s and %s",
+								security.ProtocolKey, "secret-tls", "secret-sasl",
+							)
+						},
+					),
+				},
+			},
+		},
+		{
+			Name: "One of the auth secrets not found",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5, authConfig))),
+				),
+				tlsSecret(),
+				BrokerConfig(bootstrapServers, 20, 5, authConfig),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"auth secret %s/%s not found",
+					ConfigMapNamespace, "secret-sasl",
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5, authConfig))),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						BrokerConfigMapAnnotations(),
+						BrokerConfigMapSecretsAnnotation("secret-tls", "secret-sasl"),
+						StatusBrokerAuthSecretNotFound(ConfigMapNamespace, "secret-sasl"),
+					),
+				},
+			},
+		},
+		{
+			Name: "Finalized normal - finalizer removed from every auth secret",
+			Objects: []runtime.Object{
+				NewDeletedBroker(
+					WithExternalTopic(ExternalTopicName),
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5, authConfig))),
+					BrokerConfigMapSecretsAnnotation("secret-tls", "secret-sasl"),
+				),
+				withFinalizer(tlsSecret()),
+				withFinalizer(saslSecret()),
+				BrokerConfig(bootstrapServers, 20, 5, authConfig),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{BrokerTopic()},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				SecretUpdate(tlsSecret()),
+				SecretUpdate(saslSecret()),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber: probertesting.MockNewProber(prober.StatusNotReady),
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerNamespaceTopicPrefix(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.BrokerTopicTemplate = "team.{{ .Namespace }}.{{ .Name }}.events"
+
+	prefixes := NamespaceTopicPrefixes{
+		"other-namespace": "tenant-b.",
+		BrokerNamespace:   "tenant-a.",
+	}
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	topic := fmt.Sprintf("tenant-a.team.%s.%s.events", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - with namespace topic prefix",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(topic),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{topic},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusExternalBrokerTopicReady(topic),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(topic),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				topicPrefixes: prefixes,
+			},
+		},
+		{
+			Name: "Finalized normal - topic not annotated, delete topic with namespace topic prefix",
+			Objects: []runtime.Object{
+				NewDeletedBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{topic},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber:    probertesting.MockNewProber(prober.StatusNotReady),
+				topicPrefixes: prefixes,
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerTopicDeletionGracePeriod(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.TopicDeletionGracePeriod = time.Hour
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	withPendingTopicDeletion := func(cm *corev1.ConfigMap) {
+		cm.Annotations = map[string]string{
+			PendingTopicDeletionsAnnotation: fmt.Sprintf(`{"%s":{"deletionTimestamp":"%s","bootstrapServers":["%s"]}}`,
+				BrokerTopic(), time.Now().UTC().Format(time.RFC3339), bootstrapServers),
+		}
+	}
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - pending topic deletion cancelled",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil, withPendingTopicDeletion),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(corev1.EventTypeNormal, "TopicDeletionCancelled", "Pending deletion of topic %s cancelled, the topic is adopted", BrokerTopic()),
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				clientgotesting.NewUpdateAction(
+					schema.GroupVersionResource{Group: "*", Version: "v1", Resource: "ConfigMap"},
+					env.DataPlaneConfigMapNamespace,
+					NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				),
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerDefaultBootstrapServers(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.DefaultBootstrapServers = "kafka-controller:9092"
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - bootstrap servers from broker config",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithTextData(BrokerNamespace, NamespaceDefaultsConfigMapName, map[string]string{
+					kafka.BootstrapServersConfigMapKey: "kafka-namespace:9092",
+				}),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - bootstrap servers from namespace default",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig("", 20, 5),
+				NewConfigMapWithTextData(BrokerNamespace, NamespaceDefaultsConfigMapName, map[string]string{
+					kafka.BootstrapServersConfigMapKey: "kafka-namespace:9092",
+				}),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: "kafka-namespace:9092",
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithBootstrapServerStatusAnnotation("kafka-namespace:9092"),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - bootstrap servers from controller default",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig("", 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: env.DefaultBootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithBootstrapServerStatusAnnotation(env.DefaultBootstrapServers),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerContractResourceStatusAnnotation(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.ContractResourceStatusAnnotationEnabled = true
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - contract resource status annotation",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithContractResourceStatusAnnotation(fmt.Sprintf(`{"topics":["%s"],"bootstrapServers":"%s","deliveryOrder":"UNORDERED","egresses":0}`, BrokerTopic(), bootstrapServers)),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerDiagnosticsStatusAnnotation(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.DiagnosticsStatusAnnotationEnabled = true
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - diagnostics status annotation",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithDiagnosticsStatusAnnotation(`{"externalTopic":false,"probeStatus":"Ready"}`),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerSecretConfig(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - config in a secret",
+			Objects: []runtime.Object{
+				NewBroker(WithBrokerConfig(SecretKReference(BrokerConfigSecret(bootstrapServers, 20, 5)))),
+				BrokerConfigSecret(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(SecretKReference(BrokerConfigSecret(bootstrapServers, 20, 5))),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						WithBootstrapServerStatusAnnotation(bootstrapServers),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Failed to resolve config - secret not found",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(SecretKReference(BrokerConfigSecret(bootstrapServers, 20, 5))),
+					BrokerConfigMapAnnotations(),
+				),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"failed to get contract configuration: unable to rebuild topic config, failed to get configmap %s/%s",
+					ConfigMapNamespace, ConfigMapName,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(SecretKReference(BrokerConfigSecret(bootstrapServers, 20, 5))),
+						BrokerConfigMapAnnotations(),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf("unable to rebuild topic config, failed to get configmap %s/%s", ConfigMapNamespace, ConfigMapName)),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerAuthSecretNotFound(t *testing.T) {
+	t.Parallel()
+
+	env := *DefaultEnv
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	deletedSecret := NewSSLSecret(ConfigMapNamespace, "secret-1")
+	deletedSecret.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	deletedSecret.Finalizers = []string{"other"}
+
+	table := TableTest{
+		{
+			Name: "Auth secret force-deleted",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+						BrokerAuthConfig("secret-1"),
+					))),
+				),
+				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1")),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							Auth: &contract.Resource_AuthSecret{
+								AuthSecret: &contract.Reference{
+									Uuid:      SecretUUID,
+									Namespace: ConfigMapNamespace,
+									Name:      "secret-1",
+									Version:   SecretResourceVersion,
+								},
+							},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"auth secret %s/%s not found",
+					ConfigMapNamespace, "secret-1",
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+							BrokerAuthConfig("secret-1"),
+						))),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						BrokerConfigMapAnnotations(),
+						BrokerConfigMapSecretAnnotation("secret-1"),
+						StatusBrokerAuthSecretNotFound(ConfigMapNamespace, "secret-1"),
+					),
+				},
+			},
+		},
+		{
+			Name: "Auth secret being deleted without the broker finalizer",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+						BrokerAuthConfig("secret-1"),
+					))),
+				),
+				deletedSecret,
+				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1")),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							Auth: &contract.Resource_AuthSecret{
+								AuthSecret: &contract.Reference{
+									Uuid:      SecretUUID,
+									Namespace: ConfigMapNamespace,
+									Name:      "secret-1",
+									Version:   SecretResourceVersion,
+								},
+							},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"auth secret %s/%s not found",
+					ConfigMapNamespace, "secret-1",
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+							BrokerAuthConfig("secret-1"),
+						))),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						BrokerConfigMapAnnotations(),
+						BrokerConfigMapSecretAnnotation("secret-1"),
+						StatusBrokerAuthSecretNotFound(ConfigMapNamespace, "secret-1"),
+					),
+				},
+			},
+		},
+		{
+			Name: "Auth secret created again",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+						BrokerAuthConfig("secret-1"),
+					))),
+				),
+				NewSSLSecret(ConfigMapNamespace, "secret-1"),
+				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1")),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							Auth: &contract.Resource_AuthSecret{
+								AuthSecret: &contract.Reference{
+									Uuid:      "deleted-secret-uid",
+									Namespace: ConfigMapNamespace,
+									Name:      "secret-1",
+									Version:   "1",
+								},
+							},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				SecretFinalizerUpdate("secret-1", SecretFinalizerName),
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+							Auth: &contract.Resource_AuthSecret{
+								AuthSecret: &contract.Reference{
+									Uuid:      SecretUUID,
+									Namespace: ConfigMapNamespace,
+									Name:      "secret-1",
+									Version:   SecretResourceVersion,
+								},
+							},
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+							BrokerAuthConfig("secret-1"),
+						))),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						BrokerConfigMapSecretAnnotation("secret-1"),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerTopicRecreate(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	topicRecreateEvent := Eventf(
+		corev1.EventTypeWarning,
+		"TopicRecreate",
+		"Deleting topic %s to recreate it as requested by %s %q, events in the topic are lost",
+		BrokerTopic(), TopicRecreateAnnotation, "1",
+	)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - topic recreated",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithTopicRecreateAnnotation("1"),
+					WithTopicStatusAnnotation(BrokerTopic()),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicRecreateEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithTopicRecreateAnnotation("1"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithTopicRecreateStatusAnnotation("1"),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - topic recreation already processed",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithTopicRecreateAnnotation("1"),
+					WithTopicStatusAnnotation(BrokerTopic()),
+					WithTopicRecreateStatusAnnotation("1"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithTopicRecreateAnnotation("1"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithTopicRecreateStatusAnnotation("1"),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				wantErrorOnDeleteTopic: fmt.Errorf("topic deleted"),
+			},
+		},
+		{
+			Name: "Requeue - recreated topic deletion in progress",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithTopicRecreateAnnotation("1"),
+					WithTopicStatusAnnotation(BrokerTopic()),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicRecreateEvent,
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithTopicRecreateAnnotation("1"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithTopicRecreateStatusAnnotation("1"),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				wantErrorOnCreateTopic: sarama.ErrTopicAlreadyExists,
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerSharedTopic(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	sharedTopic := "shared-topic"
+	sharingBroker := func(partitions string) runtime.Object {
+		return reconcilertesting.NewBroker("sharing-broker", BrokerNamespace,
+			reconcilertesting.WithBrokerClass(kafka.BrokerClass),
+			WithSharedTopic(sharedTopic),
+			func(broker *eventing.Broker) {
+				broker.UID = "sharing-broker-uid"
+				broker.Status.Annotations = map[string]string{kafka.DefaultTopicNumPartitionConfigMapKey: partitions}
+			},
+		)
+	}
+
+	sharedTopicPartitionsMismatch := SharedTopicPartitionsMismatch{
+		Topic:      sharedTopic,
+		Broker:     types.NamespacedName{Namespace: BrokerNamespace, Name: "sharing-broker"},
+		Partitions: "10",
+		Desired:    20,
+	}
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - shared topic",
+			Objects: []runtime.Object{
+				NewBroker(WithSharedTopic(sharedTopic)),
+				sharingBroker("20"),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(sharedTopic),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{sharedTopic},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithSharedTopic(sharedTopic),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusExternalBrokerTopicReady(sharedTopic),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(sharedTopic),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				externalTopic: sharedTopic,
+			},
+		},
+		{
+			Name: "Reconciled failed - shared topic partitions mismatch",
+			Objects: []runtime.Object{
+				NewBroker(WithSharedTopic(sharedTopic)),
+				sharingBroker("10"),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"topics %v not present or invalid: %v",
+					[]string{sharedTopic}, sharedTopicPartitionsMismatch,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithSharedTopic(sharedTopic),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						func(broker *eventing.Broker) {
+							broker.GetConditionSet().Manage(broker.GetStatus()).MarkFalse(
+								base.ConditionTopicReady,
+								base.ReasonTopicNotPresentOrInvalid,
+								"topics %v: %s", []string{sharedTopic}, sharedTopicPartitionsMismatch,
+							)
+						},
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				externalTopic: sharedTopic,
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerTopicNameCollision(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	collidingTopic := strings.ToUpper(BrokerTopic())
+	collidingBroker := reconcilertesting.NewBroker("colliding-broker", BrokerNamespace,
+		reconcilertesting.WithBrokerClass(kafka.BrokerClass),
+		func(broker *eventing.Broker) {
+			broker.UID = "colliding-broker-uid"
+			broker.Status.Annotations = map[string]string{kafka.TopicAnnotation: collidingTopic}
+		},
+	)
+
+	topicNameCollision := TopicNameCollision{
+		Topic:      BrokerTopic(),
+		Broker:     types.NamespacedName{Namespace: BrokerNamespace, Name: "colliding-broker"},
+		OtherTopic: collidingTopic,
+	}
+
+	table := TableTest{
+		{
+			Name: "Reconciled failed - topic name collides with another broker topic",
+			Objects: []runtime.Object{
+				NewBroker(),
+				collidingBroker,
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"topic %s refused: %v",
+					BrokerTopic(), topicNameCollision,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						func(broker *eventing.Broker) {
+							broker.GetConditionSet().Manage(broker.GetStatus()).MarkFalse(
+								base.ConditionTopicReady,
+								base.ReasonTopicNameCollision,
+								"Topic %s refused: %v", BrokerTopic(), topicNameCollision,
+							)
+						},
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerIngressPath(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	ingressPath := "/custom/ingress"
+	ingressAddress := &apis.URL{
+		Scheme: "http",
+		Host:   brokerAddress.Host,
+		Path:   ingressPath,
+	}
+
+	collidingBroker := reconcilertesting.NewBroker("colliding-broker", BrokerNamespace,
+		reconcilertesting.WithBrokerClass(kafka.BrokerClass),
+		func(broker *eventing.Broker) {
+			broker.UID = "colliding-broker-uid"
+		},
+	)
+	ingressPathCollision := IngressPathCollision{
+		Path:   receiver.Path(BrokerNamespace, "colliding-broker"),
+		Broker: types.NamespacedName{Namespace: BrokerNamespace, Name: "colliding-broker"},
+	}
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - ingress path annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithIngressPathAnnotation(ingressPath),
+				),
+				collidingBroker,
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: ingressPath},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithIngressPathAnnotation(ingressPath),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  ingressAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  ingressAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Invalid ingress path annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithIngressPathAnnotation("custom/../ingress"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					`failed to get contract configuration: invalid %s annotation value "custom/../ingress": expected an absolute and clean path, like /<segment>[/<segment>...]`,
+					IngressPathAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithIngressPathAnnotation("custom/../ingress"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`invalid %s annotation value "custom/../ingress": expected an absolute and clean path, like /<segment>[/<segment>...]`, IngressPathAnnotation)),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled failed - ingress path collides with another broker ingress path",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithIngressPathAnnotation(ingressPathCollision.Path),
+				),
+				collidingBroker,
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"failed to get contract configuration: %v",
+					ingressPathCollision,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithIngressPathAnnotation(ingressPathCollision.Path),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(ingressPathCollision.Error()),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerReplicationFactorFallback(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.ReplicationFactorFallbackEnabled = true
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - fewer brokers than replication factor",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeNormal,
+					base.ReasonTopicCreated,
+					"Topic %s created with %d partitions and replication factor %d",
+					BrokerTopic(), 20, 3,
+				),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						StatusBrokerTopicReplicationFactorDegraded(BrokerTopic(), 3, 5),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				maxReplicationFactor: int16(3),
+				clusterBrokers: []*sarama.Broker{
+					sarama.NewBroker("kafka-1:9092"),
+					sarama.NewBroker("kafka-2:9092"),
+					sarama.NewBroker("kafka-3:9092"),
+				},
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 3,
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerTopicConfigEnforce(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	partitions := make([]*sarama.PartitionMetadata, 20)
+	for i := range partitions {
+		partitions[i] = &sarama.PartitionMetadata{ID: int32(i), Leader: 0, Replicas: []int32{0, 1, 2, 3, 4}, Isr: []int32{0, 1, 2, 3, 4}}
+	}
+	brokerTopicMetadata := []*sarama.TopicMetadata{{Name: BrokerTopic(), Partitions: partitions}}
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - external topic config change reverted",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithTopicConfigStatusAnnotation(`{"max.message.bytes":"2097152"}`),
+				),
+				BrokerConfig(bootstrapServers, 20, 5, WithMaxMessageBytes("2097152"), WithTopicConfigEnforce()),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"TopicConfigDriftCorrected",
+					`Reverted external changes to the config of topic %s: config max.message.bytes="1048576", expected "2097152"`,
+					BrokerTopic(),
+				),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName), MaxMessageBytes: 2097152},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithMaxMessageBytesStatusAnnotation("2097152"),
+						WithTopicConfigEnforceStatusAnnotation(),
+						WithTopicConfigStatusAnnotation(`{"max.message.bytes":"2097152"}`),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				topicMetadata: brokerTopicMetadata,
+				topicConfigEntries: []sarama.ConfigEntry{
+					{Name: kafka.MaxMessageBytesTopicConfigKey, Value: "1048576"},
+				},
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+					ConfigEntries:     map[string]*string{kafka.MaxMessageBytesTopicConfigKey: pointer.String("2097152")},
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - declared topic config change applied",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithTopicConfigStatusAnnotation(`{"max.message.bytes":"1048576"}`),
+				),
+				BrokerConfig(bootstrapServers, 20, 5, WithMaxMessageBytes("2097152"), WithTopicConfigEnforce()),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName), MaxMessageBytes: 2097152},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithMaxMessageBytesStatusAnnotation("2097152"),
+						WithTopicConfigEnforceStatusAnnotation(),
+						WithTopicConfigStatusAnnotation(`{"max.message.bytes":"2097152"}`),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				topicMetadata: brokerTopicMetadata,
+				topicConfigEntries: []sarama.ConfigEntry{
+					{Name: kafka.MaxMessageBytesTopicConfigKey, Value: "1048576"},
+				},
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+					ConfigEntries:     map[string]*string{kafka.MaxMessageBytesTopicConfigKey: pointer.String("2097152")},
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - topic config in sync",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithTopicConfigStatusAnnotation(`{"max.message.bytes":"2097152"}`),
+				),
+				BrokerConfig(bootstrapServers, 20, 5, WithMaxMessageBytes("2097152"), WithTopicConfigEnforce()),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName), MaxMessageBytes: 2097152},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithMaxMessageBytesStatusAnnotation("2097152"),
+						WithTopicConfigEnforceStatusAnnotation(),
+						WithTopicConfigStatusAnnotation(`{"max.message.bytes":"2097152"}`),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				topicMetadata: brokerTopicMetadata,
+				topicConfigEntries: []sarama.ConfigEntry{
+					{Name: kafka.MaxMessageBytesTopicConfigKey, Value: "2097152"},
+				},
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+					ConfigEntries:     map[string]*string{kafka.MaxMessageBytesTopicConfigKey: pointer.String("2097152")},
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerTopicConfigDrift(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	partitions := make([]*sarama.PartitionMetadata, 20)
+	for i := range partitions {
+		partitions[i] = &sarama.PartitionMetadata{ID: int32(i), Leader: 0, Replicas: []int32{0, 1, 2, 3, 4}, Isr: []int32{0, 1, 2, 3, 4}}
+	}
+	brokerTopicMetadata := []*sarama.TopicMetadata{{Name: BrokerTopic(), Partitions: partitions}}
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - external topic config change reported",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithTopicConfigStatusAnnotation(`{"max.message.bytes":"2097152"}`),
+				),
+				BrokerConfig(bootstrapServers, 20, 5, WithMaxMessageBytes("2097152")),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"TopicConfigDriftDetected",
+					`External changes to the config of topic %s aren't reverted since %s isn't enabled: config max.message.bytes="1048576", expected "2097152"`,
+					BrokerTopic(), kafka.TopicConfigEnforceConfigMapKey,
+				),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName), MaxMessageBytes: 2097152},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithMaxMessageBytesStatusAnnotation("2097152"),
+						WithTopicConfigStatusAnnotation(`{"max.message.bytes":"2097152"}`),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				topicMetadata: brokerTopicMetadata,
+				topicConfigEntries: []sarama.ConfigEntry{
+					{Name: kafka.MaxMessageBytesTopicConfigKey, Value: "1048576"},
+				},
+				noTopicConfigAlter: true,
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+					ConfigEntries:     map[string]*string{kafka.MaxMessageBytesTopicConfigKey: pointer.String("2097152")},
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - declared topic config change applied",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithTopicConfigStatusAnnotation(`{"max.message.bytes":"1048576"}`),
+				),
+				BrokerConfig(bootstrapServers, 20, 5, WithMaxMessageBytes("2097152")),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName), MaxMessageBytes: 2097152},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithMaxMessageBytesStatusAnnotation("2097152"),
+						WithTopicConfigStatusAnnotation(`{"max.message.bytes":"2097152"}`),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				topicMetadata: brokerTopicMetadata,
+				topicConfigEntries: []sarama.ConfigEntry{
+					{Name: kafka.MaxMessageBytesTopicConfigKey, Value: "1048576"},
+				},
+				topicConfigAlter: map[string]sarama.IncrementalAlterConfigsEntry{
+					kafka.MaxMessageBytesTopicConfigKey: {
+						Operation: sarama.IncrementalAlterConfigsOperationSet,
+						Value:     pointer.String("2097152"),
+					},
+				},
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     20,
+					ReplicationFactor: 5,
+					ConfigEntries:     map[string]*string{kafka.MaxMessageBytesTopicConfigKey: pointer.String("2097152")},
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerTopicWritabilityCheck(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.TopicWritabilityCheckEnabled = true
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	// topicMetadataWith returns the metadata of the broker topic with every partition led by broker 0 and replicas
+	// in sync, except for the ones changed by the given function.
+	topicMetadataWith := func(setPartition func(p *sarama.PartitionMetadata)) []*sarama.TopicMetadata {
+		partitions := make([]*sarama.PartitionMetadata, 20)
+		for i := range partitions {
+			partitions[i] = &sarama.PartitionMetadata{ID: int32(i), Leader: 0, Replicas: []int32{0, 1, 2, 3, 4}, Isr: []int32{0, 1, 2, 3, 4}}
+			setPartition(partitions[i])
+		}
+		return []*sarama.TopicMetadata{{Name: BrokerTopic(), Partitions: partitions}}
+	}
+
+	unhealthyTopic := kafka.UnhealthyTopic{
+		Topic:                BrokerTopic(),
+		MinInSyncReplicas:    1,
+		LeaderlessPartitions: []int32{1},
+	}
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - topic writable",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				// a single in-sync replica satisfies the default min.insync.replicas.
+				topicMetadata: topicMetadataWith(func(p *sarama.PartitionMetadata) {
+					if p.ID == 1 {
+						p.Isr = []int32{0}
+					}
+				}),
+			},
+		},
+		{
+			Name: "Topic not writable - partition without leader",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"topic %s is unhealthy: %v",
+					BrokerTopic(), unhealthyTopic,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicUnhealthy(unhealthyTopic),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				topicMetadata: topicMetadataWith(func(p *sarama.PartitionMetadata) {
+					if p.ID == 1 {
+						p.Leader = -1
+						p.Isr = nil
+					}
+				}),
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerReadOnlyCluster(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	// Kafka clusters in read-only mode reject topic creations, for example, with a policy violation.
+	readOnlyError := &sarama.TopicError{Err: sarama.ErrPolicyViolation}
+
+	partitions := make([]*sarama.PartitionMetadata, 20)
+	for i := range partitions {
+		partitions[i] = &sarama.PartitionMetadata{ID: int32(i), Replicas: []int32{0, 1, 2, 3, 4}, Isr: []int32{0, 1, 2, 3, 4}}
+	}
+	existingTopicMetadata := []*sarama.TopicMetadata{{Name: BrokerTopic(), Partitions: partitions}}
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - read-only cluster and topic exists",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				// the topic isn't created again, so the rejected creation doesn't fail the broker.
+				wantErrorOnCreateTopic: readOnlyError,
+				topicMetadata:          existingTopicMetadata,
+			},
+		},
+		{
+			Name: "Failed to create topic - read-only cluster and topic doesn't exist",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					base.ReasonTopicCreationPermanentFailure,
+					"Failed to create topic %s, fix the topic config or the Kafka cluster permissions: %v",
+					BrokerTopic(), readOnlyError,
+				),
+			},
+			SkipNamespaceValidation: true, // WantCreates compare the broker namespace with configmap namespace, so skip it
+			WantCreates: []runtime.Object{
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicCreationPermanentFailure(readOnlyError),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				wantErrorOnCreateTopic: readOnlyError,
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerTopicReuseByName(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.TopicReuseByName = true
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	previousBrokerUUID := "previous-" + BrokerUUID
+
+	partitions := make([]*sarama.PartitionMetadata, 20)
+	for i := range partitions {
+		partitions[i] = &sarama.PartitionMetadata{ID: int32(i), Leader: 0, Replicas: []int32{0, 1, 2, 3, 4}, Isr: []int32{0, 1, 2, 3, 4}}
+	}
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - contract resource of previous broker adopted",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              previousBrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference: &contract.Reference{
+								Uuid:      previousBrokerUUID,
+								Namespace: BrokerNamespace,
+								Name:      BrokerName,
+							},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - existing topic adopted",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              previousBrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference: &contract.Reference{
+								Uuid:      previousBrokerUUID,
+								Namespace: BrokerNamespace,
+								Name:      BrokerName,
+							},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(corev1.EventTypeNormal, base.ReasonTopicAdopted, "Adopted existing topic %s", BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				topicMetadata: []*sarama.TopicMetadata{{Name: BrokerTopic(), Partitions: partitions}},
+			},
+		},
+		{
+			Name: "Finalized normal - topic retained by default",
+			Objects: []runtime.Object{
+				NewDeletedBroker(WithTopicStatusAnnotation(BrokerTopic())),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{BrokerTopic()},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyRetain),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+			},
+			OtherTestData: map[string]interface{}{
+				// The topic must not be deleted.
+				wantErrorOnDeleteTopic: deleteTopicError,
+				testProber:             probertesting.MockNewProber(prober.StatusNotReady),
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerClusterAdminCircuitBreaker(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	openCircuitBreaker := kafka.NewCircuitBreaker(1, time.Minute, time.Hour)
+	openCircuitBreaker.RecordFailure(strings.Split(bootstrapServers, ","))
+
+	table := TableTest{
+		{
+			Name: "Circuit breaker open - Kafka cluster not dialed",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						BrokerConfigMapAnnotations(),
+						StatusBrokerKafkaClusterUnavailable(bootstrapServers),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				circuitBreaker: openCircuitBreaker,
+				// The cluster must not be dialed.
+				unreachableCluster: bootstrapServers,
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerConfigRebuilt(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - ConfigMap not found, config rebuilt from status annotations",
+			Objects: []runtime.Object{
+				NewBroker(BrokerConfigMapAnnotations()),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					base.ReasonConfigRebuilt,
+					"ConfigMap %s/%s not found, using the config rebuilt from the status annotations",
+					ConfigMapNamespace,
+					ConfigMapName,
+				),
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerConfigRebuilt(ConfigMapNamespace, ConfigMapName),
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - ConfigMap restored",
+			Objects: []runtime.Object{
+				NewBroker(
+					BrokerConfigMapAnnotations(),
+					StatusBrokerConfigRebuilt(ConfigMapNamespace, ConfigMapName),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerTopicTargetThroughput(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.PartitionCapacityEventsPerSecond = 100
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - partitions computed from target throughput",
+			Objects: []runtime.Object{
+				NewBroker(WithTopicTargetThroughputAnnotation("250")),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeNormal,
+					base.ReasonTopicCreated,
+					"Topic %s created with %d partitions and replication factor %d",
+					BrokerTopic(), 3, 5,
+				),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithTopicTargetThroughputAnnotation("250"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithTopicPartitionsStatusAnnotation("3 partitions for a target throughput of 250 events/s with a partition capacity of 100 events/s"),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     3,
+					ReplicationFactor: 5,
+				},
+			},
+		},
+		{
+			Name: "Reconciled normal - existing topic partitions increased to match target throughput",
+			Objects: []runtime.Object{
+				NewBroker(WithTopicTargetThroughputAnnotation("250")),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithTopicTargetThroughputAnnotation("250"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithTopicPartitionsStatusAnnotation("3 partitions for a target throughput of 250 events/s with a partition capacity of 100 events/s"),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				ExpectedTopicDetail: sarama.TopicDetail{
+					NumPartitions:     3,
+					ReplicationFactor: 5,
+				},
+				wantErrorOnCreateTopic: &sarama.TopicError{Err: sarama.ErrTopicAlreadyExists},
+				topicMetadata: []*sarama.TopicMetadata{
+					{
+						Name: BrokerTopic(),
+						Partitions: []*sarama.PartitionMetadata{
+							{ID: 0, Replicas: []int32{0, 1, 2, 3, 4}},
+							{ID: 1, Replicas: []int32{0, 1, 2, 3, 4}},
+						},
+					},
+				},
+				partitionsCount: int32(3),
+			},
+		},
+		{
+			Name: "Invalid topic target throughput annotation",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithTopicTargetThroughputAnnotation("0"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					`failed to get contract configuration: invalid %s annotation value "0": expected a positive number of events per second`,
+					TopicTargetThroughputAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithTopicTargetThroughputAnnotation("0"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigNotParsed(fmt.Sprintf(`invalid %s annotation value "0": expected a positive number of events per second`, TopicTargetThroughputAnnotation)),
+						BrokerConfigMapAnnotations(),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerUnmanagedTopics(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.UnmanagedTopics = true
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - topic not managed",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicUnmanaged(BrokerTopic()),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				// The Kafka cluster must not be contacted.
+				unreachableCluster: bootstrapServers,
+			},
+		},
+		{
+			Name: "Finalized normal - topic not deleted",
+			Objects: []runtime.Object{
+				NewDeletedBroker(WithTopicStatusAnnotation(BrokerTopic())),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{BrokerTopic()},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+			},
+			Key: testKey,
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber:             probertesting.MockNewProber(prober.StatusNotReady),
+				wantErrorOnDeleteTopic: fmt.Errorf("unmanaged topic deleted"),
+				unreachableCluster:     bootstrapServers,
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerKafkaClusterID(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	brokerObjects := func() []runtime.Object {
+		return []runtime.Object{
+			NewBroker(),
+			BrokerConfig(bootstrapServers, 20, 5),
+			NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+			NewService(),
+			BrokerReceiverPod(env.SystemNamespace, map[string]string{
+				base.VolumeGenerationAnnotationKey: "0",
+			}),
+			BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+				base.VolumeGenerationAnnotationKey: "0",
+			}),
+		}
+	}
+	wantUpdates := []clientgotesting.UpdateActionImpl{
+		ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+			Resources: []*contract.Resource{
+				{
+					Uid:              BrokerUUID,
+					Topics:           []string{BrokerTopic()},
+					Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+					BootstrapServers: bootstrapServers,
+					Reference:        BrokerReference(),
+				},
+			},
+			Generation: 1,
+		}),
+		BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+			base.VolumeGenerationAnnotationKey: "1",
+		}),
+		BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+			base.VolumeGenerationAnnotationKey: "1",
+		}),
+	}
+	wantBroker := func(opts ...reconcilertesting.BrokerOption) runtime.Object {
+		return NewBroker(append([]reconcilertesting.BrokerOption{
+			reconcilertesting.WithInitBrokerConditions,
+			StatusBrokerConfigMapUpdatedReady(&env),
+			StatusBrokerDataPlaneAvailable,
+			StatusBrokerConfigParsed,
+			StatusBrokerTopicReady,
+			BrokerAddressable(&env),
+			StatusBrokerProbeSucceeded,
+			BrokerConfigMapAnnotations(),
+			WithTopicStatusAnnotation(BrokerTopic()),
+			WithBrokerAddresses([]duckv1.Addressable{
+				{
+					Name: pointer.String("http"),
+					URL:  brokerAddress,
+				},
+			}),
+			WithBrokerAddress(duckv1.Addressable{
+				Name: pointer.String("http"),
+				URL:  brokerAddress,
+			}),
+			WithBrokerAddessable(),
+		}, opts...)...)
+	}
+
+	table := TableTest{
+		{
+			Name:    "Reconciled normal - cluster id recorded",
+			Objects: brokerObjects(),
+			Key:     testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: wantUpdates,
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: wantBroker(WithKafkaClusterIDStatusAnnotation("cluster-id-1")),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				describeCluster: kafka.DescribeClusterFunc(func(sarama.ClusterAdmin, *sarama.Config) (*kafka.ClusterDescription, error) {
+					return &kafka.ClusterDescription{ClusterID: "cluster-id-1", Brokers: []string{"kafka-1:9092"}}, nil
+				}),
+			},
+		},
+		{
+			Name:    "Reconciled normal - failing to describe the cluster doesn't fail the reconciliation",
+			Objects: brokerObjects(),
+			Key:     testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: wantUpdates,
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: wantBroker(),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				describeCluster: kafka.DescribeClusterFunc(func(sarama.ClusterAdmin, *sarama.Config) (*kafka.ClusterDescription, error) {
+					return nil, fmt.Errorf("failed to describe cluster")
+				}),
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerPaused(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciliation paused",
+			Objects: []runtime.Object{
+				NewBroker(WithReconcilePausedAnnotation("true")),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					base.ReasonReconcilePaused,
+					"Reconciliation is paused by the %s annotation",
+					ReconcilePausedAnnotation,
+				),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithReconcilePausedAnnotation("true"),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerReconcilePaused,
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				unreachableCluster: bootstrapServers,
+			},
+		},
+		{
+			Name: "Reconciliation still paused",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithReconcilePausedAnnotation("true"),
+					reconcilertesting.WithInitBrokerConditions,
+					StatusBrokerReconcilePaused,
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, nil),
+				BrokerDispatcherPod(env.SystemNamespace, nil),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			OtherTestData: map[string]interface{}{
+				unreachableCluster: bootstrapServers,
+			},
+		},
+		{
+			Name: "Reconciliation resumed",
+			Objects: []runtime.Object{
+				NewBroker(
+					reconcilertesting.WithInitBrokerConditions,
+					StatusBrokerReconcilePaused,
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerReferenceVersion(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	brokerResource := func(bootstrapServers, version string) *contract.Resource {
+		reference := BrokerReference()
+		reference.Version = version
+		return &contract.Resource{
+			Uid:              BrokerUUID,
+			Topics:           []string{BrokerTopic()},
+			Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+			BootstrapServers: bootstrapServers,
+			Reference:        reference,
+		}
+	}
+	patchFinalizersWithResourceVersion := func() clientgotesting.PatchActionImpl {
+		action := patchFinalizers()
+		action.Patch = []byte(`{"metadata":{"finalizers":["` + finalizerName + `"],"resourceVersion":"2"}}`)
+		return action
+	}
+	readyBroker := func() runtime.Object {
+		return NewBroker(
+			WithBrokerResourceVersion("2"),
+			reconcilertesting.WithInitBrokerConditions,
+			StatusBrokerConfigMapUpdatedReady(&env),
+			StatusBrokerDataPlaneAvailable,
+			StatusBrokerConfigParsed,
+			StatusBrokerTopicReady,
+			BrokerAddressable(&env),
+			StatusBrokerProbeSucceeded,
+			BrokerConfigMapAnnotations(),
+			WithTopicStatusAnnotation(BrokerTopic()),
+			WithBrokerAddresses([]duckv1.Addressable{
+				{
+					Name: pointer.String("http"),
+					URL:  brokerAddress,
+				},
+			}),
+			WithBrokerAddress(duckv1.Addressable{
+				Name: pointer.String("http"),
+				URL:  brokerAddress,
+			}),
+			WithBrokerAddessable(),
+		)
+	}
+
+	table := TableTest{
+		{
+			Name: "Reference version set on added resource",
+			Objects: []runtime.Object{
+				NewBroker(WithBrokerResourceVersion("2")),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						brokerResource(bootstrapServers, "2"),
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizersWithResourceVersion(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: readyBroker(),
+				},
+			},
+		},
+		{
+			Name: "Reference version kept on unchanged resource",
+			Objects: []runtime.Object{
+				NewBroker(WithBrokerResourceVersion("2")),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						brokerResource(bootstrapServers, "1"),
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizersWithResourceVersion(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: readyBroker(),
+				},
+			},
+		},
+		{
+			Name: "Reference version updated on changed resource",
+			Objects: []runtime.Object{
+				NewBroker(WithBrokerResourceVersion("2")),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						brokerResource("kafka-old:9092", "1"),
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						brokerResource(bootstrapServers, "2"),
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizersWithResourceVersion(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: readyBroker(),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerConfigReferenceVersion(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	brokerResource := func(bootstrapServers, version, configVersion string) *contract.Resource {
+		reference := BrokerReference()
+		reference.Version = version
+		return &contract.Resource{
+			Config:           BrokerConfigReference(configVersion),
+			Uid:              BrokerUUID,
+			Topics:           []string{BrokerTopic()},
+			Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+			BootstrapServers: bootstrapServers,
+			Reference:        reference,
+		}
+	}
+	patchFinalizersWithResourceVersion := func() clientgotesting.PatchActionImpl {
+		action := patchFinalizers()
+		action.Patch = []byte(`{"metadata":{"finalizers":["` + finalizerName + `"],"resourceVersion":"2"}}`)
+		return action
+	}
+	readyBroker := func() runtime.Object {
+		return NewBroker(
+			WithBrokerResourceVersion("2"),
+			reconcilertesting.WithInitBrokerConditions,
+			StatusBrokerConfigMapUpdatedReady(&env),
+			StatusBrokerDataPlaneAvailable,
+			StatusBrokerConfigParsed,
+			StatusBrokerTopicReady,
+			BrokerAddressable(&env),
+			StatusBrokerProbeSucceeded,
+			BrokerConfigMapAnnotations(),
+			WithTopicStatusAnnotation(BrokerTopic()),
+			WithBrokerAddresses([]duckv1.Addressable{
+				{
+					Name: pointer.String("http"),
+					URL:  brokerAddress,
+				},
+			}),
+			WithBrokerAddress(duckv1.Addressable{
+				Name: pointer.String("http"),
+				URL:  brokerAddress,
+			}),
+			WithBrokerAddessable(),
+		)
+	}
+
+	table := TableTest{
+		{
+			Name: "Config reference set on added resource",
+			Objects: []runtime.Object{
+				NewBroker(WithBrokerResourceVersion("2")),
+				BrokerConfig(bootstrapServers, 20, 5, WithBrokerConfigResourceVersion("20")),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						brokerResource(bootstrapServers, "2", "20"),
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizersWithResourceVersion(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: readyBroker(),
+				},
+			},
+		},
+		{
+			Name: "Config reference version kept on unchanged resource",
+			Objects: []runtime.Object{
+				NewBroker(WithBrokerResourceVersion("2")),
+				BrokerConfig(bootstrapServers, 20, 5, WithBrokerConfigResourceVersion("20")),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						brokerResource(bootstrapServers, "1", "10"),
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizersWithResourceVersion(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: readyBroker(),
+				},
+			},
+		},
+		{
+			Name: "Config reference version updated on changed resource",
+			Objects: []runtime.Object{
+				NewBroker(WithBrokerResourceVersion("2")),
+				BrokerConfig(bootstrapServers, 20, 5, WithBrokerConfigResourceVersion("20")),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						brokerResource("kafka-old:9092", "1", "10"),
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						brokerResource(bootstrapServers, "2", "20"),
+					},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizersWithResourceVersion(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: readyBroker(),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerExternalTopicConfigIgnored(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "External topic with topic configs",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithExternalTopic(ExternalTopicName),
+					WithTopicRetentionAnnotation("3600000"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				Eventf(
+					corev1.EventTypeWarning,
+					base.ReasonTopicConfigIgnored,
+					"Topic %s is externally managed, the topic configs %s aren't applied to it",
+					ExternalTopicName,
+					kafka.RetentionMsTopicConfigKey,
+				),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{ExternalTopicName},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithExternalTopic(ExternalTopicName),
+						WithTopicRetentionAnnotation("3600000"),
+						StatusBrokerTopicConfigIgnored(ExternalTopicName, kafka.RetentionMsTopicConfigKey),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusExternalBrokerTopicReady(ExternalTopicName),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(ExternalTopicName),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				externalTopic: ExternalTopicName,
+			},
+		},
+		{
+			Name: "External topic without topic configs anymore",
+			Objects: []runtime.Object{
+				NewBroker(
+					WithExternalTopic(ExternalTopicName),
+					reconcilertesting.WithInitBrokerConditions,
+					StatusBrokerTopicConfigIgnored(ExternalTopicName, kafka.RetentionMsTopicConfigKey),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{ExternalTopicName},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						WithExternalTopic(ExternalTopicName),
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusExternalBrokerTopicReady(ExternalTopicName),
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(ExternalTopicName),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+			OtherTestData: map[string]interface{}{
+				externalTopic: ExternalTopicName,
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerContractShards(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.ContractConfigMapShards = 3
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	// The test broker is assigned to the second shard.
+	shardConfigMapName := env.ContractConfigMapName + "-1"
+	shardVolumeGenerationAnnotationKey := base.VolumeGenerationAnnotationKeyForShard(1)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - sharded contract",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, shardConfigMapName, nil),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "5",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "5",
+				}),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, shardConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "5",
+					shardVolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "5",
+					shardVolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						BrokerAddressable(&env),
+						StatusBrokerProbeSucceeded,
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithBrokerAddresses([]duckv1.Addressable{
+							{
+								Name: pointer.String("http"),
+								URL:  brokerAddress,
+							},
+						}),
+						WithBrokerAddress(duckv1.Addressable{
+							Name: pointer.String("http"),
+							URL:  brokerAddress,
+						}),
+						WithBrokerAddessable(),
+					),
+				},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerReconcilerProbeReadyThreshold(t *testing.T) {
+	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
+
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.ProbeReadyThreshold = 2
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - probe ready threshold not reached",
+			Objects: []runtime.Object{
+				NewBroker(),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapWithBinaryData(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, nil),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "0",
+				}),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:              BrokerUUID,
+							Topics:           []string{BrokerTopic()},
+							Ingress:          &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+							BootstrapServers: bootstrapServers,
+							Reference:        BrokerReference(),
+						},
+					},
+					Generation: 1,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "1",
+				}),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewBroker(
+						reconcilertesting.WithInitBrokerConditions,
+						StatusBrokerConfigMapUpdatedReady(&env),
+						StatusBrokerDataPlaneAvailable,
+						StatusBrokerConfigParsed,
+						StatusBrokerTopicReady,
+						StatusBrokerProbeBelowThreshold(1, 2),
+						BrokerConfigMapAnnotations(),
+						WithTopicStatusAnnotation(BrokerTopic()),
+					),
+				},
+			},
+		},
+		{
+			Name: "Finalized normal - probe not ready threshold not reached",
+			Objects: []runtime.Object{
+				NewDeletedBroker(WithTopicStatusAnnotation(BrokerTopic())),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{BrokerTopic()},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewDeletedBroker(
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithTopicFinalizationPendingStatusAnnotation(),
+					),
+				},
+			},
+			CmpOpts: ignoreDeletionTimestamp,
+			OtherTestData: map[string]interface{}{
+				// The topic must not be deleted until the threshold is reached.
+				wantErrorOnDeleteTopic: deleteTopicError,
+				testProber:             probertesting.MockNewProber(prober.StatusNotReady),
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+// ignoreDeletionTimestamp ignores the deletion timestamp that every NewDeletedBroker call sets to the current time.
+var ignoreDeletionTimestamp = []cmp.Option{cmpopts.IgnoreFields(metav1.ObjectMeta{}, "DeletionTimestamp")}
+
+func SecretFinalizerUpdate(secretName, finalizerName string) clientgotesting.UpdateActionImpl {
+	return clientgotesting.NewUpdateAction(
+		schema.GroupVersionResource{
+			Group:    "*",
+			Version:  "v1",
+			Resource: "Secret",
+		},
+		ConfigMapNamespace,
+		BrokerSecretWithFinalizer(ConfigMapNamespace, secretName, finalizerName),
+	)
+}
+
+func SecretUpdate(secret *corev1.Secret) clientgotesting.UpdateActionImpl {
+	return clientgotesting.NewUpdateAction(
+		schema.GroupVersionResource{
+			Group:    "*",
+			Version:  "v1",
+			Resource: "Secret",
+		},
+		secret.Namespace,
+		secret,
+	)
+}
+
+func SecretFinalizerUpdateRemove(secretName string) clientgotesting.UpdateActionImpl {
+	return clientgotesting.NewUpdateAction(
+		schema.GroupVersionResource{
+			Group:    "*",
+			Version:  "v1",
+			Resource: "Secret",
+		},
+		ConfigMapNamespace,
+		NewSSLSecret(ConfigMapNamespace, secretName),
+	)
+}
+
+func TestBrokerFinalizer(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range Formats {
+		brokerFinalization(t, f, *DefaultEnv)
+	}
+}
+
+func TestBrokerFinalizerSecretFinalizerPrefix(t *testing.T) {
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.SecretFinalizerPrefix = "other.eventing"
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - secret finalizer with another prefix not removed",
+			Objects: []runtime.Object{
+				NewDeletedBroker(
+					WithExternalTopic(ExternalTopicName),
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+						BrokerAuthConfig("secret-1"),
+					))),
+					BrokerConfigMapSecretAnnotation("secret-1"),
+				),
+				BrokerSecretWithFinalizer(ConfigMapNamespace, "secret-1", SecretFinalizerName),
+				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1")),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{BrokerTopic()},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber: probertesting.MockNewProber(prober.StatusNotReady),
+			},
+		},
+		{
+			Name: "Reconciled normal - secret finalizer with the configured prefix removed",
+			Objects: []runtime.Object{
+				NewDeletedBroker(
+					WithExternalTopic(ExternalTopicName),
+					WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+						BrokerAuthConfig("secret-1"),
+					))),
+					BrokerConfigMapSecretAnnotation("secret-1"),
+				),
+				BrokerSecretWithFinalizer(ConfigMapNamespace, "secret-1", "other.eventing/"+BrokerUUID),
+				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1")),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{BrokerTopic()},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+				NewService(),
+				BrokerReceiverPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+				BrokerDispatcherPod(env.SystemNamespace, map[string]string{
+					"annotation_to_preserve": "value_to_preserve",
+				}),
+			},
+			Key: testKey,
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+				BrokerReceiverPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				BrokerDispatcherPodUpdate(env.SystemNamespace, map[string]string{
+					base.VolumeGenerationAnnotationKey: "2",
+					"annotation_to_preserve":           "value_to_preserve",
+				}),
+				SecretFinalizerUpdateRemove("secret-1"),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber: probertesting.MockNewProber(prober.StatusNotReady),
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerFinalizerTopicDeletionConfirmation(t *testing.T) {
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.TopicDeletionConfirmationTimeout = time.Millisecond
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	contractConfigMap := func() runtime.Object {
+		return NewConfigMapFromContract(&contract.Contract{
+			Resources: []*contract.Resource{
+				{
+					Uid:     BrokerUUID,
+					Topics:  []string{BrokerTopic()},
+					Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+				},
+			},
+			Generation: 1,
+		}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat)
+	}
+
+	table := TableTest{
+		{
+			Name: "Reconciled normal - topic deletion confirmed",
+			Objects: []runtime.Object{
+				NewDeletedBroker(WithTopicStatusAnnotation(BrokerTopic())),
+				BrokerConfig(bootstrapServers, 20, 5),
+				contractConfigMap(),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber: probertesting.MockNewProber(prober.StatusNotReady),
+				listTopics: map[string]sarama.TopicDetail{"other-topic": {}},
+			},
+		},
+		{
+			Name: "Reconciled failed - topic still present, requeue",
+			Objects: []runtime.Object{
+				NewDeletedBroker(WithTopicStatusAnnotation(BrokerTopic())),
+				BrokerConfig(bootstrapServers, 20, 5),
+				contractConfigMap(),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewDeletedBroker(
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithTopicFinalizationPendingStatusAnnotation(),
+					),
+				},
+			},
+			CmpOpts: ignoreDeletionTimestamp,
+			OtherTestData: map[string]interface{}{
+				testProber: probertesting.MockNewProber(prober.StatusNotReady),
+				listTopics: map[string]sarama.TopicDetail{BrokerTopic(): {}},
+			},
+		},
+	}
+
+	useTable(t, table, &env)
+}
+
+func TestBrokerFinalizerDrain(t *testing.T) {
+	t.Parallel()
+
+	env := *DefaultEnv
+	env.BrokerDrainTimeout = time.Hour
+
+	testKey := fmt.Sprintf("%s/%s", BrokerNamespace, BrokerName)
+
+	egresses := []*contract.Egress{
+		{
+			ConsumerGroup: "trigger-cg",
+			Destination:   ServiceURL,
+			Uid:           TriggerUUID,
+		},
+	}
+	drainStartedAt := time.Now().Format(time.RFC3339)
+	drainStarted := func(broker *eventing.Broker) {
+		broker.Status.Annotations[DrainStartedStatusAnnotation] = drainStartedAt
+	}
+	contractConfigMap := func(ingress *contract.Ingress, generation uint64) runtime.Object {
+		return NewConfigMapFromContract(&contract.Contract{
+			Resources: []*contract.Resource{
+				{
+					Uid:      BrokerUUID,
+					Topics:   []string{BrokerTopic()},
+					Ingress:  ingress,
+					Egresses: egresses,
+				},
+			},
+			Generation: generation,
+		}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat)
+	}
+
+	table := TableTest{
+		{
+			Name: "Finalized - broker draining",
+			Objects: []runtime.Object{
+				NewDeletedBroker(WithTopicStatusAnnotation(BrokerTopic()), drainStarted),
+				BrokerConfig(bootstrapServers, 20, 5),
+				contractConfigMap(&contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)}, 1),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+				Eventf(corev1.EventTypeNormal, "BrokerDraining", "Draining topic %s, %d events left to consume", BrokerTopic(), 3),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:      BrokerUUID,
+							Topics:   []string{BrokerTopic()},
+							Egresses: egresses,
+						},
+					},
+					Generation: 2,
+				}),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewDeletedBroker(
+						WithTopicStatusAnnotation(BrokerTopic()),
+						drainStarted,
+						WithTopicFinalizationPendingStatusAnnotation(),
+					),
+				},
+			},
+			CmpOpts: ignoreDeletionTimestamp,
+			OtherTestData: map[string]interface{}{
+				testProber:             probertesting.MockNewProber(prober.StatusNotReady),
+				wantErrorOnDeleteTopic: deleteTopicError,
+				consumerGroupLags: map[string]kafka.ConsumerGroupLag{
+					"trigger-cg": {ByPartition: []kafka.PartitionLag{{LatestOffset: 5, ConsumerOffset: 2}}},
+				},
+			},
+		},
+		{
+			Name: "Finalized - broker drained",
+			Objects: []runtime.Object{
+				NewDeletedBroker(WithTopicStatusAnnotation(BrokerTopic()), drainStarted),
+				BrokerConfig(bootstrapServers, 20, 5),
+				contractConfigMap(nil, 2),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+				Eventf(corev1.EventTypeNormal, "BrokerDrained", "Topic %s drained", BrokerTopic()),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 3,
+				}),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber: probertesting.MockNewProber(prober.StatusNotReady),
+				consumerGroupLags: map[string]kafka.ConsumerGroupLag{
+					"trigger-cg": {ByPartition: []kafka.PartitionLag{{LatestOffset: 5, ConsumerOffset: 5}}},
+				},
 			},
 		},
 	}
 
-	for i := range table {
-		table[i].Name = table[i].Name + " - " + format
-	}
-
 	useTable(t, table, &env)
 }
 
-func SecretFinalizerUpdate(secretName, finalizerName string) clientgotesting.UpdateActionImpl {
-	return clientgotesting.NewUpdateAction(
-		schema.GroupVersionResource{
-			Group:    "*",
-			Version:  "v1",
-			Resource: "Secret",
-		},
-		ConfigMapNamespace,
-		BrokerSecretWithFinalizer(ConfigMapNamespace, secretName, finalizerName),
-	)
-}
+// consumerGroupLagProviderMock returns the lag of consumer groups on any topic.
+type consumerGroupLagProviderMock map[string]kafka.ConsumerGroupLag
 
-func SecretFinalizerUpdateRemove(secretName string) clientgotesting.UpdateActionImpl {
-	return clientgotesting.NewUpdateAction(
-		schema.GroupVersionResource{
-			Group:    "*",
-			Version:  "v1",
-			Resource: "Secret",
-		},
-		ConfigMapNamespace,
-		NewSSLSecret(ConfigMapNamespace, secretName),
-	)
+func (m consumerGroupLagProviderMock) GetLag(_, consumerGroup string) (kafka.ConsumerGroupLag, error) {
+	return m[consumerGroup], nil
 }
 
-func TestBrokerFinalizer(t *testing.T) {
-	t.Parallel()
-
-	for _, f := range Formats {
-		brokerFinalization(t, f, *DefaultEnv)
-	}
+func (m consumerGroupLagProviderMock) Close() error {
+	return nil
 }
 
 func brokerFinalization(t *testing.T, format string, env config.Env) {
@@ -2286,6 +10679,9 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
 			},
 			Key: testKey,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources:  []*contract.Resource{},
@@ -2296,6 +10692,37 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 				testProber: probertesting.MockNewProber(prober.StatusNotReady),
 			},
 		},
+		{
+			Name: "Reconciled normal - shared topic not deleted",
+			Objects: []runtime.Object{
+				NewDeletedBroker(
+					WithSharedTopic("shared-topic"),
+					WithTopicStatusAnnotation("shared-topic"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{"shared-topic"},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+			},
+			Key: testKey,
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber:             probertesting.MockNewProber(prober.StatusNotReady),
+				wantErrorOnDeleteTopic: fmt.Errorf("shared topic deleted"),
+			},
+		},
 		{
 			Name: "Reconciled normal - no ConfigMap, rebuild from annotations",
 			Objects: []runtime.Object{
@@ -2315,6 +10742,9 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
 			},
 			Key: testKey,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources:  []*contract.Resource{},
@@ -2375,6 +10805,15 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 				}),
 			},
 			WantErr: true,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewDeletedBroker(
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithTopicFinalizationPendingStatusAnnotation(),
+					),
+				},
+			},
+			CmpOpts: ignoreDeletionTimestamp,
 			OtherTestData: map[string]interface{}{
 				testProber: probertesting.MockNewProber(prober.StatusReady),
 			},
@@ -2400,6 +10839,9 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
 			},
 			Key: testKey,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Generation: 2,
@@ -2459,6 +10901,78 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 				testProber: probertesting.MockNewProber(prober.StatusNotReady),
 			},
 		},
+		{
+			Name: "Reconciled normal - topic already finalized",
+			Objects: []runtime.Object{
+				NewDeletedBroker(
+					WithTopicStatusAnnotation(BrokerTopic()),
+					BrokerConfigMapSecretAnnotation("secret-1"),
+					WithTopicFinalizedStatusAnnotation(),
+				),
+				BrokerSecretWithFinalizer(ConfigMapNamespace, "secret-1", SecretFinalizerName),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+			},
+			Key: testKey,
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				SecretFinalizerUpdateRemove("secret-1"),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber:         probertesting.MockNewProber(prober.StatusNotReady),
+				unreachableCluster: bootstrapServers,
+			},
+		},
+		{
+			Name: "Reconciled normal - broker not in contract",
+			Objects: []runtime.Object{
+				NewDeletedBroker(
+					WithTopicStatusAnnotation(BrokerTopic()),
+					BrokerConfigMapSecretAnnotation("secret-1"),
+				),
+				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1")),
+				BrokerSecretWithFinalizer(ConfigMapNamespace, "secret-1", SecretFinalizerName),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+			},
+			Key: testKey,
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				SecretFinalizerUpdateRemove("secret-1"),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber:     probertesting.MockNewProber(prober.StatusNotReady),
+				noClusterAdmin: true,
+			},
+		},
+		{
+			Name: "Reconciled normal - broker removed from contract, topic finalization pending",
+			Objects: []runtime.Object{
+				NewDeletedBroker(
+					WithTopicStatusAnnotation(BrokerTopic()),
+					BrokerConfigMapSecretAnnotation("secret-1"),
+					WithTopicFinalizationPendingStatusAnnotation(),
+				),
+				BrokerConfig(bootstrapServers, 20, 5, BrokerAuthConfig("secret-1")),
+				BrokerSecretWithFinalizer(ConfigMapNamespace, "secret-1", SecretFinalizerName),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				SecretFinalizerUpdateRemove("secret-1"),
+			},
+			OtherTestData: map[string]interface{}{
+				testProber: probertesting.MockNewProber(prober.StatusNotReady),
+			},
+		},
 		{
 			Name: "Reconciled normal - with missing auth secret",
 			Objects: []runtime.Object{
@@ -2504,6 +11018,19 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 					"annotation_to_preserve":           "value_to_preserve",
 				}),
 			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewDeletedBroker(
+						WithExternalTopic(ExternalTopicName),
+						WithBrokerConfig(KReference(BrokerConfig(bootstrapServers, 20, 5,
+							BrokerAuthConfig("secret-not-present-1"),
+						))),
+						BrokerConfigMapSecretAnnotation("secret-not-present-1"),
+						WithTopicFinalizationPendingStatusAnnotation(),
+					),
+				},
+			},
+			CmpOpts: ignoreDeletionTimestamp,
 			OtherTestData: map[string]interface{}{
 				testProber: probertesting.MockNewProber(prober.StatusNotReady),
 			},
@@ -2571,6 +11098,7 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 			Key:     testKey,
 			WantErr: true,
 			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
 				Eventf(
 					corev1.EventTypeWarning,
 					"InternalError",
@@ -2583,7 +11111,103 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 					Generation: 2,
 				}),
 			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewDeletedBroker(
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithTopicFinalizationPendingStatusAnnotation(),
+					),
+				},
+			},
+			CmpOpts: ignoreDeletionTimestamp,
+			OtherTestData: map[string]interface{}{
+				wantErrorOnDeleteTopic: deleteTopicError,
+				testProber:             probertesting.MockNewProber(prober.StatusNotReady),
+			},
+		},
+		{
+			Name: "Failed to delete topic - authorization error",
+			Objects: []runtime.Object{
+				NewDeletedBroker(WithTopicStatusAnnotation(BrokerTopic())),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:          BrokerUUID,
+							Topics:       []string{BrokerTopic()},
+							EgressConfig: &contract.EgressConfig{DeadLetter: ServiceURL},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+			},
+			Key:     testKey,
+			WantErr: true,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+				Eventf(
+					corev1.EventTypeWarning,
+					base.ReasonTopicAuthorizationFailed,
+					"Not authorized to delete topic %s, grant the required ACLs to the Kafka client: failed to delete topic %s: %v",
+					BrokerTopic(), BrokerTopic(), sarama.ErrTopicAuthorizationFailed,
+				),
+				Eventf(
+					corev1.EventTypeWarning,
+					"InternalError",
+					"failed to delete topic %s: %v",
+					BrokerTopic(), sarama.ErrTopicAuthorizationFailed,
+				),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Generation: 2,
+				}),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: NewDeletedBroker(
+						WithTopicStatusAnnotation(BrokerTopic()),
+						WithTopicFinalizationPendingStatusAnnotation(),
+					),
+				},
+			},
+			CmpOpts: ignoreDeletionTimestamp,
+			OtherTestData: map[string]interface{}{
+				wantErrorOnDeleteTopic: sarama.ErrTopicAuthorizationFailed,
+				testProber:             probertesting.MockNewProber(prober.StatusNotReady),
+			},
+		},
+		{
+			Name: "Reconciled normal - retain topic",
+			Objects: []runtime.Object{
+				NewDeletedBroker(
+					WithTopicStatusAnnotation(BrokerTopic()),
+					WithTopicDeletePolicyAnnotation(TopicDeletePolicyRetain),
+				),
+				BrokerConfig(bootstrapServers, 20, 5),
+				NewConfigMapFromContract(&contract.Contract{
+					Resources: []*contract.Resource{
+						{
+							Uid:     BrokerUUID,
+							Topics:  []string{BrokerTopic()},
+							Ingress: &contract.Ingress{Path: receiver.Path(BrokerNamespace, BrokerName)},
+						},
+					},
+					Generation: 1,
+				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
+			},
+			Key: testKey,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyRetain),
+			},
+			WantUpdates: []clientgotesting.UpdateActionImpl{
+				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
+					Resources:  []*contract.Resource{},
+					Generation: 2,
+				}),
+			},
 			OtherTestData: map[string]interface{}{
+				// The topic must not be deleted.
 				wantErrorOnDeleteTopic: deleteTopicError,
 				testProber:             probertesting.MockNewProber(prober.StatusNotReady),
 			},
@@ -2630,6 +11254,9 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
 			},
 			Key: testKey,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources: []*contract.Resource{
@@ -2668,6 +11295,9 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 				}, env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat),
 			},
 			Key: testKey,
+			WantEvents: []string{
+				topicDeletePolicyEvent(TopicDeletePolicyDelete),
+			},
 			WantUpdates: []clientgotesting.UpdateActionImpl{
 				ConfigMapUpdate(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName, env.ContractConfigMapFormat, &contract.Contract{
 					Resources: []*contract.Resource{
@@ -2740,6 +11370,7 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 			}),
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchFinalizers(),
@@ -2810,6 +11441,7 @@ func brokerFinalization(t *testing.T, format string, env config.Env) {
 			}),
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantPatches: []clientgotesting.PatchActionImpl{
 				patchFinalizers(),
@@ -2886,16 +11518,71 @@ func useTable(t *testing.T, table TableTest, env *config.Env) {
 
 		expectedTopicName, err := featureFlags.ExecuteBrokersTopicTemplate(metav1.ObjectMeta{Namespace: BrokerNamespace, Name: BrokerName, UID: BrokerUUID})
 		require.NoError(t, err, "Failed to create broker topic name from feature flags")
+		var brokerTopicTemplate *template.Template
+		if env.BrokerTopicTemplate != "" {
+			brokerTopicTemplate = template.Must(template.New("broker.topic.template").Parse(env.BrokerTopicTemplate))
+			var topic bytes.Buffer
+			require.NoError(t, brokerTopicTemplate.Execute(&topic, metav1.ObjectMeta{Namespace: BrokerNamespace, Name: BrokerName, UID: BrokerUUID}))
+			expectedTopicName = topic.String()
+		}
+		var namespaceTopicPrefixes NamespaceTopicPrefixes
+		if p, ok := row.OtherTestData[topicPrefixes]; ok {
+			namespaceTopicPrefixes = p.(NamespaceTopicPrefixes)
+			expectedTopicName = namespaceTopicPrefixes.Prefix(BrokerNamespace) + expectedTopicName
+		}
 		if t, ok := row.OtherTestData[externalTopic]; ok {
 			expectedTopicName = t.(string)
 		}
 
+		// the external topic matches the default broker config.
+		externalTopicPartitions := make([]*sarama.PartitionMetadata, 20)
+		for i := range externalTopicPartitions {
+			externalTopicPartitions[i] = &sarama.PartitionMetadata{ID: int32(i), Replicas: []int32{0, 1, 2, 3, 4}}
+		}
 		var metadata []*sarama.TopicMetadata
 		metadata = append(metadata, &sarama.TopicMetadata{
 			Name:       ExternalTopicName,
 			IsInternal: false,
-			Partitions: []*sarama.PartitionMetadata{{}},
+			Partitions: externalTopicPartitions,
 		})
+		if m, ok := row.OtherTestData[topicMetadata]; ok {
+			metadata = m.([]*sarama.TopicMetadata)
+		}
+
+		var expectedPartitionsCount int32
+		if c, ok := row.OtherTestData[partitionsCount]; ok {
+			expectedPartitionsCount = c.(int32)
+		}
+
+		var maxReplicationFactorOnCreateTopic int16
+		if rf, ok := row.OtherTestData[maxReplicationFactor]; ok {
+			maxReplicationFactorOnCreateTopic = rf.(int16)
+		}
+
+		var brokers []*sarama.Broker
+		if b, ok := row.OtherTestData[clusterBrokers]; ok {
+			brokers = b.([]*sarama.Broker)
+		}
+
+		var topics map[string]sarama.TopicDetail
+		if l, ok := row.OtherTestData[listTopics]; ok {
+			topics = l.(map[string]sarama.TopicDetail)
+		}
+
+		var configEntries []sarama.ConfigEntry
+		if e, ok := row.OtherTestData[topicConfigEntries]; ok {
+			configEntries = e.([]sarama.ConfigEntry)
+		}
+
+		var alterConfigEntries map[string]sarama.IncrementalAlterConfigsEntry
+		if e, ok := row.OtherTestData[topicConfigAlter]; ok {
+			alterConfigEntries = e.(map[string]sarama.IncrementalAlterConfigsEntry)
+		}
+
+		var onAlterConfigError error
+		if _, ok := row.OtherTestData[noTopicConfigAlter]; ok {
+			onAlterConfigError = errors.New("unexpected topic config alter")
+		}
 
 		proberMock := probertesting.MockNewProber(prober.StatusReady)
 		if p, ok := row.OtherTestData[testProber]; ok {
@@ -2913,27 +11600,78 @@ func useTable(t *testing.T, table TableTest, env *config.Env) {
 				DataPlaneNamespace:          env.SystemNamespace,
 				DispatcherLabel:             base.BrokerDispatcherLabel,
 				ReceiverLabel:               base.BrokerReceiverLabel,
+				ContractConfigMapShards:     env.ContractConfigMapShards,
 			},
 			ConfigMapLister: listers.GetConfigMapLister(),
-			NewKafkaClusterAdminClient: func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+			ServiceLister:   listers.GetServiceLister(),
+			NewKafkaClusterAdminClient: func(addrs []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+				if _, ok := row.OtherTestData[noClusterAdmin]; ok {
+					t.Errorf("unexpected cluster admin for %v", addrs)
+					return nil, fmt.Errorf("unexpected cluster admin for %v", addrs)
+				}
+				if c, ok := row.OtherTestData[unreachableCluster]; ok && c.(string) == kafka.BootstrapServersCommaSeparated(addrs) {
+					return nil, fmt.Errorf("failed to connect to %s", c)
+				}
 				return &kafkatesting.MockKafkaClusterAdmin{
-					ExpectedTopicName:                      expectedTopicName,
-					ExpectedTopicDetail:                    expectedTopicDetail,
-					ErrorOnCreateTopic:                     onCreateTopicError,
-					ErrorOnDeleteTopic:                     onDeleteTopicError,
-					ExpectedTopics:                         []string{expectedTopicName},
-					ExpectedTopicsMetadataOnDescribeTopics: metadata,
-					T:                                      t,
+					ExpectedTopicName:                             expectedTopicName,
+					ExpectedTopicDetail:                           expectedTopicDetail,
+					ErrorOnCreateTopic:                            onCreateTopicError,
+					ErrorOnDeleteTopic:                            onDeleteTopicError,
+					ExpectedTopics:                                []string{expectedTopicName},
+					ExpectedTopicsMetadataOnDescribeTopics:        metadata,
+					ExpectedCountOnCreatePartitions:               expectedPartitionsCount,
+					MaxReplicationFactorOnCreateTopic:             maxReplicationFactorOnCreateTopic,
+					ExpectedBrokersOnDescribeCluster:              brokers,
+					ExpectedTopicsOnListTopics:                    topics,
+					ExpectedConfigEntriesOnDescribeConfig:         configEntries,
+					ExpectedConfigEntriesOnIncrementalAlterConfig: alterConfigEntries,
+					ErrorOnIncrementalAlterConfig:                 onAlterConfigError,
+					T:                                             t,
 				}, nil
 			},
-			Env:               env,
-			Prober:            proberMock,
-			Counter:           counter.NewExpiringCounter(ctx),
-			KafkaFeatureFlags: featureFlags,
+			Env:                 env,
+			BootstrapServers:    env.DefaultBootstrapServers,
+			Prober:              proberMock,
+			Counter:             counter.NewExpiringCounter(ctx),
+			KafkaFeatureFlags:   featureFlags,
+			BrokerTopicTemplate: brokerTopicTemplate,
+
+			NamespaceTopicPrefixes: namespaceTopicPrefixes,
+		}
+
+		if b, ok := row.OtherTestData[circuitBreaker]; ok {
+			reconciler.ClusterAdminCircuitBreaker = b.(*kafka.CircuitBreaker)
+		}
+
+		if p, ok := row.OtherTestData[externalTopicPolicy]; ok {
+			reconciler.ExternalTopicPolicy = p.(*ExternalTopicPolicy)
+		}
+
+		if d, ok := row.OtherTestData[describeCluster]; ok {
+			reconciler.DescribeKafkaCluster = d.(kafka.DescribeClusterFunc)
+		}
+
+		if m, ok := row.OtherTestData[resourceMutator]; ok {
+			reconciler.ResourceMutator = m.(ResourceMutator)
+		}
+
+		if c, ok := row.OtherTestData[ingressReachability]; ok {
+			reconciler.CheckIngressReachability = c.(IngressReachabilityCheckFunc)
+		}
+
+		if f, ok := row.OtherTestData[reconcileFastPath]; ok {
+			reconciler.FastPath = f.(*ReconcileFastPath)
+		}
+
+		if lags, ok := row.OtherTestData[consumerGroupLags]; ok {
+			reconciler.NewConsumerGroupLagProvider = func([]string, *sarama.Config) (kafka.ConsumerGroupLagProvider, error) {
+				return consumerGroupLagProviderMock(lags.(map[string]kafka.ConsumerGroupLag)), nil
+			}
 		}
 
 		reconciler.Tracker = &FakeTracker{}
 		reconciler.Tracker = &FakeTracker{}
+		reconciler.BrokerLister = listers.GetBrokerLister()
 
 		r := brokerreconciler.NewReconciler(
 			ctx,
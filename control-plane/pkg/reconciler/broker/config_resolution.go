@@ -0,0 +1,67 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/reconciler"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
+)
+
+const (
+	// configResolutionFailureRequeueInitialDelay and configResolutionFailureRequeueMaxDelay bound the exponential
+	// backoff of the requeues of brokers whose config repeatedly fails to be resolved.
+	configResolutionFailureRequeueInitialDelay = 5 * time.Second
+	configResolutionFailureRequeueMaxDelay     = 5 * time.Minute
+)
+
+// failedToResolveConfig marks the config of the given broker as not resolved, consecutive failures are requeued with
+// an exponential backoff, instead of being returned, so that brokers stuck on a bad config don't emit an event and log
+// an error at every reconciliation, the ConfigParsed condition still reports the failure.
+func (r *Reconciler) failedToResolveConfig(logger *zap.Logger, broker *eventing.Broker, statusConditionManager base.StatusConditionManager, err error) reconciler.Event {
+	event := statusConditionManager.FailedToResolveConfig(err)
+	failures := r.Counter.Inc(configResolutionFailureCounterKey(broker))
+	if failures <= 1 {
+		return event
+	}
+	delay := configResolutionFailureRequeueDelay(failures)
+	logger.Debug("Failed to resolve config again, backing off", zap.Int("failures", failures), zap.Duration("delay", delay), zap.Error(event))
+	return controller.NewRequeueAfter(delay)
+}
+
+// configResolutionFailureRequeueDelay returns the delay before reconciling again a broker whose config failed to be
+// resolved the given number of consecutive times, it doubles at every failure up to
+// configResolutionFailureRequeueMaxDelay.
+func configResolutionFailureRequeueDelay(failures int) time.Duration {
+	delay := configResolutionFailureRequeueInitialDelay
+	for i := 2; i < failures && delay < configResolutionFailureRequeueMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > configResolutionFailureRequeueMaxDelay {
+		delay = configResolutionFailureRequeueMaxDelay
+	}
+	return delay
+}
+
+func configResolutionFailureCounterKey(broker *eventing.Broker) string {
+	return "config-resolution-failure/" + string(broker.GetUID())
+}
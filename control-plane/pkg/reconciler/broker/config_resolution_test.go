@@ -0,0 +1,70 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/controller"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/counter"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
+)
+
+func TestFailedToResolveConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := &Reconciler{Counter: counter.NewExpiringCounter(ctx)}
+	broker := &eventing.Broker{ObjectMeta: metav1.ObjectMeta{UID: "uid"}}
+	statusConditionManager := base.StatusConditionManager{Object: broker, Recorder: record.NewFakeRecorder(10)}
+	resolve := func() error {
+		return r.failedToResolveConfig(zap.NewNop(), broker, statusConditionManager, errors.New("invalid config"))
+	}
+
+	err := resolve()
+	ok, _ := controller.IsRequeueKey(err)
+	require.False(t, ok, "expected the first failure to be returned, got %v", err)
+	require.EqualError(t, err, "failed to get contract configuration: invalid config")
+	require.True(t, broker.Status.GetCondition(base.ConditionConfigParsed).IsFalse())
+
+	for _, want := range []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second} {
+		ok, delay := controller.IsRequeueKey(resolve())
+		require.True(t, ok)
+		assert.Equal(t, want, delay)
+	}
+
+	// A resolved config resets the backoff.
+	r.Counter.Del(configResolutionFailureCounterKey(broker))
+	ok, _ = controller.IsRequeueKey(resolve())
+	assert.False(t, ok)
+}
+
+func TestConfigResolutionFailureRequeueDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Second, configResolutionFailureRequeueDelay(2))
+	assert.Equal(t, 40*time.Second, configResolutionFailureRequeueDelay(5))
+	assert.Equal(t, configResolutionFailureRequeueMaxDelay, configResolutionFailureRequeueDelay(100))
+}
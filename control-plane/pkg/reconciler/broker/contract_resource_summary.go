@@ -0,0 +1,126 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+)
+
+const (
+	// ContractResourceStatusAnnotation is the broker status annotation holding a summary of the broker resource
+	// programmed into the data plane contract, it's set when ContractResourceStatusAnnotationEnabled is set.
+	ContractResourceStatusAnnotation = "contract.resource"
+
+	// maxContractResourceSummaryLength bounds the length of the ContractResourceStatusAnnotation value.
+	maxContractResourceSummaryLength = 1024
+)
+
+// contractResourceSummary is a compact view of a contract resource, it doesn't include the content of secrets.
+type contractResourceSummary struct {
+	Topics           []string        `json:"topics,omitempty"`
+	BootstrapServers string          `json:"bootstrapServers,omitempty"`
+	Retry            uint32          `json:"retry,omitempty"`
+	BackoffPolicy    string          `json:"backoffPolicy,omitempty"`
+	BackoffDelayMs   uint64          `json:"backoffDelayMs,omitempty"`
+	TimeoutMs        uint64          `json:"timeoutMs,omitempty"`
+	DeliveryOrder    string          `json:"deliveryOrder,omitempty"`
+	AuthSecret       *secretRef      `json:"authSecret,omitempty"`
+	MultiAuthSecret  *multiSecretRef `json:"multiAuthSecret,omitempty"`
+	Egresses         int             `json:"egresses"`
+	Truncated        bool            `json:"truncated,omitempty"`
+}
+
+// secretRef references a secret without its content.
+type secretRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+}
+
+// multiSecretRef references the secrets of a contract.MultiSecretReference without their content.
+type multiSecretRef struct {
+	Protocol string      `json:"protocol,omitempty"`
+	Secrets  []secretRef `json:"secrets,omitempty"`
+}
+
+// contractResourceSummaryJSON returns the JSON summary of the given contract resource.
+//
+// The summary is at most maxContractResourceSummaryLength long: when it's too long, topics and bootstrap servers beyond
+// the first one and multiple auth secrets are omitted, and the summary is marked as truncated.
+func contractResourceSummaryJSON(resource *contract.Resource) (string, error) {
+	summary := contractResourceSummary{
+		Topics:           resource.GetTopics(),
+		BootstrapServers: resource.GetBootstrapServers(),
+		DeliveryOrder:    resource.GetDeliveryOrder().String(),
+		Egresses:         len(resource.GetEgresses()),
+	}
+	if egressConfig := resource.GetEgressConfig(); egressConfig != nil {
+		summary.Retry = egressConfig.GetRetry()
+		summary.BackoffPolicy = egressConfig.GetBackoffPolicy().String()
+		summary.BackoffDelayMs = egressConfig.GetBackoffDelay()
+		summary.TimeoutMs = egressConfig.GetTimeout()
+	}
+	if authSecret := resource.GetAuthSecret(); authSecret != nil {
+		summary.AuthSecret = &secretRef{
+			Namespace: authSecret.GetNamespace(),
+			Name:      authSecret.GetName(),
+			Version:   authSecret.GetVersion(),
+		}
+	}
+	if multiAuthSecret := resource.GetMultiAuthSecret(); multiAuthSecret != nil {
+		summary.MultiAuthSecret = &multiSecretRef{Protocol: multiAuthSecret.GetProtocol().String()}
+		for _, ref := range multiAuthSecret.GetReferences() {
+			summary.MultiAuthSecret.Secrets = append(summary.MultiAuthSecret.Secrets, secretRef{
+				Namespace: ref.GetReference().GetNamespace(),
+				Name:      ref.GetReference().GetName(),
+				Version:   ref.GetReference().GetVersion(),
+			})
+		}
+	}
+
+	b, err := json.Marshal(summary)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal contract resource summary: %w", err)
+	}
+	if len(b) <= maxContractResourceSummaryLength {
+		return string(b), nil
+	}
+
+	summary.Truncated = true
+	if len(summary.Topics) > 1 {
+		summary.Topics = summary.Topics[:1]
+	}
+	summary.BootstrapServers = strings.Split(summary.BootstrapServers, ",")[0]
+	summary.MultiAuthSecret = nil
+	b, err = json.Marshal(summary)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal contract resource summary: %w", err)
+	}
+	if len(b) <= maxContractResourceSummaryLength {
+		return string(b), nil
+	}
+
+	b, err = json.Marshal(contractResourceSummary{Egresses: summary.Egresses, Truncated: true})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal contract resource summary: %w", err)
+	}
+	return string(b), nil
+}
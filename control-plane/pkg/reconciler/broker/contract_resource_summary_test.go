@@ -0,0 +1,93 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+)
+
+func TestContractResourceSummaryJSON(t *testing.T) {
+	longBootstrapServers := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		longBootstrapServers = append(longBootstrapServers, fmt.Sprintf("kafka-%d.kafka.svc.cluster.local:9092", i))
+	}
+
+	tests := []struct {
+		name     string
+		resource *contract.Resource
+		want     string
+	}{
+		{
+			name: "egress config and auth secret",
+			resource: &contract.Resource{
+				Uid:              "uid",
+				Topics:           []string{"topic"},
+				BootstrapServers: "kafka-1:9092,kafka-2:9092",
+				EgressConfig: &contract.EgressConfig{
+					DeadLetter:    "http://dls",
+					Retry:         3,
+					BackoffPolicy: contract.BackoffPolicy_Linear,
+					BackoffDelay:  200,
+				},
+				Egresses:      []*contract.Egress{{ConsumerGroup: "cg1"}, {ConsumerGroup: "cg2"}},
+				DeliveryOrder: contract.DeliveryOrder_ORDERED,
+				Auth: &contract.Resource_AuthSecret{
+					AuthSecret: &contract.Reference{Uuid: "secret-uid", Namespace: "ns", Name: "secret", Version: "1"},
+				},
+			},
+			want: `{"topics":["topic"],"bootstrapServers":"kafka-1:9092,kafka-2:9092","retry":3,"backoffPolicy":"Linear","backoffDelayMs":200,"deliveryOrder":"ORDERED","authSecret":{"namespace":"ns","name":"secret","version":"1"},"egresses":2}`,
+		},
+		{
+			name: "multi auth secret",
+			resource: &contract.Resource{
+				Topics: []string{"topic"},
+				Auth: &contract.Resource_MultiAuthSecret{
+					MultiAuthSecret: &contract.MultiSecretReference{
+						Protocol: contract.Protocol_SASL_SSL,
+						References: []*contract.SecretReference{
+							{Reference: &contract.Reference{Namespace: "ns", Name: "sasl", Version: "2"}},
+						},
+					},
+				},
+			},
+			want: `{"topics":["topic"],"deliveryOrder":"UNORDERED","multiAuthSecret":{"protocol":"SASL_SSL","secrets":[{"namespace":"ns","name":"sasl","version":"2"}]},"egresses":0}`,
+		},
+		{
+			name: "truncated",
+			resource: &contract.Resource{
+				Topics:           []string{"topic-1", "topic-2"},
+				BootstrapServers: strings.Join(longBootstrapServers, ","),
+			},
+			want: `{"topics":["topic-1"],"bootstrapServers":"kafka-0.kafka.svc.cluster.local:9092","deliveryOrder":"UNORDERED","egresses":0,"truncated":true}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := contractResourceSummaryJSON(tt.resource)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), maxContractResourceSummaryLength)
+		})
+	}
+}
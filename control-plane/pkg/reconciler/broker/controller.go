@@ -17,12 +17,16 @@
 package broker
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"text/template"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
@@ -30,6 +34,7 @@ import (
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/resolver"
 
@@ -38,6 +43,7 @@ import (
 	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
 	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
 	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
+	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 
 	apisconfig "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/config"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
@@ -52,7 +58,7 @@ const (
 	DefaultReplicationFactor = 5
 )
 
-func NewController(ctx context.Context, watcher configmap.Watcher, env *config.Env) *controller.Impl {
+func NewController(ctx context.Context, watcher configmap.Watcher, env *config.Env, opts ...ControllerOption) *controller.Impl {
 
 	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
 
@@ -61,31 +67,94 @@ func NewController(ctx context.Context, watcher configmap.Watcher, env *config.E
 
 	reconciler := &Reconciler{
 		Reconciler: &base.Reconciler{
-			KubeClient:                  kubeclient.Get(ctx),
-			PodLister:                   podinformer.Get(ctx).Lister(),
-			SecretLister:                secretinformer.Get(ctx).Lister(),
-			DataPlaneConfigMapNamespace: env.DataPlaneConfigMapNamespace,
-			ContractConfigMapName:       env.ContractConfigMapName,
-			ContractConfigMapFormat:     env.ContractConfigMapFormat,
-			DataPlaneNamespace:          env.SystemNamespace,
-			DispatcherLabel:             base.BrokerDispatcherLabel,
-			ReceiverLabel:               base.BrokerReceiverLabel,
+			KubeClient:                   kubeclient.Get(ctx),
+			PodLister:                    podinformer.Get(ctx).Lister(),
+			SecretLister:                 secretinformer.Get(ctx).Lister(),
+			DataPlaneConfigMapNamespace:  env.DataPlaneConfigMapNamespace,
+			ContractConfigMapName:        env.ContractConfigMapName,
+			ContractConfigMapFormat:      env.ContractConfigMapFormat,
+			DataPlaneNamespace:           env.SystemNamespace,
+			DispatcherLabel:              base.BrokerDispatcherLabel,
+			ReceiverLabel:                base.BrokerReceiverLabel,
+			ResourceKind:                 base.BrokerResourceKind,
+			ContractUpdateCoalesceWindow: env.ContractUpdateCoalesceWindow,
+			ContractConfigMapShards:      env.ContractConfigMapShards,
 		},
 		NewKafkaClusterAdminClient: sarama.NewClusterAdmin,
+		NewKafkaClient:             sarama.NewClient,
+		DescribeKafkaCluster:       kafka.DescribeCluster,
 		ConfigMapLister:            configmapInformer.Lister(),
+		ServiceLister:              serviceinformer.Get(ctx).Lister(),
+		BrokerLister:               brokerinformer.Get(ctx).Lister(),
 		Env:                        env,
 		Counter:                    counter.NewExpiringCounter(ctx),
 		KafkaFeatureFlags:          featureFlags,
+		BootstrapServers:           env.DefaultBootstrapServers,
 	}
 
+	if env.StatusUpdateMinInterval > 0 {
+		reconciler.StatusUpdateThrottle = NewStatusUpdateThrottle(env.StatusUpdateMinInterval)
+	}
+
+	if env.ReconcileFastPathMaxAge > 0 {
+		reconciler.FastPath = NewReconcileFastPath(env.ReconcileFastPathMaxAge)
+	}
+
+	if env.StrimziIntegrationEnabled {
+		reconciler.DynamicClient = dynamicclient.Get(ctx)
+	}
+
+	if env.ClusterAdminCircuitBreakerThreshold > 0 {
+		reconciler.ClusterAdminCircuitBreaker = kafka.NewCircuitBreaker(env.ClusterAdminCircuitBreakerThreshold, env.ClusterAdminCircuitBreakerWindow, env.ClusterAdminCircuitBreakerCooldown)
+	}
+
+	if env.ClusterAdminPoolSize > 0 {
+		reconciler.ClusterAdminPool = kafka.NewClusterAdminPool(ctx, reconciler.NewKafkaClusterAdminClient, env.ClusterAdminPoolSize, env.ClusterAdminPoolIdleTTL, reconciler.ClusterAdminCircuitBreaker)
+	}
+
+	if env.TopicMetadataCacheTTL > 0 {
+		reconciler.TopicMetadataCache = kafka.NewTopicMetadataCache(env.TopicMetadataCacheTTL)
+	}
+
+	reconciler.ConnectivityTracker = kafka.ConnectivityTrackerFromContext(ctx)
+
 	logger := logging.FromContext(ctx)
 
-	_, err := reconciler.GetOrCreateDataPlaneConfigMap(ctx)
+	brokerTopicTemplate, err := parseBrokerTopicTemplate(*env)
+	if err != nil {
+		logger.Fatal("Invalid broker topic template", zap.Error(err))
+	}
+	reconciler.BrokerTopicTemplate = brokerTopicTemplate
+
+	reconciler.ExternalTopicPolicy, err = parseExternalTopicPolicy(*env)
+	if err != nil {
+		logger.Fatal("Invalid external topic policy", zap.Error(err))
+	}
+
+	reconciler.NamespaceTopicPrefixes, err = parseNamespaceTopicPrefixes(*env)
+	if err != nil {
+		logger.Fatal("Invalid namespace topic prefixes", zap.Error(err))
+	}
+
+	reconciler.Reconciler.ReceiverPodSelector, reconciler.Reconciler.DispatcherPodSelector, err = base.ParseDataPlanePodSelectors(*env)
 	if err != nil {
-		logger.Fatal("Failed to get or create data plane config map",
-			zap.String("configmap", env.DataPlaneConfigMapAsString()),
-			zap.Error(err),
-		)
+		logger.Fatal("Invalid data plane pod selectors", zap.Error(err))
+	}
+
+	reconciler.ResourceMutator = newControllerOptions(opts).resourceMutator
+
+	if env.IngressReachabilityCheckEnabled {
+		reconciler.CheckIngressReachability = NewIngressReachabilityCheck(env.ProbeTimeout)
+	}
+
+	// The data plane pods mount every contract shard, so they're all created in advance.
+	for shard := 0; shard < reconciler.ContractConfigMapShardCount(); shard++ {
+		if _, err := reconciler.GetOrCreateDataPlaneConfigMapShard(ctx, shard); err != nil {
+			logger.Fatal("Failed to get or create data plane config map",
+				zap.String("configmap", env.DataPlaneConfigMapNamespace+"/"+reconciler.ContractConfigMapShardName(shard)),
+				zap.Error(err),
+			)
+		}
 	}
 
 	impl := brokerreconciler.NewImpl(ctx, reconciler, kafka.BrokerClass, func(impl *controller.Impl) controller.Options {
@@ -123,6 +192,9 @@ func NewController(ctx context.Context, watcher configmap.Watcher, env *config.E
 	globalResync := func(_ interface{}) {
 		impl.GlobalResync(brokerInformer.Informer())
 	}
+	reconciler.ResyncBrokers = func() {
+		globalResync(nil)
+	}
 
 	rotateCACerts := func(obj interface{}) {
 		newCerts, err := reconciler.getCaCerts()
@@ -135,7 +207,7 @@ func NewController(ctx context.Context, watcher configmap.Watcher, env *config.E
 	}
 
 	configmapInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
-		FilterFunc: controller.FilterWithNameAndNamespace(env.DataPlaneConfigMapNamespace, env.ContractConfigMapName),
+		FilterFunc: reconciler.IsContractConfigMap,
 		Handler: cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
 				globalResync(obj)
@@ -148,12 +220,16 @@ func NewController(ctx context.Context, watcher configmap.Watcher, env *config.E
 
 	reconciler.Tracker = impl.Tracker
 
-	secretinformer.Get(ctx).Informer().AddEventHandler(controller.HandleAll(reconciler.Tracker.OnChanged))
+	secretinformer.Get(ctx).Informer().AddEventHandler(controller.HandleAll(
+		newSecretChangeHandler(reconciler.ClusterAdminPool, reconciler.Tracker.OnChanged, env.SecretChangeMinInterval),
+	))
 	secretinformer.Get(ctx).Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterWithName(brokerIngressTLSSecretName),
 		Handler:    controller.HandleAll(rotateCACerts),
 	})
-	configmapinformer.Get(ctx).Informer().AddEventHandler(controller.HandleAll(
+	// Only changes to the config map content affect brokers, so skip updates that only change metadata, like labels,
+	// to avoid reconciling every broker referencing the config map.
+	configmapinformer.Get(ctx).Informer().AddEventHandler(base.OnConfigMapContentChanged(
 		// Call the tracker's OnChanged method, but we've seen the objects
 		// coming through this path missing TypeMeta, so ensure it is properly
 		// populated.
@@ -170,6 +246,14 @@ func NewController(ctx context.Context, watcher configmap.Watcher, env *config.E
 		},
 	})
 
+	// Brokers deleted while the controller wasn't running are never finalized, so their contract resources are
+	// removed at startup.
+	go reconciler.runStaleContractResourcesSweep(ctx, brokerInformer.Informer().HasSynced)
+
+	if env.OrphanedTopicsSweepInterval > 0 {
+		go reconciler.runOrphanedTopicsSweeps(ctx, reconciler.newOrphanedTopicsEventRecorder(ctx))
+	}
+
 	return impl
 }
 
@@ -179,3 +263,42 @@ func ValidateDefaultBackoffDelayMs(env config.Env) error {
 	}
 	return nil
 }
+
+// ValidateBrokerTopicTemplate validates the broker topic template, when configured.
+func ValidateBrokerTopicTemplate(env config.Env) error {
+	_, err := parseBrokerTopicTemplate(env)
+	return err
+}
+
+// parseBrokerTopicTemplate parses the broker topic template of the given env, it returns nil when no template is
+// configured.
+func parseBrokerTopicTemplate(env config.Env) (*template.Template, error) {
+	if env.BrokerTopicTemplate == "" {
+		return nil, nil
+	}
+
+	t, err := template.New("broker.topic.template").Parse(env.BrokerTopicTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid broker topic template %q: %w", env.BrokerTopicTemplate, err)
+	}
+
+	// Referencing unknown broker fields only fails when executing the template, so try it at startup.
+	var topic bytes.Buffer
+	if err := t.Execute(&topic, metav1.ObjectMeta{Namespace: "namespace", Name: "name", UID: "uid"}); err != nil {
+		return nil, fmt.Errorf("invalid broker topic template %q: %w", env.BrokerTopicTemplate, err)
+	}
+	if topic.Len() == 0 {
+		return nil, fmt.Errorf("invalid broker topic template %q: empty topic name", env.BrokerTopicTemplate)
+	}
+
+	return t, nil
+}
+
+// ValidateIngressIPFamily validates the ingress IP family, when configured.
+func ValidateIngressIPFamily(env config.Env) error {
+	switch corev1.IPFamily(env.IngressIPFamily) {
+	case "", corev1.IPv4Protocol, corev1.IPv6Protocol:
+		return nil
+	}
+	return fmt.Errorf("invalid ingress IP family %q, expected %s or %s", env.IngressIPFamily, corev1.IPv4Protocol, corev1.IPv6Protocol)
+}
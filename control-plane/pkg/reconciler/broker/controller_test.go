@@ -32,6 +32,7 @@ import (
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/fake"
 	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"
 	"knative.dev/pkg/configmap"
 	dynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
 	reconcilertesting "knative.dev/pkg/reconciler/testing"
@@ -127,3 +128,91 @@ func TestValidateDefaultBackoffDelayMs(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateBrokerTopicTemplate(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		env     config.Env
+		wantErr bool
+	}{
+		{
+			name:    "no template",
+			env:     config.Env{},
+			wantErr: false,
+		},
+		{
+			name: "valid template",
+			env: config.Env{
+				BrokerTopicTemplate: "team.{{ .Namespace }}.{{ .Name }}.{{ .UID }}.events",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid template",
+			env: config.Env{
+				BrokerTopicTemplate: "team.{{ .Namespace",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown field",
+			env: config.Env{
+				BrokerTopicTemplate: "team.{{ .Unknown }}",
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty topic name",
+			env: config.Env{
+				BrokerTopicTemplate: "{{ if false }}topic{{ end }}",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateBrokerTopicTemplate(tt.env); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBrokerTopicTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateIngressIPFamily(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		env     config.Env
+		wantErr bool
+	}{
+		{
+			name:    "no IP family",
+			env:     config.Env{},
+			wantErr: false,
+		},
+		{
+			name:    "IPv4",
+			env:     config.Env{IngressIPFamily: "IPv4"},
+			wantErr: false,
+		},
+		{
+			name:    "IPv6",
+			env:     config.Env{IngressIPFamily: "IPv6"},
+			wantErr: false,
+		},
+		{
+			name:    "unknown IP family",
+			env:     config.Env{IngressIPFamily: "ipv6"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateIngressIPFamily(tt.env); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateIngressIPFamily() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
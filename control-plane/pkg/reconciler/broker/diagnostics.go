@@ -0,0 +1,82 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+)
+
+const (
+	// DiagnosticsStatusAnnotation is the broker status annotation holding a JSON summary of the last reconciliation
+	// of the broker, it's set when DiagnosticsStatusAnnotationEnabled is set.
+	DiagnosticsStatusAnnotation = "reconcile.diagnostics"
+
+	// dataPlaneDiagnosticsPhase is the phase reported for failures happening before the timed reconcile phases, while
+	// getting the contract config map and checking the data plane availability.
+	dataPlaneDiagnosticsPhase = "data_plane"
+)
+
+// brokerDiagnostics is the machine-readable summary of a broker reconciliation.
+type brokerDiagnostics struct {
+	// FailedPhase is the reconcile phase that failed or requeued the broker, if any.
+	FailedPhase string `json:"failedPhase,omitempty"`
+	// Requeued is set when the broker has been requeued by FailedPhase rather than failed.
+	Requeued bool `json:"requeued,omitempty"`
+	// KafkaErrorCode is the error code returned by the Kafka cluster, if any.
+	KafkaErrorCode int16 `json:"kafkaErrorCode,omitempty"`
+	// ExternalTopic is set when the broker topic is an external topic.
+	ExternalTopic bool `json:"externalTopic"`
+	// ProbeStatus is the status of the broker probe, it's empty when the reconciliation didn't reach the probe.
+	ProbeStatus string `json:"probeStatus,omitempty"`
+
+	// phase is the last phase the reconciliation began.
+	phase string
+}
+
+// setDiagnosticsStatusAnnotation sets the DiagnosticsStatusAnnotation of the given broker from the diagnostics of its
+// reconciliation and the reconciliation result.
+func setDiagnosticsStatusAnnotation(ctx context.Context, broker *eventing.Broker, diagnostics *brokerDiagnostics, err error) {
+	if err != nil {
+		diagnostics.FailedPhase = diagnostics.phase
+		if diagnostics.FailedPhase == "" {
+			diagnostics.FailedPhase = dataPlaneDiagnosticsPhase
+		}
+		diagnostics.Requeued, _ = controller.IsRequeueKey(err)
+		if code, ok := kafka.KafkaErrorCode(err); ok {
+			diagnostics.KafkaErrorCode = int16(code)
+		}
+	}
+	_, diagnostics.ExternalTopic = isExternalTopic(broker)
+
+	b, marshalErr := json.Marshal(diagnostics)
+	if marshalErr != nil {
+		logging.FromContext(ctx).Desugar().Warn("Failed to marshal broker diagnostics", zap.Error(marshalErr))
+		return
+	}
+	if broker.Status.Annotations == nil {
+		broker.Status.Annotations = make(map[string]string, 1)
+	}
+	broker.Status.Annotations[DiagnosticsStatusAnnotation] = string(b)
+}
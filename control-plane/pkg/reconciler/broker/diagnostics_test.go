@@ -0,0 +1,80 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/controller"
+)
+
+func TestSetDiagnosticsStatusAnnotation(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		diagnostics brokerDiagnostics
+		err         error
+		want        string
+	}{
+		{
+			name:        "reconciled",
+			diagnostics: brokerDiagnostics{phase: addressReconcilePhase, ProbeStatus: "Ready"},
+			want:        `{"externalTopic":false,"probeStatus":"Ready"}`,
+		},
+		{
+			name:        "topic phase failed with a Kafka error",
+			diagnostics: brokerDiagnostics{phase: topicReconcilePhase},
+			err:         fmt.Errorf("failed to create topic: %w", &sarama.TopicError{Err: sarama.ErrPolicyViolation}),
+			want:        `{"failedPhase":"topic","kafkaErrorCode":44,"externalTopic":false}`,
+		},
+		{
+			name:        "external topic requeued",
+			annotations: map[string]string{ExternalTopicAnnotation: "my-topic"},
+			diagnostics: brokerDiagnostics{phase: topicReconcilePhase},
+			err:         controller.NewRequeueAfter(time.Second),
+			want:        `{"failedPhase":"topic","requeued":true,"externalTopic":true}`,
+		},
+		{
+			name: "failed before the reconcile phases",
+			err:  errors.New("failed to get contract config map"),
+			want: `{"failedPhase":"data_plane","externalTopic":false}`,
+		},
+		{
+			name:        "address phase failed with a probe not ready",
+			diagnostics: brokerDiagnostics{phase: addressReconcilePhase, ProbeStatus: "NotReady"},
+			err:         controller.NewRequeueAfter(time.Second),
+			want:        `{"failedPhase":"address","requeued":true,"externalTopic":false,"probeStatus":"NotReady"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := &eventing.Broker{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+
+			setDiagnosticsStatusAnnotation(context.Background(), broker, &tt.diagnostics, tt.err)
+
+			assert.Equal(t, tt.want, broker.Status.Annotations[DiagnosticsStatusAnnotation])
+		})
+	}
+}
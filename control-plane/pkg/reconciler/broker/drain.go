@@ -0,0 +1,153 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/reconciler"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	coreconfig "knative.dev/eventing-kafka-broker/control-plane/pkg/core/config"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+)
+
+const (
+	// DrainStartedStatusAnnotation is the status annotation recording when the drain of a deleted broker started, see
+	// config.Env.BrokerDrainTimeout.
+	DrainStartedStatusAnnotation = "drain.started"
+
+	// brokerDrainRequeueDelay is the delay before checking again whether the topic of a drained broker has been
+	// consumed.
+	brokerDrainRequeueDelay = 5 * time.Second
+)
+
+// drainsBroker returns true when the given deleted broker is drained before its topic is deleted, topics that aren't
+// deleted with the broker aren't drained.
+func (r *Reconciler) drainsBroker(broker *eventing.Broker) bool {
+	if r.Env.BrokerDrainTimeout <= 0 || r.Env.UnmanagedTopics {
+		return false
+	}
+	_, externalTopic := isExternalTopic(broker)
+	_, sharedTopic := isSharedTopic(broker)
+	return !externalTopic && !sharedTopic && r.topicDeletePolicy(broker) == TopicDeletePolicyDelete
+}
+
+// removeIngressFromContract removes the ingress of the given broker resource from the contract, so that receivers
+// stop accepting events for the broker while dispatchers keep dispatching the events of its topic.
+func (r *Reconciler) removeIngressFromContract(ctx context.Context, logger *zap.Logger, resource *contract.Resource, ct *contract.Contract, contractConfigMap *corev1.ConfigMap) error {
+	if resource == nil || resource.Ingress == nil {
+		return nil
+	}
+	resource.Ingress = nil
+
+	// Resource changed, increment contract generation.
+	coreconfig.IncrementContractGeneration(ct)
+
+	// Update the configuration map with the new contract data.
+	if err := r.UpdateDataPlaneConfigMap(ctx, ct, contractConfigMap); err != nil {
+		return err
+	}
+	logger.Debug("Ingress removed from contract config map")
+	return nil
+}
+
+// deleteDrainedResourceFromContractConfigMap deletes the resource of the given broker from the contract when the
+// broker is drained, since the drain only removed its ingress.
+func (r *Reconciler) deleteDrainedResourceFromContractConfigMap(ctx context.Context, logger *zap.Logger, broker *eventing.Broker, drain bool) error {
+	if !drain {
+		return nil
+	}
+	_, err := r.deleteResourceFromContractConfigMap(ctx, logger, broker)
+	return err
+}
+
+// drainBrokerTopic waits for the consumer groups of the triggers of the given deleted broker to consume its topic, it
+// returns nil once the topic is drained, or once the BrokerDrainTimeout elapsed when it's enforced.
+func (r *Reconciler) drainBrokerTopic(ctx context.Context, logger *zap.Logger, broker *eventing.Broker, resource *contract.Resource, securityOption kafka.ConfigOption, topicConfig *kafka.TopicConfig) reconciler.Event {
+	if len(resource.GetEgresses()) == 0 {
+		// Nothing consumes the topic.
+		return nil
+	}
+
+	topic, err := r.finalizedBrokerTopicName(broker)
+	if err != nil {
+		return err
+	}
+
+	recorder := controller.GetEventRecorder(ctx)
+	now := time.Now()
+	started, err := time.Parse(time.RFC3339, broker.Status.Annotations[DrainStartedStatusAnnotation])
+	if err != nil {
+		started = now
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[DrainStartedStatusAnnotation] = started.Format(time.RFC3339)
+		recorder.Eventf(broker, corev1.EventTypeNormal, "BrokerDrainStarted",
+			"Broker stopped accepting events, waiting for its triggers to consume topic %s before deleting it", topic)
+	}
+
+	lag, err := r.drainedBrokerTopicLag(logger, topic, resource.Egresses, securityOption, topicConfig)
+	if err == nil && lag == 0 {
+		logger.Debug("Broker topic drained", zap.String("topic", topic))
+		recorder.Eventf(broker, corev1.EventTypeNormal, "BrokerDrained", "Topic %s drained", topic)
+		return nil
+	}
+
+	if isBrokerDrainTimeoutElapsed(started, r.Env.BrokerDrainTimeout, now) {
+		if r.Env.BrokerDrainTimeoutEnforced {
+			recorder.Eventf(broker, corev1.EventTypeWarning, "BrokerDrainTimeout",
+				"Topic %s not drained within %s, deleting it with %d events left to consume", topic, r.Env.BrokerDrainTimeout, lag)
+			return nil
+		}
+		recorder.Eventf(broker, corev1.EventTypeWarning, "BrokerDrainTimeout",
+			"Topic %s not drained within %s, %d events left to consume", topic, r.Env.BrokerDrainTimeout, lag)
+	} else if err == nil {
+		recorder.Eventf(broker, corev1.EventTypeNormal, "BrokerDraining",
+			"Draining topic %s, %d events left to consume", topic, lag)
+	}
+	return controller.NewRequeueAfter(brokerDrainRequeueDelay)
+}
+
+// drainedBrokerTopicLag returns the total lag of the consumer groups of the given egresses on the given topic, failures
+// are logged and returned so that the drain is retried.
+func (r *Reconciler) drainedBrokerTopicLag(logger *zap.Logger, topic string, egresses []*contract.Egress, securityOption kafka.ConfigOption, topicConfig *kafka.TopicConfig) (uint64, error) {
+	lagProvider, err := r.newConsumerGroupLagProvider(securityOption, topicConfig)
+	if err != nil {
+		logger.Warn("Failed to create Kafka client to get drained broker topic lag", zap.Error(err))
+		return 0, err
+	}
+	defer lagProvider.Close()
+
+	lag, err := brokerTopicLag(lagProvider, topic, egresses)
+	if err != nil {
+		logger.Warn("Failed to get drained broker topic lag", zap.String("topic", topic), zap.Error(err))
+		return 0, err
+	}
+	return lag, nil
+}
+
+// isBrokerDrainTimeoutElapsed returns true when the drain started at the given time can't take longer.
+func isBrokerDrainTimeoutElapsed(started time.Time, timeout time.Duration, now time.Time) bool {
+	return !now.Before(started.Add(timeout))
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/controller"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+)
+
+func TestDrainsBroker(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         config.Env
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name: "drain disabled",
+		},
+		{
+			name: "topic deleted",
+			env:  config.Env{BrokerDrainTimeout: time.Minute},
+			want: true,
+		},
+		{
+			name:        "external topic",
+			env:         config.Env{BrokerDrainTimeout: time.Minute},
+			annotations: map[string]string{ExternalTopicAnnotation: "topic"},
+		},
+		{
+			name:        "topic retained",
+			env:         config.Env{BrokerDrainTimeout: time.Minute},
+			annotations: map[string]string{TopicDeletePolicyAnnotation: TopicDeletePolicyRetain},
+		},
+		{
+			name: "unmanaged topics",
+			env:  config.Env{BrokerDrainTimeout: time.Minute, UnmanagedTopics: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{Env: &tt.env}
+			broker := &eventing.Broker{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			assert.Equal(t, tt.want, r.drainsBroker(broker))
+		})
+	}
+}
+
+func TestDrainBrokerTopic(t *testing.T) {
+	egresses := []*contract.Egress{{ConsumerGroup: "cg-1"}}
+	lagged := map[string]kafka.ConsumerGroupLag{
+		"cg-1": {ByPartition: []kafka.PartitionLag{{LatestOffset: 10, ConsumerOffset: 7}}},
+	}
+	consumed := map[string]kafka.ConsumerGroupLag{
+		"cg-1": {ByPartition: []kafka.PartitionLag{{LatestOffset: 10, ConsumerOffset: 10}}},
+	}
+	elapsed := time.Now().Add(-2 * time.Minute).Format(time.RFC3339)
+
+	tests := []struct {
+		name        string
+		resource    *contract.Resource
+		lags        map[string]kafka.ConsumerGroupLag
+		lagErr      error
+		started     string
+		enforced    bool
+		wantRequeue bool
+		wantEvents  []string
+	}{
+		{
+			name: "no triggers",
+			resource: &contract.Resource{
+				Uid: "uid",
+			},
+		},
+		{
+			name:       "topic consumed",
+			resource:   &contract.Resource{Egresses: egresses},
+			lags:       consumed,
+			wantEvents: []string{"Normal BrokerDrainStarted", "Normal BrokerDrained Topic topic drained"},
+		},
+		{
+			name:        "topic not consumed",
+			resource:    &contract.Resource{Egresses: egresses},
+			lags:        lagged,
+			wantRequeue: true,
+			wantEvents:  []string{"Normal BrokerDrainStarted", "Normal BrokerDraining Draining topic topic, 3 events left to consume"},
+		},
+		{
+			name:        "lag not available",
+			resource:    &contract.Resource{Egresses: egresses},
+			lagErr:      errors.New("failed"),
+			wantRequeue: true,
+			wantEvents:  []string{"Normal BrokerDrainStarted"},
+		},
+		{
+			name:        "timeout elapsed",
+			resource:    &contract.Resource{Egresses: egresses},
+			lags:        lagged,
+			started:     elapsed,
+			wantRequeue: true,
+			wantEvents:  []string{"Warning BrokerDrainTimeout Topic topic not drained within 1m0s, 3 events left to consume"},
+		},
+		{
+			name:       "timeout elapsed and enforced",
+			resource:   &contract.Resource{Egresses: egresses},
+			lags:       lagged,
+			started:    elapsed,
+			enforced:   true,
+			wantEvents: []string{"Warning BrokerDrainTimeout Topic topic not drained within 1m0s, deleting it with 3 events left to consume"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{
+				Env: &config.Env{BrokerDrainTimeout: time.Minute, BrokerDrainTimeoutEnforced: tt.enforced},
+				NewConsumerGroupLagProvider: func([]string, *sarama.Config) (kafka.ConsumerGroupLagProvider, error) {
+					return &lagProviderMock{lags: tt.lags, err: tt.lagErr}, nil
+				},
+			}
+			broker := &eventing.Broker{}
+			broker.Status.Annotations = map[string]string{kafka.TopicAnnotation: "topic"}
+			if tt.started != "" {
+				broker.Status.Annotations[DrainStartedStatusAnnotation] = tt.started
+			}
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(context.Background(), recorder)
+
+			event := r.drainBrokerTopic(ctx, zap.NewNop(), broker, tt.resource, func(*sarama.Config) error { return nil }, &kafka.TopicConfig{BootstrapServers: []string{"kafka-1:9092"}})
+			if tt.wantRequeue {
+				ok, delay := controller.IsRequeueKey(event)
+				require.True(t, ok, "expected requeue, got %v", event)
+				assert.Equal(t, brokerDrainRequeueDelay, delay)
+			} else {
+				require.NoError(t, event)
+			}
+
+			require.Len(t, recorder.Events, len(tt.wantEvents))
+			for _, want := range tt.wantEvents {
+				assert.Contains(t, <-recorder.Events, want)
+			}
+			if len(tt.resource.GetEgresses()) > 0 {
+				_, err := time.Parse(time.RFC3339, broker.Status.Annotations[DrainStartedStatusAnnotation])
+				assert.NoError(t, err, "expected the drain start to be recorded")
+			}
+		})
+	}
+}
+
+func TestIsBrokerDrainTimeoutElapsed(t *testing.T) {
+	started := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, isBrokerDrainTimeoutElapsed(started, time.Minute, started.Add(59*time.Second)))
+	assert.True(t, isBrokerDrainTimeoutElapsed(started, time.Minute, started.Add(time.Minute)))
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"fmt"
+	"regexp"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
+)
+
+// ExternalTopicPolicy restricts the external topics that brokers may reference, so that in multi-tenant clusters a
+// broker can't be pointed at the topic of another tenant.
+//
+// A topic is permitted when it matches the allow pattern, if any, and it doesn't match the deny pattern, if any.
+// Patterns must match the whole topic name.
+type ExternalTopicPolicy struct {
+	Allow *regexp.Regexp
+	Deny  *regexp.Regexp
+}
+
+// Permits returns true if the given external topic is permitted by the policy, a nil policy permits every topic.
+func (p *ExternalTopicPolicy) Permits(topic string) bool {
+	if p == nil {
+		return true
+	}
+	if p.Deny != nil && p.Deny.MatchString(topic) {
+		return false
+	}
+	return p.Allow == nil || p.Allow.MatchString(topic)
+}
+
+// ValidateExternalTopicPolicy validates the external topic allow and deny patterns, when configured.
+func ValidateExternalTopicPolicy(env config.Env) error {
+	_, err := parseExternalTopicPolicy(env)
+	return err
+}
+
+// parseExternalTopicPolicy parses the external topic policy of the given env, it returns nil when no pattern is
+// configured.
+func parseExternalTopicPolicy(env config.Env) (*ExternalTopicPolicy, error) {
+	if env.ExternalTopicAllowPattern == "" && env.ExternalTopicDenyPattern == "" {
+		return nil, nil
+	}
+
+	policy := &ExternalTopicPolicy{}
+	var err error
+	if policy.Allow, err = compileTopicPattern(env.ExternalTopicAllowPattern); err != nil {
+		return nil, fmt.Errorf("invalid external topic allow pattern %q: %w", env.ExternalTopicAllowPattern, err)
+	}
+	if policy.Deny, err = compileTopicPattern(env.ExternalTopicDenyPattern); err != nil {
+		return nil, fmt.Errorf("invalid external topic deny pattern %q: %w", env.ExternalTopicDenyPattern, err)
+	}
+	return policy, nil
+}
+
+func compileTopicPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
+)
+
+func TestExternalTopicPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    config.Env
+		topics map[string]bool
+	}{
+		{
+			name: "no policy",
+			env:  config.Env{},
+			topics: map[string]bool{
+				"tenant-a.events": true,
+				"tenant-b.events": true,
+			},
+		},
+		{
+			name: "allow pattern matches the whole topic name",
+			env:  config.Env{ExternalTopicAllowPattern: `tenant-a\..*`},
+			topics: map[string]bool{
+				"tenant-a.events":        true,
+				"tenant-b.events":        false,
+				"other.tenant-a.events":  false,
+				"tenant-a-evil.events":   false,
+				"tenant-a.events.legacy": true,
+			},
+		},
+		{
+			name: "deny pattern takes precedence",
+			env:  config.Env{ExternalTopicAllowPattern: `tenant-a\..*`, ExternalTopicDenyPattern: `.*\.internal`},
+			topics: map[string]bool{
+				"tenant-a.events":   true,
+				"tenant-a.internal": false,
+			},
+		},
+		{
+			name: "deny pattern only",
+			env:  config.Env{ExternalTopicDenyPattern: `__.*|tenant-b\..*`},
+			topics: map[string]bool{
+				"tenant-a.events":    true,
+				"tenant-b.events":    false,
+				"__consumer_offsets": false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := parseExternalTopicPolicy(tt.env)
+			require.NoError(t, err)
+
+			for topic, permitted := range tt.topics {
+				assert.Equal(t, permitted, policy.Permits(topic), "topic %s", topic)
+			}
+		})
+	}
+}
+
+func TestValidateExternalTopicPolicy(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		env     config.Env
+		wantErr bool
+	}{
+		{
+			name:    "no policy",
+			env:     config.Env{},
+			wantErr: false,
+		},
+		{
+			name:    "valid patterns",
+			env:     config.Env{ExternalTopicAllowPattern: `tenant-a\..*`, ExternalTopicDenyPattern: `.*\.internal`},
+			wantErr: false,
+		},
+		{
+			name:    "invalid allow pattern",
+			env:     config.Env{ExternalTopicAllowPattern: `tenant-a\.(`},
+			wantErr: true,
+		},
+		{
+			name:    "invalid deny pattern",
+			env:     config.Env{ExternalTopicDenyPattern: `[`},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateExternalTopicPolicy(tt.env); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateExternalTopicPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+)
+
+const (
+	// IngressFilterAnnotation for rejecting, at ingestion, the events of the broker that don't match the given filter,
+	// instead of producing them to the broker topic. The filter is a list of clauses separated by ';', each clause
+	// being one of:
+	//   - required=<attribute>[,<attribute>...]: the CloudEvents attributes, including extensions, events must have
+	//   - types=<type>[,<type>...]: the types of the events accepted
+	// For example, "required=subject,tenantid;types=com.example.order.created,com.example.order.deleted".
+	// Events aren't filtered when it's not set.
+	IngressFilterAnnotation = "kafka.eventing.knative.dev/ingress.filter"
+
+	ingressFilterRequiredClause = "required"
+	ingressFilterTypesClause    = "types"
+)
+
+// ceAttributeName matches the valid CloudEvents attribute names.
+var ceAttributeName = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// IngressFilter returns the filter of the contract ingress of the given broker, it's parsed from the
+// IngressFilterAnnotation value, when set, or nil, which doesn't filter events.
+func IngressFilter(broker *eventing.Broker) (*contract.IngressFilter, error) {
+	value, ok := broker.GetAnnotations()[IngressFilterAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	filter := &contract.IngressFilter{}
+	seen := make(map[string]bool, 2)
+	for _, clause := range strings.Split(value, ";") {
+		if strings.TrimSpace(clause) == "" {
+			continue
+		}
+		name, list, ok := strings.Cut(clause, "=")
+		name = strings.TrimSpace(name)
+		if !ok {
+			return nil, invalidIngressFilter(value, fmt.Sprintf("clause %q isn't <name>=<values>", clause))
+		}
+		if seen[name] {
+			return nil, invalidIngressFilter(value, fmt.Sprintf("duplicate %s clause", name))
+		}
+		seen[name] = true
+
+		values, err := ingressFilterValues(list)
+		if err != nil {
+			return nil, invalidIngressFilter(value, fmt.Sprintf("%s clause: %v", name, err))
+		}
+
+		switch name {
+		case ingressFilterRequiredClause:
+			for _, attribute := range values {
+				if !ceAttributeName.MatchString(attribute) {
+					return nil, invalidIngressFilter(value, fmt.Sprintf("invalid attribute name %q: only lowercase letters and digits are allowed", attribute))
+				}
+			}
+			filter.RequiredAttributes = values
+		case ingressFilterTypesClause:
+			filter.AllowedTypes = values
+		default:
+			return nil, invalidIngressFilter(value, fmt.Sprintf("unknown clause %q, expected %s or %s", name, ingressFilterRequiredClause, ingressFilterTypesClause))
+		}
+	}
+
+	if len(filter.RequiredAttributes) == 0 && len(filter.AllowedTypes) == 0 {
+		return nil, invalidIngressFilter(value, "no clauses")
+	}
+	return filter, nil
+}
+
+// ingressFilterValues returns the comma separated values of a filter clause, without duplicates.
+func ingressFilterValues(list string) ([]string, error) {
+	var values []string
+	seen := make(map[string]bool)
+	for _, v := range strings.Split(list, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return nil, fmt.Errorf("empty value")
+		}
+		if !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+func invalidIngressFilter(value string, reason string) error {
+	return fmt.Errorf("invalid %s annotation value %q: %s", IngressFilterAnnotation, value, reason)
+}
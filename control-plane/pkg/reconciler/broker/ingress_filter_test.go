@@ -0,0 +1,106 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+)
+
+func TestIngressFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation *string
+		want       *contract.IngressFilter
+		wantErr    bool
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:       "required attributes",
+			annotation: pointer.String("required=subject,tenantid"),
+			want:       &contract.IngressFilter{RequiredAttributes: []string{"subject", "tenantid"}},
+		},
+		{
+			name:       "allowed types",
+			annotation: pointer.String("types=com.example.created"),
+			want:       &contract.IngressFilter{AllowedTypes: []string{"com.example.created"}},
+		},
+		{
+			name:       "required attributes and allowed types",
+			annotation: pointer.String(" types = com.example.created, com.example.deleted ; required = tenantid ;"),
+			want: &contract.IngressFilter{
+				RequiredAttributes: []string{"tenantid"},
+				AllowedTypes:       []string{"com.example.created", "com.example.deleted"},
+			},
+		},
+		{
+			name:       "duplicate values",
+			annotation: pointer.String("required=tenantid,tenantid"),
+			want:       &contract.IngressFilter{RequiredAttributes: []string{"tenantid"}},
+		},
+		{
+			name:       "empty",
+			annotation: pointer.String(""),
+			wantErr:    true,
+		},
+		{
+			name:       "clause without values",
+			annotation: pointer.String("required"),
+			wantErr:    true,
+		},
+		{
+			name:       "empty value",
+			annotation: pointer.String("types=com.example.created,"),
+			wantErr:    true,
+		},
+		{
+			name:       "duplicate clause",
+			annotation: pointer.String("types=a;types=b"),
+			wantErr:    true,
+		},
+		{
+			name:       "unknown clause",
+			annotation: pointer.String("sources=/my/source"),
+			wantErr:    true,
+		},
+		{
+			name:       "invalid attribute name",
+			annotation: pointer.String("required=tenant-id"),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := &eventing.Broker{}
+			if tt.annotation != nil {
+				broker.ObjectMeta = metav1.ObjectMeta{Annotations: map[string]string{IngressFilterAnnotation: *tt.annotation}}
+			}
+
+			got, err := IngressFilter(broker)
+			require.Equal(t, tt.wantErr, err != nil, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"time"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// DefaultIngressReachabilityCheckTimeout is the timeout of ingress reachability checks when the probe timeout isn't
+// configured.
+const DefaultIngressReachabilityCheckTimeout = 5 * time.Second
+
+// IngressReachabilityCheckFunc returns an error when the given broker address isn't reachable from the controller.
+type IngressReachabilityCheckFunc func(ctx context.Context, address duckv1.Addressable) error
+
+// NewIngressReachabilityCheck returns a check sending HEAD requests to the addresses, any HTTP response means that the
+// address is reachable, since the check is about name resolution and networking, not about the broker readiness
+// that the prober already checks from the data plane.
+func NewIngressReachabilityCheck(timeout time.Duration) IngressReachabilityCheckFunc {
+	if timeout <= 0 {
+		timeout = DefaultIngressReachabilityCheckTimeout
+	}
+	return func(ctx context.Context, address duckv1.Addressable) error {
+		if address.URL == nil {
+			return fmt.Errorf("address without URL")
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if address.CACerts != nil && *address.CACerts != "" {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM([]byte(*address.CACerts)) {
+				return fmt.Errorf("failed to parse CA certs of address %s", address.URL)
+			}
+			transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+		}
+		defer transport.CloseIdleConnections()
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, address.URL.String(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		resp, err := (&http.Client{Transport: transport}).Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+}
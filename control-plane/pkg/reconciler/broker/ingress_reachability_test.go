@@ -0,0 +1,70 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
+)
+
+func TestIngressReachabilityCheck(t *testing.T) {
+	var method string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	check := NewIngressReachabilityCheck(time.Second)
+
+	u, err := apis.ParseURL(server.URL + "/ns/name")
+	require.NoError(t, err)
+	require.NoError(t, check(context.Background(), duckv1.Addressable{URL: u}), "expected any HTTP response to be reachable")
+	require.Equal(t, http.MethodHead, method)
+
+	server.Close()
+	require.Error(t, check(context.Background(), duckv1.Addressable{URL: u}))
+
+	require.Error(t, check(context.Background(), duckv1.Addressable{}))
+}
+
+func TestIngressReachabilityCheckTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	check := NewIngressReachabilityCheck(time.Second)
+
+	u, err := apis.ParseURL(server.URL + "/ns/name")
+	require.NoError(t, err)
+	require.Error(t, check(context.Background(), duckv1.Addressable{URL: u}), "expected the unknown certificate authority to be rejected")
+
+	caCerts := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	require.NoError(t, check(context.Background(), duckv1.Addressable{URL: u, CACerts: ptr.String(caCerts)}))
+
+	require.Error(t, check(context.Background(), duckv1.Addressable{URL: u, CACerts: ptr.String("invalid")}))
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/metrics"
+	"knative.dev/pkg/metrics/metricskey"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+)
+
+const (
+	// brokerNameLabel is the metric label for the name of the broker.
+	brokerNameLabel = "broker_name"
+	// phaseLabel is the metric label for the phase of the broker reconciliation.
+	phaseLabel = "phase"
+	// bootstrapServersLabel is the metric label for the bootstrap servers of a Kafka cluster.
+	bootstrapServersLabel = "bootstrap_servers"
+
+	// phases of the broker reconciliation whose duration is recorded.
+	configReconcilePhase         = "config"
+	secretReconcilePhase         = "secret"
+	topicReconcilePhase          = "topic"
+	contractReconcilePhase       = "contract"
+	podsAnnotationReconcilePhase = "pods_annotation"
+	addressReconcilePhase        = "address"
+)
+
+var (
+	// brokerTopicLagM is the total lag of the broker triggers consumer groups across the broker topic partitions.
+	brokerTopicLagM = stats.Int64(
+		"broker_topic_lag",
+		"Total lag of the broker triggers consumer groups across the broker topic partitions",
+		stats.UnitDimensionless,
+	)
+
+	// reconcilePhaseLatenciesM is the time spent in each phase of broker reconciliations.
+	reconcilePhaseLatenciesM = stats.Float64(
+		"broker_reconcile_phase_latencies",
+		"Time spent in each phase of broker reconciliations",
+		stats.UnitMilliseconds,
+	)
+
+	// kafkaClusterReachableM is 1 when the Kafka cluster is reachable by the control plane, 0 otherwise.
+	kafkaClusterReachableM = stats.Int64(
+		"kafka_cluster_reachable",
+		"Whether the Kafka cluster is reachable by the control plane Kafka cluster admin clients",
+		stats.UnitDimensionless,
+	)
+
+	namespaceNameKey    = tag.MustNewKey(metricskey.LabelNamespaceName)
+	brokerNameKey       = tag.MustNewKey(brokerNameLabel)
+	phaseKey            = tag.MustNewKey(phaseLabel)
+	bootstrapServersKey = tag.MustNewKey(bootstrapServersLabel)
+)
+
+func init() {
+	err := view.Register(
+		&view.View{
+			Description: brokerTopicLagM.Description(),
+			Measure:     brokerTopicLagM,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{namespaceNameKey, brokerNameKey},
+		},
+		&view.View{
+			Description: reconcilePhaseLatenciesM.Description(),
+			Measure:     reconcilePhaseLatenciesM,
+			Aggregation: view.Distribution(metrics.Buckets125(1, 10000)...),
+			TagKeys:     []tag.Key{phaseKey},
+		},
+		&view.View{
+			Description: kafkaClusterReachableM.Description(),
+			Measure:     kafkaClusterReachableM,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{bootstrapServersKey},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// reportBrokerTopicLag publishes the total lag of the consumer groups of the given egresses on the broker topic.
+//
+// Failures are logged and never fail the reconciliation since the lag is only informational.
+func (r *Reconciler) reportBrokerTopicLag(ctx context.Context, logger *zap.Logger, broker *eventing.Broker, topic string, securityOption kafka.ConfigOption, topicConfig *kafka.TopicConfig, egresses []*contract.Egress) {
+	if len(egresses) == 0 {
+		return
+	}
+
+	lagProvider, err := r.newConsumerGroupLagProvider(securityOption, topicConfig)
+	if err != nil {
+		logger.Warn("Failed to create Kafka client to get broker topic lag", zap.Error(err))
+		return
+	}
+	defer lagProvider.Close()
+
+	lag, err := brokerTopicLag(lagProvider, topic, egresses)
+	if err != nil {
+		logger.Warn("Failed to get broker topic lag", zap.String("topic", topic), zap.Error(err))
+		return
+	}
+
+	if err := recordBrokerTopicLag(ctx, broker, lag); err != nil {
+		logger.Warn("Failed to record broker topic lag", zap.Error(err))
+		return
+	}
+	logger.Debug("Broker topic lag recorded", zap.String("topic", topic), zap.Uint64("lag", lag))
+}
+
+// newConsumerGroupLagProvider returns a lag provider of the consumer groups of the broker triggers, it's created with
+// NewConsumerGroupLagProvider when set.
+func (r *Reconciler) newConsumerGroupLagProvider(securityOption kafka.ConfigOption, topicConfig *kafka.TopicConfig) (kafka.ConsumerGroupLagProvider, error) {
+	saramaConfig, err := kafka.GetSaramaConfig(securityOption)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client config: %w", err)
+	}
+
+	if r.NewConsumerGroupLagProvider != nil {
+		return r.NewConsumerGroupLagProvider(topicConfig.BootstrapServers, saramaConfig)
+	}
+
+	client, err := r.NewKafkaClient(topicConfig.BootstrapServers, saramaConfig)
+	if err != nil {
+		return nil, err
+	}
+	return kafka.NewConsumerGroupLagProvider(client, sarama.NewClusterAdminFromClient, sarama.OffsetOldest), nil
+}
+
+// brokerTopicLag returns the sum of the lags of the consumer groups of the given egresses on the given topic.
+func brokerTopicLag(lagProvider kafka.ConsumerGroupLagProvider, topic string, egresses []*contract.Egress) (uint64, error) {
+	var total uint64
+	seen := make(map[string]struct{}, len(egresses))
+	for _, egress := range egresses {
+		if _, ok := seen[egress.ConsumerGroup]; ok || egress.ConsumerGroup == "" {
+			continue
+		}
+		seen[egress.ConsumerGroup] = struct{}{}
+
+		lag, err := lagProvider.GetLag(topic, egress.ConsumerGroup)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get lag of consumer group %s: %w", egress.ConsumerGroup, err)
+		}
+		total += lag.Total()
+	}
+	return total, nil
+}
+
+func recordBrokerTopicLag(ctx context.Context, broker *eventing.Broker, lag uint64) error {
+	ctx, err := tag.New(ctx,
+		tag.Insert(namespaceNameKey, broker.GetNamespace()),
+		tag.Insert(brokerNameKey, broker.GetName()),
+	)
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, brokerTopicLagM.M(int64(lag)))
+	return nil
+}
+
+// reconcilePhaseTimer records the duration of the phases of a reconciliation, one phase at a time.
+type reconcilePhaseTimer struct {
+	ctx    context.Context
+	logger *zap.Logger
+
+	phase string
+	start time.Time
+	// last is the last phase begun, it's kept once the phase ended.
+	last string
+}
+
+func newReconcilePhaseTimer(ctx context.Context, logger *zap.Logger) *reconcilePhaseTimer {
+	return &reconcilePhaseTimer{ctx: ctx, logger: logger}
+}
+
+// begin records the duration of the current phase, if any, and starts the given phase.
+func (t *reconcilePhaseTimer) begin(phase string) {
+	t.end()
+	t.phase = phase
+	t.last = phase
+	t.start = time.Now()
+}
+
+// end records the duration of the current phase, if any, phases ended because of a failure are recorded too.
+func (t *reconcilePhaseTimer) end() {
+	if t.phase == "" {
+		return
+	}
+	if err := recordReconcilePhaseLatency(t.ctx, t.phase, time.Since(t.start)); err != nil {
+		t.logger.Warn("Failed to record reconcile phase latency", zap.String("phase", t.phase), zap.Error(err))
+	}
+	t.phase = ""
+}
+
+func recordReconcilePhaseLatency(ctx context.Context, phase string, latency time.Duration) error {
+	ctx, err := tag.New(ctx, tag.Insert(phaseKey, phase))
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, reconcilePhaseLatenciesM.M(float64(latency)/float64(time.Millisecond)))
+	return nil
+}
+
+// recordKafkaConnectivity records the result of an attempt to connect to the given bootstrap servers in the
+// ConnectivityTracker, when set, and publishes the resulting connectivity of the cluster.
+func (r *Reconciler) recordKafkaConnectivity(bootstrapServers []string, err error) {
+	if r.ConnectivityTracker == nil {
+		return
+	}
+	connectivity := r.ConnectivityTracker.Record(bootstrapServers, err)
+
+	ctx, err := tag.New(context.Background(), tag.Insert(bootstrapServersKey, connectivity.BootstrapServers))
+	if err != nil {
+		return
+	}
+	reachable := int64(0)
+	if connectivity.Reachable {
+		reachable = 1
+	}
+	metrics.Record(ctx, kafkaClusterReachableM.M(reachable))
+}
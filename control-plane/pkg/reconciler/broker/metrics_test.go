@@ -0,0 +1,175 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+)
+
+type lagProviderMock struct {
+	lags  map[string]kafka.ConsumerGroupLag
+	err   error
+	calls int
+}
+
+func (m *lagProviderMock) GetLag(topic, consumerGroup string) (kafka.ConsumerGroupLag, error) {
+	m.calls++
+	if m.err != nil {
+		return kafka.ConsumerGroupLag{}, m.err
+	}
+	return m.lags[consumerGroup], nil
+}
+
+func (m *lagProviderMock) Close() error {
+	return nil
+}
+
+func TestBrokerTopicLag(t *testing.T) {
+	lags := map[string]kafka.ConsumerGroupLag{
+		"cg-1": {ByPartition: []kafka.PartitionLag{{LatestOffset: 10, ConsumerOffset: 5}, {LatestOffset: 3, ConsumerOffset: 1}}},
+		"cg-2": {ByPartition: []kafka.PartitionLag{{LatestOffset: 4, ConsumerOffset: 4}, {LatestOffset: 8, ConsumerOffset: 0}}},
+	}
+
+	tests := []struct {
+		name      string
+		egresses  []*contract.Egress
+		err       error
+		wantLag   uint64
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "sum of consumer groups lag",
+			egresses:  []*contract.Egress{{ConsumerGroup: "cg-1"}, {ConsumerGroup: "cg-2"}},
+			wantLag:   15,
+			wantCalls: 2,
+		},
+		{
+			name:      "consumer groups counted once",
+			egresses:  []*contract.Egress{{ConsumerGroup: "cg-1"}, {ConsumerGroup: "cg-1"}, {}},
+			wantLag:   7,
+			wantCalls: 1,
+		},
+		{
+			name:      "lag provider error",
+			egresses:  []*contract.Egress{{ConsumerGroup: "cg-1"}},
+			err:       errors.New("failed"),
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &lagProviderMock{lags: lags, err: tt.err}
+
+			lag, err := brokerTopicLag(provider, "topic", tt.egresses)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("brokerTopicLag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if lag != tt.wantLag {
+				t.Errorf("brokerTopicLag() = %d, want %d", lag, tt.wantLag)
+			}
+			if provider.calls != tt.wantCalls {
+				t.Errorf("GetLag calls = %d, want %d", provider.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestReconcilePhaseTimer(t *testing.T) {
+	count := func(phase string) int64 {
+		rows, err := view.RetrieveData(reconcilePhaseLatenciesM.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range rows {
+			if len(row.Tags) == 1 && row.Tags[0].Key == phaseKey && row.Tags[0].Value == phase {
+				return row.Data.(*view.DistributionData).Count
+			}
+		}
+		return 0
+	}
+
+	before := map[string]int64{
+		configReconcilePhase: count(configReconcilePhase),
+		secretReconcilePhase: count(secretReconcilePhase),
+		topicReconcilePhase:  count(topicReconcilePhase),
+	}
+
+	phases := newReconcilePhaseTimer(context.Background(), zap.NewNop())
+	phases.begin(configReconcilePhase)
+	phases.begin(secretReconcilePhase)
+	phases.end()
+	phases.end()
+
+	if got := count(configReconcilePhase) - before[configReconcilePhase]; got != 1 {
+		t.Errorf("config phase observations = %d, want 1", got)
+	}
+	if got := count(secretReconcilePhase) - before[secretReconcilePhase]; got != 1 {
+		t.Errorf("secret phase observations = %d, want 1", got)
+	}
+	if got := count(topicReconcilePhase) - before[topicReconcilePhase]; got != 0 {
+		t.Errorf("topic phase observations = %d, want 0", got)
+	}
+}
+
+func TestRecordKafkaConnectivity(t *testing.T) {
+	reachable := func(bootstrapServers string) (int64, bool) {
+		rows, err := view.RetrieveData(kafkaClusterReachableM.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range rows {
+			if len(row.Tags) == 1 && row.Tags[0].Key == bootstrapServersKey && row.Tags[0].Value == bootstrapServers {
+				return int64(row.Data.(*view.LastValueData).Value), true
+			}
+		}
+		return 0, false
+	}
+
+	servers := []string{"kafka-connectivity-1:9092", "kafka-connectivity-2:9092"}
+
+	// Nothing is recorded without a tracker.
+	(&Reconciler{}).recordKafkaConnectivity(servers, nil)
+	if _, ok := reachable("kafka-connectivity-1:9092,kafka-connectivity-2:9092"); ok {
+		t.Fatal("connectivity recorded without a tracker")
+	}
+
+	r := &Reconciler{ConnectivityTracker: kafka.NewConnectivityTracker(1, 0)}
+
+	r.recordKafkaConnectivity(servers, errors.New("dial tcp: connection refused"))
+	if got, _ := reachable("kafka-connectivity-1:9092,kafka-connectivity-2:9092"); got != 0 {
+		t.Errorf("reachable = %d, want 0", got)
+	}
+	if err := r.ConnectivityTracker.Check(); err == nil {
+		t.Error("expected unreachable cluster error")
+	}
+
+	r.recordKafkaConnectivity(servers, nil)
+	if got, _ := reachable("kafka-connectivity-1:9092,kafka-connectivity-2:9092"); got != 1 {
+		t.Errorf("reachable = %d, want 1", got)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"text/template"
 
 	mf "github.com/manifestival/manifestival"
 	"go.uber.org/zap"
@@ -33,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	appslisters "k8s.io/client-go/listers/apps/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	rbaclisters "k8s.io/client-go/listers/rbac/v1"
@@ -77,6 +79,23 @@ type NamespacedReconciler struct {
 	// NewKafkaClusterAdminClient creates new sarama ClusterAdmin. It's convenient to add this as Reconciler field so that we can
 	// mock the function used during the reconciliation loop.
 	NewKafkaClusterAdminClient kafka.NewClusterAdminClientFunc
+	NewKafkaClient             kafka.NewClientFunc
+	ClusterAdminPool           *kafka.ClusterAdminPool
+	TopicMetadataCache         *kafka.TopicMetadataCache
+	ClusterAdminCircuitBreaker *kafka.CircuitBreaker
+	ConnectivityTracker        *kafka.ConnectivityTracker
+	DescribeKafkaCluster       kafka.DescribeClusterFunc
+	DialerFactory              kafka.DialerFactoryFunc
+	DynamicClient              dynamic.Interface
+	BrokerTopicTemplate        *template.Template
+	ExternalTopicPolicy        *ExternalTopicPolicy
+	NamespaceTopicPrefixes     NamespaceTopicPrefixes
+	ResourceMutator            ResourceMutator
+	CheckIngressReachability   IngressReachabilityCheckFunc
+	StatusUpdateThrottle       *StatusUpdateThrottle
+	FastPath                   *ReconcileFastPath
+
+	ResyncBrokers func()
 
 	BootstrapServers string
 
@@ -225,6 +244,10 @@ func (r *NamespacedReconciler) createReconcilerForBrokerInstance(broker *eventin
 			ContractConfigMapFormat:      r.Reconciler.ContractConfigMapFormat,
 			DispatcherLabel:              r.Reconciler.DispatcherLabel,
 			ReceiverLabel:                r.Reconciler.ReceiverLabel,
+			DispatcherPodSelector:        r.Reconciler.DispatcherPodSelector,
+			ReceiverPodSelector:          r.Reconciler.ReceiverPodSelector,
+			ResourceKind:                 r.Reconciler.ResourceKind,
+			ContractUpdateCoalesceWindow: r.Reconciler.ContractUpdateCoalesceWindow,
 
 			DataPlaneNamespace:          broker.Namespace,
 			DataPlaneConfigMapNamespace: broker.Namespace,
@@ -237,7 +260,25 @@ func (r *NamespacedReconciler) createReconcilerForBrokerInstance(broker *eventin
 		Env:                        r.Env,
 		Resolver:                   r.Resolver,
 		ConfigMapLister:            r.ConfigMapLister,
+		ServiceLister:              r.ServiceLister,
 		NewKafkaClusterAdminClient: r.NewKafkaClusterAdminClient,
+		NewKafkaClient:             r.NewKafkaClient,
+		ClusterAdminPool:           r.ClusterAdminPool,
+		TopicMetadataCache:         r.TopicMetadataCache,
+		ClusterAdminCircuitBreaker: r.ClusterAdminCircuitBreaker,
+		ConnectivityTracker:        r.ConnectivityTracker,
+		DescribeKafkaCluster:       r.DescribeKafkaCluster,
+		DialerFactory:              r.DialerFactory,
+		DynamicClient:              r.DynamicClient,
+		BrokerTopicTemplate:        r.BrokerTopicTemplate,
+		ExternalTopicPolicy:        r.ExternalTopicPolicy,
+		NamespaceTopicPrefixes:     r.NamespaceTopicPrefixes,
+		ResourceMutator:            r.ResourceMutator,
+		CheckIngressReachability:   r.CheckIngressReachability,
+		StatusUpdateThrottle:       r.StatusUpdateThrottle,
+		FastPath:                   r.FastPath,
+		BrokerLister:               r.BrokerLister,
+		ResyncBrokers:              r.ResyncBrokers,
 		BootstrapServers:           r.BootstrapServers,
 		Prober:                     r.Prober,
 		Counter:                    r.Counter,
@@ -32,6 +32,7 @@ import (
 
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/counter"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
 	kafkatesting "knative.dev/eventing-kafka-broker/control-plane/pkg/kafka/testing"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/prober"
@@ -164,6 +165,7 @@ func namespacedBrokerReconciliation(t *testing.T, format string, env config.Env)
 			Key: testKey,
 			WantEvents: []string{
 				finalizerUpdatedEvent,
+				topicCreatedEvent(BrokerTopic()),
 			},
 			WantCreates: []runtime.Object{
 				ToManifestivalResource(t,
@@ -510,6 +512,7 @@ func useTableNamespaced(t *testing.T, table TableTest, env *config.Env) {
 			},
 			Env:                                env,
 			Prober:                             proberMock,
+			Counter:                            counter.NewExpiringCounter(ctx),
 			ManifestivalClient:                 mfcMockClient,
 			DataplaneLifecycleLocksByNamespace: util.NewExpiringLockMap[string](ctx, time.Minute*30),
 			KafkaFeatureFlags:                  apisconfig.DefaultFeaturesConfig(),
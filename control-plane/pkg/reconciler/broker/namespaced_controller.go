@@ -35,6 +35,7 @@ import (
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/resolver"
@@ -67,7 +68,7 @@ const (
 	NamespacedBrokerAdditionalResourcesConfigMapName = "config-namespaced-broker-resources"
 )
 
-func NewNamespacedController(ctx context.Context, watcher configmap.Watcher, env *config.Env) *controller.Impl {
+func NewNamespacedController(ctx context.Context, watcher configmap.Watcher, env *config.Env, opts ...ControllerOption) *controller.Impl {
 	logger := logging.FromContext(ctx)
 
 	eventing.RegisterAlternateBrokerConditionSet(base.IngressConditionSet)
@@ -92,8 +93,12 @@ func NewNamespacedController(ctx context.Context, watcher configmap.Watcher, env
 			DataPlaneNamespace:           env.SystemNamespace,
 			DispatcherLabel:              base.BrokerDispatcherLabel,
 			ReceiverLabel:                base.BrokerReceiverLabel,
+			ResourceKind:                 base.BrokerResourceKind,
+			ContractUpdateCoalesceWindow: env.ContractUpdateCoalesceWindow,
 		},
 		NewKafkaClusterAdminClient:         sarama.NewClusterAdmin,
+		NewKafkaClient:                     sarama.NewClient,
+		DescribeKafkaCluster:               kafka.DescribeCluster,
 		NamespaceLister:                    namespaceinformer.Get(ctx).Lister(),
 		ConfigMapLister:                    configmapInformer.Lister(),
 		ServiceAccountLister:               serviceaccountinformer.Get(ctx).Lister(),
@@ -106,6 +111,60 @@ func NewNamespacedController(ctx context.Context, watcher configmap.Watcher, env
 		ManifestivalClient:                 mfc,
 		DataplaneLifecycleLocksByNamespace: util.NewExpiringLockMap[string](ctx, time.Minute*30),
 		KafkaFeatureFlags:                  apisconfig.DefaultFeaturesConfig(),
+		BootstrapServers:                   env.DefaultBootstrapServers,
+	}
+
+	if env.StatusUpdateMinInterval > 0 {
+		reconciler.StatusUpdateThrottle = NewStatusUpdateThrottle(env.StatusUpdateMinInterval)
+	}
+
+	// Brokers are recorded by UID, so a single fast path serves the brokers of every namespace.
+	if env.ReconcileFastPathMaxAge > 0 {
+		reconciler.FastPath = NewReconcileFastPath(env.ReconcileFastPathMaxAge)
+	}
+
+	if env.StrimziIntegrationEnabled {
+		reconciler.DynamicClient = dynamicclient.Get(ctx)
+	}
+
+	if env.ClusterAdminCircuitBreakerThreshold > 0 {
+		reconciler.ClusterAdminCircuitBreaker = kafka.NewCircuitBreaker(env.ClusterAdminCircuitBreakerThreshold, env.ClusterAdminCircuitBreakerWindow, env.ClusterAdminCircuitBreakerCooldown)
+	}
+
+	if env.ClusterAdminPoolSize > 0 {
+		reconciler.ClusterAdminPool = kafka.NewClusterAdminPool(ctx, reconciler.NewKafkaClusterAdminClient, env.ClusterAdminPoolSize, env.ClusterAdminPoolIdleTTL, reconciler.ClusterAdminCircuitBreaker)
+	}
+
+	if env.TopicMetadataCacheTTL > 0 {
+		reconciler.TopicMetadataCache = kafka.NewTopicMetadataCache(env.TopicMetadataCacheTTL)
+	}
+
+	reconciler.ConnectivityTracker = kafka.ConnectivityTrackerFromContext(ctx)
+
+	reconciler.BrokerTopicTemplate, err = parseBrokerTopicTemplate(*env)
+	if err != nil {
+		logger.Fatal("Invalid broker topic template", zap.Error(err))
+	}
+
+	reconciler.ExternalTopicPolicy, err = parseExternalTopicPolicy(*env)
+	if err != nil {
+		logger.Fatal("Invalid external topic policy", zap.Error(err))
+	}
+
+	reconciler.NamespaceTopicPrefixes, err = parseNamespaceTopicPrefixes(*env)
+	if err != nil {
+		logger.Fatal("Invalid namespace topic prefixes", zap.Error(err))
+	}
+
+	reconciler.Reconciler.ReceiverPodSelector, reconciler.Reconciler.DispatcherPodSelector, err = base.ParseDataPlanePodSelectors(*env)
+	if err != nil {
+		logger.Fatal("Invalid data plane pod selectors", zap.Error(err))
+	}
+
+	reconciler.ResourceMutator = newControllerOptions(opts).resourceMutator
+
+	if env.IngressReachabilityCheckEnabled {
+		reconciler.CheckIngressReachability = NewIngressReachabilityCheck(env.ProbeTimeout)
 	}
 
 	impl := brokerreconciler.NewImpl(ctx, reconciler, kafka.NamespacedBrokerClass, func(impl *controller.Impl) controller.Options {
@@ -129,13 +188,16 @@ func NewNamespacedController(ctx context.Context, watcher configmap.Watcher, env
 
 	reconciler.Tracker = impl.Tracker
 	secretinformer.Get(ctx).Informer().AddEventHandler(controller.HandleAll(controller.EnsureTypeMeta(
-		reconciler.Tracker.OnChanged,
+		newSecretChangeHandler(reconciler.ClusterAdminPool, reconciler.Tracker.OnChanged, env.SecretChangeMinInterval),
 		corev1.SchemeGroupVersion.WithKind("Secret"),
 	)))
 
 	globalResync := func(_ interface{}) {
 		impl.GlobalResync(brokerInformer.Informer())
 	}
+	reconciler.ResyncBrokers = func() {
+		globalResync(nil)
+	}
 
 	configmapInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: kafka.FilterWithLabel(kafka.NamespacedBrokerDataplaneLabelKey, kafka.NamespacedBrokerDataplaneLabelValue),
@@ -196,7 +258,9 @@ func NewNamespacedController(ctx context.Context, watcher configmap.Watcher, env
 	})
 
 	reconciler.Tracker = impl.Tracker
-	configmapinformer.Get(ctx).Informer().AddEventHandler(controller.HandleAll(
+	// Only changes to the config map content affect brokers, so skip updates that only change metadata, like labels,
+	// to avoid reconciling every broker referencing the config map.
+	configmapinformer.Get(ctx).Informer().AddEventHandler(base.OnConfigMapContentChanged(
 		// Call the tracker's OnChanged method, but we've seen the objects
 		// coming through this path missing TypeMeta, so ensure it is properly
 		// populated.
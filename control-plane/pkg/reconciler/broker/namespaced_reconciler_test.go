@@ -0,0 +1,57 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
+)
+
+func TestCreateReconcilerForBrokerInstance(t *testing.T) {
+	r := &NamespacedReconciler{
+		Reconciler: &base.Reconciler{
+			ReceiverPodSelector:   labels.SelectorFromSet(map[string]string{"app": "receiver", "pool": "a"}),
+			DispatcherPodSelector: labels.SelectorFromSet(map[string]string{"app": "dispatcher", "pool": "a"}),
+		},
+		Env:                  &config.Env{StrimziIntegrationEnabled: true},
+		DynamicClient:        dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()),
+		TopicMetadataCache:   kafka.NewTopicMetadataCache(time.Minute),
+		StatusUpdateThrottle: NewStatusUpdateThrottle(time.Minute),
+		FastPath:             NewReconcileFastPath(time.Hour),
+	}
+
+	br := r.createReconcilerForBrokerInstance(&eventing.Broker{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "b"}})
+
+	assert.Equal(t, "ns", br.Reconciler.DataPlaneNamespace)
+	assert.Same(t, r.DynamicClient, br.DynamicClient)
+	assert.Equal(t, r.Reconciler.ReceiverPodSelector, br.Reconciler.ReceiverPodSelector)
+	assert.Equal(t, r.Reconciler.DispatcherPodSelector, br.Reconciler.DispatcherPodSelector)
+	assert.Same(t, r.TopicMetadataCache, br.TopicMetadataCache)
+	assert.Same(t, r.StatusUpdateThrottle, br.StatusUpdateThrottle)
+	assert.Same(t, r.FastPath, br.FastPath)
+}
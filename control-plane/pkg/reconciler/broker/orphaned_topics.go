@@ -0,0 +1,222 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/security"
+)
+
+const (
+	// OrphanedTopicPrefix is the prefix of the broker topics looked up by orphaned topics sweeps, it's the prefix of
+	// the default brokers topic template.
+	OrphanedTopicPrefix = "knative-broker-"
+
+	// orphanedTopicsSweepComponent is the source component of the events emitted by orphaned topics sweeps.
+	orphanedTopicsSweepComponent = "kafka-broker-orphaned-topics-sweep"
+)
+
+// orphanedTopicsCluster is a Kafka cluster swept for orphaned topics.
+type orphanedTopicsCluster struct {
+	bootstrapServers []string
+	// secrets are the auth secrets used to connect to the Kafka cluster, if any.
+	secrets []types.NamespacedName
+}
+
+// runOrphanedTopicsSweeps sweeps the Kafka clusters in use by brokers for orphaned topics every
+// OrphanedTopicsSweepInterval until the given context is done.
+func (r *Reconciler) runOrphanedTopicsSweeps(ctx context.Context, recorder record.EventRecorder) {
+	logger := logging.FromContext(ctx).Desugar()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.Env.OrphanedTopicsSweepInterval):
+			if err := r.sweepOrphanedTopics(ctx, logger, recorder); err != nil {
+				logger.Warn("Failed to sweep orphaned topics", zap.Error(err))
+			}
+		}
+	}
+}
+
+// newOrphanedTopicsEventRecorder returns the recorder of the events emitted by orphaned topics sweeps, sweeps run
+// outside of reconciliations so they can't use the reconciler one.
+func (r *Reconciler) newOrphanedTopicsEventRecorder(ctx context.Context) record.EventRecorder {
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+		return recorder
+	}
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(logging.FromContext(ctx).Named("orphaned-topics-sweep").Infof)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: r.KubeClient.CoreV1().Events("")})
+	go func() {
+		<-ctx.Done()
+		eventBroadcaster.Shutdown()
+	}()
+	return eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: orphanedTopicsSweepComponent})
+}
+
+// sweepOrphanedTopics looks for the topics with the OrphanedTopicPrefix that aren't owned by any broker, and deletes
+// them when OrphanedTopicsDeletionEnabled is set.
+//
+// Topics referenced by brokers, including external and shared topics, and topics whose deletion is pending are never
+// considered orphaned. The findings are summarized with events on the contract config map, the one of the first shard
+// when the contract is sharded.
+func (r *Reconciler) sweepOrphanedTopics(ctx context.Context, logger *zap.Logger, recorder record.EventRecorder) error {
+	brokers, err := r.BrokerLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list brokers: %w", err)
+	}
+
+	contractConfigMap, err := r.GetOrCreateDataPlaneConfigMap(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get contract config map %s: %w", r.DataPlaneConfigMapAsString(), err)
+	}
+
+	owned := sets.NewString()
+	for shard := 0; shard < r.ContractConfigMapShardCount(); shard++ {
+		shardConfigMap := contractConfigMap
+		if shard > 0 {
+			shardConfigMap, err = r.GetOrCreateDataPlaneConfigMapShard(ctx, shard)
+			if err != nil {
+				return fmt.Errorf("failed to get contract config map %s/%s: %w", r.Reconciler.DataPlaneConfigMapNamespace, r.ContractConfigMapShardName(shard), err)
+			}
+		}
+		deletions, err := pendingTopicDeletions(shardConfigMap)
+		if err != nil {
+			return err
+		}
+		for topic := range deletions {
+			owned.Insert(topic)
+		}
+	}
+	clusters := make(map[string]orphanedTopicsCluster)
+	if r.BootstrapServers != "" {
+		clusters[r.BootstrapServers] = orphanedTopicsCluster{bootstrapServers: kafka.BootstrapServersArray(r.BootstrapServers)}
+	}
+	for _, broker := range brokers {
+		if topic, ok := isExternalTopic(broker); ok {
+			owned.Insert(topic)
+		}
+		if topic, ok := broker.Status.Annotations[kafka.TopicAnnotation]; ok {
+			owned.Insert(topic)
+		}
+		if topic, err := r.brokerTopicName(broker); err == nil {
+			owned.Insert(topic)
+		}
+
+		bootstrapServers := broker.Status.Annotations[kafka.BootstrapServersConfigMapKey]
+		if bootstrapServers == "" {
+			continue
+		}
+		clusters[bootstrapServers] = orphanedTopicsCluster{
+			bootstrapServers: kafka.BootstrapServersArray(bootstrapServers),
+			secrets:          authSecretReferences(broker),
+		}
+	}
+
+	for bootstrapServers, cluster := range clusters {
+		orphaned, deleted, err := r.sweepClusterOrphanedTopics(ctx, logger, cluster, owned)
+		if err != nil {
+			logger.Warn("Failed to sweep orphaned topics", zap.String("bootstrapServers", bootstrapServers), zap.Error(err))
+			continue
+		}
+		if len(orphaned) == 0 {
+			continue
+		}
+
+		recorder.Eventf(contractConfigMap, corev1.EventTypeWarning, "OrphanedTopicsFound",
+			"Found %d topics not owned by any broker in Kafka cluster %s: %s",
+			len(orphaned), bootstrapServers, strings.Join(orphaned, ", "))
+		if len(deleted) > 0 {
+			recorder.Eventf(contractConfigMap, corev1.EventTypeNormal, "OrphanedTopicsDeleted",
+				"Deleted %d topics not owned by any broker in Kafka cluster %s: %s",
+				len(deleted), bootstrapServers, strings.Join(deleted, ", "))
+		}
+	}
+	return nil
+}
+
+// sweepClusterOrphanedTopics returns the orphaned topics of the given Kafka cluster and the ones deleted.
+func (r *Reconciler) sweepClusterOrphanedTopics(ctx context.Context, logger *zap.Logger, cluster orphanedTopicsCluster, owned sets.String) ([]string, []string, error) {
+	_, secret, err := r.authSecretsFromReferences(ctx, cluster.secrets)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	saramaConfig, err := r.clusterAdminSaramaConfig(security.NewSaramaSecurityOptionFromSecret(secret))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting cluster admin sarama config: %w", err)
+	}
+
+	kafkaClusterAdminClient, err := r.newKafkaClusterAdminClient(cluster.bootstrapServers, secret, nil, saramaConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot obtain Kafka cluster admin, %w", err)
+	}
+	defer kafkaClusterAdminClient.Close()
+
+	topics, err := kafkaClusterAdminClient.ListTopics()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list topics: %w", err)
+	}
+	orphaned := orphanedTopics(topics, owned)
+
+	var deleted []string
+	for _, topic := range orphaned {
+		if !r.Env.OrphanedTopicsDeletionEnabled {
+			logger.Info("Orphaned topic found", zap.String("topic", topic))
+			continue
+		}
+		if _, err := kafka.DeleteTopic(kafkaClusterAdminClient, topic); err != nil {
+			logger.Warn("Failed to delete orphaned topic", zap.String("topic", topic), zap.Error(err))
+			continue
+		}
+		deleted = append(deleted, topic)
+		logger.Info("Orphaned topic deleted", zap.String("topic", topic))
+	}
+	return orphaned, deleted, nil
+}
+
+// orphanedTopics returns the sorted topics with the OrphanedTopicPrefix that aren't owned.
+func orphanedTopics(topics map[string]sarama.TopicDetail, owned sets.String) []string {
+	var orphaned []string
+	for topic := range topics {
+		if strings.HasPrefix(topic, OrphanedTopicPrefix) && !owned.Has(topic) {
+			orphaned = append(orphaned, topic)
+		}
+	}
+	sort.Strings(orphaned)
+	return orphaned
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+
+	apisconfig "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/config"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	kafkatesting "knative.dev/eventing-kafka-broker/control-plane/pkg/kafka/testing"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
+)
+
+func TestOrphanedTopics(t *testing.T) {
+	topics := map[string]sarama.TopicDetail{
+		"knative-broker-ns-b2": {},
+		"knative-broker-ns-b1": {},
+		"knative-broker-ns-b3": {},
+		"other-topic":          {},
+	}
+
+	got := orphanedTopics(topics, sets.NewString("knative-broker-ns-b3"))
+	assert.Equal(t, []string{"knative-broker-ns-b1", "knative-broker-ns-b2"}, got)
+}
+
+func TestSweepOrphanedTopics(t *testing.T) {
+	tests := []struct {
+		name            string
+		deletionEnabled bool
+		wantEvents      int
+	}{
+		{
+			name:       "orphaned topics reported",
+			wantEvents: 1,
+		},
+		{
+			name:            "orphaned topics deleted",
+			deletionEnabled: true,
+			wantEvents:      2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			brokers := []*eventing.Broker{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "b1"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "ns",
+						Name:        "b2",
+						Annotations: map[string]string{ExternalTopicAnnotation: "knative-broker-external"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "ns",
+						Name:        "b3",
+						Annotations: map[string]string{SharedTopicAnnotation: "knative-broker-shared"},
+					},
+				},
+			}
+			brokers[0].Status.Annotations = map[string]string{kafka.BootstrapServersConfigMapKey: "kafka-1:9092"}
+			for _, b := range brokers {
+				require.NoError(t, indexer.Add(b))
+			}
+
+			admin := &kafkatesting.MockKafkaClusterAdmin{
+				ExpectedTopicName: "knative-broker-ns-deleted",
+				ExpectedTopicsOnListTopics: map[string]sarama.TopicDetail{
+					"knative-broker-ns-b1":      {},
+					"knative-broker-external":   {},
+					"knative-broker-shared":     {},
+					"knative-broker-ns-deleted": {},
+					"other-topic":               {},
+				},
+				T: t,
+			}
+			var clusters [][]string
+			r := &Reconciler{
+				Reconciler: &base.Reconciler{
+					KubeClient:                  fake.NewSimpleClientset(),
+					DataPlaneConfigMapNamespace: "knative-eventing",
+					ContractConfigMapName:       "kafka-broker-brokers-triggers",
+					ContractConfigMapFormat:     base.Json,
+				},
+				Env: &config.Env{OrphanedTopicsDeletionEnabled: tt.deletionEnabled},
+				NewKafkaClusterAdminClient: func(addrs []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+					clusters = append(clusters, addrs)
+					return admin, nil
+				},
+				BrokerLister:      eventinglisters.NewBrokerLister(indexer),
+				KafkaFeatureFlags: apisconfig.DefaultFeaturesConfig(),
+			}
+			recorder := record.NewFakeRecorder(10)
+
+			err := r.sweepOrphanedTopics(context.Background(), zap.NewNop(), recorder)
+			require.NoError(t, err)
+
+			assert.Equal(t, [][]string{{"kafka-1:9092"}}, clusters)
+			assert.Len(t, recorder.Events, tt.wantEvents)
+			assert.Contains(t, <-recorder.Events, "Found 1 topics not owned by any broker in Kafka cluster kafka-1:9092: knative-broker-ns-deleted")
+			if tt.deletionEnabled {
+				assert.Contains(t, <-recorder.Events, "Deleted 1 topics")
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	coreconfig "knative.dev/eventing-kafka-broker/control-plane/pkg/core/config"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
+)
+
+// ReconcileFastPath records the last full reconciliation of each broker, so that reconciliations of brokers whose
+// inputs haven't changed since then skip the Kafka admin calls of the topic reconciliation.
+type ReconcileFastPath struct {
+	maxAge time.Duration
+	now    func() time.Time
+
+	mu sync.Mutex
+	// reconciled are the last full reconciliations of each broker.
+	reconciled map[types.UID]fastPathEntry
+}
+
+type fastPathEntry struct {
+	generation int64
+	inputs     string
+	topic      string
+	// bootstrapServers are the bootstrap servers of the Kafka cluster the topic was reconciled against, the failover
+	// cluster when the primary one wasn't reachable.
+	bootstrapServers []string
+	// resource is the contract resource of the broker without egresses, since triggers don't affect the broker topic.
+	resource *contract.Resource
+	time     time.Time
+}
+
+// fastPathInputs are the inputs of the topic reconciliation of a broker.
+type fastPathInputs struct {
+	Annotations map[string]string
+	Labels      map[string]string
+	Config      map[string]string
+	TopicConfig *kafka.TopicConfig
+	Secret      *types.NamespacedName
+	SecretVer   string
+	TrustBundle *contract.TrustBundleReference
+}
+
+// NewReconcileFastPath returns a ReconcileFastPath requiring a full reconciliation of every broker at least once per
+// maxAge.
+func NewReconcileFastPath(maxAge time.Duration) *ReconcileFastPath {
+	return &ReconcileFastPath{
+		maxAge:     maxAge,
+		now:        time.Now,
+		reconciled: make(map[types.UID]fastPathEntry),
+	}
+}
+
+// Topic returns the topic and the active bootstrap servers of the last full reconciliation of the given broker when it
+// has the given generation, inputs and contract resource, and it happened less than the max age ago.
+func (f *ReconcileFastPath) Topic(broker *eventing.Broker, inputs string, resource *contract.Resource) (string, []string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entry, ok := f.reconciled[broker.GetUID()]
+	if !ok {
+		return "", nil, false
+	}
+	if entry.generation != broker.GetGeneration() ||
+		entry.inputs != inputs ||
+		f.now().Sub(entry.time) >= f.maxAge ||
+		!proto.Equal(entry.resource, withoutEgresses(resource)) {
+		delete(f.reconciled, broker.GetUID())
+		return "", nil, false
+	}
+	return entry.topic, entry.bootstrapServers, true
+}
+
+// Record records a full reconciliation of the given broker against the Kafka cluster with the given bootstrap servers.
+func (f *ReconcileFastPath) Record(broker *eventing.Broker, inputs string, topic string, bootstrapServers []string, resource *contract.Resource) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reconciled[broker.GetUID()] = fastPathEntry{
+		generation: broker.GetGeneration(),
+		inputs:     inputs,
+		topic:      topic,
+		// The topic config is rewritten by every reconciliation.
+		bootstrapServers: append([]string(nil), bootstrapServers...),
+		resource:         withoutEgresses(resource),
+		time:             f.now(),
+	}
+}
+
+// Forget forgets the last full reconciliation of the given broker.
+func (f *ReconcileFastPath) Forget(uid types.UID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.reconciled, uid)
+}
+
+func withoutEgresses(resource *contract.Resource) *contract.Resource {
+	if resource == nil {
+		return nil
+	}
+	resource = proto.Clone(resource).(*contract.Resource)
+	resource.Egresses = nil
+	return resource
+}
+
+// fastPathInputsHash returns the hash of the inputs of the topic reconciliation of the given broker.
+func fastPathInputsHash(broker *eventing.Broker, brokerConfig *corev1.ConfigMap, topicConfig *kafka.TopicConfig, secret *corev1.Secret, trustBundleRef *contract.TrustBundleReference) (string, error) {
+	inputs := fastPathInputs{
+		Annotations: broker.GetAnnotations(),
+		Labels:      broker.GetLabels(),
+		Config:      brokerConfig.Data,
+		TopicConfig: topicConfig,
+		TrustBundle: trustBundleRef,
+	}
+	if secret != nil {
+		inputs.Secret = &types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}
+		inputs.SecretVer = secret.ResourceVersion
+	}
+	b, err := json.Marshal(inputs)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal reconcile inputs: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// fastPathTopic returns the topic and the active bootstrap servers of the given broker when its topic reconciliation can be skipped, that is when the
+// broker is ready, its inputs haven't changed since its last full reconciliation and the contract still has the
+// broker resource programmed by it. Any uncertainty leads to a full reconciliation.
+func (r *Reconciler) fastPathTopic(logger *zap.Logger, broker *eventing.Broker, contractConfigMap *corev1.ConfigMap, inputs string) (string, []string, bool) {
+	if r.FastPath == nil || inputs == "" || r.Env.TopicLagMetricsEnabled {
+		return "", nil, false
+	}
+	if broker.Status.ObservedGeneration != broker.GetGeneration() ||
+		!broker.IsReady() ||
+		!broker.Status.GetCondition(base.ConditionProbeSucceeded).IsTrue() {
+		r.FastPath.Forget(broker.GetUID())
+		return "", nil, false
+	}
+	// Pending topic deletions are only processed by full reconciliations.
+	if deletions, err := pendingTopicDeletions(contractConfigMap); err != nil || len(deletions) > 0 {
+		r.FastPath.Forget(broker.GetUID())
+		return "", nil, false
+	}
+	ct, err := r.GetDataPlaneConfigMapData(logger, contractConfigMap)
+	if err != nil {
+		r.FastPath.Forget(broker.GetUID())
+		return "", nil, false
+	}
+	// Adopted resources aren't looked up, the broker is reconciled again once it owns its resource.
+	brokerIndex := coreconfig.FindResource(ct, broker.GetUID())
+	if brokerIndex == coreconfig.NoResource {
+		r.FastPath.Forget(broker.GetUID())
+		return "", nil, false
+	}
+	return r.FastPath.Topic(broker, inputs, ct.Resources[brokerIndex])
+}
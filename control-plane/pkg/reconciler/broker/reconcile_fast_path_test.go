@@ -0,0 +1,147 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+)
+
+func TestReconcileFastPathTopic(t *testing.T) {
+	newBroker := func(generation int64) *eventing.Broker {
+		return &eventing.Broker{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "b", UID: "uid", Generation: generation}}
+	}
+	resource := &contract.Resource{Uid: "uid", Topics: []string{"topic"}}
+	withEgress := &contract.Resource{Uid: "uid", Topics: []string{"topic"}, Egresses: []*contract.Egress{{Uid: "trigger"}}}
+
+	tests := []struct {
+		name     string
+		broker   *eventing.Broker
+		inputs   string
+		resource *contract.Resource
+		elapsed  time.Duration
+		want     bool
+	}{
+		{
+			name:     "nothing changed",
+			broker:   newBroker(1),
+			inputs:   "inputs",
+			resource: resource,
+			elapsed:  time.Minute,
+			want:     true,
+		},
+		{
+			name:     "trigger added",
+			broker:   newBroker(1),
+			inputs:   "inputs",
+			resource: withEgress,
+			want:     true,
+		},
+		{
+			name:     "generation changed",
+			broker:   newBroker(2),
+			inputs:   "inputs",
+			resource: resource,
+		},
+		{
+			name:     "inputs changed",
+			broker:   newBroker(1),
+			inputs:   "other",
+			resource: resource,
+		},
+		{
+			name:     "contract resource changed",
+			broker:   newBroker(1),
+			inputs:   "inputs",
+			resource: &contract.Resource{Uid: "uid", Topics: []string{"other"}},
+		},
+		{
+			name:     "max age elapsed",
+			broker:   newBroker(1),
+			inputs:   "inputs",
+			resource: resource,
+			elapsed:  time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(0, 0)
+			fastPath := NewReconcileFastPath(time.Hour)
+			fastPath.now = func() time.Time { return now }
+			fastPath.Record(newBroker(1), "inputs", "topic", []string{"kafka-dr:9092"}, withEgress)
+
+			now = now.Add(tt.elapsed)
+			topic, bootstrapServers, ok := fastPath.Topic(tt.broker, tt.inputs, tt.resource)
+			assert.Equal(t, tt.want, ok)
+			if tt.want {
+				assert.Equal(t, "topic", topic)
+				assert.Equal(t, []string{"kafka-dr:9092"}, bootstrapServers)
+				return
+			}
+			// Misses require a full reconciliation to be recorded again.
+			_, _, ok = fastPath.Topic(newBroker(1), "inputs", resource)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestReconcileFastPathForget(t *testing.T) {
+	b := &eventing.Broker{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "b", UID: "uid", Generation: 1}}
+	resource := &contract.Resource{Uid: "uid"}
+
+	fastPath := NewReconcileFastPath(time.Hour)
+	fastPath.Record(b, "inputs", "topic", []string{"kafka:9092"}, resource)
+	fastPath.Forget(b.GetUID())
+
+	_, _, ok := fastPath.Topic(b, "inputs", resource)
+	assert.False(t, ok)
+}
+
+func TestFastPathInputsHash(t *testing.T) {
+	b := &eventing.Broker{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "b"}}
+	config := &corev1.ConfigMap{Data: map[string]string{"default.topic.partitions": "10"}}
+	topicConfig := &kafka.TopicConfig{BootstrapServers: []string{"kafka:9092"}}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "secret", ResourceVersion: "1"}}
+
+	hash, err := fastPathInputsHash(b, config, topicConfig, secret, nil)
+	require.NoError(t, err)
+
+	same, err := fastPathInputsHash(b.DeepCopy(), config.DeepCopy(), topicConfig, secret.DeepCopy(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, hash, same)
+
+	rotated := secret.DeepCopy()
+	rotated.ResourceVersion = "2"
+	other, err := fastPathInputsHash(b, config, topicConfig, rotated, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+
+	annotated := b.DeepCopy()
+	annotated.Annotations = map[string]string{kafka.TopicAnnotation: "topic"}
+	other, err = fastPathInputsHash(annotated, config, topicConfig, secret, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+)
+
+// ResourceMutator adjusts the contract resource built for the given broker before it's written to the contract, for
+// example, to add organization-specific cloud event extensions.
+//
+// Mutators can't change the fields identifying the broker resource and its Kafka topic, see validateMutatedResource.
+type ResourceMutator func(ctx context.Context, broker *eventing.Broker, resource *contract.Resource) error
+
+// ControllerOption configures the broker reconcilers created by NewController and NewNamespacedController.
+type ControllerOption func(o *controllerOptions)
+
+type controllerOptions struct {
+	resourceMutator ResourceMutator
+}
+
+// WithResourceMutator makes the broker reconciler call the given mutator with every broker contract resource.
+func WithResourceMutator(mutator ResourceMutator) ControllerOption {
+	return func(o *controllerOptions) {
+		o.resourceMutator = mutator
+	}
+}
+
+func newControllerOptions(opts []ControllerOption) *controllerOptions {
+	o := &controllerOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// mutateResource calls the ResourceMutator, when configured, with the given broker resource, the resource is left
+// untouched when the mutator fails or when it changes a required field.
+func (r *Reconciler) mutateResource(ctx context.Context, broker *eventing.Broker, resource *contract.Resource) (*contract.Resource, error) {
+	if r.ResourceMutator == nil {
+		return resource, nil
+	}
+
+	mutated := proto.Clone(resource).(*contract.Resource)
+	if err := r.ResourceMutator(ctx, broker, mutated); err != nil {
+		return nil, fmt.Errorf("failed to mutate contract resource: %w", err)
+	}
+	if err := validateMutatedResource(resource, mutated); err != nil {
+		return nil, fmt.Errorf("invalid contract resource mutation: %w", err)
+	}
+	return mutated, nil
+}
+
+// validateMutatedResource returns an error when the given mutated resource changes the fields the data plane relies
+// on to serve the broker, its UID, topics, bootstrap servers, auth, reference and ingress path and host.
+func validateMutatedResource(resource, mutated *contract.Resource) error {
+	if mutated.GetUid() != resource.GetUid() {
+		return fmt.Errorf("uid changed from %q to %q", resource.GetUid(), mutated.GetUid())
+	}
+	if !equalStrings(mutated.GetTopics(), resource.GetTopics()) {
+		return fmt.Errorf("topics changed from %v to %v", resource.GetTopics(), mutated.GetTopics())
+	}
+	if mutated.GetBootstrapServers() != resource.GetBootstrapServers() {
+		return fmt.Errorf("bootstrap servers changed from %q to %q", resource.GetBootstrapServers(), mutated.GetBootstrapServers())
+	}
+	if !proto.Equal(mutated.GetReference(), resource.GetReference()) {
+		return fmt.Errorf("reference changed")
+	}
+	if !proto.Equal(mutated.GetAuthSecret(), resource.GetAuthSecret()) || !proto.Equal(mutated.GetMultiAuthSecret(), resource.GetMultiAuthSecret()) ||
+		!proto.Equal(mutated.GetAbsentAuth(), resource.GetAbsentAuth()) {
+		return fmt.Errorf("auth changed")
+	}
+	if (resource.GetIngress() == nil) != (mutated.GetIngress() == nil) {
+		return fmt.Errorf("ingress added or removed")
+	}
+	if mutated.GetIngress().GetPath() != resource.GetIngress().GetPath() || mutated.GetIngress().GetHost() != resource.GetIngress().GetHost() {
+		return fmt.Errorf("ingress path or host changed")
+	}
+	return nil
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
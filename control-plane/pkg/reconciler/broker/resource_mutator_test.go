@@ -0,0 +1,131 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+)
+
+func TestMutateResource(t *testing.T) {
+	newResource := func() *contract.Resource {
+		return &contract.Resource{
+			Uid:              "uid",
+			Topics:           []string{"topic"},
+			BootstrapServers: "kafka-1:9092",
+			Ingress:          &contract.Ingress{Path: "/ns/name"},
+			Reference:        &contract.Reference{Uuid: "uid", Namespace: "ns", Name: "name"},
+			Auth:             &contract.Resource_AbsentAuth{AbsentAuth: &contract.Empty{}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutator ResourceMutator
+		want    *contract.Resource
+		wantErr bool
+	}{
+		{
+			name: "no mutator",
+			want: newResource(),
+		},
+		{
+			name: "optional fields",
+			mutator: func(_ context.Context, _ *eventing.Broker, resource *contract.Resource) error {
+				resource.CloudEventOverrides = &contract.CloudEventOverrides{Extensions: map[string]string{"tenant": "a"}}
+				resource.ProducerAcks = contract.ProducerAcks_AllAcks
+				return nil
+			},
+			want: func() *contract.Resource {
+				r := newResource()
+				r.CloudEventOverrides = &contract.CloudEventOverrides{Extensions: map[string]string{"tenant": "a"}}
+				r.ProducerAcks = contract.ProducerAcks_AllAcks
+				return r
+			}(),
+		},
+		{
+			name: "mutator error",
+			mutator: func(context.Context, *eventing.Broker, *contract.Resource) error {
+				return errors.New("failed")
+			},
+			wantErr: true,
+		},
+		{
+			name: "topics changed",
+			mutator: func(_ context.Context, _ *eventing.Broker, resource *contract.Resource) error {
+				resource.Topics = append(resource.Topics, "other")
+				return nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "bootstrap servers changed",
+			mutator: func(_ context.Context, _ *eventing.Broker, resource *contract.Resource) error {
+				resource.BootstrapServers = "kafka-2:9092"
+				return nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "auth changed",
+			mutator: func(_ context.Context, _ *eventing.Broker, resource *contract.Resource) error {
+				resource.Auth = &contract.Resource_AuthSecret{AuthSecret: &contract.Reference{Namespace: "ns", Name: "secret"}}
+				return nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "ingress removed",
+			mutator: func(_ context.Context, _ *eventing.Broker, resource *contract.Resource) error {
+				resource.Ingress = nil
+				return nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "ingress path changed",
+			mutator: func(_ context.Context, _ *eventing.Broker, resource *contract.Resource) error {
+				resource.Ingress.Path = "/other"
+				return nil
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{ResourceMutator: tt.mutator}
+			resource := newResource()
+
+			got, err := r.mutateResource(context.Background(), &eventing.Broker{}, resource)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, proto.Equal(newResource(), resource), "expected the resource to be left untouched")
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tt.want, got), "want %v got %v", tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/kmeta"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+)
+
+// SecretChangeThrottle coalesces the changes of each secret made within an interval into a single change, so that a
+// credential rotation updating a secret multiple times reconciles the brokers sharing it once.
+type SecretChangeThrottle struct {
+	interval  time.Duration
+	onChanged func(obj interface{})
+	afterFunc func(d time.Duration, f func())
+
+	mu sync.Mutex
+	// pending are the latest changed secrets whose change hasn't been handled yet.
+	pending map[types.NamespacedName]interface{}
+}
+
+// NewSecretChangeThrottle returns a SecretChangeThrottle calling onChanged with the latest version of each changed
+// secret, interval after its first change.
+func NewSecretChangeThrottle(interval time.Duration, onChanged func(obj interface{})) *SecretChangeThrottle {
+	return &SecretChangeThrottle{
+		interval:  interval,
+		onChanged: onChanged,
+		afterFunc: func(d time.Duration, f func()) { time.AfterFunc(d, f) },
+		pending:   make(map[types.NamespacedName]interface{}),
+	}
+}
+
+// OnChanged records a change of the given secret, it's meant to be used as an informer event handler.
+func (t *SecretChangeThrottle) OnChanged(obj interface{}) {
+	accessor, err := kmeta.DeletionHandlingAccessor(obj)
+	if err != nil {
+		t.onChanged(obj)
+		return
+	}
+	key := types.NamespacedName{Namespace: accessor.GetNamespace(), Name: accessor.GetName()}
+
+	t.mu.Lock()
+	_, scheduled := t.pending[key]
+	t.pending[key] = obj
+	t.mu.Unlock()
+
+	if !scheduled {
+		t.afterFunc(t.interval, func() { t.flush(key) })
+	}
+}
+
+func (t *SecretChangeThrottle) flush(key types.NamespacedName) {
+	t.mu.Lock()
+	obj, ok := t.pending[key]
+	delete(t.pending, key)
+	t.mu.Unlock()
+
+	if ok {
+		t.onChanged(obj)
+	}
+}
+
+// newSecretChangeHandler returns the handler of secret changes, it closes the pooled Kafka cluster admin clients
+// created with the changed secret, once for all the brokers sharing it, and then calls onChanged, usually the tracker
+// reconciling the brokers referencing the secret.
+//
+// Changes are coalesced by a SecretChangeThrottle when the given interval is positive.
+func newSecretChangeHandler(pool *kafka.ClusterAdminPool, onChanged func(obj interface{}), interval time.Duration) func(obj interface{}) {
+	handler := onChanged
+	if pool != nil {
+		handler = func(obj interface{}) {
+			if accessor, err := kmeta.DeletionHandlingAccessor(obj); err == nil {
+				pool.InvalidateSecret(accessor.GetNamespace(), accessor.GetName())
+			}
+			onChanged(obj)
+		}
+	}
+	if interval <= 0 {
+		return handler
+	}
+	return NewSecretChangeThrottle(interval, handler).OnChanged
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	kafkatesting "knative.dev/eventing-kafka-broker/control-plane/pkg/kafka/testing"
+)
+
+func TestSecretChangeThrottle(t *testing.T) {
+	var scheduled []func()
+	var changed []interface{}
+	throttle := NewSecretChangeThrottle(time.Minute, func(obj interface{}) {
+		changed = append(changed, obj)
+	})
+	throttle.afterFunc = func(_ time.Duration, f func()) { scheduled = append(scheduled, f) }
+
+	newSecret := func(name, version string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, ResourceVersion: version}}
+	}
+
+	throttle.OnChanged(newSecret("s1", "1"))
+	throttle.OnChanged(newSecret("s1", "2"))
+	throttle.OnChanged(newSecret("s2", "1"))
+	require.Len(t, scheduled, 2)
+	require.Empty(t, changed)
+
+	for _, f := range scheduled {
+		f()
+	}
+	require.Equal(t, []interface{}{newSecret("s1", "2"), newSecret("s2", "1")}, changed)
+
+	throttle.OnChanged(newSecret("s1", "3"))
+	require.Len(t, scheduled, 3)
+}
+
+func TestSecretChangeHandlerSharedSecretRotation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const brokers = 100
+	addrs := []string{"kafka-1:9092"}
+
+	var created []*kafkatesting.MockKafkaClusterAdmin
+	pool := kafka.NewClusterAdminPool(ctx, func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+		admin := &kafkatesting.MockKafkaClusterAdmin{T: t}
+		created = append(created, admin)
+		return admin, nil
+	}, 10, time.Hour, nil)
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "credentials", ResourceVersion: "1"}}
+
+	// reconcileBrokers simulates the tracker reconciling every broker referencing the secret.
+	reconciles := 0
+	reconcileBrokers := func(obj interface{}) {
+		for i := 0; i < brokers; i++ {
+			reconciles++
+			admin, err := pool.Get(addrs, obj.(*corev1.Secret), nil, sarama.NewConfig())
+			require.NoError(t, err)
+			require.NoError(t, admin.Close())
+		}
+	}
+	reconcileBrokers(secret)
+	require.Len(t, created, 1)
+
+	var scheduled []func()
+	throttle := NewSecretChangeThrottle(time.Minute, newSecretChangeHandler(pool, reconcileBrokers, 0))
+	throttle.afterFunc = func(_ time.Duration, f func()) { scheduled = append(scheduled, f) }
+
+	// The rotation updates the secret multiple times, for example, the password and then the username.
+	for version := 2; version <= 4; version++ {
+		rotated := secret.DeepCopy()
+		rotated.ResourceVersion = strconv.Itoa(version)
+		throttle.OnChanged(rotated)
+	}
+	require.Len(t, scheduled, 1)
+	scheduled[0]()
+
+	require.Equal(t, 2*brokers, reconciles, "expected brokers to be reconciled once per rotation")
+	require.Len(t, created, 2, "expected a single client to be created for the rotated secret")
+	require.True(t, created[0].ExpectedClose, "expected the client of the previous secret to be closed")
+	require.Equal(t, 1, pool.Len())
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"knative.dev/pkg/logging"
+
+	coreconfig "knative.dev/eventing-kafka-broker/control-plane/pkg/core/config"
+)
+
+// runStaleContractResourcesSweep removes, once the given brokers informer has synced, the contract resources of the
+// brokers deleted while the controller wasn't running, since their finalization never happened.
+func (r *Reconciler) runStaleContractResourcesSweep(ctx context.Context, brokersSynced cache.InformerSynced) {
+	logger := logging.FromContext(ctx).Desugar()
+	if !cache.WaitForCacheSync(ctx.Done(), brokersSynced) {
+		return
+	}
+
+	pruned, err := r.sweepStaleContractResources(ctx, logger)
+	if err != nil {
+		logger.Warn("Failed to sweep stale contract resources", zap.Int("pruned", pruned), zap.Error(err))
+		return
+	}
+	logger.Info("Swept stale contract resources", zap.Int("pruned", pruned))
+}
+
+// sweepStaleContractResources removes the contract resources whose broker doesn't exist, it returns the number of
+// resources removed.
+func (r *Reconciler) sweepStaleContractResources(ctx context.Context, logger *zap.Logger) (int, error) {
+	pruned := 0
+	for shard := 0; shard < r.ContractConfigMapShardCount(); shard++ {
+		n, err := r.sweepShardStaleContractResources(ctx, logger, shard)
+		pruned += n
+		if err != nil {
+			return pruned, err
+		}
+	}
+	return pruned, nil
+}
+
+func (r *Reconciler) sweepShardStaleContractResources(ctx context.Context, logger *zap.Logger, shard int) (int, error) {
+	pruned := 0
+	var generation uint64
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pruned = 0
+
+		contractConfigMap, err := r.GetOrCreateDataPlaneConfigMapShard(ctx, shard)
+		if err != nil {
+			return fmt.Errorf("failed to get contract config map %s/%s: %w", r.Reconciler.DataPlaneConfigMapNamespace, r.ContractConfigMapShardName(shard), err)
+		}
+		ct, err := r.GetDataPlaneConfigMapData(logger, contractConfigMap)
+		if err != nil {
+			return fmt.Errorf("failed to get contract: %w", err)
+		}
+
+		// Brokers are listed after the contract is read, so that the resources added to the contract in the meantime
+		// by the reconciliation of new brokers belong to listed brokers.
+		brokers, err := r.BrokerLister.List(labels.Everything())
+		if err != nil {
+			return fmt.Errorf("failed to list brokers: %w", err)
+		}
+		uids := sets.NewString()
+		for _, broker := range brokers {
+			uids.Insert(string(broker.GetUID()))
+		}
+
+		for i := len(ct.Resources) - 1; i >= 0; i-- {
+			resource := ct.Resources[i]
+			if resource.GetUid() == "" || uids.Has(resource.GetUid()) {
+				continue
+			}
+			logger.Info("Removing stale contract resource",
+				zap.String("uid", resource.GetUid()),
+				zap.String("namespace", resource.GetReference().GetNamespace()),
+				zap.String("name", resource.GetReference().GetName()),
+			)
+			coreconfig.DeleteResource(ct, i)
+			pruned++
+		}
+		if pruned == 0 {
+			return nil
+		}
+
+		coreconfig.IncrementContractGeneration(ct)
+		if err := r.UpdateDataPlaneConfigMap(ctx, ct, contractConfigMap); err != nil {
+			return err
+		}
+		generation = ct.Generation
+		return nil
+	})
+	if err != nil || pruned == 0 {
+		return 0, err
+	}
+
+	if err := r.UpdateReceiverPodsShardAnnotation(ctx, logger, shard, generation); err != nil {
+		return pruned, err
+	}
+	if err := r.UpdateDispatcherPodsShardAnnotation(ctx, logger, shard, generation); err != nil {
+		return pruned, err
+	}
+	return pruned, nil
+}
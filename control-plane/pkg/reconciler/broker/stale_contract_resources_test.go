@@ -0,0 +1,95 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
+)
+
+func TestSweepStaleContractResources(t *testing.T) {
+	ct := &contract.Contract{
+		Generation: 3,
+		Resources: []*contract.Resource{
+			{Uid: "b1", Reference: &contract.Reference{Namespace: "ns", Name: "b1"}},
+			{Uid: "deleted-1", Reference: &contract.Reference{Namespace: "ns", Name: "deleted-1"}},
+			{Uid: "b2", Reference: &contract.Reference{Namespace: "ns", Name: "b2"}},
+			{Uid: "deleted-2", Reference: &contract.Reference{Namespace: "ns", Name: "deleted-2"}},
+		},
+	}
+	data, err := protojson.Marshal(ct)
+	require.NoError(t, err)
+	kubeClient := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "knative-eventing", Name: "kafka-broker-brokers-triggers"},
+		BinaryData: map[string][]byte{base.ConfigMapDataKey: data},
+	})
+
+	brokers := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, name := range []string{"b1", "b2"} {
+		require.NoError(t, brokers.Add(&eventing.Broker{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, UID: types.UID(name)}}))
+	}
+
+	r := &Reconciler{
+		Reconciler: &base.Reconciler{
+			KubeClient:                  kubeClient,
+			PodLister:                   corelisters.NewPodLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+			DataPlaneConfigMapNamespace: "knative-eventing",
+			ContractConfigMapName:       "kafka-broker-brokers-triggers",
+			ContractConfigMapFormat:     base.Json,
+			DataPlaneNamespace:          "knative-eventing",
+			ReceiverLabel:               base.BrokerReceiverLabel,
+			DispatcherLabel:             base.BrokerDispatcherLabel,
+		},
+		BrokerLister: eventinglisters.NewBrokerLister(brokers),
+	}
+
+	pruned, err := r.sweepStaleContractResources(context.Background(), zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, 2, pruned)
+
+	cm, err := kubeClient.CoreV1().ConfigMaps("knative-eventing").Get(context.Background(), "kafka-broker-brokers-triggers", metav1.GetOptions{})
+	require.NoError(t, err)
+	got := &contract.Contract{}
+	require.NoError(t, protojson.Unmarshal(cm.BinaryData[base.ConfigMapDataKey], got))
+	assert.Equal(t, uint64(4), got.Generation)
+	var uids []string
+	for _, resource := range got.Resources {
+		uids = append(uids, resource.Uid)
+	}
+	assert.ElementsMatch(t, []string{"b1", "b2"}, uids)
+
+	// Nothing is written when there are no stale resources.
+	pruned, err = r.sweepStaleContractResources(context.Background(), zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, 0, pruned)
+}
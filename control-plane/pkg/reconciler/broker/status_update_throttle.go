@@ -0,0 +1,114 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+)
+
+// StatusUpdateThrottle enforces a minimum interval between the status updates of each broker, so that brokers whose
+// probes flap don't generate a storm of status updates.
+type StatusUpdateThrottle struct {
+	interval time.Duration
+	now      func() time.Time
+
+	mu sync.Mutex
+	// lastUpdates are the times of the last status update of each broker.
+	lastUpdates map[types.UID]time.Time
+}
+
+// NewStatusUpdateThrottle returns a StatusUpdateThrottle allowing a status update per broker every interval.
+func NewStatusUpdateThrottle(interval time.Duration) *StatusUpdateThrottle {
+	return &StatusUpdateThrottle{
+		interval:    interval,
+		now:         time.Now,
+		lastUpdates: make(map[types.UID]time.Time),
+	}
+}
+
+// Reserve returns the delay before the status of the given broker can be updated, when the status can be updated
+// right away it's recorded as updated.
+func (t *StatusUpdateThrottle) Reserve(uid types.UID) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if last, ok := t.lastUpdates[uid]; ok {
+		if delay := last.Add(t.interval).Sub(now); delay > 0 {
+			return delay
+		}
+	}
+	t.lastUpdates[uid] = now
+	return 0
+}
+
+// Record records a status update of the given broker that wasn't throttled.
+func (t *StatusUpdateThrottle) Record(uid types.UID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.lastUpdates[uid] = t.now()
+}
+
+// Forget forgets the status updates of the given broker.
+func (t *StatusUpdateThrottle) Forget(uid types.UID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.lastUpdates, uid)
+}
+
+// throttleStatusUpdate reverts the status changes made to the given broker by a reconciliation when its status was
+// updated less than StatusUpdateMinInterval ago, the broker is then requeued so that its latest status is written once
+// the interval elapsed.
+//
+// Status changes of brokers whose spec changed aren't throttled, so that spec changes are reflected right away.
+func (r *Reconciler) throttleStatusUpdate(ctx context.Context, broker *eventing.Broker, status *eventing.BrokerStatus, event reconciler.Event) reconciler.Event {
+	if r.StatusUpdateThrottle == nil || equality.Semantic.DeepEqual(status, &broker.Status) {
+		return event
+	}
+	if broker.Status.ObservedGeneration != broker.GetGeneration() {
+		r.StatusUpdateThrottle.Record(broker.GetUID())
+		return event
+	}
+
+	delay := r.StatusUpdateThrottle.Reserve(broker.GetUID())
+	if delay == 0 {
+		return event
+	}
+
+	logging.FromContext(ctx).Desugar().Debug("Status update throttled", zap.Duration("delay", delay))
+	broker.Status = *status
+
+	// Errors other than requeues are retried before the delay anyway, and shorter requeues are preserved.
+	if event != nil {
+		if ok, requeueDelay := controller.IsRequeueKey(event); !ok || requeueDelay < delay {
+			return event
+		}
+	}
+	return controller.NewRequeueAfter(delay)
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+func TestStatusUpdateThrottleReserve(t *testing.T) {
+	now := time.Unix(0, 0)
+	throttle := NewStatusUpdateThrottle(time.Minute)
+	throttle.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), throttle.Reserve("b1"))
+	now = now.Add(20 * time.Second)
+	assert.Equal(t, 40*time.Second, throttle.Reserve("b1"))
+	assert.Equal(t, time.Duration(0), throttle.Reserve("b2"))
+
+	now = now.Add(40 * time.Second)
+	assert.Equal(t, time.Duration(0), throttle.Reserve("b1"))
+
+	throttle.Forget("b1")
+	assert.Equal(t, time.Duration(0), throttle.Reserve("b1"))
+}
+
+func TestThrottleStatusUpdate(t *testing.T) {
+	newBroker := func() *eventing.Broker {
+		b := &eventing.Broker{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "b", UID: "uid", Generation: 1}}
+		b.Status.ObservedGeneration = 1
+		return b
+	}
+	reconcile := func(b *eventing.Broker) {
+		b.Status.SetConditions(apis.Conditions{{Type: apis.ConditionReady, Status: "False"}})
+	}
+
+	tests := []struct {
+		name        string
+		event       error
+		spec        bool
+		wantStatus  bool
+		wantRequeue time.Duration
+		wantErr     error
+	}{
+		{
+			name:        "throttled",
+			wantRequeue: time.Minute,
+		},
+		{
+			name:        "throttled with a longer requeue",
+			event:       controller.NewRequeueAfter(time.Hour),
+			wantRequeue: time.Minute,
+		},
+		{
+			name:        "throttled with a shorter requeue",
+			event:       controller.NewRequeueAfter(time.Second),
+			wantRequeue: time.Second,
+		},
+		{
+			name:    "throttled with an error",
+			event:   errors.New("failed"),
+			wantErr: errors.New("failed"),
+		},
+		{
+			name:       "spec changed",
+			spec:       true,
+			wantStatus: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(0, 0)
+			r := &Reconciler{StatusUpdateThrottle: NewStatusUpdateThrottle(time.Minute)}
+			r.StatusUpdateThrottle.now = func() time.Time { return now }
+			r.StatusUpdateThrottle.Record("uid")
+
+			b := newBroker()
+			if tt.spec {
+				b.Generation = 2
+			}
+			status := b.Status.DeepCopy()
+			reconcile(b)
+
+			event := r.throttleStatusUpdate(context.Background(), b, status, tt.event)
+
+			assert.Equal(t, tt.wantStatus, b.Status.GetCondition(apis.ConditionReady) != nil)
+			if tt.wantRequeue > 0 {
+				requeue, delay := controller.IsRequeueKey(event)
+				assert.True(t, requeue)
+				assert.Equal(t, tt.wantRequeue, delay)
+			} else {
+				assert.Equal(t, tt.wantErr, event)
+			}
+		})
+	}
+
+	t.Run("not throttled", func(t *testing.T) {
+		r := &Reconciler{StatusUpdateThrottle: NewStatusUpdateThrottle(time.Minute)}
+		b := newBroker()
+		status := b.Status.DeepCopy()
+		reconcile(b)
+
+		assert.Nil(t, r.throttleStatusUpdate(context.Background(), b, status, nil))
+		assert.NotNil(t, b.Status.GetCondition(apis.ConditionReady))
+	})
+}
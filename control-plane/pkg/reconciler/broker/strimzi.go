@@ -0,0 +1,115 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/security"
+)
+
+const (
+	// StrimziKafkaAnnotation for connecting the broker to the Kafka cluster of the referenced Strimzi Kafka resource,
+	// as [namespace/]name, the bootstrap servers and the security config are resolved from the Strimzi resources
+	// instead of the broker config. It requires StrimziIntegrationEnabled.
+	StrimziKafkaAnnotation = "kafka.eventing.knative.dev/strimzi.kafka"
+
+	// StrimziListenerAnnotation for choosing the listener of the Strimzi Kafka resource used to connect to the
+	// cluster, the first listener is used by default
+	StrimziListenerAnnotation = "kafka.eventing.knative.dev/strimzi.listener"
+
+	// StrimziUserAnnotation for authenticating to the Kafka cluster with the credentials of the referenced Strimzi
+	// KafkaUser resource, in the namespace of the Strimzi Kafka resource
+	StrimziUserAnnotation = "kafka.eventing.knative.dev/strimzi.user"
+)
+
+// strimziClusterConfig resolves the config of the Strimzi Kafka cluster referenced by the StrimziKafkaAnnotation of
+// the given broker, it returns nil when the broker doesn't reference a Strimzi Kafka cluster.
+func (r *Reconciler) strimziClusterConfig(ctx context.Context, broker *eventing.Broker) (*security.StrimziClusterConfig, error) {
+	ref, ok := broker.GetAnnotations()[StrimziKafkaAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	if !r.Env.StrimziIntegrationEnabled || r.DynamicClient == nil {
+		return nil, fmt.Errorf("the %s annotation requires the Strimzi integration, which is disabled", StrimziKafkaAnnotation)
+	}
+
+	cluster := security.StrimziCluster{
+		Namespace: broker.GetNamespace(),
+		Name:      ref,
+		Listener:  broker.GetAnnotations()[StrimziListenerAnnotation],
+		User:      broker.GetAnnotations()[StrimziUserAnnotation],
+	}
+	if namespace, name, ok := strings.Cut(ref, "/"); ok {
+		cluster.Namespace, cluster.Name = namespace, name
+	}
+	if cluster.Name == "" {
+		return nil, fmt.Errorf("invalid %s annotation value %q: expected [namespace/]name", StrimziKafkaAnnotation, ref)
+	}
+	return security.ResolveStrimziCluster(ctx, r.DynamicClient, r.SecretLister, cluster)
+}
+
+// withStrimziBootstrapServers returns the given broker config with the bootstrap servers of the given Strimzi Kafka
+// cluster config, if any.
+func withStrimziBootstrapServers(brokerConfig *corev1.ConfigMap, strimziConfig *security.StrimziClusterConfig) *corev1.ConfigMap {
+	if brokerConfig == nil || strimziConfig == nil {
+		return brokerConfig
+	}
+	brokerConfig = brokerConfig.DeepCopy()
+	if brokerConfig.Data == nil {
+		brokerConfig.Data = make(map[string]string, 1)
+	}
+	brokerConfig.Data[kafka.BootstrapServersConfigMapKey] = strimziConfig.BootstrapServers
+	return brokerConfig
+}
+
+// trackStrimziSecrets tracks the secrets referenced by the given Strimzi Kafka cluster config, so that the broker is
+// reconciled again when Strimzi rotates the certificates.
+func (r *Reconciler) trackStrimziSecrets(broker *eventing.Broker, strimziConfig *security.StrimziClusterConfig) error {
+	for _, ref := range strimziConfig.AuthContext.MultiSecretReference.GetReferences() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace: ref.GetReference().GetNamespace(),
+			Name:      ref.GetReference().GetName(),
+		}}
+		if err := r.TrackSecret(secret, broker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setStrimziAuth sets the auth of the given broker resource to the secrets referenced by the given Strimzi Kafka
+// cluster config, if any.
+func setStrimziAuth(resource *contract.Resource, strimziConfig *security.StrimziClusterConfig) {
+	if strimziConfig == nil {
+		return
+	}
+	resource.Auth = nil
+	if len(strimziConfig.AuthContext.MultiSecretReference.GetReferences()) > 0 {
+		resource.Auth = &contract.Resource_MultiAuthSecret{
+			MultiAuthSecret: strimziConfig.AuthContext.MultiSecretReference,
+		}
+	}
+}
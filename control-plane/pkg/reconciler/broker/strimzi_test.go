@@ -0,0 +1,78 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/security"
+)
+
+func TestStrimziClusterConfigDisabled(t *testing.T) {
+	r := &Reconciler{Env: &config.Env{}}
+
+	got, err := r.strimziClusterConfig(context.Background(), &eventing.Broker{})
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	broker := &eventing.Broker{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "ns",
+		Name:        "b",
+		Annotations: map[string]string{StrimziKafkaAnnotation: "kafka/my-cluster"},
+	}}
+	_, err = r.strimziClusterConfig(context.Background(), broker)
+	assert.Error(t, err)
+}
+
+func TestWithStrimziBootstrapServers(t *testing.T) {
+	brokerConfig := &corev1.ConfigMap{Data: map[string]string{
+		kafka.BootstrapServersConfigMapKey: "kafka-1:9092",
+		"default.topic.partitions":         "10",
+	}}
+	strimziConfig := &security.StrimziClusterConfig{BootstrapServers: "my-cluster-kafka-bootstrap.kafka:9093"}
+
+	got := withStrimziBootstrapServers(brokerConfig, strimziConfig)
+	assert.Equal(t, "my-cluster-kafka-bootstrap.kafka:9093", got.Data[kafka.BootstrapServersConfigMapKey])
+	assert.Equal(t, "10", got.Data["default.topic.partitions"])
+	assert.Equal(t, "kafka-1:9092", brokerConfig.Data[kafka.BootstrapServersConfigMapKey], "broker config modified")
+
+	assert.Same(t, brokerConfig, withStrimziBootstrapServers(brokerConfig, nil))
+}
+
+func TestSetStrimziAuth(t *testing.T) {
+	references := &contract.MultiSecretReference{
+		Protocol:   contract.Protocol_SSL,
+		References: []*contract.SecretReference{{Reference: &contract.Reference{Namespace: "kafka", Name: "my-user"}}},
+	}
+	resource := &contract.Resource{Auth: &contract.Resource_AuthSecret{AuthSecret: &contract.Reference{Name: "strimzi.my-cluster.my-user"}}}
+
+	setStrimziAuth(resource, &security.StrimziClusterConfig{AuthContext: &security.NetSpecAuthContext{MultiSecretReference: references}})
+	assert.Equal(t, &contract.Resource_MultiAuthSecret{MultiAuthSecret: references}, resource.Auth)
+
+	setStrimziAuth(resource, &security.StrimziClusterConfig{AuthContext: &security.NetSpecAuthContext{MultiSecretReference: &contract.MultiSecretReference{}}})
+	assert.Nil(t, resource.Auth)
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+)
+
+const (
+	// TopicConfigStatusAnnotation is the status annotation recording the topic config entries last applied to the
+	// broker topic, it's set when the broker config sets topic configs or enables the
+	// kafka.TopicConfigEnforceConfigMapKey enforce mode.
+	TopicConfigStatusAnnotation = "topic.config"
+)
+
+// alterBrokerTopicConfig makes sure that the config of the broker topic matches the desired one.
+//
+// A topic config differing from a config entry that didn't change since it was last applied, as recorded in the
+// TopicConfigStatusAnnotation status annotation, has been changed externally. In enforce mode, such drift is reverted
+// and reported with an event, otherwise it's only reported and just the config entries whose desired value changed
+// are applied, all of them when no config has been recorded yet.
+func (r *Reconciler) alterBrokerTopicConfig(recorder record.EventRecorder, logger *zap.Logger, admin sarama.ClusterAdmin, broker *eventing.Broker, topic string, topicConfig *kafka.TopicConfig) error {
+	discrepancies, err := kafka.TopicConfigEntriesDiscrepancies(admin, topic, topicConfig)
+	if err != nil {
+		return err
+	}
+
+	previous, recorded := recordedTopicConfig(broker)
+	var changed, drift []kafka.Discrepancy
+	for _, d := range discrepancies {
+		if v, ok := previous[d.ConfigEntry]; recorded && ok && v == d.Desired {
+			drift = append(drift, d)
+		} else {
+			changed = append(changed, d)
+		}
+	}
+
+	alter := changed
+	if topicConfig.EnforceConfig {
+		alter = discrepancies
+	}
+	if err := kafka.AlterTopicConfigEntries(admin, logger, topic, alter); err != nil {
+		return err
+	}
+
+	if len(drift) > 0 {
+		descriptions := make([]string, 0, len(drift))
+		for _, d := range drift {
+			descriptions = append(descriptions, d.String())
+		}
+		if topicConfig.EnforceConfig {
+			logger.Warn("Topic config drift corrected", zap.String("topic", topic), zap.Strings("drift", descriptions))
+			recorder.Eventf(broker, corev1.EventTypeWarning, "TopicConfigDriftCorrected",
+				"Reverted external changes to the config of topic %s: %s", topic, strings.Join(descriptions, ", "))
+		} else {
+			logger.Warn("Topic config drift detected", zap.String("topic", topic), zap.Strings("drift", descriptions))
+			recorder.Eventf(broker, corev1.EventTypeWarning, "TopicConfigDriftDetected",
+				"External changes to the config of topic %s aren't reverted since %s isn't enabled: %s",
+				topic, kafka.TopicConfigEnforceConfigMapKey, strings.Join(descriptions, ", "))
+		}
+	}
+
+	if !topicConfig.EnforceConfig && len(topicConfig.TopicDetail.ConfigEntries) == 0 {
+		delete(broker.Status.Annotations, TopicConfigStatusAnnotation)
+		return nil
+	}
+	if broker.Status.Annotations == nil {
+		broker.Status.Annotations = make(map[string]string, 1)
+	}
+	broker.Status.Annotations[TopicConfigStatusAnnotation] = topicConfigStatus(topicConfig)
+	return nil
+}
+
+// recordedTopicConfig returns the topic config entries recorded in the TopicConfigStatusAnnotation status annotation
+// of the given broker, and whether they have been recorded.
+func recordedTopicConfig(broker *eventing.Broker) (map[string]string, bool) {
+	status, ok := broker.Status.Annotations[TopicConfigStatusAnnotation]
+	if !ok {
+		return nil, false
+	}
+	var entries map[string]string
+	if err := json.Unmarshal([]byte(status), &entries); err != nil {
+		return nil, false
+	}
+	return entries, true
+}
+
+// topicConfigStatus returns the TopicConfigStatusAnnotation value of the given topic config, the JSON object of its
+// config entries.
+func topicConfigStatus(topicConfig *kafka.TopicConfig) string {
+	entries := make(map[string]string, len(topicConfig.TopicDetail.ConfigEntries))
+	for k, v := range topicConfig.TopicDetail.ConfigEntries {
+		if v != nil {
+			entries[k] = *v
+		}
+	}
+	// Maps are marshalled with sorted keys, so equal entries have the same status.
+	status, _ := json.Marshal(entries)
+	return string(status)
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/controller"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/reconciler/base"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/security"
+)
+
+const (
+	// PendingTopicDeletionsAnnotation is the contract config map annotation that tracks the broker topics whose
+	// deletion is deferred until the topic deletion grace period elapses.
+	PendingTopicDeletionsAnnotation = "kafka.eventing.knative.dev/pending.topic.deletions"
+)
+
+// pendingTopicDeletion is a broker topic whose deletion has been deferred.
+type pendingTopicDeletion struct {
+	// DeletionTimestamp is the time when the broker owning the topic has been finalized.
+	DeletionTimestamp metav1.Time `json:"deletionTimestamp"`
+	// BootstrapServers are the bootstrap servers of the Kafka cluster hosting the topic.
+	BootstrapServers []string `json:"bootstrapServers"`
+	// Secret is the auth secret used to connect to the Kafka cluster, if any.
+	Secret *types.NamespacedName `json:"secret,omitempty"`
+	// Secrets are the auth secrets used to connect to the Kafka cluster when the broker config references multiple
+	// auth secrets.
+	Secrets []types.NamespacedName `json:"secrets,omitempty"`
+	// SecretFinalizer is the finalizer of the broker on the auth secrets, it's removed once the topic is deleted.
+	SecretFinalizer string `json:"secretFinalizer,omitempty"`
+}
+
+// pendingTopicDeletions returns the pending topic deletions tracked in the given contract config map, keyed by topic.
+func pendingTopicDeletions(cm *corev1.ConfigMap) (map[string]pendingTopicDeletion, error) {
+	deletions := make(map[string]pendingTopicDeletion)
+	value, ok := cm.Annotations[PendingTopicDeletionsAnnotation]
+	if !ok || value == "" {
+		return deletions, nil
+	}
+	if err := json.Unmarshal([]byte(value), &deletions); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation %s: %w", PendingTopicDeletionsAnnotation, err)
+	}
+	return deletions, nil
+}
+
+// setPendingTopicDeletions stores the given pending topic deletions in the given contract config map.
+func setPendingTopicDeletions(cm *corev1.ConfigMap, deletions map[string]pendingTopicDeletion) error {
+	if len(deletions) == 0 {
+		delete(cm.Annotations, PendingTopicDeletionsAnnotation)
+		return nil
+	}
+	value, err := json.Marshal(deletions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending topic deletions: %w", err)
+	}
+	if cm.Annotations == nil {
+		cm.Annotations = make(map[string]string, 1)
+	}
+	cm.Annotations[PendingTopicDeletionsAnnotation] = string(value)
+	return nil
+}
+
+// isTopicDeletionGracePeriodElapsed returns true when the given pending deletion can be carried out.
+func isTopicDeletionGracePeriodElapsed(deletion pendingTopicDeletion, gracePeriod time.Duration, now time.Time) bool {
+	return !now.Before(deletion.DeletionTimestamp.Add(gracePeriod))
+}
+
+// scheduleBrokerTopicDeletion defers the deletion of the topic of the given broker until the topic deletion grace
+// period elapses.
+func (r *Reconciler) scheduleBrokerTopicDeletion(ctx context.Context, logger *zap.Logger, broker *eventing.Broker, secrets []*corev1.Secret, topicConfig *kafka.TopicConfig) error {
+	topicName, err := r.finalizedBrokerTopicName(broker)
+	if err != nil {
+		return err
+	}
+
+	// Deletions are tracked in the contract shard of the broker, so that a broker created again with the same name
+	// finds them.
+	contractConfigMap, err := r.GetOrCreateDataPlaneConfigMapShard(ctx, r.ContractConfigMapShard(broker))
+	if err != nil {
+		return fmt.Errorf("failed to get contract config map %s: %w", r.DataPlaneConfigMapAsString(), err)
+	}
+
+	deletions, err := pendingTopicDeletions(contractConfigMap)
+	if err != nil {
+		return err
+	}
+	if _, ok := deletions[topicName]; ok {
+		return nil
+	}
+
+	deletion := pendingTopicDeletion{
+		DeletionTimestamp: metav1.Now(),
+		BootstrapServers:  topicConfig.BootstrapServers,
+	}
+	if len(secrets) == 1 {
+		deletion.Secret = &types.NamespacedName{Namespace: secrets[0].GetNamespace(), Name: secrets[0].GetName()}
+	} else {
+		for _, secret := range secrets {
+			deletion.Secrets = append(deletion.Secrets, types.NamespacedName{Namespace: secret.GetNamespace(), Name: secret.GetName()})
+		}
+	}
+	if len(secrets) > 0 {
+		deletion.SecretFinalizer = r.finalizerSecret(broker)
+	}
+	deletions[topicName] = deletion
+
+	if err := r.updatePendingTopicDeletions(ctx, contractConfigMap, deletions); err != nil {
+		return err
+	}
+
+	logger.Debug("Topic deletion scheduled", zap.String("topic", topicName), zap.Duration("gracePeriod", r.Env.TopicDeletionGracePeriod))
+	controller.GetEventRecorder(ctx).Eventf(broker, corev1.EventTypeNormal, "TopicDeletionScheduled",
+		"Topic %s will be deleted in %s unless broker %s/%s is created again",
+		topicName, r.Env.TopicDeletionGracePeriod, broker.GetNamespace(), broker.GetName(),
+	)
+	return nil
+}
+
+// reconcilePendingTopicDeletions cancels the pending deletion of the topic of the given broker, so that a broker
+// created again during the grace period adopts its previous topic, and it deletes topics whose grace period elapsed.
+func (r *Reconciler) reconcilePendingTopicDeletions(ctx context.Context, logger *zap.Logger, broker *eventing.Broker, contractConfigMap *corev1.ConfigMap, statusConditionManager base.StatusConditionManager) error {
+	deletions, err := pendingTopicDeletions(contractConfigMap)
+	if err != nil {
+		return err
+	}
+	if len(deletions) == 0 {
+		return nil
+	}
+
+	changed := false
+	if _, externalTopic := isExternalTopic(broker); !externalTopic {
+		topicName, err := r.brokerTopicName(broker)
+		if err != nil {
+			return err
+		}
+		if _, ok := deletions[topicName]; ok {
+			delete(deletions, topicName)
+			changed = true
+
+			logger.Debug("Topic deletion cancelled", zap.String("topic", topicName))
+			statusConditionManager.Recorder.Eventf(broker, corev1.EventTypeNormal, "TopicDeletionCancelled",
+				"Pending deletion of topic %s cancelled, the topic is adopted", topicName)
+		}
+	}
+
+	now := time.Now()
+	for topicName, deletion := range deletions {
+		if !isTopicDeletionGracePeriodElapsed(deletion, r.Env.TopicDeletionGracePeriod, now) {
+			continue
+		}
+		if err := r.deletePendingTopic(ctx, topicName, deletion); err != nil {
+			// The deletion is retried at the next reconciliation.
+			logger.Warn("Failed to delete topic", zap.String("topic", topicName), zap.Error(err))
+			continue
+		}
+		delete(deletions, topicName)
+		changed = true
+
+		logger.Debug("Topic deleted", zap.String("topic", topicName))
+	}
+
+	if !changed {
+		return nil
+	}
+	return r.updatePendingTopicDeletions(ctx, contractConfigMap, deletions)
+}
+
+func (r *Reconciler) deletePendingTopic(ctx context.Context, topicName string, deletion pendingTopicDeletion) error {
+	refs := deletion.Secrets
+	if deletion.Secret != nil {
+		refs = []types.NamespacedName{*deletion.Secret}
+	}
+	secrets, secret, err := r.authSecretsFromReferences(ctx, refs)
+	if err != nil {
+		return err
+	}
+
+	saramaConfig, err := r.clusterAdminSaramaConfig(security.NewSaramaSecurityOptionFromSecret(secret))
+	if err != nil {
+		return fmt.Errorf("error getting cluster admin sarama config: %w", err)
+	}
+
+	kafkaClusterAdminClient, err := r.newKafkaClusterAdminClient(deletion.BootstrapServers, secret, nil, saramaConfig)
+	if err != nil {
+		return fmt.Errorf("cannot obtain Kafka cluster admin, %w", err)
+	}
+	defer kafkaClusterAdminClient.Close()
+
+	if _, err := kafka.DeleteTopic(kafkaClusterAdminClient, topicName); err != nil {
+		return err
+	}
+
+	for _, secret := range secrets {
+		if err := r.removeFinalizerSecret(ctx, deletion.SecretFinalizer, secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updatePendingTopicDeletions stores the given pending topic deletions in the contract config map, the given config
+// map is updated with the stored config map so that it can be updated again.
+func (r *Reconciler) updatePendingTopicDeletions(ctx context.Context, contractConfigMap *corev1.ConfigMap, deletions map[string]pendingTopicDeletion) error {
+	if err := setPendingTopicDeletions(contractConfigMap, deletions); err != nil {
+		return err
+	}
+
+	updated, err := r.KubeClient.CoreV1().ConfigMaps(contractConfigMap.Namespace).Update(ctx, contractConfigMap, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update pending topic deletions of config map %s: %w", r.DataPlaneConfigMapAsString(), err)
+	}
+	updated.DeepCopyInto(contractConfigMap)
+	return nil
+}
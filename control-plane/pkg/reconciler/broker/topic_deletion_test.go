@@ -0,0 +1,120 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestPendingTopicDeletionsRoundTrip(t *testing.T) {
+	deletions := map[string]pendingTopicDeletion{
+		"topic-1": {
+			DeletionTimestamp: metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+			BootstrapServers:  []string{"kafka-1:9092", "kafka-2:9092"},
+			Secret:            &types.NamespacedName{Namespace: "ns", Name: "secret"},
+			SecretFinalizer:   "kafka.eventing/uid",
+		},
+		"topic-2": {
+			DeletionTimestamp: metav1.NewTime(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)),
+			BootstrapServers:  []string{"kafka-1:9092"},
+		},
+		"topic-3": {
+			DeletionTimestamp: metav1.NewTime(time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)),
+			BootstrapServers:  []string{"kafka-1:9092"},
+			Secrets: []types.NamespacedName{
+				{Namespace: "ns", Name: "secret-tls"},
+				{Namespace: "ns", Name: "secret-sasl"},
+			},
+			SecretFinalizer: "kafka.eventing/uid",
+		},
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := setPendingTopicDeletions(cm, deletions); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := pendingTopicDeletions(cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(deletions, got); diff != "" {
+		t.Errorf("(-want, +got) %s", diff)
+	}
+
+	if err := setPendingTopicDeletions(cm, map[string]pendingTopicDeletion{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cm.Annotations[PendingTopicDeletionsAnnotation]; ok {
+		t.Errorf("expected annotation %s to be removed", PendingTopicDeletionsAnnotation)
+	}
+}
+
+func TestPendingTopicDeletionsInvalidAnnotation(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{PendingTopicDeletionsAnnotation: "{"},
+		},
+	}
+	if _, err := pendingTopicDeletions(cm); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestIsTopicDeletionGracePeriodElapsed(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		deletionTimestamp time.Time
+		gracePeriod       time.Duration
+		want              bool
+	}{
+		{
+			name:              "within grace period",
+			deletionTimestamp: now.Add(-time.Minute),
+			gracePeriod:       time.Hour,
+			want:              false,
+		},
+		{
+			name:              "grace period elapsed",
+			deletionTimestamp: now.Add(-time.Hour),
+			gracePeriod:       time.Hour,
+			want:              true,
+		},
+		{
+			name:              "no grace period",
+			deletionTimestamp: now,
+			want:              true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deletion := pendingTopicDeletion{DeletionTimestamp: metav1.NewTime(tt.deletionTimestamp)}
+			if got := isTopicDeletionGracePeriodElapsed(deletion, tt.gracePeriod, now); got != tt.want {
+				t.Errorf("isTopicDeletionGracePeriodElapsed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
+)
+
+// topicPrefixPattern matches the prefixes made of legal Kafka topic characters.
+var topicPrefixPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// NamespaceTopicPrefixes maps namespaces to the prefix of the topics of their brokers, so that in multi-tenant
+// clusters each tenant's topics can be isolated with prefixed Kafka ACLs.
+type NamespaceTopicPrefixes map[string]string
+
+// Prefix returns the topic prefix of the brokers of the given namespace, it's empty for namespaces without a prefix.
+func (p NamespaceTopicPrefixes) Prefix(namespace string) string {
+	return p[namespace]
+}
+
+// ValidateNamespaceTopicPrefixes validates the namespace topic prefixes, when configured.
+func ValidateNamespaceTopicPrefixes(env config.Env) error {
+	_, err := parseNamespaceTopicPrefixes(env)
+	return err
+}
+
+// parseNamespaceTopicPrefixes parses the comma separated <namespace>=<prefix> pairs of the given env, it returns nil
+// when no prefix is configured.
+func parseNamespaceTopicPrefixes(env config.Env) (NamespaceTopicPrefixes, error) {
+	if strings.TrimSpace(env.NamespaceTopicPrefixes) == "" {
+		return nil, nil
+	}
+
+	prefixes := make(NamespaceTopicPrefixes)
+	for _, pair := range strings.Split(env.NamespaceTopicPrefixes, ",") {
+		namespace, prefix, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		namespace, prefix = strings.TrimSpace(namespace), strings.TrimSpace(prefix)
+		if !ok || namespace == "" {
+			return nil, fmt.Errorf("invalid namespace topic prefix %q: expected <namespace>=<prefix>", pair)
+		}
+		if !topicPrefixPattern.MatchString(prefix) {
+			return nil, fmt.Errorf("invalid topic prefix %q of namespace %s: expected a non empty prefix made of %s", prefix, namespace, topicPrefixPattern)
+		}
+		if _, ok := prefixes[namespace]; ok {
+			return nil, fmt.Errorf("duplicate topic prefix of namespace %s", namespace)
+		}
+		prefixes[namespace] = prefix
+	}
+	return prefixes, nil
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
+)
+
+func TestParseNamespaceTopicPrefixes(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    NamespaceTopicPrefixes
+		wantErr bool
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:  "prefixes",
+			value: "tenant-a=tenant-a., tenant-b = tenant_b-",
+			want:  NamespaceTopicPrefixes{"tenant-a": "tenant-a.", "tenant-b": "tenant_b-"},
+		},
+		{
+			name:    "missing prefix",
+			value:   "tenant-a",
+			wantErr: true,
+		},
+		{
+			name:    "empty prefix",
+			value:   "tenant-a=",
+			wantErr: true,
+		},
+		{
+			name:    "illegal prefix characters",
+			value:   "tenant-a=tenant/a",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate namespace",
+			value:   "tenant-a=a.,tenant-a=b.",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNamespaceTopicPrefixes(config.Env{NamespaceTopicPrefixes: tt.value})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNamespaceTopicPrefixesPrefix(t *testing.T) {
+	var none NamespaceTopicPrefixes
+	assert.Equal(t, "", none.Prefix("tenant-a"))
+
+	prefixes := NamespaceTopicPrefixes{"tenant-a": "tenant-a."}
+	assert.Equal(t, "tenant-a.", prefixes.Prefix("tenant-a"))
+	assert.Equal(t, "", prefixes.Prefix("tenant-b"))
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/security"
+)
+
+// trustBundle resolves the trust bundle referenced by the given broker config, in the namespace of the broker config,
+// it returns nil when the broker config doesn't reference a trust bundle.
+//
+// The trust bundle is tracked even when it doesn't exist, so that the broker is reconciled again when it's created
+// or when its CA certificates are rotated.
+func (r *Reconciler) trustBundle(broker *eventing.Broker, brokerConfig *corev1.ConfigMap) (*contract.TrustBundleReference, []byte, error) {
+	name, key, ok := security.TrustBundleRef(brokerConfig)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	ref := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: brokerConfig.Namespace, Name: name}}
+	if err := r.TrackConfigMap(ref, broker); err != nil {
+		return nil, nil, fmt.Errorf("failed to track trust bundle: %w", err)
+	}
+
+	cm, err := r.ConfigMapLister.ConfigMaps(ref.Namespace).Get(ref.Name)
+	if apierrors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("trust bundle %s/%s not found", ref.Namespace, ref.Name)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get trust bundle %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	bundle, err := security.TrustBundle(cm, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &contract.TrustBundleReference{
+		Reference: &contract.Reference{
+			Uuid:      string(cm.UID),
+			Namespace: cm.Namespace,
+			Name:      cm.Name,
+			Version:   cm.ResourceVersion,
+		},
+		Key: key,
+	}, bundle, nil
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/config"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/security"
+)
+
+// ValidateBrokerConfigOption configures the checks made by ValidateBrokerConfig.
+type ValidateBrokerConfigOption func(v *brokerConfigValidation)
+
+type brokerConfigValidation struct {
+	newKafkaClusterAdminClient kafka.NewClusterAdminClientFunc
+	secret                     *corev1.Secret
+}
+
+// WithKafkaClusterAdmin makes ValidateBrokerConfig connect to the Kafka cluster of the broker with clients created by
+// newAdmin, to check that the bootstrap servers are reachable and that the external topic, if any, is present.
+func WithKafkaClusterAdmin(newAdmin kafka.NewClusterAdminClientFunc) ValidateBrokerConfigOption {
+	return func(v *brokerConfigValidation) {
+		v.newKafkaClusterAdminClient = newAdmin
+	}
+}
+
+// WithAuthSecret sets the auth secret referenced by the broker config, it's used to connect to the Kafka cluster. For
+// broker configs referencing multiple auth secrets, it's the virtual secret of security.ResolveAuthContextFromSecrets.
+func WithAuthSecret(secret *corev1.Secret) ValidateBrokerConfigOption {
+	return func(v *brokerConfigValidation) {
+		v.secret = secret
+	}
+}
+
+// ValidateBrokerConfig runs the checks the broker reconciler makes before reconciling the given broker with the given
+// config, the ConfigMap built from the Secret data for Secret based configs, and returns every failed check.
+//
+// Neither the broker nor the config are modified, so that brokers can be validated offline, for example, by CI checks.
+// The Kafka cluster is contacted only when WithKafkaClusterAdmin is given. Checks depending on the controller
+// configuration, like the default bootstrap servers, aren't made.
+func ValidateBrokerConfig(ctx context.Context, broker *eventing.Broker, cm *corev1.ConfigMap, options ...ValidateBrokerConfigOption) []error {
+	v := &brokerConfigValidation{}
+	for _, opt := range options {
+		opt(v)
+	}
+
+	var errs []error
+	if broker.Spec.Config == nil {
+		errs = append(errs, fmt.Errorf("broker %s/%s has no config", broker.GetNamespace(), broker.GetName()))
+	} else if !kafka.IsBrokerConfigSecret(broker) && !strings.EqualFold(broker.Spec.Config.Kind, "configmap") {
+		errs = append(errs, fmt.Errorf("supported config Kind: ConfigMap - got %s", broker.Spec.Config.Kind))
+	}
+
+	topicConfig, err := kafka.TopicConfigFromConfigMap(logging.FromContext(ctx).Desugar(), cm)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("unable to build topic config from configmap: %w", err))
+	} else if err := topicConfigFromAnnotations(broker, topicConfig); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := DefaultBackoffDelayMs(broker, 0); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := DeliveryOrder(broker); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := DeadLetterSinkContentMode(broker); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := DeliveryBackoffJitter(broker); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := IngressContentMode(broker); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := IngressFilter(broker); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := ProducerAcks(broker); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := security.AuthSecretNames(cm.Data); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := IngressPath(broker); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := (&Reconciler{Env: &config.Env{}}).isDataPlaneAvailabilityGateSoft(broker); err != nil {
+		errs = append(errs, err)
+	}
+
+	if topicConfig == nil || v.newKafkaClusterAdminClient == nil {
+		return errs
+	}
+	return append(errs, v.validateKafkaCluster(ctx, broker, cm, topicConfig)...)
+}
+
+// validateKafkaCluster checks that the Kafka cluster of the given topic config is reachable and that the external
+// topic of the broker, if any, is present.
+func (v *brokerConfigValidation) validateKafkaCluster(ctx context.Context, broker *eventing.Broker, cm *corev1.ConfigMap, topicConfig *kafka.TopicConfig) []error {
+	if name := cm.Data[security.AuthSecretNameKey]; name != "" && v.secret == nil {
+		return []error{fmt.Errorf("the auth secret %s referenced by the broker config is required to connect to the Kafka cluster", name)}
+	}
+	if names, _ := security.AuthSecretNames(cm.Data); len(names) > 0 && v.secret == nil {
+		return []error{fmt.Errorf("the auth secrets %v referenced by the broker config are required to connect to the Kafka cluster", names)}
+	}
+
+	saramaConfig, err := kafka.GetSaramaConfig(security.NewSaramaSecurityOptionFromSecret(v.secret))
+	if err != nil {
+		return []error{fmt.Errorf("error getting cluster admin config: %w", err)}
+	}
+	admin, err := v.newKafkaClusterAdminClient(topicConfig.BootstrapServers, saramaConfig)
+	if err != nil {
+		return []error{fmt.Errorf("failed to connect to Kafka cluster %s: %w", topicConfig.GetBootstrapServers(), err)}
+	}
+	defer func() {
+		if err := admin.Close(); err != nil {
+			logging.FromContext(ctx).Desugar().Debug("Failed to close cluster admin", zap.Error(err))
+		}
+	}()
+
+	topic, ok := isExternalTopic(broker)
+	if !ok {
+		return nil
+	}
+	isPresentAndValid, err := kafka.AreTopicsPresentAndValid(admin, topic)
+	if err != nil {
+		return []error{fmt.Errorf("topics %v not present or invalid: %w", []string{topic}, err)}
+	}
+	if !isPresentAndValid {
+		return []error{fmt.Errorf("topics %v not present or invalid: check topic configuration", []string{topic})}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventing "knative.dev/eventing/pkg/apis/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+	kafkatesting "knative.dev/eventing-kafka-broker/control-plane/pkg/kafka/testing"
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/security"
+)
+
+func TestValidateBrokerConfig(t *testing.T) {
+	newBroker := func(kind string, annotations map[string]string) *eventing.Broker {
+		return &eventing.Broker{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "b", Annotations: annotations},
+			Spec: eventing.BrokerSpec{
+				Config: &duckv1.KReference{Kind: kind, Namespace: "ns", Name: "config"},
+			},
+		}
+	}
+	newConfigMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "config"},
+			Data:       data,
+		}
+	}
+	validData := map[string]string{
+		kafka.BootstrapServersConfigMapKey:              "kafka-1:9092",
+		kafka.DefaultTopicNumPartitionConfigMapKey:      "10",
+		kafka.DefaultTopicReplicationFactorConfigMapKey: "3",
+	}
+
+	tests := []struct {
+		name       string
+		broker     *eventing.Broker
+		cm         *corev1.ConfigMap
+		options    []ValidateBrokerConfigOption
+		wantErrors int
+	}{
+		{
+			name:   "valid config",
+			broker: newBroker("ConfigMap", nil),
+			cm:     newConfigMap(validData),
+		},
+		{
+			name:       "invalid config kind and missing keys",
+			broker:     newBroker("Pod", nil),
+			cm:         newConfigMap(map[string]string{kafka.BootstrapServersConfigMapKey: "kafka-1:9092"}),
+			wantErrors: 2,
+		},
+		{
+			name: "invalid annotations",
+			broker: newBroker("ConfigMap", map[string]string{
+				DeliveryOrderAnnotation:             "random",
+				DataPlaneAvailabilityGateAnnotation: "none",
+				IngressPathAnnotation:               "relative",
+				ProducerAcksAnnotation:              "2",
+				IngressContentModeAnnotation:        "json",
+				IngressFilterAnnotation:             "types=",
+			}),
+			cm:         newConfigMap(validData),
+			wantErrors: 6,
+		},
+		{
+			name:   "external topic present",
+			broker: newBroker("ConfigMap", map[string]string{ExternalTopicAnnotation: "my-topic"}),
+			cm:     newConfigMap(validData),
+			options: []ValidateBrokerConfigOption{WithKafkaClusterAdmin(func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+				return &kafkatesting.MockKafkaClusterAdmin{
+					ExpectedTopics: []string{"my-topic"},
+					ExpectedTopicsMetadataOnDescribeTopics: []*sarama.TopicMetadata{
+						{Name: "my-topic", Partitions: []*sarama.PartitionMetadata{{}}},
+					},
+					T: t,
+				}, nil
+			})},
+		},
+		{
+			name:   "external topic not present",
+			broker: newBroker("ConfigMap", map[string]string{ExternalTopicAnnotation: "my-topic"}),
+			cm:     newConfigMap(validData),
+			options: []ValidateBrokerConfigOption{WithKafkaClusterAdmin(func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+				return &kafkatesting.MockKafkaClusterAdmin{
+					ExpectedTopics:                         []string{"my-topic"},
+					ExpectedTopicsMetadataOnDescribeTopics: []*sarama.TopicMetadata{},
+					T:                                      t,
+				}, nil
+			})},
+			wantErrors: 1,
+		},
+		{
+			name:   "unreachable bootstrap servers",
+			broker: newBroker("ConfigMap", nil),
+			cm:     newConfigMap(validData),
+			options: []ValidateBrokerConfigOption{WithKafkaClusterAdmin(func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+				return nil, errors.New("unreachable")
+			})},
+			wantErrors: 1,
+		},
+		{
+			name:   "missing auth secret",
+			broker: newBroker("ConfigMap", nil),
+			cm: newConfigMap(map[string]string{
+				kafka.BootstrapServersConfigMapKey:              "kafka-1:9092",
+				kafka.DefaultTopicNumPartitionConfigMapKey:      "10",
+				kafka.DefaultTopicReplicationFactorConfigMapKey: "3",
+				security.AuthSecretNameKey:                      "my-secret",
+			}),
+			options: []ValidateBrokerConfigOption{WithKafkaClusterAdmin(func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+				t.Error("unexpected cluster admin creation")
+				return nil, errors.New("unexpected")
+			})},
+			wantErrors: 1,
+		},
+		{
+			name:   "missing multiple auth secrets",
+			broker: newBroker("ConfigMap", nil),
+			cm: newConfigMap(map[string]string{
+				kafka.BootstrapServersConfigMapKey:              "kafka-1:9092",
+				kafka.DefaultTopicNumPartitionConfigMapKey:      "10",
+				kafka.DefaultTopicReplicationFactorConfigMapKey: "3",
+				security.AuthSecretNamesKey:                     "my-tls-secret,my-sasl-secret",
+			}),
+			options: []ValidateBrokerConfigOption{WithKafkaClusterAdmin(func(_ []string, _ *sarama.Config) (sarama.ClusterAdmin, error) {
+				t.Error("unexpected cluster admin creation")
+				return nil, errors.New("unexpected")
+			})},
+			wantErrors: 1,
+		},
+		{
+			name:   "single and multiple auth secrets",
+			broker: newBroker("ConfigMap", nil),
+			cm: newConfigMap(map[string]string{
+				kafka.BootstrapServersConfigMapKey:              "kafka-1:9092",
+				kafka.DefaultTopicNumPartitionConfigMapKey:      "10",
+				kafka.DefaultTopicReplicationFactorConfigMapKey: "3",
+				security.AuthSecretNameKey:                      "my-secret",
+				security.AuthSecretNamesKey:                     "my-tls-secret,my-sasl-secret",
+			}),
+			wantErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := tt.broker.DeepCopy()
+			cm := tt.cm.DeepCopy()
+
+			errs := ValidateBrokerConfig(context.Background(), broker, cm, tt.options...)
+
+			require.Len(t, errs, tt.wantErrors, "%v", errs)
+			require.Equal(t, tt.broker, broker)
+			require.Equal(t, tt.cm, cm)
+		})
+	}
+}
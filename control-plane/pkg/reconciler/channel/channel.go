@@ -114,6 +114,7 @@ func (r *Reconciler) reconcileKind(ctx context.Context, channel *messagingv1beta
 		SetAddress: channel.Status.SetAddress,
 		Env:        r.Env,
 		Recorder:   controller.GetEventRecorder(ctx),
+		Trace:      base.GetConditionTrace(ctx),
 	}
 
 	// do not proceed, if data plane is not available
@@ -208,6 +209,7 @@ func (r *Reconciler) reconcileKind(ctx context.Context, channel *messagingv1beta
 	defer kafkaClient.Close()
 
 	// create the topic
+	topicConfig.RackAwareReplicaAssignmentEnabled = r.Env.RackAwareReplicaAssignmentEnabled
 	topic, err := kafka.CreateTopicIfDoesntExist(kafkaClusterAdminClient, logger, topicName, topicConfig)
 	if err != nil {
 		return statusConditionManager.FailedToCreateTopic(topic, err)
@@ -68,6 +68,7 @@ func NewController(ctx context.Context, watcher configmap.Watcher, configs *conf
 			DataPlaneNamespace:          configs.SystemNamespace,
 			DispatcherLabel:             base.ChannelDispatcherLabel,
 			ReceiverLabel:               base.ChannelReceiverLabel,
+			ResourceKind:                base.ChannelResourceKind,
 		},
 		SubscriptionLister:         subscriptioninformer.Get(ctx).Lister(),
 		NewKafkaClient:             sarama.NewClient,
@@ -89,6 +90,11 @@ func NewController(ctx context.Context, watcher configmap.Watcher, configs *conf
 		)
 	}
 
+	reconciler.Reconciler.ReceiverPodSelector, reconciler.Reconciler.DispatcherPodSelector, err = base.ParseDataPlanePodSelectors(*configs)
+	if err != nil {
+		logger.Fatal("Invalid data plane pod selectors", zap.Error(err))
+	}
+
 	impl := kafkachannelreconciler.NewImpl(ctx, reconciler)
 	IPsLister := prober.IdentityIPsLister()
 	reconciler.Prober = prober.NewAsync(ctx, http.DefaultClient, "", IPsLister, impl.EnqueueKey)
@@ -120,6 +120,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, channel *messagingv1beta
 		SetAddress: channel.Status.SetAddress,
 		Env:        r.Env,
 		Recorder:   controller.GetEventRecorder(ctx),
+		Trace:      base.GetConditionTrace(ctx),
 	}
 
 	// Get the channel configmap
@@ -190,6 +191,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, channel *messagingv1beta
 	defer kafkaClusterAdminClient.Close()
 
 	// create the topic
+	topicConfig.RackAwareReplicaAssignmentEnabled = r.Env.RackAwareReplicaAssignmentEnabled
 	topic, err := kafka.CreateTopicIfDoesntExist(kafkaClusterAdminClient, logger, topicName, topicConfig)
 	if err != nil {
 		return statusConditionManager.FailedToCreateTopic(topic, err)
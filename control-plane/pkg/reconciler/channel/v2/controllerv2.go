@@ -69,6 +69,7 @@ func NewController(ctx context.Context, watcher configmap.Watcher, configs *conf
 			ContractConfigMapName:       configs.ContractConfigMapName,
 			ContractConfigMapFormat:     configs.ContractConfigMapFormat,
 			DataPlaneNamespace:          configs.SystemNamespace,
+			ResourceKind:                base.ChannelResourceKind,
 		},
 		NewKafkaClusterAdminClient: sarama.NewClusterAdmin,
 		Env:                        configs,
@@ -60,6 +60,7 @@ func NewController(ctx context.Context, _ configmap.Watcher, configs *config.Env
 			ContractConfigMapFormat:     configs.ContractConfigMapFormat,
 			DataPlaneNamespace:          configs.SystemNamespace,
 			ReceiverLabel:               base.SinkReceiverLabel,
+			ResourceKind:                base.SinkResourceKind,
 		},
 		ConfigMapLister:            configmapInformer.Lister(),
 		NewKafkaClusterAdminClient: sarama.NewClusterAdmin,
@@ -74,6 +75,11 @@ func NewController(ctx context.Context, _ configmap.Watcher, configs *config.Env
 		)
 	}
 
+	reconciler.Reconciler.ReceiverPodSelector, reconciler.Reconciler.DispatcherPodSelector, err = base.ParseDataPlanePodSelectors(*configs)
+	if err != nil {
+		logger.Fatal("Invalid data plane pod selectors", zap.Error(err))
+	}
+
 	impl := sinkreconciler.NewImpl(ctx, reconciler)
 	IPsLister := prober.IPsListerFromService(types.NamespacedName{Namespace: configs.SystemNamespace, Name: configs.IngressName})
 	reconciler.Prober = prober.NewAsync(ctx, http.DefaultClient, configs.IngressPodPort, IPsLister, impl.EnqueueKey)
@@ -84,6 +84,7 @@ func (r *Reconciler) reconcileKind(ctx context.Context, ks *eventing.KafkaSink)
 		SetAddress: ks.Status.SetAddress,
 		Env:        r.Env,
 		Recorder:   controller.GetEventRecorder(ctx),
+		Trace:      base.GetConditionTrace(ctx),
 	}
 
 	if !r.IsReceiverRunning() {
@@ -131,6 +132,7 @@ func (r *Reconciler) reconcileKind(ctx context.Context, ks *eventing.KafkaSink)
 		ks.GetStatus().Annotations[base.TopicOwnerAnnotation] = ControllerTopicOwner
 
 		topicConfig := topicConfigFromSinkSpec(&ks.Spec)
+		topicConfig.RackAwareReplicaAssignmentEnabled = r.Env.RackAwareReplicaAssignmentEnabled
 
 		topic, err := kafka.CreateTopicIfDoesntExist(kafkaClusterAdminClient, logger, ks.Spec.Topic, topicConfig)
 		if err != nil {
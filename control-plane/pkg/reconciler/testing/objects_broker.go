@@ -49,6 +49,7 @@ import (
 const (
 	BrokerUUID          = "e7185016-5d98-4b54-84e8-3b1cd4acc6b4"
 	BrokerNamespaceUUID = "d1234567-8910-1234-5678-901234567890"
+	BrokerConfigUUID    = "e7185016-5d98-4b54-84e8-3b1cd4acc6b8"
 	BrokerNamespace     = "test-namespace"
 	BrokerName          = "test-broker"
 	ExternalTopicName   = "test-topic"
@@ -197,6 +198,17 @@ func WithBrokerAddessable() reconcilertesting.BrokerOption {
 	}
 }
 
+func WithSharedTopic(topic string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[SharedTopicAnnotation] = topic
+		broker.SetAnnotations(annotations)
+	}
+}
+
 func WithExternalTopic(topic string) func(*eventing.Broker) {
 	return func(broker *eventing.Broker) {
 		annotations := broker.GetAnnotations()
@@ -209,6 +221,193 @@ func WithExternalTopic(topic string) func(*eventing.Broker) {
 	}
 }
 
+func WithTopicDeletePolicyAnnotation(policy string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[TopicDeletePolicyAnnotation] = policy
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithTopicRetentionAnnotation(retention string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[TopicRetentionMsAnnotation] = retention
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithTopicTargetThroughputAnnotation(throughput string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[TopicTargetThroughputAnnotation] = throughput
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithTopicPartitionsStatusAnnotation(value string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[TopicPartitionsStatusAnnotation] = value
+	}
+}
+
+func WithKafkaClusterIDStatusAnnotation(clusterID string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[KafkaClusterIDStatusAnnotation] = clusterID
+	}
+}
+
+func WithDefaultBackoffDelayAnnotation(backoffDelay string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[DefaultBackoffDelayAnnotation] = backoffDelay
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithDataPlaneAvailabilityGateAnnotation(gate string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[DataPlaneAvailabilityGateAnnotation] = gate
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithReconcilePausedAnnotation(paused string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[ReconcilePausedAnnotation] = paused
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithBrokerResourceVersion(resourceVersion string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.SetResourceVersion(resourceVersion)
+	}
+}
+
+func WithDeadLetterSinkContentModeAnnotation(mode string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[DeadLetterSinkContentModeAnnotation] = mode
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithIngressContentModeAnnotation(mode string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[IngressContentModeAnnotation] = mode
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithIngressFilterAnnotation(filter string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[IngressFilterAnnotation] = filter
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithIngressPathAnnotation(path string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[IngressPathAnnotation] = path
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithDeliveryBackoffJitterAnnotation(jitter string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[DeliveryBackoffJitterAnnotation] = jitter
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithProducerAcksAnnotation(acks string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[ProducerAcksAnnotation] = acks
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithDeliveryOrderAnnotation(order string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[DeliveryOrderAnnotation] = order
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithTopicRecreateAnnotation(nonce string) func(*eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		annotations := broker.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[TopicRecreateAnnotation] = nonce
+		broker.SetAnnotations(annotations)
+	}
+}
+
+func WithTopicRecreateStatusAnnotation(nonce string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[TopicRecreateStatusAnnotation] = nonce
+	}
+}
+
 func WithTopicStatusAnnotation(topic string) reconcilertesting.BrokerOption {
 	return func(broker *eventing.Broker) {
 		if broker.Status.Annotations == nil {
@@ -227,6 +426,116 @@ func WithBootstrapServerStatusAnnotation(servers string) reconcilertesting.Broke
 	}
 }
 
+func WithMaxMessageBytesStatusAnnotation(maxMessageBytes string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[kafka.DefaultTopicMaxMessageBytesConfigMapKey] = maxMessageBytes
+	}
+}
+
+func WithTopicConfigEnforceStatusAnnotation() reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[kafka.TopicConfigEnforceConfigMapKey] = "true"
+	}
+}
+
+func WithTopicConfigStatusAnnotation(value string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[TopicConfigStatusAnnotation] = value
+	}
+}
+
+func WithFailoverBootstrapServersStatusAnnotation(servers string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[kafka.FailoverBootstrapServersConfigMapKey] = servers
+	}
+}
+
+func WithDryRunStatusAnnotation(actions string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[DryRunStatusAnnotation] = actions
+	}
+}
+
+func WithContractResourceStatusAnnotation(summary string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[ContractResourceStatusAnnotation] = summary
+	}
+}
+
+func WithDiagnosticsStatusAnnotation(diagnostics string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[DiagnosticsStatusAnnotation] = diagnostics
+	}
+}
+
+func WithTopicFinalizedStatusAnnotation() reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[TopicFinalizedStatusAnnotation] = "true"
+	}
+}
+
+func WithTopicFinalizationPendingStatusAnnotation() reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[TopicFinalizationPendingStatusAnnotation] = "true"
+	}
+}
+
+func WithActiveBootstrapServersStatusAnnotation(servers string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[ActiveBootstrapServersStatusAnnotation] = servers
+	}
+}
+
+func WithDeliveryStatusAnnotations(retry, backoffPolicy, backoffDelay string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 3)
+		}
+		broker.Status.Annotations[DeliveryRetryStatusAnnotation] = retry
+		broker.Status.Annotations[DeliveryBackoffPolicyStatusAnnotation] = backoffPolicy
+		broker.Status.Annotations[DeliveryBackoffDelayStatusAnnotation] = backoffDelay
+	}
+}
+
+func WithDefaultBackoffDelayStatusAnnotation(delay string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 1)
+		}
+		broker.Status.Annotations[DefaultBackoffDelayStatusAnnotation] = delay
+	}
+}
+
 func WithSecretStatusAnnotation(name string) reconcilertesting.BrokerOption {
 	return func(broker *eventing.Broker) {
 		if broker.Status.Annotations == nil {
@@ -258,6 +567,40 @@ func WithConfigMapNamespace(namespace string) CMOption {
 	}
 }
 
+func WithFailoverBootstrapServers(failoverBootstrapServers string) CMOption {
+	return func(cm *corev1.ConfigMap) {
+		cm.Data[kafka.FailoverBootstrapServersConfigMapKey] = failoverBootstrapServers
+	}
+}
+
+func WithMaxMessageBytes(maxMessageBytes string) CMOption {
+	return func(cm *corev1.ConfigMap) {
+		cm.Data[kafka.DefaultTopicMaxMessageBytesConfigMapKey] = maxMessageBytes
+	}
+}
+
+func WithBrokerConfigResourceVersion(version string) CMOption {
+	return func(cm *corev1.ConfigMap) {
+		cm.UID = BrokerConfigUUID
+		cm.ResourceVersion = version
+	}
+}
+
+func BrokerConfigReference(version string) *contract.Reference {
+	return &contract.Reference{
+		Uuid:      BrokerConfigUUID,
+		Namespace: ConfigMapNamespace,
+		Name:      ConfigMapName,
+		Version:   version,
+	}
+}
+
+func WithTopicConfigEnforce() CMOption {
+	return func(cm *corev1.ConfigMap) {
+		cm.Data[kafka.TopicConfigEnforceConfigMapKey] = "true"
+	}
+}
+
 func BrokerConfig(bootstrapServers string, numPartitions, replicationFactor int, options ...CMOption) *corev1.ConfigMap {
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -276,6 +619,20 @@ func BrokerConfig(bootstrapServers string, numPartitions, replicationFactor int,
 	return cm
 }
 
+func BrokerConfigSecret(bootstrapServers string, numPartitions, replicationFactor int) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ConfigMapNamespace,
+			Name:      ConfigMapName,
+		},
+		Data: map[string][]byte{
+			kafka.BootstrapServersConfigMapKey:              []byte(bootstrapServers),
+			kafka.DefaultTopicReplicationFactorConfigMapKey: []byte(fmt.Sprintf("%d", replicationFactor)),
+			kafka.DefaultTopicNumPartitionConfigMapKey:      []byte(fmt.Sprintf("%d", numPartitions)),
+		},
+	}
+}
+
 func BogusBrokerConfig() *corev1.ConfigMap {
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -298,6 +655,24 @@ func BrokerAuthConfig(name string) CMOption {
 	}
 }
 
+func BrokerAuthSecretsConfig(names ...string) CMOption {
+	return func(cm *corev1.ConfigMap) {
+		if cm.Data == nil {
+			cm.Data = make(map[string]string, 1)
+		}
+		cm.Data[security.AuthSecretNamesKey] = strings.Join(names, ",")
+	}
+}
+
+func BrokerTrustBundleConfig(name string) CMOption {
+	return func(cm *corev1.ConfigMap) {
+		if cm.Data == nil {
+			cm.Data = make(map[string]string, 1)
+		}
+		cm.Data[security.AuthTrustBundleNameKey] = name
+	}
+}
+
 func KReference(configMap *corev1.ConfigMap) *duckv1.KReference {
 	return &duckv1.KReference{
 		Kind:       "ConfigMap",
@@ -307,6 +682,15 @@ func KReference(configMap *corev1.ConfigMap) *duckv1.KReference {
 	}
 }
 
+func SecretKReference(secret *corev1.Secret) *duckv1.KReference {
+	return &duckv1.KReference{
+		Kind:       "Secret",
+		Namespace:  secret.Namespace,
+		Name:       secret.Name,
+		APIVersion: secret.APIVersion,
+	}
+}
+
 func BrokerReady(broker *eventing.Broker) {
 	broker.Status.Conditions = duckv1.Conditions{
 		{
@@ -336,6 +720,89 @@ func StatusExternalBrokerTopicReady(topic string) func(broker *eventing.Broker)
 	}
 }
 
+func StatusBrokerTopicConfigNotSynced(topic string, err error) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.GetConditionSet().Manage(broker.GetStatus()).SetCondition(apis.Condition{
+			Type:     base.ConditionTopicConfigSynced,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityWarning,
+			Reason:   fmt.Sprintf("Topic %s config not synced", topic),
+			Message:  err.Error(),
+		})
+	}
+}
+
+func StatusBrokerTopicReplicationFactorDegraded(topic string, replicationFactor, desiredReplicationFactor int16) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.GetConditionSet().Manage(broker.GetStatus()).SetCondition(apis.Condition{
+			Type:     base.ConditionTopicConfigSynced,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityWarning,
+			Reason:   fmt.Sprintf("Topic %s replication factor degraded", topic),
+			Message: fmt.Sprintf("topic created with replication factor %d instead of %d since the Kafka cluster doesn't have enough brokers, durability is degraded",
+				replicationFactor, desiredReplicationFactor),
+		})
+	}
+}
+
+func StatusBrokerConfigRebuilt(namespace, name string) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.GetConditionSet().Manage(broker.GetStatus()).SetCondition(apis.Condition{
+			Type:     base.ConditionConfigPresent,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityWarning,
+			Reason:   base.ReasonConfigRebuilt,
+			Message:  fmt.Sprintf("ConfigMap %s/%s not found, using the config rebuilt from the status annotations", namespace, name),
+		})
+	}
+}
+
+func StatusBrokerReconcilePaused(broker *eventing.Broker) {
+	broker.GetConditionSet().Manage(broker.GetStatus()).SetCondition(apis.Condition{
+		Type:     base.ConditionPaused,
+		Status:   corev1.ConditionTrue,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   base.ReasonReconcilePaused,
+		Message:  fmt.Sprintf("Reconciliation is paused by the %s annotation, changes aren't applied until it's removed", ReconcilePausedAnnotation),
+	})
+}
+
+func StatusBrokerTopicConfigIgnored(topic string, configs ...string) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.GetConditionSet().Manage(broker.GetStatus()).SetCondition(apis.Condition{
+			Type:     base.ConditionTopicConfigIgnored,
+			Status:   corev1.ConditionTrue,
+			Severity: apis.ConditionSeverityWarning,
+			Reason:   base.ReasonTopicConfigIgnored,
+			Message:  fmt.Sprintf("Topic %s is externally managed, the topic configs %s aren't applied to it", topic, strings.Join(configs, ", ")),
+		})
+	}
+}
+
+func StatusBrokerIngressUnreachable(address *apis.URL, err error) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.GetConditionSet().Manage(broker.GetStatus()).SetCondition(apis.Condition{
+			Type:     base.ConditionIngressReachable,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityWarning,
+			Reason:   base.ReasonIngressUnreachable,
+			Message:  fmt.Sprintf("Address %s is advertised but it isn't reachable from the controller: %v", address, err),
+		})
+	}
+}
+
+func StatusBrokerAuthSecretNotProtected(namespace, name string) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.GetConditionSet().Manage(broker.GetStatus()).SetCondition(apis.Condition{
+			Type:     base.ConditionAuthSecretProtected,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityWarning,
+			Reason:   base.ReasonSecretFinalizersDisabled,
+			Message:  fmt.Sprintf("Secret finalizers are disabled, deleting the auth secret %s/%s before this resource may prevent its topic from being deleted", namespace, name),
+		})
+	}
+}
+
 func StatusBrokerDataPlaneAvailable(broker *eventing.Broker) {
 	StatusDataPlaneAvailable(broker)
 }
@@ -405,12 +872,102 @@ func StatusBrokerFailedToCreateTopic(broker *eventing.Broker) {
 	StatusFailedToCreateTopic(BrokerTopic())(broker)
 }
 
+func StatusBrokerTopicCreationTransientFailure(err error) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		StatusTopicCreationTransientFailure(BrokerTopic(), err)(broker)
+	}
+}
+
+func StatusBrokerTopicCreationPermanentFailure(err error) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		StatusTopicCreationPermanentFailure(BrokerTopic(), err)(broker)
+	}
+}
+
+func StatusBrokerTopicAuthorizationFailed(operation string, err error) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		StatusTopicAuthorizationFailed(operation, BrokerTopic(), err)(broker)
+	}
+}
+
 func StatusExternalBrokerTopicNotPresentOrInvalid(topicname string) func(broker *eventing.Broker) {
 	return func(broker *eventing.Broker) {
 		StatusTopicNotPresentOrInvalid(topicname)(broker)
 	}
 }
 
+func StatusExternalBrokerTopicAuthorizationFailed(topicname string, err error) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		StatusTopicAuthorizationFailed("describe", topicname, err)(broker)
+	}
+}
+
+func StatusExternalBrokerTopicNotPermitted(topicname string) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.GetConditionSet().Manage(broker.GetStatus()).MarkFalse(
+			base.ConditionTopicReady,
+			base.ReasonExternalTopicNotPermitted,
+			"external topic %s not permitted by policy",
+			topicname,
+		)
+	}
+}
+
+func StatusBrokerTopicUnmanaged(topic string) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.GetConditionSet().Manage(broker.GetStatus()).MarkTrueWithReason(
+			base.ConditionTopicReady,
+			base.ReasonTopicUnmanaged,
+			"Topic %s isn't managed, it must be created, configured and deleted out-of-band",
+			topic,
+		)
+	}
+}
+
+func StatusBrokerAuthSecretNotFound(namespace, name string) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.GetConditionSet().Manage(broker.GetStatus()).MarkFalse(
+			base.ConditionTopicReady,
+			base.ReasonAuthSecretNotFound,
+			"auth secret %s/%s not found",
+			namespace,
+			name,
+		)
+	}
+}
+
+func StatusBrokerKafkaClusterUnavailable(bootstrapServers string) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.GetConditionSet().Manage(broker.GetStatus()).MarkFalse(
+			base.ConditionTopicReady,
+			base.ReasonKafkaClusterUnavailable,
+			"circuit breaker open for Kafka cluster %s after repeated connection failures",
+			bootstrapServers,
+		)
+	}
+}
+
+func StatusBrokerTopicUnhealthy(err error) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.GetConditionSet().Manage(broker.GetStatus()).MarkFalse(
+			base.ConditionTopicReady,
+			base.ReasonTopicUnhealthy,
+			"%v",
+			err,
+		)
+	}
+}
+
+func StatusExternalBrokerTopicWaiting(topicname string) func(broker *eventing.Broker) {
+	return func(broker *eventing.Broker) {
+		broker.GetConditionSet().Manage(broker.GetStatus()).MarkUnknown(
+			base.ConditionTopicReady,
+			base.ReasonWaitingForExternalTopic,
+			fmt.Sprintf("waiting for external topic %s: invalid topic %s", topicname, topicname),
+		)
+	}
+}
+
 func BrokerDispatcherPod(namespace string, annotations map[string]string) runtime.Object {
 	return &corev1.Pod{
 		TypeMeta: metav1.TypeMeta{
@@ -487,6 +1044,12 @@ func StatusBrokerProbeFailed(status prober.Status) reconcilertesting.BrokerOptio
 	}
 }
 
+func StatusBrokerProbeBelowThreshold(count, threshold int) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		StatusProbeBelowThreshold(count, threshold)(broker)
+	}
+}
+
 func BrokerConfigMapAnnotations() reconcilertesting.BrokerOption {
 	return func(broker *eventing.Broker) {
 		if broker.Status.Annotations == nil {
@@ -507,6 +1070,24 @@ func BrokerConfigMapSecretAnnotation(name string) reconcilertesting.BrokerOption
 	}
 }
 
+func BrokerConfigMapSecretsAnnotation(names ...string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 10)
+		}
+		broker.Status.Annotations[security.AuthSecretNamesKey] = strings.Join(names, ",")
+	}
+}
+
+func BrokerConfigMapTrustBundleAnnotation(name string) reconcilertesting.BrokerOption {
+	return func(broker *eventing.Broker) {
+		if broker.Status.Annotations == nil {
+			broker.Status.Annotations = make(map[string]string, 10)
+		}
+		broker.Status.Annotations[security.AuthTrustBundleNameKey] = name
+	}
+}
+
 func getKafkaTopic() string {
 	topicName, err := kafkaFeatureFlags.ExecuteBrokersTopicTemplate(metav1.ObjectMeta{Namespace: BrokerNamespace, Name: BrokerName})
 	if err != nil {
@@ -64,6 +64,9 @@ const (
 	SecretResourceVersion = "1234"
 	SecretUUID            = "a7185016-5d98-4b54-84e8-3b1cd4acc6b6"
 
+	TrustBundleResourceVersion = "4321"
+	TrustBundleUUID            = "b7185016-5d98-4b54-84e8-3b1cd4acc6b7"
+
 	SystemNamespace = "knative-eventing"
 
 	DispatcherPodUUID = "a7185016-5d98-4b54-84e8-3b1cd4acc6bp"
@@ -265,6 +268,12 @@ func NewConfigMapFromContract(contract *contract.Contract, namespace string, nam
 		panic(err)
 	}
 
+	options = append([]reconcilertesting.ConfigMapOption{func(configMap *corev1.ConfigMap) {
+		if configMap.Annotations == nil {
+			configMap.Annotations = make(map[string]string, 1)
+		}
+		configMap.Annotations[base.ContractChecksumAnnotation] = base.ContractChecksum(data)
+	}}, options...)
 	return NewConfigMapWithBinaryData(namespace, name, data, options...)
 }
 
@@ -300,6 +309,25 @@ func NewSSLSecret(ns, name string) *corev1.Secret {
 	}
 }
 
+func NewTrustBundle(ns, name string, data string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ns,
+			Name:            name,
+			ResourceVersion: TrustBundleResourceVersion,
+			UID:             TrustBundleUUID,
+		},
+		Data: map[string]string{
+			security.DefaultTrustBundleKey: data,
+		},
+	}
+}
+
+func NewTrustBundleCA() string {
+	ca, _, _ := loadCerts()
+	return string(ca)
+}
+
 func NewLegacySSLSecret(ns, name string) *corev1.Secret {
 
 	ca, userKey, userCert := loadCerts()
@@ -381,6 +409,45 @@ func NewSASLSSLSecret(ns, name string) *corev1.Secret {
 	}
 }
 
+// NewTLSClientCertSecret returns a secret with only the CA and the client certificate, to be combined with a
+// NewSASLCredentialsSecret secret.
+func NewTLSClientCertSecret(ns, name string) *corev1.Secret {
+
+	ca, userKey, userCert := loadCerts()
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ns,
+			Name:            name,
+			ResourceVersion: SecretResourceVersion,
+			UID:             SecretUUID,
+		},
+		Data: map[string][]byte{
+			security.CaCertificateKey: ca,
+			security.UserKey:          userKey,
+			security.UserCertificate:  userCert,
+		},
+	}
+}
+
+// NewSASLCredentialsSecret returns a secret with the SASL_SSL protocol and only the SASL credentials.
+func NewSASLCredentialsSecret(ns, name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ns,
+			Name:            name,
+			ResourceVersion: SecretResourceVersion,
+			UID:             SecretUUID,
+		},
+		Data: map[string][]byte{
+			security.ProtocolKey:      []byte(security.ProtocolSASLSSL),
+			security.SaslMechanismKey: []byte(security.SaslScramSha512),
+			security.SaslUserKey:      []byte("user"),
+			security.SaslPasswordKey:  []byte("password"),
+		},
+	}
+}
+
 func NewKedaSecret(ns, name string) *corev1.Secret {
 
 	return &corev1.Secret{
@@ -536,6 +603,43 @@ func StatusFailedToCreateTopic(topicName string) func(obj duckv1.KRShaped) {
 	}
 }
 
+func StatusTopicCreationTransientFailure(topicName string, err error) func(obj duckv1.KRShaped) {
+	return func(obj duckv1.KRShaped) {
+		obj.GetConditionSet().Manage(obj.GetStatus()).MarkFalse(
+			base.ConditionTopicReady,
+			base.ReasonTopicCreationTransientFailure,
+			"Failed to create topic %s, retrying: %v",
+			topicName,
+			err,
+		)
+	}
+}
+
+func StatusTopicCreationPermanentFailure(topicName string, err error) func(obj duckv1.KRShaped) {
+	return func(obj duckv1.KRShaped) {
+		obj.GetConditionSet().Manage(obj.GetStatus()).MarkFalse(
+			base.ConditionTopicReady,
+			base.ReasonTopicCreationPermanentFailure,
+			"Failed to create topic %s, fix the topic config or the Kafka cluster permissions: %v",
+			topicName,
+			err,
+		)
+	}
+}
+
+func StatusTopicAuthorizationFailed(operation string, topicName string, err error) func(obj duckv1.KRShaped) {
+	return func(obj duckv1.KRShaped) {
+		obj.GetConditionSet().Manage(obj.GetStatus()).MarkFalse(
+			base.ConditionTopicReady,
+			base.ReasonTopicAuthorizationFailed,
+			"Not authorized to %s topics %v, grant the required ACLs to the Kafka client: %v",
+			operation,
+			[]string{topicName},
+			err,
+		)
+	}
+}
+
 func StatusTopicNotPresentOrInvalid(topicName string) func(obj duckv1.KRShaped) {
 	return func(obj duckv1.KRShaped) {
 		obj.GetConditionSet().Manage(obj.GetStatus()).MarkFalse(
@@ -576,6 +680,16 @@ func StatusProbeFailed(status prober.Status) func(obj duckv1.KRShaped) {
 	}
 }
 
+func StatusProbeBelowThreshold(count, threshold int) func(obj duckv1.KRShaped) {
+	return func(obj duckv1.KRShaped) {
+		obj.GetConditionSet().Manage(obj.GetStatus()).MarkFalse(
+			base.ConditionProbeSucceeded,
+			"ProbeStatus",
+			fmt.Sprintf("status: %s, %d of %d consecutive probes succeeded", prober.StatusReady.String(), count, threshold),
+		)
+	}
+}
+
 func allocateStatusAnnotations(obj duckv1.KRShaped) {
 	if obj.GetStatus().Annotations == nil {
 		obj.GetStatus().Annotations = make(map[string]string, 1)
@@ -21,6 +21,7 @@ import (
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 	v1 "knative.dev/eventing/pkg/client/informers/externalversions/eventing/v1"
@@ -77,6 +78,9 @@ func NewController(ctx context.Context, watcher configmap.Watcher, configs *conf
 			DataPlaneNamespace:           configs.SystemNamespace,
 			DispatcherLabel:              base.BrokerDispatcherLabel,
 			ReceiverLabel:                base.BrokerReceiverLabel,
+			ResourceKind:                 base.TriggerResourceKind,
+			ContractUpdateCoalesceWindow: configs.ContractUpdateCoalesceWindow,
+			ContractConfigMapShards:      configs.ContractConfigMapShards,
 		},
 		FlagsHolder: &FlagsHolder{
 			Flags: feature.Flags{},
@@ -93,6 +97,18 @@ func NewController(ctx context.Context, watcher configmap.Watcher, configs *conf
 		InitOffsetsFunc:            offset.InitOffsets,
 	}
 
+	receiverPodSelector, dispatcherPodSelector, err := base.ParseDataPlanePodSelectors(*configs)
+	if err != nil {
+		logger.Fatal("Invalid data plane pod selectors", zap.Error(err))
+	}
+	reconciler.ReceiverPodSelector = receiverPodSelector
+	reconciler.DispatcherPodSelector = dispatcherPodSelector
+
+	if configs.DebugEgressFanOut > 0 {
+		logger.Warn("Debug egress fan-out enabled, trigger egresses are duplicated in the data plane contract",
+			zap.Int("copies", configs.DebugEgressFanOut))
+	}
+
 	impl := triggerreconciler.NewImpl(ctx, reconciler, func(impl *controller.Impl) controller.Options {
 		return controller.Options{
 			FinalizerName:     FinalizerName,
@@ -122,9 +138,20 @@ func NewController(ctx context.Context, watcher configmap.Watcher, configs *conf
 	}
 
 	configmapInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
-		FilterFunc: controller.FilterWithNameAndNamespace(configs.DataPlaneConfigMapNamespace, configs.ContractConfigMapName),
+		FilterFunc: reconciler.IsContractConfigMap,
 		Handler: cache.ResourceEventHandlerFuncs{
-			AddFunc:    globalResync,
+			AddFunc: globalResync,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				// Triggers egresses need to be added back to rebuilt contracts.
+				oldConfigMap, ok := oldObj.(*corev1.ConfigMap)
+				if !ok {
+					return
+				}
+				newConfigMap, ok := newObj.(*corev1.ConfigMap)
+				if ok && base.IsContractRebuilt(oldConfigMap, newConfigMap) {
+					globalResync(newObj)
+				}
+			},
 			DeleteFunc: globalResync,
 		},
 	})
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trigger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	coreconfig "knative.dev/eventing-kafka-broker/control-plane/pkg/core/config"
+)
+
+// fanOutEgressSuffix is the suffix of the UID and of the consumer group of the copies of a trigger egress added to
+// the contract when config.Env.DebugEgressFanOut is set.
+const fanOutEgressSuffix = "-debug-fan-out-"
+
+// reconcileFanOutEgresses makes the given resource have n copies of the given trigger egress, and removes the copies
+// in excess, for example, when DebugEgressFanOut has been lowered or unset.
+//
+// It returns coreconfig.EgressChanged when the resource egresses changed.
+func reconcileFanOutEgresses(resource *contract.Resource, egress *contract.Egress, n int) int {
+	changed := coreconfig.EgressUnchanged
+	if deleteFanOutEgresses(resource, egress.Uid, n) {
+		changed = coreconfig.EgressChanged
+	}
+
+	for i := 1; i <= n; i++ {
+		fanOut := proto.Clone(egress).(*contract.Egress)
+		fanOut.Uid = fmt.Sprintf("%s%s%d", egress.Uid, fanOutEgressSuffix, i)
+		fanOut.ConsumerGroup = fmt.Sprintf("%s%s%d", egress.ConsumerGroup, fanOutEgressSuffix, i)
+
+		index := coreconfig.FindEgress(resource.Egresses, types.UID(fanOut.GetUid()))
+		if coreconfig.AddOrUpdateEgressConfigForResource(resource, fanOut, index) == coreconfig.EgressChanged {
+			changed = coreconfig.EgressChanged
+		}
+	}
+	return changed
+}
+
+// deleteFanOutEgresses removes the copies of the trigger egress with the given UID beyond the first keep ones from
+// the given resource, it returns whether any copy has been removed.
+func deleteFanOutEgresses(resource *contract.Resource, uid string, keep int) bool {
+	prefix := uid + fanOutEgressSuffix
+	egresses := resource.Egresses[:0]
+	for _, e := range resource.Egresses {
+		if strings.HasPrefix(e.GetUid(), prefix) && !isKeptFanOutEgress(strings.TrimPrefix(e.GetUid(), prefix), keep) {
+			continue
+		}
+		egresses = append(egresses, e)
+	}
+	removed := len(egresses) != len(resource.Egresses)
+	if len(egresses) == 0 {
+		egresses = nil
+	}
+	resource.Egresses = egresses
+	return removed
+}
+
+func isKeptFanOutEgress(index string, keep int) bool {
+	i, err := strconv.Atoi(index)
+	return err == nil && i >= 1 && i <= keep
+}
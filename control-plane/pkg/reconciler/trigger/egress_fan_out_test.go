@@ -0,0 +1,65 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trigger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+	coreconfig "knative.dev/eventing-kafka-broker/control-plane/pkg/core/config"
+)
+
+func TestReconcileFanOutEgresses(t *testing.T) {
+	egress := &contract.Egress{Uid: "t1", ConsumerGroup: "cg", Destination: "http://sink"}
+	other := &contract.Egress{Uid: "t2", ConsumerGroup: "cg2", Destination: "http://sink"}
+	resource := &contract.Resource{Egresses: []*contract.Egress{egress, other}}
+
+	assert.Equal(t, coreconfig.EgressChanged, reconcileFanOutEgresses(resource, egress, 2))
+	assert.Equal(t, []string{"t1", "t2", "t1-debug-fan-out-1", "t1-debug-fan-out-2"}, egressUIDs(resource))
+	assert.Equal(t, "cg-debug-fan-out-2", resource.Egresses[3].ConsumerGroup)
+	assert.Equal(t, "http://sink", resource.Egresses[3].Destination)
+
+	assert.Equal(t, coreconfig.EgressUnchanged, reconcileFanOutEgresses(resource, egress, 2))
+
+	assert.Equal(t, coreconfig.EgressChanged, reconcileFanOutEgresses(resource, egress, 1))
+	assert.Equal(t, []string{"t1", "t2", "t1-debug-fan-out-1"}, egressUIDs(resource))
+
+	assert.Equal(t, coreconfig.EgressChanged, reconcileFanOutEgresses(resource, egress, 0))
+	assert.Equal(t, []string{"t1", "t2"}, egressUIDs(resource))
+}
+
+func TestDeleteFanOutEgresses(t *testing.T) {
+	resource := &contract.Resource{Egresses: []*contract.Egress{
+		{Uid: "t1-debug-fan-out-1"},
+		{Uid: "t2"},
+		{Uid: "t1-debug-fan-out-2"},
+	}}
+
+	assert.True(t, deleteFanOutEgresses(resource, "t1", 0))
+	assert.Equal(t, []string{"t2"}, egressUIDs(resource))
+	assert.False(t, deleteFanOutEgresses(resource, "t1", 0))
+}
+
+func egressUIDs(resource *contract.Resource) []string {
+	uids := make([]string, 0, len(resource.Egresses))
+	for _, e := range resource.Egresses {
+		uids = append(uids, e.Uid)
+	}
+	return uids
+}
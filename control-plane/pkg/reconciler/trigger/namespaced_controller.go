@@ -22,6 +22,7 @@ import (
 	"github.com/Shopify/sarama"
 	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka/offset"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
@@ -70,6 +71,8 @@ func NewNamespacedController(ctx context.Context, watcher configmap.Watcher, con
 			DataPlaneNamespace:           configs.SystemNamespace,
 			DispatcherLabel:              base.BrokerDispatcherLabel,
 			ReceiverLabel:                base.BrokerReceiverLabel,
+			ResourceKind:                 base.TriggerResourceKind,
+			ContractUpdateCoalesceWindow: configs.ContractUpdateCoalesceWindow,
 		},
 		FlagsHolder: &FlagsHolder{
 			Flags: feature.Flags{},
@@ -117,6 +120,17 @@ func NewNamespacedController(ctx context.Context, watcher configmap.Watcher, con
 			AddFunc: func(obj interface{}) {
 				globalResync(obj)
 			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				// Triggers egresses need to be added back to rebuilt contracts.
+				oldConfigMap, ok := oldObj.(*corev1.ConfigMap)
+				if !ok {
+					return
+				}
+				newConfigMap, ok := newObj.(*corev1.ConfigMap)
+				if ok && base.IsContractRebuilt(oldConfigMap, newConfigMap) {
+					globalResync(newObj)
+				}
+			},
 			DeleteFunc: func(obj interface{}) {
 				globalResync(obj)
 			},
@@ -73,6 +73,8 @@ func (r *NamespacedReconciler) createReconcilerForTriggerInstance(trigger *event
 			DataPlaneConfigConfigMapName: r.DataPlaneConfigConfigMapName,
 			DispatcherLabel:              r.DispatcherLabel,
 			ReceiverLabel:                r.ReceiverLabel,
+			ResourceKind:                 r.ResourceKind,
+			ContractUpdateCoalesceWindow: r.ContractUpdateCoalesceWindow,
 
 			// override
 			DataPlaneNamespace:          trigger.Namespace,
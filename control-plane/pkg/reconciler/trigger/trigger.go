@@ -150,8 +150,9 @@ func (r *Reconciler) reconcileKind(ctx context.Context, trigger *eventing.Trigge
 		return statusConditionManager.failedToResolveTriggerConfig(fmt.Errorf("missing broker status annotations, waiting"))
 	}
 
-	// Get data plane config map.
-	contractConfigMap, err := r.GetOrCreateDataPlaneConfigMap(ctx)
+	// Get data plane config map, the trigger egress is in the contract shard of its broker.
+	shard := r.ContractConfigMapShard(broker)
+	contractConfigMap, err := r.GetOrCreateDataPlaneConfigMapShard(ctx, shard)
 	if err != nil {
 		return statusConditionManager.failedToGetDataPlaneConfigMap(err)
 	}
@@ -184,6 +185,9 @@ func (r *Reconciler) reconcileKind(ctx context.Context, trigger *eventing.Trigge
 	coreconfig.SetDeadLetterSinkURIFromEgressConfig(&trigger.Status.DeliveryStatus, triggerConfig.EgressConfig)
 
 	changed := coreconfig.AddOrUpdateEgressConfig(ct, brokerIndex, triggerConfig, triggerIndex)
+	if reconcileFanOutEgresses(ct.Resources[brokerIndex], triggerConfig, r.Env.DebugEgressFanOut) == coreconfig.EgressChanged {
+		changed = coreconfig.EgressChanged
+	}
 
 	coreconfig.IncrementContractGeneration(ct)
 
@@ -197,7 +201,7 @@ func (r *Reconciler) reconcileKind(ctx context.Context, trigger *eventing.Trigge
 		}
 
 		// Update volume generation annotation of dispatcher pods
-		if err := r.UpdateDispatcherPodsAnnotation(ctx, logger, ct.Generation); err != nil {
+		if err := r.UpdateDispatcherPodsShardAnnotation(ctx, logger, shard, ct.Generation); err != nil {
 			// Failing to update dispatcher pods annotation leads to config map refresh delayed by several seconds.
 			// Since the dispatcher side is the consumer side, we don't lose availability, and we can consider the Trigger
 			// ready. So, log out the error and move on to the next step.
@@ -237,7 +241,8 @@ func (r *Reconciler) finalizeKind(ctx context.Context, trigger *eventing.Trigger
 	}
 
 	// Get data plane config map.
-	dataPlaneConfigMap, err := r.GetOrCreateDataPlaneConfigMap(ctx)
+	shard := r.ContractConfigMapShard(broker)
+	dataPlaneConfigMap, err := r.GetOrCreateDataPlaneConfigMapShard(ctx, shard)
 	if err != nil {
 		return fmt.Errorf("failed to get data plane config map %s: %w", r.Env.DataPlaneConfigMapAsString(), err)
 	}
@@ -276,6 +281,7 @@ func (r *Reconciler) finalizeKind(ctx context.Context, trigger *eventing.Trigger
 
 	// Delete the Trigger from the config map data.
 	ct.Resources[brokerIndex].Egresses = deleteTrigger(egresses, triggerIndex)
+	deleteFanOutEgresses(ct.Resources[brokerIndex], string(trigger.UID), 0)
 
 	// Increment volume generation
 	coreconfig.IncrementContractGeneration(ct)
@@ -289,7 +295,7 @@ func (r *Reconciler) finalizeKind(ctx context.Context, trigger *eventing.Trigger
 	logger.Debug("Updated data plane config map", zap.String("configmap", r.Env.DataPlaneConfigMapAsString()))
 
 	// Update volume generation annotation of dispatcher pods
-	if err := r.UpdateDispatcherPodsAnnotation(ctx, logger, ct.Generation); err != nil {
+	if err := r.UpdateDispatcherPodsShardAnnotation(ctx, logger, shard, ct.Generation); err != nil {
 		// Failing to update dispatcher pods annotation leads to config map refresh delayed by several seconds.
 		// The delete trigger will eventually be seen by the data plane pods, so log out the error and move on to the
 		// next step.
@@ -351,17 +357,37 @@ func (r *Reconciler) reconcileTriggerEgress(ctx context.Context, broker *eventin
 		}
 	}
 
-	triggerEgressConfig, err := coreconfig.EgressConfigFromDelivery(ctx, r.Resolver, trigger, trigger.Spec.Delivery, r.Env.DefaultBackoffDelayMs)
+	defaultBackoffDelayMs, err := brokerreconciler.DefaultBackoffDelayMs(broker, r.Env.DefaultBackoffDelayMs)
+	if err != nil {
+		return nil, fmt.Errorf("[broker] %w", err)
+	}
+	triggerEgressConfig, err := coreconfig.EgressConfigFromDelivery(ctx, r.Resolver, trigger, trigger.Spec.Delivery, defaultBackoffDelayMs)
 	if err != nil {
 		return nil, fmt.Errorf("[trigger] %w", err)
 	}
-	brokerEgressConfig, err := coreconfig.EgressConfigFromDelivery(ctx, r.Resolver, broker, broker.Spec.Delivery, r.Env.DefaultBackoffDelayMs)
+	brokerEgressConfig, err := coreconfig.EgressConfigFromDelivery(ctx, r.Resolver, broker, broker.Spec.Delivery, defaultBackoffDelayMs)
 	if err != nil {
 		return nil, fmt.Errorf("[broker] %w", err)
 	}
 	// Merge Broker and Trigger egress configuration prioritizing the Trigger configuration.
 	egress.EgressConfig = coreconfig.MergeEgressConfig(triggerEgressConfig, brokerEgressConfig)
 
+	deadLetterContentMode, err := brokerreconciler.DeadLetterSinkContentMode(broker)
+	if err != nil {
+		return nil, fmt.Errorf("[broker] %w", err)
+	}
+	if egress.EgressConfig.GetDeadLetter() != "" {
+		egress.EgressConfig.DeadLetterContentMode = deadLetterContentMode
+	}
+
+	backoffJitter, err := brokerreconciler.DeliveryBackoffJitter(broker)
+	if err != nil {
+		return nil, fmt.Errorf("[broker] %w", err)
+	}
+	if egress.EgressConfig.GetRetry() > 0 {
+		egress.EgressConfig.BackoffJitter = backoffJitter
+	}
+
 	deliveryOrderAnnotationValue, ok := trigger.Annotations[deliveryOrderAnnotation]
 	if ok {
 		deliveryOrder, err := deliveryOrderFromString(deliveryOrderAnnotationValue)
@@ -410,7 +436,11 @@ func (r *Reconciler) reconcileConsumerGroup(ctx context.Context, broker *eventin
 		return false, fmt.Errorf("failed to track secret: %w", err)
 	}
 
-	bootstrapServers, ok := broker.Status.Annotations[kafka.BootstrapServersConfigMapKey]
+	// The broker topic lives in the failover cluster when the broker failed over to it.
+	bootstrapServers, ok := broker.Status.Annotations[brokerreconciler.ActiveBootstrapServersStatusAnnotation]
+	if !ok {
+		bootstrapServers, ok = broker.Status.Annotations[kafka.BootstrapServersConfigMapKey]
+	}
 	if !ok {
 		return false, nil
 	}
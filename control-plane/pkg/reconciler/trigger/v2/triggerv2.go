@@ -149,7 +149,11 @@ func (r Reconciler) reconcileConsumerGroup(ctx context.Context, broker *eventing
 		return nil, fmt.Errorf("failed to get secret: %w", err)
 	}
 
-	bootstrapServers := broker.Status.Annotations[kafka.BootstrapServersConfigMapKey]
+	// The broker topic lives in the failover cluster when the broker failed over to it.
+	bootstrapServers, ok := broker.Status.Annotations[brokerreconciler.ActiveBootstrapServersStatusAnnotation]
+	if !ok {
+		bootstrapServers = broker.Status.Annotations[kafka.BootstrapServersConfigMapKey]
+	}
 	topicName := broker.Status.Annotations[kafka.TopicAnnotation]
 
 	// Existing Triggers might not yet have this annotation
@@ -32,6 +32,9 @@ import (
 const (
 	AuthSecretNameKey      = "auth.secret.ref.name"      /* #nosec G101 */ /* Potential hardcoded credentials (gosec) */
 	AuthSecretNamespaceKey = "auth.secret.ref.namespace" /* #nosec G101 */ /* Potential hardcoded credentials (gosec) */
+	// AuthSecretNamesKey references multiple auth secrets as a comma separated list of names, for example, one with
+	// the TLS client certificate and one with the SASL credentials, see ResolveAuthContextFromSecrets.
+	AuthSecretNamesKey = "auth.secret.ref.names" /* #nosec G101 */ /* Potential hardcoded credentials (gosec) */
 )
 
 // SecretLocator locates a secret in a cluster.
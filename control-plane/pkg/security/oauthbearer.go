@@ -0,0 +1,76 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// oauthBearerTokenProvider is a sarama.AccessTokenProvider getting tokens from an OAuth 2.0 token endpoint using the
+// client credentials flow.
+//
+// Tokens are cached and refreshed once they expire, so long-lived clients keep authenticating.
+type oauthBearerTokenProvider struct {
+	tokenSource oauth2.TokenSource
+}
+
+func (p *oauthBearerTokenProvider) Token() (*sarama.AccessToken, error) {
+	token, err := p.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OAuth token: %w", err)
+	}
+	return &sarama.AccessToken{Token: token.AccessToken}, nil
+}
+
+func oauthBearerConfig(protocol string, data map[string][]byte) (*clientcredentials.Config, error) {
+	tokenURL, ok := data[SaslOAuthTokenEndpointKey]
+	if !ok || len(tokenURL) == 0 {
+		return nil, fmt.Errorf("[protocol %s] SASL OAuth token endpoint required (key: %s)", protocol, SaslOAuthTokenEndpointKey)
+	}
+	u, err := url.ParseRequestURI(string(tokenURL))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("[protocol %s] invalid SASL OAuth token endpoint %q (key: %s)", protocol, string(tokenURL), SaslOAuthTokenEndpointKey)
+	}
+
+	clientID, ok := data[SaslOAuthClientIDKey]
+	if !ok || len(clientID) == 0 {
+		return nil, fmt.Errorf("[protocol %s] SASL OAuth client id required (key: %s)", protocol, SaslOAuthClientIDKey)
+	}
+
+	clientSecret, ok := data[SaslOAuthClientSecretKey]
+	if !ok || len(clientSecret) == 0 {
+		return nil, fmt.Errorf("[protocol %s] SASL OAuth client secret required (key: %s)", protocol, SaslOAuthClientSecretKey)
+	}
+
+	return &clientcredentials.Config{
+		ClientID:     string(clientID),
+		ClientSecret: string(clientSecret),
+		TokenURL:     u.String(),
+		Scopes:       strings.Fields(string(data[SaslOAuthScopeKey])),
+	}, nil
+}
+
+func oauthBearerTokenProviderFromConfig(config *clientcredentials.Config) sarama.AccessTokenProvider {
+	return &oauthBearerTokenProvider{tokenSource: config.TokenSource(context.Background())}
+}
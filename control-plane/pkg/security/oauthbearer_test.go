@@ -0,0 +1,129 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+)
+
+func TestSASLOAuthBearer(t *testing.T) {
+	secret := map[string][]byte{
+		"protocol":                  []byte("SASL_PLAINTEXT"),
+		"sasl.mechanism":            []byte("OAUTHBEARER"),
+		"sasl.oauth.token.endpoint": []byte("https://auth.example.com/token"),
+		"sasl.oauth.client.id":      []byte("my-client-id"),
+		"sasl.oauth.client.secret":  []byte("my-client-secret"),
+	}
+	config := sarama.NewConfig()
+
+	err := kafka.Options(config, secretData(secret))
+
+	assert.Nil(t, err)
+	assert.True(t, config.Net.SASL.Enable)
+	assert.True(t, config.Net.SASL.Handshake)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeOAuth), config.Net.SASL.Mechanism)
+	assert.NotNil(t, config.Net.SASL.TokenProvider)
+	assert.Empty(t, config.Net.SASL.User)
+	assert.Empty(t, config.Net.SASL.Password)
+}
+
+func TestSASLOAuthBearerInvalidSecret(t *testing.T) {
+	valid := func() map[string][]byte {
+		return map[string][]byte{
+			"protocol":                  []byte("SASL_SSL"),
+			"sasl.mechanism":            []byte("OAUTHBEARER"),
+			"sasl.oauth.token.endpoint": []byte("https://auth.example.com/token"),
+			"sasl.oauth.client.id":      []byte("my-client-id"),
+			"sasl.oauth.client.secret":  []byte("my-client-secret"),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(data map[string][]byte)
+	}{
+		{
+			name:   "no token endpoint",
+			mutate: func(data map[string][]byte) { delete(data, SaslOAuthTokenEndpointKey) },
+		},
+		{
+			name:   "invalid token endpoint",
+			mutate: func(data map[string][]byte) { data[SaslOAuthTokenEndpointKey] = []byte("auth.example.com/token") },
+		},
+		{
+			name:   "no client id",
+			mutate: func(data map[string][]byte) { delete(data, SaslOAuthClientIDKey) },
+		},
+		{
+			name:   "empty client secret",
+			mutate: func(data map[string][]byte) { data[SaslOAuthClientSecretKey] = []byte("") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := valid()
+			tt.mutate(secret)
+			config := sarama.NewConfig()
+
+			err := kafka.Options(config, secretData(secret))
+
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestOAuthBearerTokenProvider(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("scope") != "kafka events" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"my-token","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	oauthConfig, err := oauthBearerConfig(ProtocolSASLPlaintext, map[string][]byte{
+		SaslOAuthTokenEndpointKey: []byte(server.URL),
+		SaslOAuthClientIDKey:      []byte("my-client-id"),
+		SaslOAuthClientSecretKey:  []byte("my-client-secret"),
+		SaslOAuthScopeKey:         []byte("kafka events"),
+	})
+	require.Nil(t, err)
+
+	provider := oauthBearerTokenProviderFromConfig(oauthConfig)
+	for i := 0; i < 2; i++ {
+		token, err := provider.Token()
+		require.Nil(t, err)
+		assert.Equal(t, "my-token", token.Token)
+	}
+
+	// The token is cached until it expires.
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
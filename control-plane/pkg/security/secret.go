@@ -43,6 +43,11 @@ const (
 	SaslTypeLegacy   = "saslType" // legacy secrets
 	SaslUsernameKey  = "username" // legacy secrets
 
+	SaslOAuthTokenEndpointKey = "sasl.oauth.token.endpoint"
+	SaslOAuthClientIDKey      = "sasl.oauth.client.id"
+	SaslOAuthClientSecretKey  = "sasl.oauth.client.secret" /* #nosec G101 */ /* Potential hardcoded credentials (gosec) */
+	SaslOAuthScopeKey         = "sasl.oauth.scope"         // optional, space separated scopes
+
 	ProtocolPlaintext     = "PLAINTEXT"
 	ProtocolSASLPlaintext = "SASL_PLAINTEXT"
 	ProtocolSSL           = "SSL"
@@ -51,6 +56,7 @@ const (
 	SaslPlain       = "PLAIN"
 	SaslScramSha256 = "SCRAM-SHA-256"
 	SaslScramSha512 = "SCRAM-SHA-512"
+	SaslOAuthBearer = "OAUTHBEARER"
 
 	// Legacy Channel config to enable TLS, see https://github.com/knative-sandbox/eventing-kafka-broker/issues/2231
 	SSLLegacyEnabled = "tls.enabled"
@@ -102,13 +108,26 @@ func secretData(data map[string][]byte) kafka.ConfigOption {
 func saslConfig(protocol string, data map[string][]byte) kafka.ConfigOption {
 	return func(config *sarama.Config) error {
 
-		// Supported mechanism SASL/PLAIN (default if not specified) or SASL/SCRAM.
+		// Supported mechanism SASL/PLAIN (default if not specified), SASL/SCRAM or SASL/OAUTHBEARER.
 		saslMechanism := SaslPlain
 		givenSASLMechanism, ok := data[SaslMechanismKey]
 		if ok {
 			saslMechanism = string(givenSASLMechanism)
 		}
 
+		if saslMechanism == SaslOAuthBearer {
+			oauthConfig, err := oauthBearerConfig(protocol, data)
+			if err != nil {
+				return err
+			}
+
+			config.Net.SASL.Enable = true
+			config.Net.SASL.Handshake = true
+			config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+			config.Net.SASL.TokenProvider = oauthBearerTokenProviderFromConfig(oauthConfig)
+			return nil
+		}
+
 		user, ok := data[SaslUserKey]
 		if !ok || len(user) == 0 {
 			return fmt.Errorf("[protocol %s] SASL user required (key: %s)", protocol, SaslUserKey)
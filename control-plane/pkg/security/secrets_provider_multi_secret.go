@@ -0,0 +1,169 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+)
+
+// multiSecretFields are the contract fields of the secret keys the data plane reads from multiple auth secrets.
+var multiSecretFields = map[string]contract.SecretField{
+	CaCertificateKey: contract.SecretField_CA_CRT,
+	UserCertificate:  contract.SecretField_USER_CRT,
+	UserKey:          contract.SecretField_USER_KEY,
+	SaslMechanismKey: contract.SecretField_SASL_MECHANISM,
+	SaslUserKey:      contract.SecretField_USER,
+	SaslPasswordKey:  contract.SecretField_PASSWORD,
+}
+
+var protocolsContract = map[string]contract.Protocol{
+	ProtocolPlaintext:     contract.Protocol_PLAINTEXT,
+	ProtocolSASLPlaintext: contract.Protocol_SASL_PLAINTEXT,
+	ProtocolSSL:           contract.Protocol_SSL,
+	ProtocolSASLSSL:       contract.Protocol_SASL_SSL,
+}
+
+// AuthSecretNames returns the names of the auth secrets referenced by the AuthSecretNamesKey key of the given config
+// data, or nil when the key isn't set.
+func AuthSecretNames(data map[string]string) ([]string, error) {
+	value, ok := data[AuthSecretNamesKey]
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	if name := data[AuthSecretNameKey]; name != "" {
+		return nil, fmt.Errorf("only one of %s and %s can be set, got %q and %q", AuthSecretNameKey, AuthSecretNamesKey, name, value)
+	}
+
+	names := strings.Split(value, ",")
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid %s value %q: empty secret name", AuthSecretNamesKey, value)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("invalid %s value %q: secret %s referenced more than once", AuthSecretNamesKey, value, name)
+		}
+		seen[name] = true
+		names[i] = name
+	}
+	return names, nil
+}
+
+// ResolveAuthContextFromSecrets creates a NetSpecAuthContext merging the credentials of the given auth secrets, which
+// are referenced in the given order.
+//
+// The virtual secret has the keys of every secret, and the combination has to be coherent: a key set by multiple
+// secrets has to have the same value, like the protocol or the SASL mechanism, and SASL credentials require a SASL
+// protocol. The SASL OAUTHBEARER mechanism isn't supported since the data plane only reads the multiSecretFields keys.
+func ResolveAuthContextFromSecrets(secrets []*corev1.Secret) (*NetSpecAuthContext, error) {
+	if len(secrets) == 0 {
+		return nil, errors.New("no auth secrets")
+	}
+
+	virtualSecretData := make(map[string][]byte)
+	keyOwners := make(map[string]string)
+	references := make([]*contract.SecretReference, 0, len(secrets))
+	names := make([]string, 0, len(secrets))
+	versions := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		keys := make([]string, 0, len(secret.Data))
+		for k := range secret.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		keyFieldReferences := make([]*contract.KeyFieldReference, 0, len(keys))
+		for _, k := range keys {
+			value := secret.Data[k]
+			if owner, ok := keyOwners[k]; ok {
+				if !bytes.Equal(virtualSecretData[k], value) {
+					return nil, fmt.Errorf("conflicting values of key %s in auth secrets %s and %s", k, owner, secret.Name)
+				}
+				continue
+			}
+			keyOwners[k] = secret.Name
+			virtualSecretData[k] = value
+
+			if field, ok := multiSecretFields[k]; ok && len(value) > 0 {
+				keyFieldReferences = append(keyFieldReferences, &contract.KeyFieldReference{
+					SecretKey: k,
+					Field:     field,
+				})
+			}
+		}
+
+		if len(keyFieldReferences) > 0 {
+			references = append(references, &contract.SecretReference{
+				Reference: &contract.Reference{
+					Uuid:      string(secret.GetUID()),
+					Namespace: secret.GetNamespace(),
+					Name:      secret.GetName(),
+					Version:   secret.GetResourceVersion(),
+				},
+				KeyFieldReferences: keyFieldReferences,
+			})
+		}
+		names = append(names, secret.GetName())
+		versions = append(versions, secret.GetResourceVersion())
+	}
+
+	protocol, ok := virtualSecretData[ProtocolKey]
+	if !ok {
+		return nil, fmt.Errorf("protocol required (key: %s) in one of the auth secrets %v, supported protocols: %s", ProtocolKey, names, supportedProtocols)
+	}
+	protocolContract, ok := protocolsContract[string(protocol)]
+	if !ok {
+		return nil, fmt.Errorf("protocol %s unsupported (key: %s), supported protocols: %s", protocol, ProtocolKey, supportedProtocols)
+	}
+	if protocolContract == contract.Protocol_PLAINTEXT || protocolContract == contract.Protocol_SSL {
+		for _, k := range []string{SaslMechanismKey, SaslUserKey, SaslPasswordKey} {
+			if owner, ok := keyOwners[k]; ok {
+				return nil, fmt.Errorf("[protocol %s] SASL credentials (key: %s) of auth secret %s require a SASL protocol", protocol, k, owner)
+			}
+		}
+	}
+	if mechanism := string(virtualSecretData[SaslMechanismKey]); mechanism == SaslOAuthBearer {
+		return nil, fmt.Errorf("[protocol %s] SASL mechanism %s (key: %s) isn't supported with multiple auth secrets", protocol, mechanism, SaslMechanismKey)
+	}
+
+	return &NetSpecAuthContext{
+		// The virtual secret identifies the credentials, so that Kafka clients keyed by their secret, like pooled ones,
+		// aren't shared across credentials.
+		VirtualSecret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       secrets[0].GetNamespace(),
+				Name:            strings.Join(names, ","),
+				ResourceVersion: strings.Join(versions, "."),
+			},
+			Data: virtualSecretData,
+		},
+		MultiSecretReference: &contract.MultiSecretReference{
+			Protocol:   protocolContract,
+			References: references,
+		},
+	}, nil
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+)
+
+func TestAuthSecretNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "not set",
+			data: map[string]string{AuthSecretNameKey: "secret"},
+		},
+		{
+			name: "empty",
+			data: map[string]string{AuthSecretNamesKey: " "},
+		},
+		{
+			name: "multiple secrets",
+			data: map[string]string{AuthSecretNamesKey: "tls, sasl"},
+			want: []string{"tls", "sasl"},
+		},
+		{
+			name:    "both keys",
+			data:    map[string]string{AuthSecretNameKey: "secret", AuthSecretNamesKey: "tls,sasl"},
+			wantErr: true,
+		},
+		{
+			name:    "empty name",
+			data:    map[string]string{AuthSecretNamesKey: "tls,,sasl"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate name",
+			data:    map[string]string{AuthSecretNamesKey: "tls,sasl,tls"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AuthSecretNames(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AuthSecretNames() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveAuthContextFromSecrets(t *testing.T) {
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tls", UID: "tls-uid", ResourceVersion: "1"},
+		Data: map[string][]byte{
+			CaCertificateKey: []byte("ca"),
+			UserCertificate:  []byte("cert"),
+			UserKey:          []byte("key"),
+		},
+	}
+	saslSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "sasl", UID: "sasl-uid", ResourceVersion: "2"},
+		Data: map[string][]byte{
+			ProtocolKey:      []byte(ProtocolSASLSSL),
+			SaslMechanismKey: []byte(SaslScramSha512),
+			SaslUserKey:      []byte("user"),
+			SaslPasswordKey:  []byte("password"),
+		},
+	}
+	secret := func(name string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name}, Data: data}
+	}
+
+	tests := []struct {
+		name    string
+		secrets []*corev1.Secret
+		want    *NetSpecAuthContext
+		wantErr string
+	}{
+		{
+			name:    "TLS client cert and SASL credentials",
+			secrets: []*corev1.Secret{tlsSecret, saslSecret},
+			want: &NetSpecAuthContext{
+				VirtualSecret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tls,sasl", ResourceVersion: "1.2"},
+					Data: map[string][]byte{
+						ProtocolKey:      []byte(ProtocolSASLSSL),
+						CaCertificateKey: []byte("ca"),
+						UserCertificate:  []byte("cert"),
+						UserKey:          []byte("key"),
+						SaslMechanismKey: []byte(SaslScramSha512),
+						SaslUserKey:      []byte("user"),
+						SaslPasswordKey:  []byte("password"),
+					},
+				},
+				MultiSecretReference: &contract.MultiSecretReference{
+					Protocol: contract.Protocol_SASL_SSL,
+					References: []*contract.SecretReference{
+						{
+							Reference: &contract.Reference{Uuid: "tls-uid", Namespace: "ns", Name: "tls", Version: "1"},
+							KeyFieldReferences: []*contract.KeyFieldReference{
+								{SecretKey: CaCertificateKey, Field: contract.SecretField_CA_CRT},
+								{SecretKey: UserCertificate, Field: contract.SecretField_USER_CRT},
+								{SecretKey: UserKey, Field: contract.SecretField_USER_KEY},
+							},
+						},
+						{
+							Reference: &contract.Reference{Uuid: "sasl-uid", Namespace: "ns", Name: "sasl", Version: "2"},
+							KeyFieldReferences: []*contract.KeyFieldReference{
+								{SecretKey: SaslPasswordKey, Field: contract.SecretField_PASSWORD},
+								{SecretKey: SaslMechanismKey, Field: contract.SecretField_SASL_MECHANISM},
+								{SecretKey: SaslUserKey, Field: contract.SecretField_USER},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "same value in multiple secrets",
+			secrets: []*corev1.Secret{
+				secret("a", map[string][]byte{ProtocolKey: []byte(ProtocolSASLPlaintext), SaslUserKey: []byte("user")}),
+				secret("b", map[string][]byte{ProtocolKey: []byte(ProtocolSASLPlaintext), SaslPasswordKey: []byte("password")}),
+			},
+			want: &NetSpecAuthContext{
+				VirtualSecret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "a,b", ResourceVersion: "."},
+					Data: map[string][]byte{
+						ProtocolKey:     []byte(ProtocolSASLPlaintext),
+						SaslUserKey:     []byte("user"),
+						SaslPasswordKey: []byte("password"),
+					},
+				},
+				MultiSecretReference: &contract.MultiSecretReference{
+					Protocol: contract.Protocol_SASL_PLAINTEXT,
+					References: []*contract.SecretReference{
+						{
+							Reference:          &contract.Reference{Namespace: "ns", Name: "a"},
+							KeyFieldReferences: []*contract.KeyFieldReference{{SecretKey: SaslUserKey, Field: contract.SecretField_USER}},
+						},
+						{
+							Reference:          &contract.Reference{Namespace: "ns", Name: "b"},
+							KeyFieldReferences: []*contract.KeyFieldReference{{SecretKey: SaslPasswordKey, Field: contract.SecretField_PASSWORD}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "conflicting SASL mechanisms",
+			secrets: []*corev1.Secret{
+				saslSecret,
+				secret("other", map[string][]byte{SaslMechanismKey: []byte(SaslPlain)}),
+			},
+			wantErr: "conflicting values of key sasl.mechanism in auth secrets sasl and other",
+		},
+		{
+			name:    "no protocol",
+			secrets: []*corev1.Secret{tlsSecret},
+			wantErr: "protocol required (key: protocol) in one of the auth secrets [tls], supported protocols: " + supportedProtocols,
+		},
+		{
+			name: "unsupported protocol",
+			secrets: []*corev1.Secret{
+				tlsSecret,
+				secret("other", map[string][]byte{ProtocolKey: []byte("TLS")}),
+			},
+			wantErr: "protocol TLS unsupported (key: protocol), supported protocols: " + supportedProtocols,
+		},
+		{
+			name: "SASL credentials without SASL protocol",
+			secrets: []*corev1.Secret{
+				secret("ssl", map[string][]byte{ProtocolKey: []byte(ProtocolSSL), CaCertificateKey: []byte("ca")}),
+				secret("sasl", map[string][]byte{SaslUserKey: []byte("user"), SaslPasswordKey: []byte("password")}),
+			},
+			wantErr: "[protocol SSL] SASL credentials (key: user) of auth secret sasl require a SASL protocol",
+		},
+		{
+			name: "OAUTHBEARER",
+			secrets: []*corev1.Secret{
+				tlsSecret,
+				secret("oauth", map[string][]byte{ProtocolKey: []byte(ProtocolSASLSSL), SaslMechanismKey: []byte(SaslOAuthBearer)}),
+			},
+			wantErr: "[protocol SASL_SSL] SASL mechanism OAUTHBEARER (key: sasl.mechanism) isn't supported with multiple auth secrets",
+		},
+		{
+			name:    "no secrets",
+			wantErr: "no auth secrets",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveAuthContextFromSecrets(tt.secrets)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("(-want, +got) %s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveAuthContextFromSecretsSaramaConfig(t *testing.T) {
+	ca, _, _ := loadCerts(t)
+
+	authContext, err := ResolveAuthContextFromSecrets([]*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ca"},
+			Data:       map[string][]byte{ProtocolKey: []byte(ProtocolSASLSSL), CaCertificateKey: ca},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "sasl"},
+			Data: map[string][]byte{
+				SaslMechanismKey: []byte(SaslScramSha256),
+				SaslUserKey:      []byte("my-user-name"),
+				SaslPasswordKey:  []byte("my-user-password"),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	config := sarama.NewConfig()
+	require.NoError(t, NewSaramaSecurityOptionFromSecret(authContext.VirtualSecret)(config))
+
+	assert.True(t, config.Net.SASL.Enable)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA256), config.Net.SASL.Mechanism)
+	assert.Equal(t, "my-user-name", config.Net.SASL.User)
+	assert.Equal(t, "my-user-password", config.Net.SASL.Password)
+	assert.True(t, config.Net.TLS.Enable)
+	assert.NotNil(t, config.Net.TLS.Config.RootCAs)
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	bindings "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/bindings/v1beta1"
+)
+
+var (
+	// StrimziKafkaGVR and StrimziKafkaUserGVR are the resources of the Kafka clusters and of the Kafka users managed
+	// by Strimzi.
+	StrimziKafkaGVR     = schema.GroupVersionResource{Group: "kafka.strimzi.io", Version: "v1beta2", Resource: "kafkas"}
+	StrimziKafkaUserGVR = schema.GroupVersionResource{Group: "kafka.strimzi.io", Version: "v1beta2", Resource: "kafkausers"}
+)
+
+const (
+	// strimziUserAuthenticationTLS is the Strimzi KafkaUser authentication type of users authenticated with a
+	// client certificate.
+	strimziUserAuthenticationTLS = "tls"
+)
+
+// StrimziCluster references a Kafka cluster managed by Strimzi.
+type StrimziCluster struct {
+	// Namespace is the namespace of the Strimzi Kafka resource and of the KafkaUser resource.
+	Namespace string
+	// Name is the name of the Strimzi Kafka resource.
+	Name string
+	// Listener is the name of the Kafka listener used to connect to the cluster, the first listener of the Kafka
+	// resource is used when it's empty.
+	Listener string
+	// User is the name of the Strimzi KafkaUser resource used to authenticate to the cluster, if any.
+	User string
+}
+
+func (c StrimziCluster) String() string {
+	return fmt.Sprintf("%s/%s", c.Namespace, c.Name)
+}
+
+// StrimziClusterConfig is the config used to connect to a Kafka cluster managed by Strimzi.
+type StrimziClusterConfig struct {
+	// BootstrapServers are the comma separated bootstrap servers of the Kafka listener.
+	BootstrapServers string
+	// AuthContext is the auth context of the listener protocol and of the KafkaUser credentials.
+	AuthContext *NetSpecAuthContext
+}
+
+// ResolveStrimziCluster resolves the bootstrap servers and the security config of the given Kafka cluster from the
+// Strimzi Kafka and KafkaUser resources.
+//
+// TLS listeners use the cluster CA certificate of the Strimzi <cluster>-cluster-ca-cert secret, and only KafkaUser
+// resources authenticated with a client certificate are supported.
+func ResolveStrimziCluster(ctx context.Context, client dynamic.Interface, lister corelisters.SecretLister, cluster StrimziCluster) (*StrimziClusterConfig, error) {
+	kafka, err := client.Resource(StrimziKafkaGVR).Namespace(cluster.Namespace).Get(ctx, cluster.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Strimzi Kafka %s: %w", cluster, err)
+	}
+
+	listener, tls, err := strimziListener(kafka, cluster.Listener)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the listener of Strimzi Kafka %s: %w", cluster, err)
+	}
+	bootstrapServers, err := strimziListenerBootstrapServers(kafka, listener)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the bootstrap servers of Strimzi Kafka %s: %w", cluster, err)
+	}
+
+	netSpec := bindings.KafkaNetSpec{}
+	if tls {
+		netSpec.TLS.Enable = true
+		netSpec.TLS.CACert = secretValueFromSource(cluster.Name+"-cluster-ca-cert", "ca.crt")
+	}
+
+	if cluster.User != "" {
+		user, err := client.Resource(StrimziKafkaUserGVR).Namespace(cluster.Namespace).Get(ctx, cluster.User, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Strimzi KafkaUser %s/%s: %w", cluster.Namespace, cluster.User, err)
+		}
+		authentication, _, _ := unstructured.NestedString(user.Object, "spec", "authentication", "type")
+		if authentication != strimziUserAuthenticationTLS {
+			return nil, fmt.Errorf("unsupported authentication %q of Strimzi KafkaUser %s/%s, expected %s", authentication, cluster.Namespace, cluster.User, strimziUserAuthenticationTLS)
+		}
+		if !tls {
+			return nil, fmt.Errorf("Strimzi KafkaUser %s/%s requires a TLS listener, listener %s of Strimzi Kafka %s isn't", cluster.Namespace, cluster.User, listener, cluster)
+		}
+		secretName, ok, _ := unstructured.NestedString(user.Object, "status", "secret")
+		if !ok || secretName == "" {
+			return nil, fmt.Errorf("Strimzi KafkaUser %s/%s isn't ready, its secret isn't known yet", cluster.Namespace, cluster.User)
+		}
+		netSpec.TLS.Cert = secretValueFromSource(secretName, "user.crt")
+		netSpec.TLS.Key = secretValueFromSource(secretName, "user.key")
+	}
+
+	authContext, err := ResolveAuthContextFromNetSpec(lister, cluster.Namespace, netSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the security config of Strimzi Kafka %s: %w", cluster, err)
+	}
+
+	// Keep the contract stable across reconciliations.
+	references := authContext.MultiSecretReference.References
+	sort.Slice(references, func(i, j int) bool {
+		return references[i].GetReference().GetName() < references[j].GetReference().GetName()
+	})
+	// The virtual secret identifies the credentials, so that Kafka clients keyed by their secret, like pooled ones,
+	// aren't shared across credentials.
+	versions := make([]string, 0, len(references))
+	for _, r := range references {
+		versions = append(versions, r.GetReference().GetVersion())
+	}
+	authContext.VirtualSecret.ObjectMeta = metav1.ObjectMeta{
+		Namespace:       cluster.Namespace,
+		Name:            fmt.Sprintf("strimzi.%s.%s", cluster.Name, cluster.User),
+		ResourceVersion: strings.Join(versions, "."),
+	}
+
+	return &StrimziClusterConfig{BootstrapServers: bootstrapServers, AuthContext: authContext}, nil
+}
+
+// strimziListener returns the name of the given listener, or of the first listener when the given name is empty, of
+// the given Strimzi Kafka resource and whether it's a TLS listener.
+func strimziListener(kafka *unstructured.Unstructured, name string) (string, bool, error) {
+	listeners, _, err := unstructured.NestedSlice(kafka.Object, "spec", "kafka", "listeners")
+	if err != nil {
+		return "", false, err
+	}
+	for _, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		listenerName, _, _ := unstructured.NestedString(listener, "name")
+		if name != "" && listenerName != name {
+			continue
+		}
+		tls, _, _ := unstructured.NestedBool(listener, "tls")
+		return listenerName, tls, nil
+	}
+	if name != "" {
+		return "", false, fmt.Errorf("listener %s not found", name)
+	}
+	return "", false, fmt.Errorf("no listeners")
+}
+
+// strimziListenerBootstrapServers returns the bootstrap servers of the given listener reported in the status of the
+// given Strimzi Kafka resource.
+func strimziListenerBootstrapServers(kafka *unstructured.Unstructured, name string) (string, error) {
+	listeners, _, err := unstructured.NestedSlice(kafka.Object, "status", "listeners")
+	if err != nil {
+		return "", err
+	}
+	for _, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if listenerName, _, _ := unstructured.NestedString(listener, "name"); listenerName != name {
+			continue
+		}
+		bootstrapServers, _, _ := unstructured.NestedString(listener, "bootstrapServers")
+		if bootstrapServers == "" {
+			break
+		}
+		return bootstrapServers, nil
+	}
+	return "", fmt.Errorf("listener %s isn't ready, its bootstrap servers aren't known yet", name)
+}
+
+func secretValueFromSource(name, key string) bindings.SecretValueFromSource {
+	return bindings.SecretValueFromSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		},
+	}
+}
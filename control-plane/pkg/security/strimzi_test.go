@@ -0,0 +1,160 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret/fake"
+	reconcilertesting "knative.dev/pkg/reconciler/testing"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/contract"
+)
+
+func strimziKafka(listeners ...map[string]interface{}) *unstructured.Unstructured {
+	spec := make([]interface{}, 0, len(listeners))
+	status := make([]interface{}, 0, len(listeners))
+	for _, l := range listeners {
+		spec = append(spec, map[string]interface{}{"name": l["name"], "tls": l["tls"]})
+		status = append(status, map[string]interface{}{"name": l["name"], "bootstrapServers": l["bootstrapServers"]})
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kafka.strimzi.io/v1beta2",
+		"kind":       "Kafka",
+		"metadata":   map[string]interface{}{"namespace": "kafka", "name": "my-cluster"},
+		"spec":       map[string]interface{}{"kafka": map[string]interface{}{"listeners": spec}},
+		"status":     map[string]interface{}{"listeners": status},
+	}}
+}
+
+func strimziKafkaUser(authentication string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kafka.strimzi.io/v1beta2",
+		"kind":       "KafkaUser",
+		"metadata":   map[string]interface{}{"namespace": "kafka", "name": "my-user"},
+		"spec":       map[string]interface{}{"authentication": map[string]interface{}{"type": authentication}},
+		"status":     map[string]interface{}{"secret": "my-user"},
+	}}
+}
+
+func TestResolveStrimziCluster(t *testing.T) {
+	plain := map[string]interface{}{"name": "plain", "tls": false, "bootstrapServers": "my-cluster-kafka-bootstrap.kafka:9092"}
+	tls := map[string]interface{}{"name": "tls", "tls": true, "bootstrapServers": "my-cluster-kafka-bootstrap.kafka:9093"}
+	notReady := map[string]interface{}{"name": "tls", "tls": true, "bootstrapServers": ""}
+
+	tests := []struct {
+		name                 string
+		objects              []runtime.Object
+		cluster              StrimziCluster
+		wantBootstrapServers string
+		wantProtocol         string
+		wantReferences       []string
+		wantErr              bool
+	}{
+		{
+			name:                 "first listener, no user",
+			objects:              []runtime.Object{strimziKafka(plain, tls)},
+			cluster:              StrimziCluster{Namespace: "kafka", Name: "my-cluster"},
+			wantBootstrapServers: "my-cluster-kafka-bootstrap.kafka:9092",
+			wantProtocol:         ProtocolPlaintext,
+		},
+		{
+			name:                 "TLS listener with TLS user",
+			objects:              []runtime.Object{strimziKafka(plain, tls), strimziKafkaUser("tls")},
+			cluster:              StrimziCluster{Namespace: "kafka", Name: "my-cluster", Listener: "tls", User: "my-user"},
+			wantBootstrapServers: "my-cluster-kafka-bootstrap.kafka:9093",
+			wantProtocol:         ProtocolSSL,
+			wantReferences:       []string{"my-cluster-cluster-ca-cert", "my-user"},
+		},
+		{
+			name:    "SCRAM user",
+			objects: []runtime.Object{strimziKafka(tls), strimziKafkaUser("scram-sha-512")},
+			cluster: StrimziCluster{Namespace: "kafka", Name: "my-cluster", User: "my-user"},
+			wantErr: true,
+		},
+		{
+			name:    "TLS user on a plaintext listener",
+			objects: []runtime.Object{strimziKafka(plain), strimziKafkaUser("tls")},
+			cluster: StrimziCluster{Namespace: "kafka", Name: "my-cluster", User: "my-user"},
+			wantErr: true,
+		},
+		{
+			name:    "listener not ready",
+			objects: []runtime.Object{strimziKafka(notReady)},
+			cluster: StrimziCluster{Namespace: "kafka", Name: "my-cluster"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown listener",
+			objects: []runtime.Object{strimziKafka(plain)},
+			cluster: StrimziCluster{Namespace: "kafka", Name: "my-cluster", Listener: "external"},
+			wantErr: true,
+		},
+		{
+			name:    "Kafka not found",
+			cluster: StrimziCluster{Namespace: "kafka", Name: "my-cluster"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := reconcilertesting.SetupFakeContext(t)
+			secrets := secretinformer.Get(ctx)
+			for _, s := range []*corev1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "kafka", Name: "my-cluster-cluster-ca-cert", ResourceVersion: "1"},
+					Data:       map[string][]byte{"ca.crt": []byte("ca")},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "kafka", Name: "my-user", ResourceVersion: "2"},
+					Data:       map[string][]byte{"user.crt": []byte("crt"), "user.key": []byte("key")},
+				},
+			} {
+				require.NoError(t, secrets.Informer().GetIndexer().Add(s))
+			}
+			client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), tt.objects...)
+
+			got, err := ResolveStrimziCluster(ctx, client, secrets.Lister(), tt.cluster)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantBootstrapServers, got.BootstrapServers)
+			assert.Equal(t, tt.wantProtocol, string(got.AuthContext.VirtualSecret.Data[ProtocolKey]))
+			assert.Equal(t, "kafka", got.AuthContext.VirtualSecret.Namespace)
+
+			var references []string
+			for _, r := range got.AuthContext.MultiSecretReference.GetReferences() {
+				references = append(references, r.GetReference().GetName())
+			}
+			assert.Equal(t, tt.wantReferences, references)
+			if len(tt.wantReferences) > 0 {
+				assert.Equal(t, contract.Protocol_SSL, got.AuthContext.MultiSecretReference.GetProtocol())
+				assert.Equal(t, "1.2", got.AuthContext.VirtualSecret.ResourceVersion)
+			}
+		})
+	}
+}
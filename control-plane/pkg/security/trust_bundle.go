@@ -0,0 +1,124 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	corev1 "k8s.io/api/core/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+)
+
+const (
+	// AuthTrustBundleNameKey is the key of the name of the ConfigMap, in the namespace of the config referencing it,
+	// holding the CA certificates used to verify the server certificates of the Kafka brokers, for example, a
+	// cluster-wide CA bundle.
+	AuthTrustBundleNameKey = "auth.trust.bundle.ref.name"
+	// AuthTrustBundleKeyKey is the key of the trust bundle ConfigMap key holding the PEM encoded CA certificates, it
+	// defaults to DefaultTrustBundleKey.
+	AuthTrustBundleKeyKey = "auth.trust.bundle.ref.key"
+
+	DefaultTrustBundleKey = "ca.crt"
+)
+
+// TrustBundleRef returns the name and the key of the trust bundle referenced by the given config, it returns false
+// when the config doesn't reference a trust bundle.
+func TrustBundleRef(cm *corev1.ConfigMap) (string, string, bool) {
+	if cm == nil {
+		return "", "", false
+	}
+	name := strings.TrimSpace(cm.Data[AuthTrustBundleNameKey])
+	if name == "" {
+		return "", "", false
+	}
+	key := strings.TrimSpace(cm.Data[AuthTrustBundleKeyKey])
+	if key == "" {
+		key = DefaultTrustBundleKey
+	}
+	return name, key, true
+}
+
+// TrustBundle returns the PEM encoded CA certificates of the given trust bundle at the given key, the trust bundle
+// must only contain certificates.
+func TrustBundle(cm *corev1.ConfigMap, key string) ([]byte, error) {
+	bundle, ok := cm.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("trust bundle %s/%s has no %s key", cm.Namespace, cm.Name, key)
+	}
+	if err := validateTrustBundle([]byte(bundle)); err != nil {
+		return nil, fmt.Errorf("invalid trust bundle %s/%s (key: %s): %w", cm.Namespace, cm.Name, key, err)
+	}
+	return []byte(bundle), nil
+}
+
+func validateTrustBundle(bundle []byte) error {
+	certificates := 0
+	for rest := bundle; len(strings.TrimSpace(string(rest))) > 0; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return fmt.Errorf("failed to decode PEM block %d", certificates+1)
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected PEM block %d type %s, expected CERTIFICATE", certificates+1, block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("failed to parse certificate %d: %w", certificates+1, err)
+		}
+		certificates++
+	}
+	if certificates == 0 {
+		return fmt.Errorf("no certificates")
+	}
+	return nil
+}
+
+// NewSaramaSecurityOptionWithTrustBundle returns the security option of the given secret that also trusts the CA
+// certificates of the given trust bundle, when TLS is enabled.
+//
+// The trust bundle is added to the CA certificates of the secret, or it replaces the system's root CA set when the
+// secret has no CA certificates.
+func NewSaramaSecurityOptionWithTrustBundle(secret *corev1.Secret, bundle []byte) kafka.ConfigOption {
+	securityOption := NewSaramaSecurityOptionFromSecret(secret)
+	if len(bundle) == 0 {
+		return securityOption
+	}
+	return func(config *sarama.Config) error {
+		if err := securityOption(config); err != nil {
+			return err
+		}
+		if !config.Net.TLS.Enable {
+			return nil
+		}
+		if config.Net.TLS.Config == nil {
+			config.Net.TLS.Config = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		if config.Net.TLS.Config.RootCAs == nil {
+			config.Net.TLS.Config.RootCAs = x509.NewCertPool()
+		}
+		if !config.Net.TLS.Config.RootCAs.AppendCertsFromPEM(bundle) {
+			return fmt.Errorf("failed to parse trust bundle CA certificates")
+		}
+		return nil
+	}
+}
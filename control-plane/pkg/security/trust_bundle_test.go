@@ -0,0 +1,135 @@
+/*
+ * Copyright 2023 The Knative Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka-broker/control-plane/pkg/kafka"
+)
+
+func TestTrustBundleRef(t *testing.T) {
+	_, _, ok := TrustBundleRef(nil)
+	assert.False(t, ok)
+
+	_, _, ok = TrustBundleRef(&corev1.ConfigMap{Data: map[string]string{}})
+	assert.False(t, ok)
+
+	name, key, ok := TrustBundleRef(&corev1.ConfigMap{Data: map[string]string{AuthTrustBundleNameKey: "ca-bundle"}})
+	assert.True(t, ok)
+	assert.Equal(t, "ca-bundle", name)
+	assert.Equal(t, DefaultTrustBundleKey, key)
+
+	name, key, ok = TrustBundleRef(&corev1.ConfigMap{Data: map[string]string{
+		AuthTrustBundleNameKey: "ca-bundle",
+		AuthTrustBundleKeyKey:  "bundle.pem",
+	}})
+	assert.True(t, ok)
+	assert.Equal(t, "ca-bundle", name)
+	assert.Equal(t, "bundle.pem", key)
+}
+
+func TestTrustBundle(t *testing.T) {
+	ca, userKey, userCert := loadCerts(t)
+
+	tests := []struct {
+		name    string
+		data    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "single certificate",
+			data: map[string]string{DefaultTrustBundleKey: string(ca)},
+		},
+		{
+			name: "multiple certificates",
+			data: map[string]string{DefaultTrustBundleKey: string(ca) + string(userCert)},
+		},
+		{
+			name:    "missing key",
+			data:    map[string]string{"bundle.pem": string(ca)},
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			data:    map[string]string{DefaultTrustBundleKey: ""},
+			wantErr: true,
+		},
+		{
+			name:    "not PEM",
+			data:    map[string]string{DefaultTrustBundleKey: "not a certificate"},
+			wantErr: true,
+		},
+		{
+			name:    "private key",
+			data:    map[string]string{DefaultTrustBundleKey: string(ca) + string(userKey)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "knative-eventing", Name: "ca-bundle"},
+				Data:       tt.data,
+			}
+			bundle, err := TrustBundle(cm, DefaultTrustBundleKey)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.data[DefaultTrustBundleKey], string(bundle))
+		})
+	}
+}
+
+func TestSaramaSecurityOptionWithTrustBundle(t *testing.T) {
+	ca, userKey, userCert := loadCerts(t)
+
+	config := sarama.NewConfig()
+	err := kafka.Options(config, NewSaramaSecurityOptionWithTrustBundle(&corev1.Secret{Data: map[string][]byte{
+		"protocol": []byte("SSL"),
+		"user.key": userKey,
+		"user.crt": userCert,
+		"ca.crt":   ca,
+	}}, ca))
+	assert.Nil(t, err)
+	assert.True(t, config.Net.TLS.Enable)
+	assert.NotNil(t, config.Net.TLS.Config.RootCAs)
+
+	config = sarama.NewConfig()
+	err = kafka.Options(config, NewSaramaSecurityOptionWithTrustBundle(&corev1.Secret{Data: map[string][]byte{
+		"protocol":  []byte("SSL"),
+		"user.skip": []byte("true"),
+	}}, ca))
+	assert.Nil(t, err)
+	assert.True(t, config.Net.TLS.Enable)
+	// Without CA certificates in the secret, the trust bundle replaces the system's root CA set.
+	assert.NotNil(t, config.Net.TLS.Config.RootCAs)
+
+	// The trust bundle is ignored when TLS isn't enabled.
+	config = sarama.NewConfig()
+	err = kafka.Options(config, NewSaramaSecurityOptionWithTrustBundle(nil, ca))
+	assert.Nil(t, err)
+	assert.False(t, config.Net.TLS.Enable)
+}